@@ -0,0 +1,114 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/notify"
+)
+
+// The queries the scheduler needs, satisfied by db.Storage
+type Store interface {
+	ListExpiringPantryItems(ctx context.Context, today time.Time) ([]db.ListExpiringPantryItemsRow, error)
+	MarkPantryItemsAlerted(ctx context.Context, ids []int64) error
+}
+
+// Periodically alerts users about pantry items expiring within their alert window.
+// Every item is reported once, items of users the notifier failed for are retried on the next check
+type Scheduler struct {
+	store    Store
+	notifier notify.Notifier
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewScheduler(store Store, notifier notify.Notifier, interval time.Duration) (*Scheduler, error) {
+	if interval <= 0 {
+		return nil, errors.New("alert interval has to be positive")
+	}
+
+	return &Scheduler{
+		store:    store,
+		notifier: notifier,
+		interval: interval,
+		now:      time.Now,
+	}, nil
+}
+
+// Checks right away and then on every interval until the context is done
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Check(ctx); err != nil {
+			log.Printf("expiry alerts: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sends one notification per user with expiring items. A failing user does not stop the others,
+// all failures are returned together
+func (s *Scheduler) Check(ctx context.Context) error {
+	now := s.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := s.store.ListExpiringPantryItems(ctx, today)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, n := range notifications(rows) {
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("unable to notify user %s: %w", n.UserID, err))
+			continue
+		}
+
+		ids := make([]int64, len(n.Items))
+		for i, item := range n.Items {
+			ids[i] = item.PantryItemID
+		}
+		if err := s.store.MarkPantryItemsAlerted(ctx, ids); err != nil {
+			errs = append(errs, fmt.Errorf("unable to mark alerts of user %s: %w", n.UserID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Rows are ordered by user, so every user is one consecutive run
+func notifications(rows []db.ListExpiringPantryItemsRow) []notify.Notification {
+	result := []notify.Notification{}
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].UserID != row.UserID {
+			result = append(result, notify.Notification{
+				UserID:   row.UserID,
+				Username: row.Username,
+				Email:    row.Email,
+			})
+		}
+
+		n := &result[len(result)-1]
+		n.Items = append(n.Items, notify.Item{
+			PantryItemID: row.ID,
+			IngredientID: row.IngredientID,
+			Name:         row.Name,
+			Amount:       row.Amount,
+			Unit:         row.UnitName.String,
+			ExpiresOn:    row.ExpiresOn.Time,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,198 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/notify"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type fakeNotifier struct {
+	sent []notify.Notification
+	fail map[uuid.UUID]bool
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, n notify.Notification) error {
+	if f.fail[n.UserID] {
+		return errors.New("unreachable")
+	}
+	f.sent = append(f.sent, n)
+
+	return nil
+}
+
+func randomExpiringRow(userID uuid.UUID, id int64, expiresOn time.Time) db.ListExpiringPantryItemsRow {
+	return db.ListExpiringPantryItemsRow{
+		ID:           id,
+		UserID:       userID,
+		Username:     util.RandomUsername(),
+		Email:        util.RandomEmail(),
+		IngredientID: int32(util.RandomInt(1, 100)),
+		Name:         util.RandomString(6),
+		Amount:       float64(util.RandomInt(1, 10)),
+		UnitName:     sql.NullString{String: "gram", Valid: true},
+		ExpiresOn:    sql.NullTime{Time: expiresOn, Valid: true},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2026, 10, 18, 21, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	userA, err := uuid.NewRandom()
+	require.NoError(t, err)
+	userB, err := uuid.NewRandom()
+	require.NoError(t, err)
+	rows := []db.ListExpiringPantryItemsRow{
+		randomExpiringRow(userA, 1, today),
+		randomExpiringRow(userA, 2, today.AddDate(0, 0, 2)),
+		randomExpiringRow(userB, 3, today.AddDate(0, 0, 1)),
+	}
+
+	testCases := []struct {
+		name       string
+		fail       map[uuid.UUID]bool
+		buildStubs func(store *dbmock.MockStorage)
+		check      func(t *testing.T, notifier *fakeNotifier, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *dbmock.MockStorage) {
+				store.EXPECT().
+					ListExpiringPantryItems(gomock.Any(), gomock.Eq(today)).
+					Times(1).
+					Return(rows, nil)
+				store.EXPECT().
+					MarkPantryItemsAlerted(gomock.Any(), gomock.Eq([]int64{1, 2})).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					MarkPantryItemsAlerted(gomock.Any(), gomock.Eq([]int64{3})).
+					Times(1).
+					Return(nil)
+			},
+			check: func(t *testing.T, notifier *fakeNotifier, err error) {
+				require.NoError(t, err)
+				require.Len(t, notifier.sent, 2)
+				require.Equal(t, userA, notifier.sent[0].UserID)
+				require.Equal(t, rows[0].Email, notifier.sent[0].Email)
+				require.Len(t, notifier.sent[0].Items, 2)
+				require.Equal(t, "gram", notifier.sent[0].Items[1].Unit)
+				require.Equal(t, rows[1].ExpiresOn.Time, notifier.sent[0].Items[1].ExpiresOn)
+				require.Equal(t, userB, notifier.sent[1].UserID)
+				require.Len(t, notifier.sent[1].Items, 1)
+			},
+		},
+		{
+			name: "Notifier Failure",
+			fail: map[uuid.UUID]bool{userA: true},
+			buildStubs: func(store *dbmock.MockStorage) {
+				store.EXPECT().
+					ListExpiringPantryItems(gomock.Any(), gomock.Eq(today)).
+					Times(1).
+					Return(rows, nil)
+				store.EXPECT().
+					MarkPantryItemsAlerted(gomock.Any(), gomock.Eq([]int64{3})).
+					Times(1).
+					Return(nil)
+			},
+			check: func(t *testing.T, notifier *fakeNotifier, err error) {
+				require.ErrorContains(t, err, userA.String())
+				require.Len(t, notifier.sent, 1)
+				require.Equal(t, userB, notifier.sent[0].UserID)
+			},
+		},
+		{
+			name: "Nothing Expiring",
+			buildStubs: func(store *dbmock.MockStorage) {
+				store.EXPECT().
+					ListExpiringPantryItems(gomock.Any(), gomock.Eq(today)).
+					Times(1).
+					Return([]db.ListExpiringPantryItemsRow{}, nil)
+				store.EXPECT().
+					MarkPantryItemsAlerted(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			check: func(t *testing.T, notifier *fakeNotifier, err error) {
+				require.NoError(t, err)
+				require.Empty(t, notifier.sent)
+			},
+		},
+		{
+			name: "Store Failure",
+			buildStubs: func(store *dbmock.MockStorage) {
+				store.EXPECT().
+					ListExpiringPantryItems(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, notifier *fakeNotifier, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Empty(t, notifier.sent)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(store)
+
+			notifier := &fakeNotifier{fail: tc.fail}
+			scheduler, err := NewScheduler(store, notifier, time.Hour)
+			require.NoError(t, err)
+			scheduler.now = func() time.Time { return now }
+
+			err = scheduler.Check(context.Background())
+			tc.check(t, notifier, err)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := dbmock.NewMockStorage(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	// the first check runs right away, cancelling there stops the loop
+	store.EXPECT().
+		ListExpiringPantryItems(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(context.Context, time.Time) ([]db.ListExpiringPantryItemsRow, error) {
+			cancel()
+			return []db.ListExpiringPantryItemsRow{}, nil
+		})
+
+	scheduler, err := NewScheduler(store, &fakeNotifier{}, time.Hour)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after the context was cancelled")
+	}
+
+	_, err = NewScheduler(store, &fakeNotifier{}, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/export"
+)
+
+const (
+	calendarPath = "/calendar"
+	// Days of past meals a feed still lists
+	calendarPastDays = 30
+	defaultMealTime  = 18 * time.Hour
+)
+
+var errUnknownFeed = errors.New("unknown calendar feed")
+
+type calendarFeedResponse struct {
+	Token uuid.UUID `json:"token"`
+	// Secret URL to subscribe to in a calendar app
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (server *Server) newCalendarFeedResponse(feed db.CalendarFeed) calendarFeedResponse {
+	return calendarFeedResponse{
+		Token:     feed.Token,
+		URL:       server.publicURL + calendarPath + "/" + feed.Token.String() + ".ics",
+		CreatedAt: feed.CreatedAt,
+	}
+}
+
+// Create the caller's feed, or replace its token so the old URL stops working
+func (server *Server) createCalendarFeed(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+
+	feed, err := server.storage.UpsertCalendarFeed(ctx, db.UpsertCalendarFeedParams{
+		UserID: authPayload.Subject,
+		Token:  uuid.New(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newCalendarFeedResponse(feed))
+}
+
+func (server *Server) getCalendarFeed(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+
+	feed, err := server.storage.GetCalendarFeed(ctx, authPayload.Subject)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newCalendarFeedResponse(feed))
+}
+
+func (server *Server) deleteCalendarFeed(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+
+	err := server.storage.DeleteCalendarFeed(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type calendarUri struct {
+	// Token with the .ics extension
+	Feed string `uri:"feed" binding:"required"`
+}
+
+type calendarQuery struct {
+	// Time the meals start, 18:00 when empty
+	Time string `form:"time" binding:"omitempty,datetime=15:04"`
+}
+
+// iCalendar feed of the dated schedule entries. Calendar apps can not send a
+// bearer token, the secret token in the URL authorizes the request instead
+func (server *Server) calendarFeed(ctx *gin.Context) {
+	var reqUri calendarUri
+	var reqQuery calendarQuery
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&reqQuery); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// a malformed token is as unknown as a revoked one
+	name, ok := strings.CutSuffix(reqUri.Feed, ".ics")
+	token, err := uuid.Parse(name)
+	if !ok || err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(errUnknownFeed))
+		return
+	}
+
+	mealTime := defaultMealTime
+	if reqQuery.Time != "" {
+		t, _ := time.Parse("15:04", reqQuery.Time)
+		mealTime = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	entries, err := server.storage.GetCalendarFeedTx(ctx, db.CalendarFeedParams{
+		Token: token,
+		Since: time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -calendarPastDays),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errUnknownFeed))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	data := export.Calendar(entries, export.CalendarOptions{
+		Name:     "Meal plan",
+		MealTime: mealTime,
+		BaseURL:  server.publicURL,
+	})
+	ctx.Data(http.StatusOK, export.ContentTypes[export.FormatICal], data)
+}
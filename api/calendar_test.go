@@ -0,0 +1,193 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCreateCalendarFeedAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := dbmock.NewMockStorage(ctrl)
+	storage.EXPECT().
+		UpsertCalendarFeed(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.UpsertCalendarFeedParams) (db.CalendarFeed, error) {
+			require.Equal(t, user.ID, arg.UserID)
+			require.NotEqual(t, uuid.Nil, arg.Token)
+			return db.CalendarFeed{UserID: arg.UserID, Token: arg.Token, CreatedAt: time.Now().UTC()}, nil
+		})
+
+	t.Setenv("PUBLIC_URL", "https://planner.example.com/")
+	server := newTestServer(t, storage)
+	recorder := httptest.NewRecorder()
+
+	// the link ignores where the request claims to be sent to
+	request, err := http.NewRequest(http.MethodPost, "/user/calendar", nil)
+	require.NoError(t, err)
+	request.Host = "attacker.example.net"
+	request.Header.Set("X-Forwarded-Proto", "http")
+
+	addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got calendarFeedResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Equal(t, "https://planner.example.com/calendar/"+got.Token.String()+".ics", got.URL)
+}
+
+func TestGetCalendarFeedAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := dbmock.NewMockStorage(ctrl)
+	storage.EXPECT().
+		GetCalendarFeed(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(db.CalendarFeed{}, sql.ErrNoRows)
+
+	server := newTestServer(t, storage)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/user/calendar", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestCalendarFeedAPI(t *testing.T) {
+	token := uuid.New()
+	entries := []db.CalendarEntry{
+		{
+			ListCalendarEntriesRow: db.ListCalendarEntriesRow{
+				ScheduleID:    1,
+				RecipeID:      2,
+				Name:          "Tomato Soup",
+				Portion:       2,
+				ScheduledDate: sql.NullTime{Time: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Valid: true},
+				CreatedAt:     time.Now().UTC(),
+			},
+			Ingredients: []db.CalendarIngredient{},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			path: fmt.Sprintf("/calendar/%s.ics?time=19:15", token),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCalendarFeedTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CalendarFeedParams) ([]db.CalendarEntry, error) {
+						require.Equal(t, token, arg.Token)
+						require.True(t, arg.Since.Before(time.Now().AddDate(0, 0, -calendarPastDays+1)))
+						return entries, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/calendar")
+				require.Contains(t, recorder.Body.String(), "SUMMARY:Tomato Soup\r\n")
+				require.Contains(t, recorder.Body.String(), "DTSTART:20261019T191500\r\n")
+				require.Contains(t, recorder.Body.String(), "URL:http://example.com/recipe/2\r\n")
+			},
+		},
+		{
+			name: "400 Invalid Time",
+			path: fmt.Sprintf("/calendar/%s.ics?time=7pm", token),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCalendarFeedTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Without Extension",
+			path: fmt.Sprintf("/calendar/%s", token),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCalendarFeedTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "404 Malformed Token",
+			path: "/calendar/not-a-token.ics",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCalendarFeedTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "404 Unknown Token",
+			path: fmt.Sprintf("/calendar/%s.ics", token),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCalendarFeedTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			t.Setenv("PUBLIC_URL", "http://example.com")
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "http://attacker.example.net"+tc.path, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type upsertIngredientCategoryUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// One of the grocery categories, see /groceries/categories
+type upsertIngredientCategoryJSON struct {
+	Category string `json:"category" binding:"required,max=50"`
+}
+
+func (server *Server) upsertIngredientCategory(ctx *gin.Context) {
+	var reqUri upsertIngredientCategoryUri
+	var reqJSON upsertIngredientCategoryJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, err := server.storage.UpsertIngredientCategory(ctx, db.UpsertIngredientCategoryParams{
+		IngredientID: reqUri.ID,
+		Category:     reqJSON.Category,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+type getIngredientCategoryRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getIngredientCategory(ctx *gin.Context) {
+	var req getIngredientCategoryRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, err := server.storage.GetIngredientCategory(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+// Categories in the order a store is walked through
+func (server *Server) listGroceryCategories(ctx *gin.Context) {
+	categories, err := server.storage.ListGroceryCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, categories)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestUpsertIngredientCategoryAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	ingredientID := int32(util.RandomInt(1, 300))
+	arg := db.UpsertIngredientCategoryParams{
+		IngredientID: ingredientID,
+		Category:     "produce",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"category": "produce"},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientCategory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsCategory{IngredientID: ingredientID, Category: "produce"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 No Category",
+			body: gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Unknown Category",
+			body: gin.H{"category": "produce"},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientCategory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsCategory{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{Role: "admin"}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/category/%d", ingredientID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetIngredientCategoryAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := dbmock.NewMockStorage(ctrl)
+	storage.EXPECT().
+		GetIngredientCategory(gomock.Any(), gomock.Eq(int32(7))).
+		Times(1).
+		Return(db.IngredientsCategory{}, sql.ErrNoRows)
+
+	server := newTestServer(t, storage)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/ingredients/category/7", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,536 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+var ErrEmptyCollection = errors.New("collection does not contain any recipe")
+
+type favoriteRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) createFavorite(ctx *gin.Context) {
+	var req favoriteRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	err := server.storage.CreateFavorite(ctx, db.CreateFavoriteParams{
+		UserID:   authPayload.Subject,
+		RecipeID: req.ID,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+func (server *Server) deleteFavorite(ctx *gin.Context) {
+	var req favoriteRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	err := server.storage.DeleteFavorite(ctx, db.DeleteFavoriteParams{
+		UserID:   authPayload.Subject,
+		RecipeID: req.ID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+func (server *Server) listFavorites(ctx *gin.Context) {
+	var req listPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	favorites, err := server.storage.ListFavorites(ctx, db.ListFavoritesParams{
+		UserID: authPayload.Subject,
+		Limit:  req.PageSize,
+		Offset: (req.PageNum - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, favorites)
+}
+
+type collectionResult struct {
+	Collection db.Collection                 `json:"collection"`
+	Recipes    []db.ListCollectionRecipesRow `json:"recipes"`
+}
+
+type collectionUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type collectionNameJSON struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+func (server *Server) createCollection(ctx *gin.Context) {
+	var req collectionNameJSON
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	collection, err := server.storage.CreateCollection(ctx, db.CreateCollectionParams{
+		Owner: authPayload.Subject,
+		Name:  req.Name,
+	})
+	if err != nil {
+		server.collectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+func (server *Server) listCollectionsUser(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	collections, err := server.storage.ListCollectionsUser(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collections)
+}
+
+func (server *Server) getCollection(ctx *gin.Context) {
+	var req collectionUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	collection, ok := server.editableCollection(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	server.sendCollection(ctx, collection)
+}
+
+type getSharedCollectionRequest struct {
+	Token string `uri:"token" binding:"required,uuid"`
+}
+
+// Shared collections are readable by anyone holding the share link
+func (server *Server) getSharedCollection(ctx *gin.Context) {
+	var req getSharedCollectionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	collection, err := server.storage.GetCollectionByShareToken(ctx, uuid.NullUUID{
+		UUID:  uuid.MustParse(req.Token),
+		Valid: true,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sendCollection(ctx, collection)
+}
+
+func (server *Server) updateCollection(ctx *gin.Context) {
+	var reqUri collectionUri
+	var reqJSON collectionNameJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableCollection(ctx, reqUri.ID); !ok {
+		return
+	}
+
+	collection, err := server.storage.UpdateCollectionName(ctx, db.UpdateCollectionNameParams{
+		ID:   reqUri.ID,
+		Name: reqJSON.Name,
+	})
+	if err != nil {
+		server.collectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+func (server *Server) deleteCollection(ctx *gin.Context) {
+	var req collectionUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableCollection(ctx, req.ID); !ok {
+		return
+	}
+
+	err := server.storage.DeleteCollection(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type addCollectionRecipeJSON struct {
+	RecipeID int64 `json:"recipeID" binding:"required,min=1"`
+}
+
+// New recipes are appended to the end of the collection
+func (server *Server) addCollectionRecipe(ctx *gin.Context) {
+	var reqUri collectionUri
+	var reqJSON addCollectionRecipeJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableCollection(ctx, reqUri.ID); !ok {
+		return
+	}
+
+	recipe, err := server.storage.CreateCollectionRecipe(ctx, db.CreateCollectionRecipeParams{
+		CollectionID: reqUri.ID,
+		RecipeID:     reqJSON.RecipeID,
+	})
+	if err != nil {
+		server.collectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+type removeCollectionRecipeRequest struct {
+	ID       int64 `uri:"id" binding:"required,min=1"`
+	RecipeID int64 `uri:"recipeID" binding:"required,min=1"`
+}
+
+func (server *Server) removeCollectionRecipe(ctx *gin.Context) {
+	var req removeCollectionRecipeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableCollection(ctx, req.ID); !ok {
+		return
+	}
+
+	recipes, err := server.storage.RemoveCollectionRecipeTx(ctx, db.RemoveCollectionRecipeParams{
+		CollectionID: req.ID,
+		RecipeID:     req.RecipeID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipes)
+}
+
+type reorderCollectionJSON struct {
+	RecipeIDs []int64 `json:"recipeIDs" binding:"required,min=1,dive,min=1"`
+}
+
+func (server *Server) reorderCollection(ctx *gin.Context) {
+	var reqUri collectionUri
+	var reqJSON reorderCollectionJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableCollection(ctx, reqUri.ID); !ok {
+		return
+	}
+
+	recipes, err := server.storage.ReorderCollectionTx(ctx, db.ReorderCollectionParams{
+		CollectionID: reqUri.ID,
+		RecipeIDs:    reqJSON.RecipeIDs,
+	})
+	if err != nil {
+		if err == db.ErrInvalidCollectionOrder {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipes)
+}
+
+// Create a new share link, an existing link stops working
+func (server *Server) shareCollection(ctx *gin.Context) {
+	server.setCollectionShareToken(ctx, uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	})
+}
+
+func (server *Server) unshareCollection(ctx *gin.Context) {
+	server.setCollectionShareToken(ctx, uuid.NullUUID{})
+}
+
+func (server *Server) setCollectionShareToken(ctx *gin.Context, token uuid.NullUUID) {
+	var req collectionUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableCollection(ctx, req.ID); !ok {
+		return
+	}
+
+	collection, err := server.storage.UpdateCollectionShareToken(ctx, db.UpdateCollectionShareTokenParams{
+		ID:         req.ID,
+		ShareToken: token,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+type planCollectionJSON struct {
+	// Portion of every recipe, zero keeps each recipe's own portion
+	Portion int32 `json:"portion" binding:"omitempty,min=1"`
+	// Schedule the recipes on consecutive days in collection order, empty leaves them undated
+	StartDate    string `json:"startDate" binding:"omitempty,datetime=2006-01-02"`
+	Restrictions string `json:"restrictions" binding:"omitempty,oneof=exclude warn"`
+	UnitSystem   string `json:"unitSystem" binding:"omitempty,oneof=metric imperial"`
+	PinRevisions bool   `json:"pinRevisions"`
+	Store        string `json:"store" binding:"max=100"`
+}
+
+// Plan every recipe of a collection into a new schedule of the caller, only the
+// owner and admins can plan by ID
+func (server *Server) planCollection(ctx *gin.Context) {
+	var reqUri collectionUri
+	var reqJSON planCollectionJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	collection, ok := server.editableCollection(ctx, reqUri.ID)
+	if !ok {
+		return
+	}
+
+	server.planCollectionRecipes(ctx, collection, reqJSON)
+}
+
+// Shared collections can be planned by anyone holding the share link
+func (server *Server) planSharedCollection(ctx *gin.Context) {
+	var reqUri getSharedCollectionRequest
+	var reqJSON planCollectionJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	collection, err := server.storage.GetCollectionByShareToken(ctx, uuid.NullUUID{
+		UUID:  uuid.MustParse(reqUri.Token),
+		Valid: true,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.planCollectionRecipes(ctx, collection, reqJSON)
+}
+
+func (server *Server) planCollectionRecipes(ctx *gin.Context, collection db.Collection, reqJSON planCollectionJSON) {
+	recipes, err := server.storage.ListCollectionRecipes(ctx, collection.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(recipes) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrEmptyCollection))
+		return
+	}
+
+	var startDate time.Time
+	if reqJSON.StartDate != "" {
+		startDate, _ = time.Parse("2006-01-02", reqJSON.StartDate)
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.GenerateGroceriesParam{
+		Author: uuid.NullUUID{
+			UUID:  authPayload.Subject,
+			Valid: true,
+		},
+		Recipes:          make([]db.ScheduleRecipePortion, 0, len(recipes)),
+		Restrictions:     reqJSON.Restrictions,
+		RestrictionsUser: authPayload.Subject,
+		UnitSystem:       reqJSON.UnitSystem,
+		PinRevisions:     reqJSON.PinRevisions,
+		Store:            reqJSON.Store,
+	}
+	for i, recipe := range recipes {
+		portion := recipe.Portion
+		if reqJSON.Portion > 0 {
+			portion = reqJSON.Portion
+		}
+		item := db.ScheduleRecipePortion{
+			RecipeID: recipe.RecipeID,
+			Portion:  portion,
+		}
+		if !startDate.IsZero() {
+			item.ScheduledDate = sql.NullTime{
+				Time:  startDate.AddDate(0, 0, i),
+				Valid: true,
+			}
+		}
+		arg.Recipes = append(arg.Recipes, item)
+	}
+
+	groceries, err := server.storage.GenerateGroceries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groceries)
+}
+
+func (server *Server) sendCollection(ctx *gin.Context, collection db.Collection) {
+	recipes, err := server.storage.ListCollectionRecipes(ctx, collection.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collectionResult{
+		Collection: collection,
+		Recipes:    recipes,
+	})
+}
+
+// Load a collection the authenticated user is allowed to change, writes the error response otherwise
+func (server *Server) editableCollection(ctx *gin.Context, id int64) (db.Collection, bool) {
+	collection, err := server.storage.GetCollection(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return collection, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return collection, false
+	}
+
+	return collection, server.ownsCollection(ctx, collection)
+}
+
+// Check that the authenticated user is the owner of a collection or an admin, writes the error response otherwise
+func (server *Server) ownsCollection(ctx *gin.Context, collection db.Collection) bool {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if collection.Owner != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return false
+	}
+
+	return true
+}
+
+func (server *Server) collectionError(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23503":
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		case "23505":
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
@@ -0,0 +1,847 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func randomCollection(owner uuid.UUID) db.Collection {
+	return db.Collection{
+		ID:         util.RandomInt(1, 100),
+		Owner:      owner,
+		Name:       util.RandomString(10),
+		CreatedAt:  time.Now().UTC(),
+		ModifiedAt: time.Now().UTC(),
+	}
+}
+
+func randomCollectionRecipes(collection db.Collection, n int) []db.ListCollectionRecipesRow {
+	recipes := make([]db.ListCollectionRecipesRow, n)
+	for i := range recipes {
+		recipes[i] = db.ListCollectionRecipesRow{
+			RecipeID: int64(i + 1),
+			Name:     util.RandomString(10),
+			Author:   collection.Owner,
+			Portion:  int32(util.RandomInt(1, 10)),
+			Position: int32(i + 1),
+		}
+	}
+
+	return recipes
+}
+
+func TestCreateFavoriteAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+
+	testCases := []struct {
+		name          string
+		recipeID      int64
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			recipeID: recipe.Recipe.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateFavorite(gomock.Any(), gomock.Eq(db.CreateFavoriteParams{
+						UserID:   user.ID,
+						RecipeID: recipe.Recipe.ID,
+					})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "400 Invalid ID",
+			recipeID: 0,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateFavorite(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "404 Recipe Not Found",
+			recipeID: recipe.Recipe.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateFavorite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/favorites/%d", tc.recipeID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateCollectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	collection := randomCollection(user.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name": collection.Name,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateCollection(gomock.Any(), gomock.Eq(db.CreateCollectionParams{
+						Owner: user.ID,
+						Name:  collection.Name,
+					})).
+					Times(1).
+					Return(collection, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.Collection
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, collection.ID, result.ID)
+				require.Equal(t, collection.Name, result.Name)
+				require.False(t, result.ShareToken.Valid)
+			},
+		},
+		{
+			name: "400 Missing Name",
+			body: gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateCollection(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "409 Duplicate Name",
+			body: gin.H{
+				"name": collection.Name,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateCollection(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Collection{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/collection/add", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetCollectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	collection := randomCollection(user.ID)
+	recipes := randomCollectionRecipes(collection, 3)
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(recipes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result collectionResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, collection.ID, result.Collection.ID)
+				require.Equal(t, recipes, result.Recipes)
+			},
+		},
+		{
+			name:   "403 Not Owner",
+			userID: other.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Not Found",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(db.Collection{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/collection/%d", collection.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetSharedCollectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	collection := randomCollection(user.ID)
+	collection.ShareToken = uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+	recipes := randomCollectionRecipes(collection, 2)
+
+	testCases := []struct {
+		name          string
+		token         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			token: collection.ShareToken.UUID.String(),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollectionByShareToken(gomock.Any(), gomock.Eq(collection.ShareToken)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(recipes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result collectionResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, collection.ID, result.Collection.ID)
+				require.Len(t, result.Recipes, len(recipes))
+			},
+		},
+		{
+			name:  "400 Invalid Token",
+			token: "not-a-token",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollectionByShareToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "404 Not Shared",
+			token: uuid.NewString(),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollectionByShareToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Collection{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/collection/shared/%s", tc.token)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReorderCollectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	collection := randomCollection(user.ID)
+	recipes := randomCollectionRecipes(collection, 3)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"recipeIDs": []int64{3, 1, 2},
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ReorderCollectionTx(gomock.Any(), gomock.Eq(db.ReorderCollectionParams{
+						CollectionID: collection.ID,
+						RecipeIDs:    []int64{3, 1, 2},
+					})).
+					Times(1).
+					Return(recipes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Invalid Order",
+			body: gin.H{
+				"recipeIDs": []int64{1, 1, 2},
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ReorderCollectionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrInvalidCollectionOrder)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 Empty Order",
+			body: gin.H{
+				"recipeIDs": []int64{},
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ReorderCollectionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/collection/%d/order", collection.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPlanCollectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	collection := randomCollection(user.ID)
+	shared := randomCollection(user.ID)
+	shared.ShareToken = uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+	recipes := randomCollectionRecipes(collection, 3)
+	startDate := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		collection    db.Collection
+		userID        uuid.UUID
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			collection: collection,
+			userID:     user.ID,
+			body:       gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(recipes, nil)
+
+				arg := db.GenerateGroceriesParam{
+					Author:           uuid.NullUUID{UUID: user.ID, Valid: true},
+					RestrictionsUser: user.ID,
+				}
+				for _, recipe := range recipes {
+					arg.Recipes = append(arg.Recipes, db.ScheduleRecipePortion{
+						RecipeID: recipe.RecipeID,
+						Portion:  recipe.Portion,
+					})
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.GenerateGroceriesResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "OK Portion And Dates",
+			collection: collection,
+			userID:     user.ID,
+			body: gin.H{
+				"portion":      4,
+				"startDate":    "2026-10-19",
+				"pinRevisions": true,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(recipes, nil)
+
+				arg := db.GenerateGroceriesParam{
+					Author:           uuid.NullUUID{UUID: user.ID, Valid: true},
+					RestrictionsUser: user.ID,
+					PinRevisions:     true,
+				}
+				for i, recipe := range recipes {
+					arg.Recipes = append(arg.Recipes, db.ScheduleRecipePortion{
+						RecipeID: recipe.RecipeID,
+						Portion:  4,
+						ScheduledDate: sql.NullTime{
+							Time:  startDate.AddDate(0, 0, i),
+							Valid: true,
+						},
+					})
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.GenerateGroceriesResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "OK Store",
+			collection: collection,
+			userID:     user.ID,
+			body:       gin.H{"store": "market"},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(recipes, nil)
+
+				arg := db.GenerateGroceriesParam{
+					Author:           uuid.NullUUID{UUID: user.ID, Valid: true},
+					RestrictionsUser: user.ID,
+					Store:            "market",
+				}
+				for _, recipe := range recipes {
+					arg.Recipes = append(arg.Recipes, db.ScheduleRecipePortion{
+						RecipeID: recipe.RecipeID,
+						Portion:  recipe.Portion,
+					})
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.GenerateGroceriesResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "403 Shared By ID",
+			collection: shared,
+			userID:     other.ID,
+			body:       gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(shared.ID)).
+					Times(1).
+					Return(shared, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "403 Not Shared",
+			collection: collection,
+			userID:     other.ID,
+			body:       gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "400 Empty Collection",
+			collection: collection,
+			userID:     user.ID,
+			body:       gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return(collection, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(collection.ID)).
+					Times(1).
+					Return([]db.ListCollectionRecipesRow{}, nil)
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "400 Invalid Start Date",
+			collection: collection,
+			userID:     user.ID,
+			body: gin.H{
+				"startDate": "19-10-2026",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollection(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/collection/%d/plan", tc.collection.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPlanSharedCollectionAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	other, _ := randomUser(t)
+	shared := randomCollection(owner.ID)
+	shared.ShareToken = uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+	recipes := randomCollectionRecipes(shared, 2)
+
+	testCases := []struct {
+		name          string
+		token         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			token: shared.ShareToken.UUID.String(),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollectionByShareToken(gomock.Any(), gomock.Eq(shared.ShareToken)).
+					Times(1).
+					Return(shared, nil)
+				storage.EXPECT().
+					ListCollectionRecipes(gomock.Any(), gomock.Eq(shared.ID)).
+					Times(1).
+					Return(recipes, nil)
+
+				arg := db.GenerateGroceriesParam{
+					Author:           uuid.NullUUID{UUID: other.ID, Valid: true},
+					RestrictionsUser: other.ID,
+				}
+				for _, recipe := range recipes {
+					arg.Recipes = append(arg.Recipes, db.ScheduleRecipePortion{
+						RecipeID: recipe.RecipeID,
+						Portion:  recipe.Portion,
+					})
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.GenerateGroceriesResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "404 Unknown Token",
+			token: uuid.New().String(),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollectionByShareToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Collection{}, sql.ErrNoRows)
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Invalid Token",
+			token: "not-a-token",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetCollectionByShareToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/collection/shared/%s/plan", tc.token)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte("{}")))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, other.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
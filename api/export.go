@@ -0,0 +1,125 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/export"
+)
+
+type exportRecipeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type exportRecipeQuery struct {
+	Format string `form:"format" binding:"required,oneof=jsonld markdown txt"`
+	// Serve as a file attachment for backups
+	Download bool `form:"download"`
+}
+
+func (server *Server) exportRecipe(ctx *gin.Context) {
+	var req exportRecipeRequest
+	var query exportRecipeQuery
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	recipe, err := server.storage.GetRecipeTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	data, err := export.Recipe(recipe, query.Format)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if query.Download {
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=recipe-%d.%s", recipe.Recipe.ID, exportExtension(query.Format)))
+	}
+	ctx.Data(http.StatusOK, export.ContentTypes[query.Format], data)
+}
+
+func exportExtension(format string) string {
+	switch format {
+	case export.FormatJSONLD:
+		return "jsonld"
+	case export.FormatMarkdown:
+		return "md"
+	}
+
+	return format
+}
+
+type exportScheduleGroceriesRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type exportScheduleGroceriesQuery struct {
+	// md is short for markdown
+	Format     string `form:"format" binding:"required,oneof=csv md markdown txt html"`
+	UnitSystem string `form:"unitSystem" binding:"omitempty,oneof=metric imperial"`
+	Store      string `form:"store" binding:"max=100"`
+	Download   bool   `form:"download"`
+}
+
+// Grocery list of a schedule grouped by category, for printing or pasting into notes
+func (server *Server) exportScheduleGroceries(ctx *gin.Context) {
+	var req exportScheduleGroceriesRequest
+	var query exportScheduleGroceriesQuery
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if query.Format == "md" {
+		query.Format = export.FormatMarkdown
+	}
+
+	schedule, ok := server.editableSchedule(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	groceries, err := server.storage.GetScheduleGroceriesTx(ctx, db.ScheduleGroceriesParams{
+		ScheduleID: schedule.ID,
+		UnitSystem: query.UnitSystem,
+		Store:      query.Store,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	data, err := export.Groceries(groceries, query.Format)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if query.Download {
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=groceries-%d.%s", schedule.ID, exportExtension(query.Format)))
+	}
+	ctx.Data(http.StatusOK, export.ContentTypes[query.Format], data)
+}
@@ -0,0 +1,296 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestExportRecipeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK JSON-LD",
+			query: "format=jsonld",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/ld+json")
+				require.Contains(t, recorder.Body.String(), recipe.Recipe.Name)
+			},
+		},
+		{
+			name:  "OK Markdown Download",
+			query: "format=markdown&download=true",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/markdown")
+				require.Equal(t,
+					fmt.Sprintf("attachment; filename=recipe-%d.md", recipe.Recipe.ID),
+					recorder.Header().Get("Content-Disposition"),
+				)
+				require.Contains(t, recorder.Body.String(), "# "+recipe.Recipe.Name)
+			},
+		},
+		{
+			name:  "OK Text",
+			query: "format=txt",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+			},
+		},
+		{
+			name:  "400 Invalid Format",
+			query: "format=pdf",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "404 Not Found",
+			query: "format=txt",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(db.RecipeResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/export?%s", recipe.Recipe.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExportScheduleGroceriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	schedule := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true})
+	groceries := db.ScheduleGroceriesResult{
+		Schedule: schedule.Schedule,
+		Recipes:  schedule.Recipes,
+		Groceries: []db.GroceryItem{
+			{ID: 1, Name: "tomatoes", Category: "produce", Quantities: []measure.Quantity{{Amount: 800, Unit: "g"}}},
+			{ID: 2, Name: "salt", Quantities: []measure.Quantity{{Amount: 1, Unit: "tsp"}}},
+		},
+		Categories: []db.GroceryCategory{{Name: "produce", Position: 1}},
+	}
+	arg := db.ScheduleGroceriesParams{ScheduleID: schedule.Schedule.ID}
+
+	testCases := []struct {
+		name          string
+		query         string
+		userID        uuid.UUID
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK Markdown Download",
+			query:  "format=md&download=true",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetScheduleGroceriesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(groceries, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/markdown")
+				require.Equal(t,
+					fmt.Sprintf("attachment; filename=groceries-%d.md", schedule.Schedule.ID),
+					recorder.Header().Get("Content-Disposition"),
+				)
+				require.Contains(t, recorder.Body.String(), "## Produce\n\n- [ ] 800 g tomatoes\n")
+				require.Contains(t, recorder.Body.String(), "## Other\n\n- [ ] 1 tsp salt\n")
+			},
+		},
+		{
+			name:   "OK CSV",
+			query:  "format=csv&unitSystem=imperial&store=corner",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetScheduleGroceriesTx(gomock.Any(), gomock.Eq(db.ScheduleGroceriesParams{
+						ScheduleID: schedule.Schedule.ID,
+						UnitSystem: "imperial",
+						Store:      "corner",
+					})).
+					Times(1).
+					Return(groceries, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/csv")
+				require.Contains(t, recorder.Body.String(), "Produce,tomatoes,800,g,,,,\n")
+			},
+		},
+		{
+			name:   "OK HTML",
+			query:  "format=html",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetScheduleGroceriesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(groceries, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/html")
+				require.Contains(t, recorder.Body.String(), "@media print")
+			},
+		},
+		{
+			name:   "400 Invalid Format",
+			query:  "format=pdf",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+				storage.EXPECT().
+					GetScheduleGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "403 Not Author",
+			query:  "format=txt",
+			userID: other.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetScheduleGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Schedule Not Found",
+			query:  "format=txt",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(db.Schedule{}, sql.ErrNoRows)
+				storage.EXPECT().
+					GetScheduleGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{Role: "common"}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/schedule/%d/groceries?%s", schedule.Schedule.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+)
+
+type forkRecipeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Any user can fork a recipe, the fork is owned by the caller
+func (server *Server) forkRecipe(ctx *gin.Context) {
+	var req forkRecipeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	recipe, err := server.storage.ForkRecipeTx(ctx, db.ForkRecipeParams{
+		Author: authPayload.Subject,
+		ID:     req.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestForkRecipeAPI(t *testing.T) {
+	author, _ := randomUser(t)
+	user, _ := randomUser(t)
+	recipe := randomRecipe(author.ID)
+
+	fork := randomRecipe(user.ID)
+	fork.Recipe.ForkedFrom = sql.NullInt64{
+		Int64: recipe.Recipe.ID,
+		Valid: true,
+	}
+	fork.Forks = &db.RecipeForks{
+		Parent: &db.ListRecipeForksRow{
+			ID:         recipe.Recipe.ID,
+			Name:       recipe.Recipe.Name,
+			Author:     recipe.Recipe.Author,
+			ModifiedAt: recipe.Recipe.ModifiedAt,
+		},
+		Children: []db.ListRecipeForksRow{},
+	}
+
+	testCases := []struct {
+		name          string
+		recipeID      int64
+		setupAuth     bool
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			recipeID:  recipe.Recipe.ID,
+			setupAuth: true,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ForkRecipeTx(gomock.Any(), gomock.Eq(db.ForkRecipeParams{
+						Author: user.ID,
+						ID:     recipe.Recipe.ID,
+					})).
+					Times(1).
+					Return(fork, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.RecipeResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, user.ID, result.Recipe.Author)
+				require.Equal(t, fork.Recipe.ForkedFrom, result.Recipe.ForkedFrom)
+				require.NotNil(t, result.Forks)
+				require.Equal(t, recipe.Recipe.ID, result.Forks.Parent.ID)
+			},
+		},
+		{
+			name:      "400 Bad Request",
+			recipeID:  0,
+			setupAuth: true,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ForkRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "401 Unauthorized",
+			recipeID:  recipe.Recipe.ID,
+			setupAuth: false,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ForkRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "404 Not Found",
+			recipeID:  recipe.Recipe.ID,
+			setupAuth: true,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ForkRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "500 Internal Server Error",
+			recipeID:  recipe.Recipe.ID,
+			setupAuth: true,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ForkRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/fork", tc.recipeID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			if tc.setupAuth {
+				addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			}
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/blob"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/imaging"
+)
+
+// Route the local blob store is served from
+const ImagesPath = "/images"
+
+const (
+	maxImageSize  = 10 << 20
+	thumbnailSize = 320
+)
+
+// Accepted upload content types and the extension of their blob keys
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+var (
+	ErrImageTooLarge   = fmt.Errorf("image is larger than %d bytes", maxImageSize)
+	ErrImageType       = errors.New("image has to be jpeg, png or gif")
+	ErrImageStepRecipe = errors.New("step does not belong to the recipe")
+)
+
+// Fill the URLs of the images from the blob store
+func (server *Server) imageURLs(images []db.RecipeImage) []db.RecipeImage {
+	for i := range images {
+		images[i].URL = server.blobs.URL(images[i].BlobKey)
+		images[i].ThumbnailURL = server.blobs.URL(images[i].ThumbnailKey)
+	}
+
+	return images
+}
+
+// Blobs are removed after their rows, a failure only leaves an unreferenced file behind
+func (server *Server) deleteImageBlobs(ctx context.Context, images []db.RecipesImage) {
+	for _, image := range images {
+		for _, key := range []string{image.BlobKey, image.ThumbnailKey} {
+			if err := server.blobs.Delete(ctx, key); err != nil {
+				log.Printf("unable to delete blob %s: %s", key, err)
+			}
+		}
+	}
+}
+
+type recipeImageUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type uploadRecipeImageForm struct {
+	StepID int64 `form:"stepID" binding:"omitempty,min=1"`
+}
+
+// Multipart upload with the file in the "image" field and an optional step of the recipe
+func (server *Server) uploadRecipeImage(ctx *gin.Context) {
+	var reqUri recipeImageUri
+	var reqForm uploadRecipeImageForm
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeRecipe(ctx, reqUri.ID) {
+		return
+	}
+
+	// leave room for the multipart headers and the other fields
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImageSize+1<<20)
+	if err := ctx.ShouldBind(&reqForm); err != nil {
+		imageFormError(ctx, err)
+		return
+	}
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		imageFormError(ctx, err)
+		return
+	}
+	if header.Size > maxImageSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrImageTooLarge))
+		return
+	}
+
+	stepID := sql.NullInt64{}
+	if reqForm.StepID > 0 {
+		step, err := server.storage.GetRecipeStep(ctx, reqForm.StepID)
+		if err != nil {
+			stepErrorResponse(ctx, err)
+			return
+		}
+		if step.RecipeID != reqUri.ID {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrImageStepRecipe))
+			return
+		}
+		stepID = sql.NullInt64{Int64: step.ID, Valid: true}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxImageSize+1))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(data) > maxImageSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrImageTooLarge))
+		return
+	}
+
+	// trust the content, not the declared type
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(ErrImageType))
+		return
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		if err == imaging.ErrTooManyPixels {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var thumbnail bytes.Buffer
+	thumbnailType, err := imaging.Encode(&thumbnail, imaging.Thumbnail(img, thumbnailSize), format)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	name := uuid.NewString()
+	arg := db.CreateRecipeImageParams{
+		RecipeID:     reqUri.ID,
+		StepID:       stepID,
+		BlobKey:      fmt.Sprintf("recipes/%d/%s%s", reqUri.ID, name, ext),
+		ThumbnailKey: fmt.Sprintf("recipes/%d/%s_thumb%s", reqUri.ID, name, imageExtensions[thumbnailType]),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        int32(img.Bounds().Dx()),
+		Height:       int32(img.Bounds().Dy()),
+	}
+
+	err = server.blobs.Put(ctx, arg.BlobKey, contentType, bytes.NewReader(data))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	err = server.blobs.Put(ctx, arg.ThumbnailKey, thumbnailType, &thumbnail)
+	if err != nil {
+		server.deleteImageBlobs(ctx, []db.RecipesImage{{BlobKey: arg.BlobKey, ThumbnailKey: arg.ThumbnailKey}})
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	image, err := server.storage.CreateRecipeImage(ctx, arg)
+	if err != nil {
+		server.deleteImageBlobs(ctx, []db.RecipesImage{{BlobKey: arg.BlobKey, ThumbnailKey: arg.ThumbnailKey}})
+		stepErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.imageURLs([]db.RecipeImage{{RecipesImage: image}})[0])
+}
+
+func imageFormError(ctx *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrImageTooLarge))
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, errorResponse(err))
+}
+
+func (server *Server) listRecipeImages(ctx *gin.Context) {
+	var req recipeImageUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.storage.GetRecipe(ctx, req.ID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rows, err := server.storage.ListRecipeImages(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	images := make([]db.RecipeImage, len(rows))
+	for i, row := range rows {
+		images[i] = db.RecipeImage{RecipesImage: row}
+	}
+
+	ctx.JSON(http.StatusOK, server.imageURLs(images))
+}
+
+type deleteRecipeImageUri struct {
+	ImageID int64 `uri:"imageID" binding:"required,min=1"`
+}
+
+func (server *Server) deleteRecipeImage(ctx *gin.Context) {
+	var req deleteRecipeImageUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	image, err := server.storage.GetRecipeImage(ctx, req.ImageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !server.authorizeRecipe(ctx, image.RecipeID) {
+		return
+	}
+
+	err = server.storage.DeleteRecipeImage(ctx, image.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.deleteImageBlobs(ctx, []db.RecipesImage{image})
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+// Serve a blob of the local store, keys are random so they can be cached forever
+func (server *Server) getImage(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	file, err := server.blobs.Get(ctx, key)
+	if err != nil {
+		if err == blob.ErrNotFound || err == blob.ErrInvalidKey {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	ctx.DataFromReader(http.StatusOK, -1, contentType, file, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
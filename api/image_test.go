@@ -0,0 +1,663 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	"github.com/hasnaroihan/grocery-planner/blob"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestUploadRecipeImageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	step := db.RecipesStep{ID: 7, RecipeID: recipe.Recipe.ID, Position: 1, Text: "Plate it."}
+	photo := randomPNG(t, 640, 320)
+
+	var created db.CreateRecipeImageParams
+	createImage := func(ctx context.Context, arg db.CreateRecipeImageParams) (db.RecipesImage, error) {
+		created = arg
+		return recipeImageFromParams(arg), nil
+	}
+
+	testCases := []struct {
+		name          string
+		fields        map[string]string
+		file          []byte
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			file: photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(createImage)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.RecipeImage
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, recipe.Recipe.ID, result.RecipeID)
+				require.False(t, result.StepID.Valid)
+				require.Equal(t, "image/png", result.ContentType)
+				require.Equal(t, int64(len(photo)), result.Size)
+				require.Equal(t, int32(640), result.Width)
+				require.Equal(t, int32(320), result.Height)
+				require.True(t, strings.HasPrefix(result.BlobKey, fmt.Sprintf("recipes/%d/", recipe.Recipe.ID)))
+				require.Equal(t, ImagesPath+"/"+result.BlobKey, result.URL)
+				require.Equal(t, ImagesPath+"/"+result.ThumbnailKey, result.ThumbnailURL)
+
+				require.Equal(t, photo, readBlob(t, server, result.BlobKey))
+				thumbnail, format, err := image.Decode(bytes.NewReader(readBlob(t, server, result.ThumbnailKey)))
+				require.NoError(t, err)
+				require.Equal(t, "png", format)
+				require.Equal(t, thumbnailSize, thumbnail.Bounds().Dx())
+				require.Equal(t, thumbnailSize/2, thumbnail.Bounds().Dy())
+			},
+		},
+		{
+			name:   "OK Step",
+			fields: map[string]string{"stepID": fmt.Sprint(step.ID)},
+			file:   photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetRecipeStep(gomock.Any(), gomock.Eq(step.ID)).
+					Times(1).
+					Return(step, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(createImage)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.RecipeImage
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, sql.NullInt64{Int64: step.ID, Valid: true}, result.StepID)
+			},
+		},
+		{
+			name:   "400 Step Of Other Recipe",
+			fields: map[string]string{"stepID": fmt.Sprint(step.ID)},
+			file:   photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetRecipeStep(gomock.Any(), gomock.Eq(step.ID)).
+					Times(1).
+					Return(db.RecipesStep{ID: step.ID, RecipeID: recipe.Recipe.ID + 1000}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Step Not Found",
+			fields: map[string]string{"stepID": fmt.Sprint(step.ID)},
+			file:   photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetRecipeStep(gomock.Any(), gomock.Eq(step.ID)).
+					Times(1).
+					Return(db.RecipesStep{}, sql.ErrNoRows)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "415 Unsupported Type",
+			file: []byte("just some text, not a picture"),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name: "400 Corrupt Image",
+			file: append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "413 Too Large",
+			file: append(photo, make([]byte, maxImageSize)...),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name: "400 Missing File",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			file: photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Any()).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "401 No Authorization",
+			file:      photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "500 Create Removes Blobs",
+			file: photo,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateRecipeImage(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateRecipeImageParams) (db.RecipesImage, error) {
+						created = arg
+						return db.RecipesImage{}, sql.ErrConnDone
+					})
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+				_, err := server.blobs.Get(context.Background(), created.BlobKey)
+				require.ErrorIs(t, err, blob.ErrNotFound)
+				_, err = server.blobs.Get(context.Background(), created.ThumbnailKey)
+				require.ErrorIs(t, err, blob.ErrNotFound)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			for field, value := range tc.fields {
+				require.NoError(t, writer.WriteField(field, value))
+			}
+			if tc.file != nil {
+				part, err := writer.CreateFormFile("image", "photo.png")
+				require.NoError(t, err)
+				_, err = part.Write(tc.file)
+				require.NoError(t, err)
+			}
+			require.NoError(t, writer.Close())
+
+			url := fmt.Sprintf("/recipe/%d/image", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
+
+func TestListRecipeImagesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	images := []db.RecipesImage{
+		randomRecipeImage(recipe.Recipe.ID),
+		randomRecipeImage(recipe.Recipe.ID),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ListRecipeImages(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(images, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []db.RecipeImage
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Len(t, result, len(images))
+				for i, image := range result {
+					require.Equal(t, images[i].ID, image.ID)
+					require.Equal(t, ImagesPath+"/"+images[i].BlobKey, image.URL)
+					require.Equal(t, ImagesPath+"/"+images[i].ThumbnailKey, image.ThumbnailURL)
+				}
+			},
+		},
+		{
+			name: "404 Recipe Not Found",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(db.Recipe{}, sql.ErrNoRows)
+				storage.EXPECT().
+					ListRecipeImages(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/images", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteRecipeImageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	image := randomRecipeImage(recipe.Recipe.ID)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeImage(gomock.Any(), gomock.Eq(image.ID)).
+					Times(1).
+					Return(image, nil)
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					DeleteRecipeImage(gomock.Any(), gomock.Eq(image.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				_, err := server.blobs.Get(context.Background(), image.BlobKey)
+				require.ErrorIs(t, err, blob.ErrNotFound)
+				_, err = server.blobs.Get(context.Background(), image.ThumbnailKey)
+				require.ErrorIs(t, err, blob.ErrNotFound)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeImage(gomock.Any(), gomock.Eq(image.ID)).
+					Times(1).
+					Return(image, nil)
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Any()).
+					AnyTimes().
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					DeleteRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+
+				require.NotEmpty(t, readBlob(t, server, image.BlobKey))
+			},
+		},
+		{
+			name: "404 Image Not Found",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeImage(gomock.Any(), gomock.Eq(image.ID)).
+					Times(1).
+					Return(db.RecipesImage{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DeleteRecipeImage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			putBlob(t, server, image.BlobKey, []byte("photo"))
+			putBlob(t, server, image.ThumbnailKey, []byte("thumbnail"))
+
+			url := fmt.Sprintf("/recipe/image/%d", image.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
+
+func TestGetImageAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, dbmock.NewMockStorage(ctrl))
+	photo := randomPNG(t, 4, 4)
+	putBlob(t, server, "recipes/1/photo.png", photo)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, ImagesPath+"/recipes/1/photo.png", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+	require.Equal(t, photo, recorder.Body.Bytes())
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, ImagesPath+"/recipes/1/missing.png", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func randomPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func randomRecipeImage(recipeID int64) db.RecipesImage {
+	name := uuid.NewString()
+
+	return db.RecipesImage{
+		ID:           int64(uuid.New().ID()),
+		RecipeID:     recipeID,
+		BlobKey:      fmt.Sprintf("recipes/%d/%s.png", recipeID, name),
+		ThumbnailKey: fmt.Sprintf("recipes/%d/%s_thumb.png", recipeID, name),
+		ContentType:  "image/png",
+		Size:         1024,
+		Width:        640,
+		Height:       480,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func recipeImageFromParams(arg db.CreateRecipeImageParams) db.RecipesImage {
+	return db.RecipesImage{
+		ID:           1,
+		RecipeID:     arg.RecipeID,
+		StepID:       arg.StepID,
+		BlobKey:      arg.BlobKey,
+		ThumbnailKey: arg.ThumbnailKey,
+		ContentType:  arg.ContentType,
+		Size:         arg.Size,
+		Width:        arg.Width,
+		Height:       arg.Height,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+func putBlob(t *testing.T, server *Server, key string, data []byte) {
+	err := server.blobs.Put(context.Background(), key, "", bytes.NewReader(data))
+	require.NoError(t, err)
+}
+
+func readBlob(t *testing.T, server *Server, key string) []byte {
+	rc, err := server.blobs.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/schemaorg"
+)
+
+// Recipe pages are rarely more than a few hundred kilobytes
+const maxImportSize = 5 << 20
+
+var (
+	ErrImportTooLarge     = fmt.Errorf("imported document is larger than %d bytes", maxImportSize)
+	ErrImportNoIngredient = errors.New("none of the recipe ingredients could be parsed")
+)
+
+type importRecipeResponse struct {
+	Recipe   db.RecipeResult `json:"recipe"`
+	Unparsed []unparsedLine  `json:"unparsed"`
+}
+
+// Import a schema.org Recipe from a JSON-LD document or an HTML page containing one
+func (server *Server) importRecipe(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	body, err := ctx.GetRawData()
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrImportTooLarge))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	recipe, err := schemaorg.Parse(body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if recipe.Name == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("recipe has no name")))
+		return
+	}
+
+	p, err := server.ingredientParser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ingredients, unparsed := p.ingredientParams(recipe.Ingredients)
+	// recipes need at least one ingredient, like the ones created directly
+	if len(ingredients) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrImportNoIngredient))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.NewRecipeParams{
+		Name:    recipe.Name,
+		Author:  authPayload.Subject,
+		Portion: recipe.Yield,
+		Steps: sql.NullString{
+			String: strings.Join(recipe.Instructions, "\n"),
+			Valid:  len(recipe.Instructions) > 0,
+		},
+		ListIngredients: ingredients,
+	}
+	if arg.Portion <= 0 {
+		arg.Portion = 1
+	}
+	for _, instruction := range recipe.Instructions {
+		arg.StepList = append(arg.StepList, db.StepParam{Text: instruction})
+	}
+
+	result, err := server.storage.NewRecipeTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, importRecipeResponse{
+		Recipe:   result,
+		Unparsed: unparsed,
+	})
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const importJSONLD = `{
+	"@context": "https://schema.org",
+	"@type": "Recipe",
+	"name": "Tomato Soup",
+	"recipeYield": "4 servings",
+	"recipeIngredient": ["2 cups chopped onion", "800 g tomatoes", "1 pint cream", "salt to taste"],
+	"recipeInstructions": [
+		{"@type": "HowToStep", "text": "Fry the onion."},
+		{"@type": "HowToStep", "text": "Add the tomatoes."}
+	]
+}`
+
+const importHTML = `<html><head>
+<script type="application/ld+json">{"@type": "Recipe", "name": "Pancakes", "recipeIngredient": ["2 eggs"]}</script>
+</head></html>`
+
+func TestImportRecipeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	units := []db.Unit{
+		{ID: 1, Name: "gram"},
+		{ID: 2, Name: "cup"},
+		{ID: 3, Name: "piece"},
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK JSON-LD",
+			body: importJSONLD,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.NewRecipeParams{
+					Name:    "Tomato Soup",
+					Author:  user.ID,
+					Portion: 4,
+					Steps: sql.NullString{
+						String: "Fry the onion.\nAdd the tomatoes.",
+						Valid:  true,
+					},
+					ListIngredients: []db.ListIngredientParam{
+						{Name: "onion", Amount: 2, UnitID: 2},
+						{Name: "tomatoes", Amount: 800, UnitID: 1},
+					},
+					StepList: []db.StepParam{
+						{Text: "Fry the onion."},
+						{Text: "Add the tomatoes."},
+					},
+				}
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return(units, nil)
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result importRecipeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, []unparsedLine{
+					{Line: "1 pint cream", Error: ErrUnknownUnit.Error()},
+					{Line: "salt to taste", Error: "ingredient line does not start with an amount"},
+				}, result.Unparsed)
+			},
+		},
+		{
+			name: "OK HTML",
+			body: importHTML,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.NewRecipeParams{
+					Name:    "Pancakes",
+					Author:  user.ID,
+					Portion: 1,
+					ListIngredients: []db.ListIngredientParam{
+						{Name: "eggs", Amount: 2, UnitID: 3},
+					},
+				}
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return(units, nil)
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 No Recipe",
+			body: `{"@type": "Organization", "name": "Example Kitchen"}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 Invalid JSON",
+			body: `{"@type": "Recipe",`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 No Parsed Ingredient",
+			body: `{"@type": "Recipe", "name": "Soup", "recipeIngredient": ["salt to taste", "1 pint cream"]}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return(units, nil)
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "413 Too Large",
+			body: importHTML + strings.Repeat(" ", maxImportSize),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			body: importJSONLD,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return(units, nil)
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/recipe/import", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
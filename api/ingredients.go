@@ -180,3 +180,91 @@ func (server *Server) updateIngredient(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, ingredient)
 }
+
+type createIngredientAliasUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type createIngredientAliasJSON struct {
+	Alias string `json:"alias" binding:"required,lowercase"`
+}
+
+func (server *Server) createIngredientAlias(ctx *gin.Context) {
+	var reqUri createIngredientAliasUri
+	var reqJSON createIngredientAliasJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateIngredientAliasParams{
+		IngredientID: reqUri.ID,
+		Alias:        reqJSON.Alias,
+	}
+
+	alias, err := server.storage.CreateIngredientAlias(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "23505":
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alias)
+}
+
+type listIngredientAliasesRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listIngredientAliases(ctx *gin.Context) {
+	var req listIngredientAliasesRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	aliases, err := server.storage.ListIngredientAliases(ctx, req.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, aliases)
+}
+
+type deleteIngredientAliasRequest struct {
+	Alias string `form:"alias" binding:"required"`
+}
+
+func (server *Server) deleteIngredientAlias(ctx *gin.Context) {
+	var req deleteIngredientAliasRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := server.storage.DeleteIngredientAlias(ctx, req.Alias)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
@@ -818,3 +818,223 @@ func randomIngredient(t *testing.T) db.Ingredient {
 		},
 	}
 }
+
+func TestCreateIngredientAliasAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	alias := db.IngredientsAlias{
+		IngredientID: int32(util.RandomInt(1, 300)),
+		Alias:        strings.ToLower(util.RandomIngredient()),
+	}
+	arg := db.CreateIngredientAliasParams{
+		IngredientID: alias.IngredientID,
+		Alias:        alias.Alias,
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  alias.IngredientID,
+			body: gin.H{
+				"alias": alias.Alias,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientAlias(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(alias, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.IngredientsAlias
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, alias, got)
+			},
+		},
+		{
+			name: "400 Uppercase Alias",
+			uri:  alias.IngredientID,
+			body: gin.H{
+				"alias": strings.ToUpper(alias.Alias),
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientAlias(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Foreign Key Violation",
+			uri:  alias.IngredientID,
+			body: gin.H{
+				"alias": alias.Alias,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientAlias(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsAlias{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "409 Unique Violation",
+			uri:  alias.IngredientID,
+			body: gin.H{
+				"alias": alias.Alias,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientAlias(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsAlias{}, error(&pq.Error{
+						Code: "23505",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  alias.IngredientID,
+			body: gin.H{
+				"alias": alias.Alias,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientAlias(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsAlias{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "admin",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/alias/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListIngredientAliasesAPI(t *testing.T) {
+	ingredientID := int32(util.RandomInt(1, 300))
+	aliases := []db.IngredientsAlias{
+		{
+			IngredientID: ingredientID,
+			Alias:        strings.ToLower(util.RandomIngredient()),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  ingredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientAliases(gomock.Any(), gomock.Eq(ingredientID)).
+					Times(1).
+					Return(aliases, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Bad Request",
+			uri:  -1,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientAliases(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  ingredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientAliases(gomock.Any(), gomock.Eq(ingredientID)).
+					Times(1).
+					Return([]db.IngredientsAlias{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/alias/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/blob"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/require"
@@ -18,7 +19,10 @@ func newTestServer(t *testing.T, storage db.Storage) *Server {
 		log.Fatalf("Error loading environment variables. Err: %s", err)
 	}
 	
-	server, err := NewServer(storage)
+	blobs, err := blob.NewLocalStore(t.TempDir(), ImagesPath)
+	require.NoError(t, err)
+
+	server, err := NewServer(storage, blobs)
 	require.NoError(t, err)
 
 	return server
@@ -19,29 +19,28 @@ const (
 
 func authMiddleware(tokenMaker auth.TokenMaker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		authHeader := ctx.GetHeader(authHeaderKey)
-		if len(authHeader) == 0 {
-			err := errors.New("authorization header is not provided")
+		payload, err := verifyAuthHeader(tokenMaker, ctx.GetHeader(authHeaderKey))
+		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
 
-		fields := strings.Fields(authHeader)
-		if len(fields) < 2 {
-			err := errors.New("invalid authorization header format")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
-			return
-		}
+		ctx.Set(authPayloadKey, payload)
+		ctx.Next()
+	}
+}
 
-		authType := strings.ToLower(fields[0])
-		if authType != authBearerType {
-			err := fmt.Errorf("unsupported authorization type: %s", authType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+// Like authMiddleware, but requests without authorization header are let through
+// without auth payload
+func optionalAuthMiddleware(tokenMaker auth.TokenMaker) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authHeader := ctx.GetHeader(authHeaderKey)
+		if len(authHeader) == 0 {
+			ctx.Next()
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := verifyAuthHeader(tokenMaker, authHeader)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
@@ -52,6 +51,26 @@ func authMiddleware(tokenMaker auth.TokenMaker) gin.HandlerFunc {
 	}
 }
 
+func verifyAuthHeader(tokenMaker auth.TokenMaker, authHeader string) (*auth.Payload, error) {
+	if len(authHeader) == 0 {
+		return nil, errors.New("authorization header is not provided")
+	}
+
+	fields := strings.Fields(authHeader)
+	if len(fields) < 2 {
+		return nil, errors.New("invalid authorization header format")
+	}
+
+	authType := strings.ToLower(fields[0])
+	if authType != authBearerType {
+		return nil, fmt.Errorf("unsupported authorization type: %s", authType)
+	}
+
+	accessToken := fields[1]
+
+	return tokenMaker.VerifyToken(accessToken)
+}
+
 func adminMiddleware(storage db.Storage) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
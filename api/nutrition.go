@@ -0,0 +1,87 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type upsertNutritionUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// Nutrition facts per 100 g of the ingredient, sodium in mg
+type upsertNutritionJSON struct {
+	Calories float32 `json:"calories" binding:"min=0"`
+	Protein  float32 `json:"protein" binding:"min=0"`
+	Fat      float32 `json:"fat" binding:"min=0"`
+	Carbs    float32 `json:"carbs" binding:"min=0"`
+	Fiber    float32 `json:"fiber" binding:"min=0"`
+	Sodium   float32 `json:"sodium" binding:"min=0"`
+}
+
+func (server *Server) upsertNutrition(ctx *gin.Context) {
+	var reqUri upsertNutritionUri
+	var reqJSON upsertNutritionJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertNutritionParams{
+		IngredientID: reqUri.ID,
+		Calories:     reqJSON.Calories,
+		Protein:      reqJSON.Protein,
+		Fat:          reqJSON.Fat,
+		Carbs:        reqJSON.Carbs,
+		Fiber:        reqJSON.Fiber,
+		Sodium:       reqJSON.Sodium,
+	}
+
+	nutrition, err := server.storage.UpsertNutrition(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nutrition)
+}
+
+type getNutritionRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getNutrition(ctx *gin.Context) {
+	var req getNutritionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	nutrition, err := server.storage.GetNutrition(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nutrition)
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestUpsertNutritionAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	user, _ := randomUser(t)
+	nutrition := randomNutrition()
+	arg := db.UpsertNutritionParams{
+		IngredientID: nutrition.IngredientID,
+		Calories:     nutrition.Calories,
+		Protein:      nutrition.Protein,
+		Fat:          nutrition.Fat,
+		Carbs:        nutrition.Carbs,
+		Fiber:        nutrition.Fiber,
+		Sodium:       nutrition.Sodium,
+	}
+	body := gin.H{
+		"calories": nutrition.Calories,
+		"protein":  nutrition.Protein,
+		"fat":      nutrition.Fat,
+		"carbs":    nutrition.Carbs,
+		"fiber":    nutrition.Fiber,
+		"sodium":   nutrition.Sodium,
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  nutrition.IngredientID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "admin",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					UpsertNutrition(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nutrition, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Nutrition
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, nutrition.IngredientID, got.IngredientID)
+				require.Equal(t, nutrition.Calories, got.Calories)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			uri:  nutrition.IngredientID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					UpsertNutrition(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "400 Negative Value",
+			uri:  nutrition.IngredientID,
+			body: gin.H{
+				"calories": -1,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "admin",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					UpsertNutrition(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Ingredient Not Found",
+			uri:  nutrition.IngredientID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "admin",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					UpsertNutrition(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Nutrition{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  nutrition.IngredientID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "admin",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					UpsertNutrition(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Nutrition{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/nutrition/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetNutritionAPI(t *testing.T) {
+	nutrition := randomNutrition()
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  nutrition.IngredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetNutrition(gomock.Any(), gomock.Eq(nutrition.IngredientID)).
+					Times(1).
+					Return(nutrition, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Bad Request",
+			uri:  -1,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetNutrition(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Not Found",
+			uri:  nutrition.IngredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetNutrition(gomock.Any(), gomock.Eq(nutrition.IngredientID)).
+					Times(1).
+					Return(db.Nutrition{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  nutrition.IngredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetNutrition(gomock.Any(), gomock.Eq(nutrition.IngredientID)).
+					Times(1).
+					Return(db.Nutrition{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/nutrition/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomNutrition() db.Nutrition {
+	return db.Nutrition{
+		IngredientID: int32(util.RandomInt(1, 300)),
+		Calories:     float32(util.RandomInt(0, 900)),
+		Protein:      float32(util.RandomInt(0, 100)),
+		Fat:          float32(util.RandomInt(0, 100)),
+		Carbs:        float32(util.RandomInt(0, 100)),
+		Fiber:        float32(util.RandomInt(0, 30)),
+		Sodium:       float32(util.RandomInt(0, 2000)),
+		ModifiedAt:   time.Now().UTC(),
+	}
+}
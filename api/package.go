@@ -0,0 +1,115 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type createIngredientPackageUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// Size the ingredient is sold in, no unit means a number of pieces and no store
+// means it is sold anywhere
+type createIngredientPackageJSON struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	UnitID int32   `json:"unitID" binding:"omitempty,min=1"`
+	Store  string  `json:"store" binding:"max=100"`
+}
+
+func (server *Server) createIngredientPackage(ctx *gin.Context) {
+	var reqUri createIngredientPackageUri
+	var reqJSON createIngredientPackageJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateIngredientPackageParams{
+		IngredientID: reqUri.ID,
+		Amount:       reqJSON.Amount,
+		UnitID: sql.NullInt32{
+			Int32: reqJSON.UnitID,
+			Valid: reqJSON.UnitID > 0,
+		},
+		Store: nullString(reqJSON.Store),
+	}
+
+	pkg, err := server.storage.CreateIngredientPackage(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "23505":
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pkg)
+}
+
+type listIngredientPackagesUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listIngredientPackages(ctx *gin.Context) {
+	var req listIngredientPackagesUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	packages, err := server.storage.ListIngredientPackages(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, packages)
+}
+
+type deleteIngredientPackageUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteIngredientPackage(ctx *gin.Context) {
+	var req deleteIngredientPackageUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.storage.GetIngredientPackage(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.storage.DeleteIngredientPackage(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,376 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCreateIngredientPackageAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	user, _ := randomUser(t)
+	pkg := randomIngredientPackage()
+	arg := db.CreateIngredientPackageParams{
+		IngredientID: pkg.IngredientID,
+		Amount:       pkg.Amount,
+		UnitID:       pkg.UnitID,
+		Store:        pkg.Store,
+	}
+	body := gin.H{
+		"amount": pkg.Amount,
+		"unitID": pkg.UnitID.Int32,
+		"store":  pkg.Store.String,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(pkg, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.IngredientsPackage
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, pkg.ID, got.ID)
+				require.Equal(t, pkg.Amount, got.Amount)
+			},
+		},
+		{
+			name: "OK Pieces Any Store",
+			body: gin.H{
+				"amount": 6,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Eq(db.CreateIngredientPackageParams{
+						IngredientID: pkg.IngredientID,
+						Amount:       6,
+					})).
+					Times(1).
+					Return(pkg, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "400 Zero Amount",
+			body: gin.H{
+				"amount": 0,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Ingredient Not Found",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsPackage{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "409 Duplicate Package",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsPackage{}, error(&pq.Error{
+						Code: "23505",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPackage(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsPackage{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/package/%d", pkg.IngredientID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListIngredientPackagesAPI(t *testing.T) {
+	ingredientID := int32(util.RandomInt(1, 300))
+	packages := []db.ListIngredientPackagesRow{
+		{ID: 1, IngredientID: ingredientID, Amount: 250, UnitName: sql.NullString{String: "g", Valid: true}},
+		{ID: 2, IngredientID: ingredientID, Amount: 500, Store: sql.NullString{String: "market", Valid: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientPackages(gomock.Any(), gomock.Eq(ingredientID)).
+					Times(1).
+					Return(packages, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ListIngredientPackagesRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+				require.Equal(t, packages[0].Amount, got[0].Amount)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientPackages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/package/%d", ingredientID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteIngredientPackageAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	pkg := randomIngredientPackage()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetIngredientPackage(gomock.Any(), gomock.Eq(pkg.ID)).
+					Times(1).
+					Return(pkg, nil)
+				storage.EXPECT().
+					DeleteIngredientPackage(gomock.Any(), gomock.Eq(pkg.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "404 Not Found",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetIngredientPackage(gomock.Any(), gomock.Eq(pkg.ID)).
+					Times(1).
+					Return(db.IngredientsPackage{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DeleteIngredientPackage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{Role: "admin"}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/package/delete/%d", pkg.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomIngredientPackage() db.IngredientsPackage {
+	return db.IngredientsPackage{
+		ID:           util.RandomInt(1, 1000),
+		IngredientID: int32(util.RandomInt(1, 300)),
+		Amount:       float64(util.RandomInt(1, 20)) * 50,
+		UnitID:       sql.NullInt32{Int32: int32(util.RandomInt(1, 20)), Valid: true},
+		Store:        sql.NullString{String: util.RandomString(8), Valid: true},
+		CreatedAt:    time.Now().UTC(),
+	}
+}
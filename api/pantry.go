@@ -0,0 +1,293 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+const defaultPantrySuggestions = 10
+
+type upsertIngredientShelfLifeUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// Days the ingredient keeps once bought
+type upsertIngredientShelfLifeJSON struct {
+	Days int32 `json:"days" binding:"required,min=1,max=3650"`
+}
+
+func (server *Server) upsertIngredientShelfLife(ctx *gin.Context) {
+	var reqUri upsertIngredientShelfLifeUri
+	var reqJSON upsertIngredientShelfLifeJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shelfLife, err := server.storage.UpsertIngredientShelfLife(ctx, db.UpsertIngredientShelfLifeParams{
+		IngredientID: reqUri.ID,
+		Days:         reqJSON.Days,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, shelfLife)
+}
+
+type getIngredientShelfLifeRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getIngredientShelfLife(ctx *gin.Context) {
+	var req getIngredientShelfLifeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shelfLife, err := server.storage.GetIngredientShelfLife(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, shelfLife)
+}
+
+type createPantryItemRequest struct {
+	IngredientID int32   `json:"ingredientID" binding:"required,min=1"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	UnitID       int32   `json:"unitID" binding:"omitempty,min=1"`
+	// Empty when the item does not expire
+	ExpiresOn string `json:"expiresOn" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (server *Server) createPantryItem(ctx *gin.Context) {
+	var req createPantryItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.CreatePantryItemParams{
+		UserID:       authPayload.Subject,
+		IngredientID: req.IngredientID,
+		Amount:       req.Amount,
+		UnitID:       nullInt32(req.UnitID),
+	}
+	if req.ExpiresOn != "" {
+		expiresOn, _ := time.Parse("2006-01-02", req.ExpiresOn)
+		arg.ExpiresOn = sql.NullTime{Time: expiresOn, Valid: true}
+	}
+
+	item, err := server.storage.CreatePantryItem(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+// The user's pantry, items expiring first
+func (server *Server) listPantryItems(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+
+	items, err := server.storage.ListPantryItems(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+type deletePantryItemRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deletePantryItem(ctx *gin.Context) {
+	var req deletePantryItemRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	item, err := server.storage.GetPantryItem(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	if item.UserID != authPayload.Subject {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	err = server.storage.DeletePantryItem(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type suggestPantryRecipesRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// Recipes for the next plan that use up the pantry before it expires
+func (server *Server) suggestPantryRecipes(ctx *gin.Context) {
+	var req suggestPantryRecipesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultPantrySuggestions
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	suggestions, err := server.storage.SuggestPantryRecipesTx(ctx, db.SuggestPantryRecipesParams{
+		UserID: authPayload.Subject,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+type scheduleLeftoversUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type scheduleLeftoversRequest struct {
+	Store string `form:"store" json:"store" binding:"max=100"`
+	// Empty for the schedule's last scheduled day
+	CookedOn string `form:"cookedOn" json:"cookedOn" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (req scheduleLeftoversRequest) params(schedule db.Schedule, userID uuid.UUID) db.ScheduleLeftoversParams {
+	arg := db.ScheduleLeftoversParams{
+		ScheduleID: schedule.ID,
+		UserID:     userID,
+		Store:      req.Store,
+	}
+	if req.CookedOn != "" {
+		arg.CookedOn, _ = time.Parse("2006-01-02", req.CookedOn)
+	}
+
+	return arg
+}
+
+// Leftovers the schedule's groceries are expected to leave
+func (server *Server) listScheduleLeftovers(ctx *gin.Context) {
+	var reqUri scheduleLeftoversUri
+	var reqQuery scheduleLeftoversRequest
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&reqQuery); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, ok := server.editableSchedule(ctx, reqUri.ID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	leftovers, err := server.storage.ListScheduleLeftoversTx(ctx, reqQuery.params(schedule, authPayload.Subject))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, leftovers)
+}
+
+// Add the schedule's expected leftovers to the pantry of its author
+func (server *Server) addScheduleLeftovers(ctx *gin.Context) {
+	var reqUri scheduleLeftoversUri
+	var reqJSON scheduleLeftoversRequest
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, ok := server.editableSchedule(ctx, reqUri.ID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	userID := authPayload.Subject
+	if schedule.Author.Valid {
+		userID = schedule.Author.UUID
+	}
+
+	items, err := server.storage.AddScheduleLeftoversTx(ctx, reqJSON.params(schedule, userID))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" {
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
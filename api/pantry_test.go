@@ -0,0 +1,567 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestUpsertIngredientShelfLifeAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	ingredientID := int32(util.RandomInt(1, 300))
+	arg := db.UpsertIngredientShelfLifeParams{
+		IngredientID: ingredientID,
+		Days:         5,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"days": 5},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientShelfLife(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsShelfLife{IngredientID: ingredientID, Days: 5}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Zero Days",
+			body: gin.H{"days": 0},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientShelfLife(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Ingredient Not Found",
+			body: gin.H{"days": 5},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientShelfLife(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsShelfLife{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{Role: "admin"}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/shelflife/%d", ingredientID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreatePantryItemAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	item := randomPantryItem(user.ID)
+	arg := db.CreatePantryItemParams{
+		UserID:       user.ID,
+		IngredientID: item.IngredientID,
+		Amount:       item.Amount,
+		UnitID:       item.UnitID,
+		ExpiresOn:    item.ExpiresOn,
+	}
+	body := gin.H{
+		"ingredientID": item.IngredientID,
+		"amount":       item.Amount,
+		"unitID":       item.UnitID.Int32,
+		"expiresOn":    item.ExpiresOn.Time.Format("2006-01-02"),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreatePantryItem(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(item, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.PantryItem
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, item.ID, got.ID)
+			},
+		},
+		{
+			name: "400 Invalid Date",
+			body: gin.H{
+				"ingredientID": item.IngredientID,
+				"amount":       item.Amount,
+				"expiresOn":    "tomorrow",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreatePantryItem(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Ingredient Not Found",
+			body: body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreatePantryItem(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.PantryItem{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/pantry/add", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeletePantryItemAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	item := randomPantryItem(user.ID)
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPantryItem(gomock.Any(), gomock.Eq(item.ID)).
+					Times(1).
+					Return(item, nil)
+				storage.EXPECT().
+					DeletePantryItem(gomock.Any(), gomock.Eq(item.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "403 Other User",
+			userID: other.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPantryItem(gomock.Any(), gomock.Eq(item.ID)).
+					Times(1).
+					Return(item, nil)
+				storage.EXPECT().
+					DeletePantryItem(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Not Found",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPantryItem(gomock.Any(), gomock.Eq(item.ID)).
+					Times(1).
+					Return(db.PantryItem{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DeletePantryItem(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/pantry/delete/%d", item.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSuggestPantryRecipesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK Default Limit",
+			query: "",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestPantryRecipesTx(gomock.Any(), gomock.Eq(db.SuggestPantryRecipesParams{
+						UserID: user.ID,
+						Limit:  defaultPantrySuggestions,
+					})).
+					Times(1).
+					Return([]db.PantrySuggestion{{RecipeID: 1, Ingredients: []int32{2}}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Limit Too Large",
+			query: "limit=100",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestPantryRecipesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "500 Internal Server Error",
+			query: "limit=5",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestPantryRecipesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/pantry/suggestions?%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAddScheduleLeftoversAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	schedule := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true}).Schedule
+	arg := db.ScheduleLeftoversParams{
+		ScheduleID: schedule.ID,
+		UserID:     user.ID,
+		Store:      "market",
+		CookedOn:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+	}
+	body := gin.H{
+		"store":    "market",
+		"cookedOn": "2026-10-20",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					AddScheduleLeftoversTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.PantryItem{randomPantryItem(user.ID)}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, other.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					AddScheduleLeftoversTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "404 Schedule Not Found",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(db.Schedule{}, sql.ErrNoRows)
+				storage.EXPECT().
+					AddScheduleLeftoversTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "409 Already Added",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					AddScheduleLeftoversTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil, error(&pq.Error{
+						Code: "23505",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/schedule/leftovers/%d", schedule.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListScheduleLeftoversAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	schedule := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true}).Schedule
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := dbmock.NewMockStorage(ctrl)
+	storage.EXPECT().
+		GetSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+		Times(1).
+		Return(schedule, nil)
+	storage.EXPECT().
+		GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(db.GetPermissionRow{Role: "common"}, nil)
+	storage.EXPECT().
+		ListScheduleLeftoversTx(gomock.Any(), gomock.Eq(db.ScheduleLeftoversParams{
+			ScheduleID: schedule.ID,
+			UserID:     user.ID,
+		})).
+		Times(1).
+		Return([]db.ScheduleLeftover{{IngredientID: 1, Amount: 100, Unit: "g"}}, nil)
+
+	server := newTestServer(t, storage)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/schedule/leftovers/%d", schedule.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.ScheduleLeftover
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
+func randomPantryItem(userID uuid.UUID) db.PantryItem {
+	return db.PantryItem{
+		ID:           util.RandomInt(1, 1000),
+		UserID:       userID,
+		IngredientID: int32(util.RandomInt(1, 300)),
+		Amount:       float64(util.RandomInt(1, 500)),
+		UnitID:       sql.NullInt32{Int32: int32(util.RandomInt(1, 20)), Valid: true},
+		ExpiresOn:    sql.NullTime{Time: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), Valid: true},
+		CreatedAt:    time.Now().UTC(),
+	}
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/hasnaroihan/grocery-planner/parser"
+)
+
+var (
+	ErrUnknownUnit         = errors.New("unit does not exist")
+	ErrDuplicateIngredient = errors.New("ingredient is listed more than once")
+)
+
+// An ingredient line left out of a recipe for the user to fix
+type unparsedLine struct {
+	Line  string `json:"line"`
+	Error string `json:"error"`
+}
+
+// Ingredient line parser knowing the units table
+type lineParser struct {
+	parser *parser.Parser
+	// Unit ids by the unit names the parser returns
+	unitIDs map[string]int32
+}
+
+func (server *Server) ingredientParser(ctx *gin.Context) (*lineParser, error) {
+	units, err := server.storage.ListUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(units))
+	unitIDs := make(map[string]int32, len(units))
+	for i, unit := range units {
+		names[i] = unit.Name
+		if name, ok := measure.UnitName(unit.Name); ok {
+			unitIDs[name] = unit.ID
+		} else {
+			unitIDs[unit.Name] = unit.ID
+		}
+	}
+
+	return &lineParser{
+		parser:  parser.New(names),
+		unitIDs: unitIDs,
+	}, nil
+}
+
+// Parse a line and resolve its unit id
+func (p *lineParser) parse(line string) (parser.Line, int32, error) {
+	parsed, err := p.parser.Parse(line)
+	if err != nil {
+		return parsed, 0, err
+	}
+
+	unitID, ok := p.unitIDs[parsed.Unit]
+	// counted units missing from the units table are counted in pieces
+	if !ok && measure.IsCount(parsed.Unit) {
+		parsed.Unit = "piece"
+		unitID, ok = p.unitIDs[parsed.Unit]
+	}
+	if !ok {
+		return parsed, 0, ErrUnknownUnit
+	}
+
+	return parsed, unitID, nil
+}
+
+// Parse ingredient lines into recipe ingredients, lines that can not be used are
+// returned with the reason. Ranges use their upper bound so groceries are enough.
+func (p *lineParser) ingredientParams(lines []string) ([]db.ListIngredientParam, []unparsedLine) {
+	ingredients := []db.ListIngredientParam{}
+	unparsed := []unparsedLine{}
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		parsed, unitID, err := p.parse(line)
+		if err != nil {
+			unparsed = append(unparsed, unparsedLine{Line: line, Error: err.Error()})
+			continue
+		}
+		if seen[parsed.Name] {
+			unparsed = append(unparsed, unparsedLine{Line: line, Error: ErrDuplicateIngredient.Error()})
+			continue
+		}
+		seen[parsed.Name] = true
+
+		amount := parsed.Amount
+		if parsed.AmountMax > amount {
+			amount = parsed.AmountMax
+		}
+		ingredients = append(ingredients, db.ListIngredientParam{
+			Name:   parsed.Name,
+			Amount: float32(amount),
+			UnitID: unitID,
+		})
+	}
+
+	return ingredients, unparsed
+}
+
+// Non empty lines of a block of text
+func textLines(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+type parseIngredientsRequest struct {
+	Lines []string `json:"lines" binding:"required_without=Text,omitempty,max=200"`
+	Text  string   `json:"text" binding:"required_without=Lines"`
+}
+
+type parsedLine struct {
+	Text string `json:"line"`
+	parser.Line
+	UnitID int32  `json:"unitID,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (server *Server) parseIngredients(ctx *gin.Context) {
+	var req parseIngredientsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	p, err := server.ingredientParser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines := append(req.Lines, textLines(req.Text)...)
+	result := make([]parsedLine, len(lines))
+	for i, line := range lines {
+		parsed, unitID, err := p.parse(line)
+		result[i] = parsedLine{
+			Text:   line,
+			Line:   parsed,
+			UnitID: unitID,
+		}
+		if err != nil {
+			result[i].Error = err.Error()
+		}
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/parser"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestParseIngredientsAPI(t *testing.T) {
+	units := []db.Unit{
+		{ID: 1, Name: "gram"},
+		{ID: 2, Name: "tbsp"},
+		{ID: 3, Name: "pinch"},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"lines": []string{"1 1/2 tbsp olive oil, divided", "200g butter"},
+				"text":  "1 pinch nutmeg\n2 cups milk",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return(units, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []parsedLine
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, []parsedLine{
+					{
+						Text:   "1 1/2 tbsp olive oil, divided",
+						Line:   parser.Line{Amount: 1.5, Unit: "tbsp", Name: "olive oil", Note: "divided"},
+						UnitID: 2,
+					},
+					{
+						Text:   "200g butter",
+						Line:   parser.Line{Amount: 200, Unit: "g", Name: "butter"},
+						UnitID: 1,
+					},
+					{
+						Text:   "1 pinch nutmeg",
+						Line:   parser.Line{Amount: 1, Unit: "pinch", Name: "nutmeg"},
+						UnitID: 3,
+					},
+					{
+						Text:  "2 cups milk",
+						Line:  parser.Line{Amount: 2, Unit: "cup", Name: "milk"},
+						Error: ErrUnknownUnit.Error(),
+					},
+				}, result)
+			},
+		},
+		{
+			name: "400 Bad Request",
+			body: gin.H{},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			body: gin.H{"text": "200g butter"},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/parse/ingredients", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/planner"
+)
+
+type suggestMealPlanRequest struct {
+	Budget      float64 `json:"budget" binding:"required,gt=0"`
+	Days        int     `json:"days" binding:"required,min=1,max=28"`
+	MealsPerDay int     `json:"mealsPerDay" binding:"required,min=1,max=6"`
+	Servings    int32   `json:"servings" binding:"required,min=1,max=50"`
+	// exclude to leave out recipes conflicting with the user's dietary restrictions
+	Restrictions string `json:"restrictions" binding:"omitempty,oneof=exclude"`
+	// First day of the plan, empty for today
+	StartDate string `json:"startDate" binding:"omitempty,datetime=2006-01-02"`
+	Cuisine   string `json:"cuisine" binding:"omitempty,lowercase,max=50"`
+	Course    string `json:"course" binding:"omitempty,lowercase,max=50"`
+}
+
+// Draft a schedule under the budget, the returned recipes can be sent as is
+// to /groceries to accept it
+func (server *Server) suggestMealPlan(ctx *gin.Context) {
+	var req suggestMealPlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	startDate := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.StartDate != "" {
+		startDate, _ = time.Parse("2006-01-02", req.StartDate)
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.SuggestMealPlanParams{
+		Author: uuid.NullUUID{
+			UUID:  authPayload.Subject,
+			Valid: true,
+		},
+		Restrictions: req.Restrictions,
+		Budget:       req.Budget,
+		Days:         req.Days,
+		MealsPerDay:  req.MealsPerDay,
+		Servings:     req.Servings,
+		StartDate:    startDate,
+		Cuisine:      nullString(req.Cuisine),
+		Course:       nullString(req.Course),
+	}
+
+	plan, err := server.storage.SuggestMealPlanTx(ctx, arg)
+	if err != nil {
+		switch err {
+		case planner.ErrNoCandidates, planner.ErrOverBudget, planner.ErrNotEnough:
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/planner"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestSuggestMealPlanAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	startDate := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	arg := db.SuggestMealPlanParams{
+		Author:       uuid.NullUUID{UUID: user.ID, Valid: true},
+		Restrictions: db.RestrictionsExclude,
+		Budget:       50,
+		Days:         2,
+		MealsPerDay:  2,
+		Servings:     2,
+		StartDate:    startDate,
+		Course:       sql.NullString{String: "dinner", Valid: true},
+	}
+	body := gin.H{
+		"budget":       50,
+		"days":         2,
+		"mealsPerDay":  2,
+		"servings":     2,
+		"restrictions": "exclude",
+		"startDate":    "2026-10-19",
+		"course":       "dinner",
+	}
+	suggestion := randomMealPlanSuggestion(arg)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestMealPlanTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(suggestion, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.MealPlanSuggestion
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got.Recipes, len(suggestion.Recipes))
+				require.Equal(t, suggestion.Cost, got.Cost)
+			},
+		},
+		{
+			name: "400 Over Budget",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestMealPlanTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MealPlanSuggestion{}, planner.ErrOverBudget)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 Too Many Days",
+			body: gin.H{
+				"budget":      50,
+				"days":        29,
+				"mealsPerDay": 2,
+				"servings":    2,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestMealPlanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 No Budget",
+			body: gin.H{
+				"days":        2,
+				"mealsPerDay": 2,
+				"servings":    2,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestMealPlanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "401 Unauthorized",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestMealPlanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SuggestMealPlanTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MealPlanSuggestion{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/schedule/suggest", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomMealPlanSuggestion(arg db.SuggestMealPlanParams) db.MealPlanSuggestion {
+	suggestion := db.MealPlanSuggestion{
+		Budget:   arg.Budget,
+		Unpriced: []int64{},
+	}
+
+	for day := 0; day < arg.Days; day++ {
+		date := arg.StartDate.AddDate(0, 0, day)
+		for slot := 0; slot < arg.MealsPerDay; slot++ {
+			meal := db.SuggestedMeal{
+				Date:       date,
+				Slot:       slot,
+				RecipeID:   util.RandomInt(1, 100),
+				RecipeName: util.RandomString(12),
+				Cost:       float64(util.RandomInt(100, 1000)) / 100,
+			}
+			suggestion.Meals = append(suggestion.Meals, meal)
+			suggestion.Recipes = append(suggestion.Recipes, db.ScheduleRecipePortion{
+				RecipeID:      meal.RecipeID,
+				Portion:       arg.Servings,
+				ScheduledDate: sql.NullTime{Time: date, Valid: true},
+			})
+			suggestion.Cost += meal.Cost
+		}
+	}
+
+	return suggestion
+}
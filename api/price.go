@@ -0,0 +1,138 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type createIngredientPriceUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// Price of one unit of the ingredient, no unit means one piece
+type createIngredientPriceJSON struct {
+	Price  float64 `json:"price" binding:"required,gt=0"`
+	UnitID int32   `json:"unitID" binding:"omitempty,min=1"`
+	Store  string  `json:"store" binding:"max=100"`
+	// Day the price was seen, empty for today
+	ObservedOn string `json:"observedOn" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (server *Server) createIngredientPrice(ctx *gin.Context) {
+	var reqUri createIngredientPriceUri
+	var reqJSON createIngredientPriceJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateIngredientPriceParams{
+		IngredientID: reqUri.ID,
+		UnitID: sql.NullInt32{
+			Int32: reqJSON.UnitID,
+			Valid: reqJSON.UnitID > 0,
+		},
+		Price: reqJSON.Price,
+		Store: nullString(reqJSON.Store),
+	}
+	if reqJSON.ObservedOn != "" {
+		observedOn, _ := time.Parse("2006-01-02", reqJSON.ObservedOn)
+		arg.ObservedOn = sql.NullTime{Time: observedOn, Valid: true}
+	}
+
+	price, err := server.storage.CreateIngredientPrice(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, price)
+}
+
+type listIngredientPricesUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// Price history, newest first
+type listIngredientPricesQuery struct {
+	PageSize int32  `form:"pageSize" binding:"required,number"`
+	PageNum  int32  `form:"pageNum" binding:"required,number"`
+	Store    string `form:"store"`
+}
+
+func (server *Server) listIngredientPrices(ctx *gin.Context) {
+	var reqUri listIngredientPricesUri
+	var reqQuery listIngredientPricesQuery
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&reqQuery); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListIngredientPricesParams{
+		IngredientID: reqUri.ID,
+		Store:        nullString(reqQuery.Store),
+		Limit:        reqQuery.PageSize,
+		Offset:       (reqQuery.PageNum - 1) * reqQuery.PageSize,
+	}
+
+	prices, err := server.storage.ListIngredientPrices(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prices)
+}
+
+type deleteIngredientPriceUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteIngredientPrice(ctx *gin.Context) {
+	var req deleteIngredientPriceUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.storage.GetIngredientPrice(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.storage.DeleteIngredientPrice(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
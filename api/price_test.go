@@ -0,0 +1,417 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCreateIngredientPriceAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	user, _ := randomUser(t)
+	price := randomIngredientPrice()
+	observedOn := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	arg := db.CreateIngredientPriceParams{
+		IngredientID: price.IngredientID,
+		UnitID:       price.UnitID,
+		Price:        price.Price,
+		Store:        price.Store,
+		ObservedOn:   sql.NullTime{Time: observedOn, Valid: true},
+	}
+	body := gin.H{
+		"price":      price.Price,
+		"unitID":     price.UnitID.Int32,
+		"store":      price.Store.String,
+		"observedOn": "2026-10-01",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(price, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.IngredientsPrice
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, price.ID, got.ID)
+				require.Equal(t, price.Price, got.Price)
+			},
+		},
+		{
+			name: "OK Per Piece Today",
+			body: gin.H{
+				"price": 0.25,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Eq(db.CreateIngredientPriceParams{
+						IngredientID: price.IngredientID,
+						Price:        0.25,
+					})).
+					Times(1).
+					Return(price, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "400 Missing Price",
+			body: gin.H{
+				"store": "market",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 Invalid Date",
+			body: gin.H{
+				"price":      1.5,
+				"observedOn": "01/10/2026",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Ingredient Not Found",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsPrice{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					CreateIngredientPrice(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsPrice{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/price/%d", price.IngredientID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListIngredientPricesAPI(t *testing.T) {
+	ingredientID := int32(util.RandomInt(1, 300))
+	prices := []db.ListIngredientPricesRow{
+		{ID: 2, IngredientID: ingredientID, Price: 2.49, Store: sql.NullString{String: "market", Valid: true}},
+		{ID: 1, IngredientID: ingredientID, Price: 2.29, Store: sql.NullString{String: "market", Valid: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "pageSize=5&pageNum=1",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientPrices(gomock.Any(), gomock.Eq(db.ListIngredientPricesParams{
+						IngredientID: ingredientID,
+						Limit:        5,
+						Offset:       0,
+					})).
+					Times(1).
+					Return(prices, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ListIngredientPricesRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+				require.Equal(t, prices[0].Price, got[0].Price)
+			},
+		},
+		{
+			name:  "OK Store",
+			query: "pageSize=5&pageNum=2&store=market",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientPrices(gomock.Any(), gomock.Eq(db.ListIngredientPricesParams{
+						IngredientID: ingredientID,
+						Store:        sql.NullString{String: "market", Valid: true},
+						Limit:        5,
+						Offset:       5,
+					})).
+					Times(1).
+					Return([]db.ListIngredientPricesRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Missing Page",
+			query: "",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientPrices(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "500 Internal Server Error",
+			query: "pageSize=5&pageNum=1",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientPrices(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/price/%d?%s", ingredientID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteIngredientPriceAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	price := randomIngredientPrice()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetIngredientPrice(gomock.Any(), gomock.Eq(price.ID)).
+					Times(1).
+					Return(price, nil)
+				storage.EXPECT().
+					DeleteIngredientPrice(gomock.Any(), gomock.Eq(price.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "404 Not Found",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetIngredientPrice(gomock.Any(), gomock.Eq(price.ID)).
+					Times(1).
+					Return(db.IngredientsPrice{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DeleteIngredientPrice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{Role: "admin"}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/price/delete/%d", price.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomIngredientPrice() db.IngredientsPrice {
+	return db.IngredientsPrice{
+		ID:           util.RandomInt(1, 1000),
+		IngredientID: int32(util.RandomInt(1, 300)),
+		UnitID:       sql.NullInt32{Int32: int32(util.RandomInt(1, 20)), Valid: true},
+		Price:        float64(util.RandomInt(1, 2000)) / 100,
+		Store:        sql.NullString{String: util.RandomString(8), Valid: true},
+		ObservedOn:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:    time.Now().UTC(),
+	}
+}
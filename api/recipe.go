@@ -7,16 +7,23 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/hasnaroihan/grocery-planner/auth"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
 	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
 )
 
 type newRecipeRequest struct {
-	Name            string                   `json:"name" binding:"required"`
-	Portion         int32                    `json:"portion" binding:"required,number,min=1"`
-	Steps           sql.NullString           `json:"steps" binding:"required,alpha"`
-	ListIngredients []db.ListIngredientParam `json:"ingredients" binding:"required,min=1"`
+	Name    string `json:"name" binding:"required"`
+	Portion int32  `json:"portion" binding:"required,number,min=1"`
+	// Replaced by the step texts, one per line, when a step list is given
+	Steps           sql.NullString           `json:"steps"`
+	ListIngredients []db.ListIngredientParam `json:"ingredients" binding:"required_without=IngredientsText,omitempty,min=1"`
+	// Raw text mode, one ingredient line like "200g butter" per line
+	IngredientsText string              `json:"ingredientsText" binding:"required_without=ListIngredients"`
+	StepList        []recipeStepRequest `json:"stepList" binding:"omitempty,dive"`
+	recipeTaxonomyRequest
 }
 
 func (server *Server) newRecipe(ctx *gin.Context) {
@@ -26,6 +33,24 @@ func (server *Server) newRecipe(ctx *gin.Context) {
 		return
 	}
 
+	if req.IngredientsText != "" {
+		p, err := server.ingredientParser(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ingredients, unparsed := p.ingredientParams(textLines(req.IngredientsText))
+		if len(unparsed) > 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":    "ingredient lines can not be parsed",
+				"unparsed": unparsed,
+			})
+			return
+		}
+		req.ListIngredients = append(req.ListIngredients, ingredients...)
+	}
+
 	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
 	arg := db.NewRecipeParams{
 		Name:            req.Name,
@@ -33,9 +58,16 @@ func (server *Server) newRecipe(ctx *gin.Context) {
 		Portion:         req.Portion,
 		Steps:           req.Steps,
 		ListIngredients: req.ListIngredients,
+		StepList:        stepParams(req.StepList),
+		Taxonomy:        req.taxonomy(),
+		Keywords:        normalizeKeywords(req.Keywords),
 	}
 	recipe, err := server.storage.NewRecipeTx(ctx, arg)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -77,6 +109,13 @@ func (server *Server) deleteRecipe(ctx *gin.Context) {
 		return
 	}
 
+	// the rows go with the recipe, the blobs have to be removed afterwards
+	images, err := server.storage.ListRecipeImages(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	err = server.storage.DeleteRecipe(ctx, req.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -86,6 +125,7 @@ func (server *Server) deleteRecipe(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.deleteImageBlobs(ctx, images)
 
 	ctx.JSON(http.StatusOK, nil)
 }
@@ -125,12 +165,13 @@ func (server *Server) deleteRecipeIngredient(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.DeleteRecipeIngredientParams{
+	arg := db.DeleteRecipeIngredientTxParams{
 		RecipeID:     req.RecipeID,
 		IngredientID: req.IngredientID,
+		Editor:       authPayload.Subject,
 	}
 
-	err = server.storage.DeleteRecipeIngredient(ctx, arg)
+	err = server.storage.DeleteRecipeIngredientTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -147,13 +188,45 @@ type getRecipeRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+type getRecipeQuery struct {
+	Portions int32  `form:"portions" binding:"omitempty,min=1"`
+	Units    string `form:"units" binding:"omitempty,oneof=metric imperial"`
+}
+
 func (server *Server) getRecipe(ctx *gin.Context) {
 	var req getRecipeRequest
+	var query getRecipeQuery
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// scaled recipe
+	if query.Portions > 0 || query.Units != "" {
+		arg := db.ScaleRecipeParams{
+			ID:         req.ID,
+			Portions:   query.Portions,
+			UnitSystem: query.Units,
+		}
+		recipe, err := server.storage.ScaleRecipeTx(ctx, arg)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, recipe)
+		return
+	}
+
 	recipe, err := server.storage.GetRecipeTx(ctx, req.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -163,6 +236,7 @@ func (server *Server) getRecipe(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	recipe.Images = server.imageURLs(recipe.Images)
 
 	ctx.JSON(http.StatusOK, recipe)
 }
@@ -172,17 +246,73 @@ type listPageRequest struct {
 	PageNum  int32 `form:"pageNum" binding:"required,number"`
 }
 
+type listRecipesRequest struct {
+	PageSize     int32  `form:"pageSize" binding:"required,number"`
+	PageNum      int32  `form:"pageNum" binding:"required,number"`
+	Restrictions string `form:"restrictions" binding:"omitempty,oneof=exclude warn"`
+	// Highest average rating or most cooked first, last modified first otherwise
+	Sort string `form:"sort" binding:"omitempty,oneof=rating popularity"`
+	recipeFilterQuery
+}
+
+// Recipe list item with the requesting user's violated dietary restrictions
+type recipeConflictsResponse struct {
+	db.Recipe
+	Conflicts []string `json:"conflicts"`
+}
+
 func (server *Server) listRecipes(ctx *gin.Context) {
-	var req listPageRequest
+	var req listRecipesRequest
 
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	// restrictions are the ones of the authenticated user
+	var userID uuid.UUID
+	if req.Restrictions != "" {
+		authPayload, ok := ctx.Get(authPayloadKey)
+		if !ok {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(ErrRestrictionsAuth))
+			return
+		}
+		userID = authPayload.(*auth.Payload).Subject
+	}
+
+	if req.Restrictions == db.RestrictionsExclude {
+		arg := db.ListRecipesAllowedParams{
+			UserID:       userID,
+			Cuisine:      nullString(req.Cuisine),
+			Course:       nullString(req.Course),
+			Difficulty:   nullString(req.Difficulty),
+			MaxTotalTime: nullInt32(req.MaxTotalTime),
+			Keywords:     normalizeKeywords(req.Keywords),
+			Sort:         req.Sort,
+			Limit:        req.PageSize,
+			Offset:       (req.PageNum - 1) * req.PageSize,
+		}
+		recipes, err := server.storage.ListRecipesAllowed(ctx, arg)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, recipes)
+		return
+	}
+
 	arg := db.ListRecipesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageNum - 1) * req.PageSize,
+		Cuisine:      nullString(req.Cuisine),
+		Course:       nullString(req.Course),
+		Difficulty:   nullString(req.Difficulty),
+		MaxTotalTime: nullInt32(req.MaxTotalTime),
+		Keywords:     normalizeKeywords(req.Keywords),
+		Sort:         req.Sort,
+		Limit:        req.PageSize,
+		Offset:       (req.PageNum - 1) * req.PageSize,
 	}
 	recipes, err := server.storage.ListRecipes(ctx, arg)
 	if err != nil {
@@ -192,7 +322,51 @@ func (server *Server) listRecipes(ctx *gin.Context) {
 		}
 	}
 
-	ctx.JSON(http.StatusOK, recipes)
+	if req.Restrictions != db.RestrictionsWarn {
+		ctx.JSON(http.StatusOK, recipes)
+		return
+	}
+
+	recipeIDs := make([]int64, 0, len(recipes))
+	for _, recipe := range recipes {
+		recipeIDs = append(recipeIDs, recipe.ID)
+	}
+	conflicts, err := server.recipeConflicts(ctx, userID, recipeIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]recipeConflictsResponse, 0, len(recipes))
+	for _, recipe := range recipes {
+		result = append(result, recipeConflictsResponse{
+			Recipe:    recipe,
+			Conflicts: conflicts[recipe.ID],
+		})
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Violated dietary restrictions of the user by recipe id, every recipe gets a non nil list
+func (server *Server) recipeConflicts(ctx *gin.Context, userID uuid.UUID, recipeIDs []int64) (map[int64][]string, error) {
+	rows, err := server.storage.ListRecipeConflicts(ctx, db.ListRecipeConflictsParams{
+		UserID:    userID,
+		RecipeIds: recipeIDs,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	result := map[int64][]string{}
+	for _, id := range recipeIDs {
+		result[id] = []string{}
+	}
+	for _, row := range rows {
+		result[row.RecipeID] = append(result[row.RecipeID], row.Tag)
+	}
+
+	return result, nil
 }
 
 func (server *Server) listRecipesUser(ctx *gin.Context) {
@@ -238,9 +412,17 @@ func (server *Server) listRecipesUser(ctx *gin.Context) {
 }
 
 type searchRecipeRequest struct {
-	Name     string `form:"name" binding:"omitempty,lowercase"`
-	PageSize int32  `form:"pageSize" binding:"required,number"`
-	PageNum  int32  `form:"pageNum" binding:"required,number"`
+	Name         string `form:"name" binding:"omitempty,lowercase"`
+	PageSize     int32  `form:"pageSize" binding:"required,number"`
+	PageNum      int32  `form:"pageNum" binding:"required,number"`
+	Restrictions string `form:"restrictions" binding:"omitempty,oneof=exclude warn"`
+	Sort         string `form:"sort" binding:"omitempty,oneof=rating popularity"`
+	recipeFilterQuery
+}
+
+type searchRecipeConflictsResponse struct {
+	db.SearchRecipeRow
+	Conflicts []string `json:"conflicts"`
 }
 
 func (server *Server) searchRecipe(ctx *gin.Context) {
@@ -251,10 +433,52 @@ func (server *Server) searchRecipe(ctx *gin.Context) {
 		return
 	}
 
+	// restrictions are the ones of the authenticated user
+	var userID uuid.UUID
+	if req.Restrictions != "" {
+		authPayload, ok := ctx.Get(authPayloadKey)
+		if !ok {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(ErrRestrictionsAuth))
+			return
+		}
+		userID = authPayload.(*auth.Payload).Subject
+	}
+
+	if req.Restrictions == db.RestrictionsExclude {
+		arg := db.SearchRecipeAllowedParams{
+			Name:         fmt.Sprintf("%%%s%%", req.Name),
+			UserID:       userID,
+			Cuisine:      nullString(req.Cuisine),
+			Course:       nullString(req.Course),
+			Difficulty:   nullString(req.Difficulty),
+			MaxTotalTime: nullInt32(req.MaxTotalTime),
+			Keywords:     normalizeKeywords(req.Keywords),
+			Sort:         req.Sort,
+			Limit:        req.PageSize,
+			Offset:       (req.PageNum - 1) * req.PageSize,
+		}
+		recipes, err := server.storage.SearchRecipeAllowed(ctx, arg)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, recipes)
+		return
+	}
+
 	arg := db.SearchRecipeParams{
-		Name:   fmt.Sprintf("%%%s%%", req.Name),
-		Limit:  req.PageSize,
-		Offset: (req.PageNum - 1) * req.PageSize,
+		Name:         fmt.Sprintf("%%%s%%", req.Name),
+		Cuisine:      nullString(req.Cuisine),
+		Course:       nullString(req.Course),
+		Difficulty:   nullString(req.Difficulty),
+		MaxTotalTime: nullInt32(req.MaxTotalTime),
+		Keywords:     normalizeKeywords(req.Keywords),
+		Sort:         req.Sort,
+		Limit:        req.PageSize,
+		Offset:       (req.PageNum - 1) * req.PageSize,
 	}
 	recipes, err := server.storage.SearchRecipe(ctx, arg)
 	if err != nil {
@@ -264,7 +488,30 @@ func (server *Server) searchRecipe(ctx *gin.Context) {
 		}
 	}
 
-	ctx.JSON(http.StatusOK, recipes)
+	if req.Restrictions != db.RestrictionsWarn {
+		ctx.JSON(http.StatusOK, recipes)
+		return
+	}
+
+	recipeIDs := make([]int64, 0, len(recipes))
+	for _, recipe := range recipes {
+		recipeIDs = append(recipeIDs, recipe.ID)
+	}
+	conflicts, err := server.recipeConflicts(ctx, userID, recipeIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]searchRecipeConflictsResponse, 0, len(recipes))
+	for _, recipe := range recipes {
+		result = append(result, searchRecipeConflictsResponse{
+			SearchRecipeRow: recipe,
+			Conflicts:       conflicts[recipe.ID],
+		})
+	}
+
+	ctx.JSON(http.StatusOK, result)
 }
 
 type updateRecipeUri struct {
@@ -272,11 +519,14 @@ type updateRecipeUri struct {
 }
 
 type updateRecipeJSON struct {
-	ID              int64                    `uri:"id" binding:"required,number,min=1"`
-	Name            string                   `json:"name" binding:"required,lowercase"`
-	Portion         int32                    `json:"portion" binding:"required,number,min=1"`
-	Steps           sql.NullString           `json:"steps" binding:"required,alpha"`
+	ID      int64  `uri:"id" binding:"required,number,min=1"`
+	Name    string `json:"name" binding:"required,lowercase"`
+	Portion int32  `json:"portion" binding:"required,number,min=1"`
+	// Only kept for recipes without a step list, the text follows the step list otherwise
+	Steps           sql.NullString           `json:"steps"`
 	ListIngredients []db.ListIngredientParam `json:"ingredients" binding:"required,min=1"`
+	// Classification is replaced when any of its fields is given, keywords only when listed
+	recipeTaxonomyRequest
 }
 
 func (server *Server) updateRecipe(ctx *gin.Context) {
@@ -306,6 +556,8 @@ func (server *Server) updateRecipe(ctx *gin.Context) {
 			Steps:   reqJSON.Steps,
 		},
 		ListIngredients: reqJSON.ListIngredients,
+		Taxonomy:        reqJSON.taxonomyUpdate(),
+		Keywords:        normalizeKeywords(reqJSON.Keywords),
 	}
 
 	recipe, err := server.storage.GetRecipe(ctx, arg.Recipe.ID)
@@ -330,8 +582,14 @@ func (server *Server) updateRecipe(ctx *gin.Context) {
 		return
 	}
 
+	arg.Editor = authPayload.Subject
 	recipeUp, err := server.storage.UpdateRecipeTx(ctx, arg)
 	if err != nil {
+		// unknown cuisine or course
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
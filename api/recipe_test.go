@@ -16,7 +16,9 @@ import (
 	"github.com/hasnaroihan/grocery-planner/auth"
 	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
 	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -73,6 +75,87 @@ func TestNewRecipeAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "OK Taxonomy",
+			body: gin.H{
+				"name":        recipe.Recipe.Name,
+				"portion":     recipe.Recipe.Portion,
+				"steps":       recipe.Recipe.Steps,
+				"ingredients": ingredients,
+				"cuisine":     "indonesian",
+				"course":      "main",
+				"difficulty":  "medium",
+				"totalTime":   45,
+				"keywords":    []string{"Weeknight", "spicy"},
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.NewRecipeParams{
+					Name:            recipe.Recipe.Name,
+					Author:          user.ID,
+					Portion:         recipe.Recipe.Portion,
+					Steps:           recipe.Recipe.Steps,
+					ListIngredients: ingredients,
+					Taxonomy: db.RecipeTaxonomy{
+						Cuisine:    sql.NullString{String: "indonesian", Valid: true},
+						Course:     sql.NullString{String: "main", Valid: true},
+						Difficulty: sql.NullString{String: "medium", Valid: true},
+						TotalTime:  sql.NullInt32{Int32: 45, Valid: true},
+					},
+					Keywords: []string{"weeknight", "spicy"},
+				}
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "404 Unknown Cuisine",
+			body: gin.H{
+				"name":        recipe.Recipe.Name,
+				"portion":     recipe.Recipe.Portion,
+				"ingredients": ingredients,
+				"cuisine":     "atlantean",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "400 Invalid Total Time",
+			body: gin.H{
+				"name":        recipe.Recipe.Name,
+				"portion":     recipe.Recipe.Portion,
+				"ingredients": ingredients,
+				"totalTime":   -5,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "400 Invalid Portion",
 			body: gin.H{
@@ -154,6 +237,81 @@ func TestNewRecipeAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "OK Ingredients Text",
+			body: gin.H{
+				"name":            recipe.Recipe.Name,
+				"portion":         recipe.Recipe.Portion,
+				"ingredientsText": "200g butter\n\n1 1/2 cups flour, sifted",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.NewRecipeParams{
+					Name:    recipe.Recipe.Name,
+					Author:  user.ID,
+					Portion: recipe.Recipe.Portion,
+					ListIngredients: []db.ListIngredientParam{
+						{Name: "butter", Amount: 200, UnitID: 1},
+						{Name: "flour", Amount: 1.5, UnitID: 2},
+					},
+				}
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return([]db.Unit{{ID: 1, Name: "g"}, {ID: 2, Name: "cup"}}, nil)
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Unparseable Ingredients Text",
+			body: gin.H{
+				"name":            recipe.Recipe.Name,
+				"portion":         recipe.Recipe.Portion,
+				"ingredientsText": "200g butter\nsalt to taste",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUnits(gomock.Any()).
+					Times(1).
+					Return([]db.Unit{{ID: 1, Name: "g"}}, nil)
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "salt to taste")
+			},
+		},
+		{
+			name: "400 Missing Ingredients",
+			body: gin.H{
+				"name":    recipe.Recipe.Name,
+				"portion": recipe.Recipe.Portion,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					NewRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "500 Internal Server Error",
 			body: gin.H{
@@ -241,6 +399,10 @@ func TestDeleteRecipeAPI(t *testing.T) {
 						Role:       "common",
 						VerifiedAt: sql.NullTime{},
 					}, nil)
+				storage.EXPECT().
+					ListRecipeImages(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return([]db.RecipesImage{}, nil)
 				storage.EXPECT().
 					DeleteRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
 					Times(1).
@@ -268,6 +430,10 @@ func TestDeleteRecipeAPI(t *testing.T) {
 						Role:       "admin",
 						VerifiedAt: sql.NullTime{},
 					}, nil)
+				storage.EXPECT().
+					ListRecipeImages(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return([]db.RecipesImage{}, nil)
 				storage.EXPECT().
 					DeleteRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
 					Times(1).
@@ -387,6 +553,10 @@ func TestDeleteRecipeAPI(t *testing.T) {
 					Return(db.GetPermissionRow{
 						Role: "common",
 					}, nil)
+				storage.EXPECT().
+					ListRecipeImages(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return([]db.RecipesImage{}, nil)
 				storage.EXPECT().
 					DeleteRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
 					Times(1).
@@ -413,6 +583,10 @@ func TestDeleteRecipeAPI(t *testing.T) {
 					Return(db.GetPermissionRow{
 						Role: "common",
 					}, nil)
+				storage.EXPECT().
+					ListRecipeImages(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return([]db.RecipesImage{}, nil)
 				storage.EXPECT().
 					DeleteRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
 					Times(1).
@@ -469,9 +643,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -485,7 +660,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(nil)
 			},
@@ -502,9 +677,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -518,7 +694,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(nil)
 			},
@@ -537,9 +713,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, id, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -553,7 +730,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -567,9 +744,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -578,7 +756,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
 					Times(0)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -594,9 +772,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -606,7 +785,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 					GetPermission(gomock.Any(), gomock.Not(user.ID)).
 					Times(0)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -622,9 +801,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -634,7 +814,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 					GetPermission(gomock.Any(), gomock.Not(user.ID)).
 					Times(0)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -650,9 +830,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -665,7 +846,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 						Role: "common",
 					}, nil)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(sql.ErrNoRows)
 			},
@@ -682,9 +863,10 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
 			},
 			buildStubs: func(storage *dbmock.MockStorage) {
-				arg := db.DeleteRecipeIngredientParams{
+				arg := db.DeleteRecipeIngredientTxParams{
 					RecipeID:     recipe.Recipe.ID,
 					IngredientID: recipe.Ingredients[0].IngredientID,
+					Editor:       user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -697,7 +879,7 @@ func TestDeleteRecipeIngredientAPI(t *testing.T) {
 						Role: "common",
 					}, nil)
 				storage.EXPECT().
-					DeleteRecipeIngredient(gomock.Any(), gomock.Eq(arg)).
+					DeleteRecipeIngredientTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -817,6 +999,162 @@ func TestGetRecipeAPI(t *testing.T) {
 	}
 }
 
+func TestScaleRecipeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	scaled := db.ScaledRecipeResult{
+		Recipe:     recipe.Recipe,
+		Portions:   4,
+		UnitSystem: measure.Imperial,
+		Ingredients: []db.ScaledIngredient{
+			{
+				IngredientID: recipe.Ingredients[0].IngredientID,
+				Name:         recipe.Ingredients[0].Name,
+				Amount:       1.5,
+				Unit:         "lb",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "portions=4&units=imperial",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.ScaleRecipeParams{
+					ID:         recipe.Recipe.ID,
+					Portions:   4,
+					UnitSystem: measure.Imperial,
+				}
+				storage.EXPECT().
+					ScaleRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(scaled, nil)
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.ScaledRecipeResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, scaled.Portions, got.Portions)
+				require.Equal(t, scaled.Ingredients, got.Ingredients)
+			},
+		},
+		{
+			name:  "OK Units Only",
+			query: "units=metric",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.ScaleRecipeParams{
+					ID:         recipe.Recipe.ID,
+					UnitSystem: measure.Metric,
+				}
+				storage.EXPECT().
+					ScaleRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(scaled, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OK Zero Portions",
+			query: "portions=0",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// zero portions count as omitted
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Negative Portions",
+			query: "portions=-1",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ScaleRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Unknown Unit System",
+			query: "portions=2&units=nautical",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ScaleRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "404 Not Found",
+			query: "portions=2",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ScaleRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ScaledRecipeResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "500 Internal Server Error",
+			query: "portions=2",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ScaleRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ScaledRecipeResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d?%s", recipe.Recipe.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func TestListRecipesAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	recipes := []db.Recipe{
@@ -848,6 +1186,69 @@ func TestListRecipesAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:  "OK Sort By Rating",
+			query: "pageSize=2&pageNum=1&sort=rating",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.ListRecipesParams{
+					Sort:   "rating",
+					Limit:  2,
+					Offset: 0,
+				}
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OK Filters",
+			query: "pageSize=2&pageNum=1&cuisine=italian&difficulty=easy&maxTotalTime=30&keyword=Pasta&keyword=quick",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.ListRecipesParams{
+					Cuisine:      sql.NullString{String: "italian", Valid: true},
+					Difficulty:   sql.NullString{String: "easy", Valid: true},
+					MaxTotalTime: sql.NullInt32{Int32: 30, Valid: true},
+					Keywords:     []string{"pasta", "quick"},
+					Limit:        2,
+					Offset:       0,
+				}
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Invalid Difficulty",
+			query: "pageSize=2&pageNum=1&difficulty=impossible",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Invalid Sort",
+			query: "pageSize=2&pageNum=1&sort=name",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:  "400 Bad Request",
 			query: "pageSize=2",
@@ -906,6 +1307,268 @@ func TestListRecipesAPI(t *testing.T) {
 	}
 }
 
+func TestListRecipesRestrictionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipes := []db.Recipe{
+		randomRecipe(user.ID).Recipe,
+		randomRecipe(user.ID).Recipe,
+	}
+	arg := db.ListRecipesParams{
+		Limit:  2,
+		Offset: 0,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK Exclude",
+			query: "pageSize=2&pageNum=1&restrictions=exclude",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				allowedArg := db.ListRecipesAllowedParams{
+					UserID: user.ID,
+					Limit:  2,
+					Offset: 0,
+				}
+				storage.EXPECT().
+					ListRecipesAllowed(gomock.Any(), gomock.Eq(allowedArg)).
+					Times(1).
+					Return(recipes[:1], nil)
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.Recipe
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 1)
+			},
+		},
+		{
+			name:  "OK Warn",
+			query: "pageSize=2&pageNum=1&restrictions=warn",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipes, nil)
+				conflictsArg := db.ListRecipeConflictsParams{
+					UserID:    user.ID,
+					RecipeIds: []int64{recipes[0].ID, recipes[1].ID},
+				}
+				storage.EXPECT().
+					ListRecipeConflicts(gomock.Any(), gomock.Eq(conflictsArg)).
+					Times(1).
+					Return([]db.ListRecipeConflictsRow{
+						{RecipeID: recipes[0].ID, Tag: "gluten"},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []recipeConflictsResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+				require.Equal(t, recipes[0].ID, got[0].ID)
+				require.Contains(t, got[0].Conflicts, "gluten")
+			},
+		},
+		{
+			name:  "400 Unknown Mode",
+			query: "pageSize=2&pageNum=1&restrictions=ignore",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "401 No Authorization",
+			query: "pageSize=2&pageNum=1&restrictions=exclude",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipesAllowed(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "401 Invalid Authorization",
+			query: "pageSize=2&pageNum=1",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, "unsupported", user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "500 Internal Server Error",
+			query: "pageSize=2&pageNum=1&restrictions=warn",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipes(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipes, nil)
+				storage.EXPECT().
+					ListRecipeConflicts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/all?%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSearchRecipesRestrictionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID).Recipe
+	rows := []db.SearchRecipeRow{
+		{
+			ID:         recipe.ID,
+			Name:       recipe.Name,
+			Author:     recipe.Author,
+			ModifiedAt: recipe.ModifiedAt,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK Exclude",
+			query: "name=soup&pageSize=5&pageNum=1&restrictions=exclude",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.SearchRecipeAllowedParams{
+					Name:   "%soup%",
+					UserID: user.ID,
+					Limit:  5,
+					Offset: 0,
+				}
+				storage.EXPECT().
+					SearchRecipeAllowed(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.SearchRecipeAllowedRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OK Warn",
+			query: "name=soup&pageSize=5&pageNum=1&restrictions=warn",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.SearchRecipeParams{
+					Name:   "%soup%",
+					Limit:  5,
+					Offset: 0,
+				}
+				storage.EXPECT().
+					SearchRecipe(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rows, nil)
+				storage.EXPECT().
+					ListRecipeConflicts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListRecipeConflictsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []searchRecipeConflictsResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 1)
+				require.NotNil(t, got[0].Conflicts)
+				require.Empty(t, got[0].Conflicts)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe?%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func TestListRecipesUserAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	extraID := uuid.New()
@@ -1197,6 +1860,54 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          user.ID,
+				}
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role: "common",
+					}, nil)
+				storage.EXPECT().
+					UpdateRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK Taxonomy",
+			uri:  recipe.Recipe.ID,
+			body: gin.H{
+				"id":          recipe.Recipe.ID,
+				"name":        "new recipe name",
+				"portion":     5,
+				"ingredients": ingredients,
+				"course":      "main",
+				"totalTime":   30,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.TxUpdateRecipeParams{
+					Recipe: db.UpdateRecipeParams{
+						ID:      recipe.Recipe.ID,
+						Name:    "new recipe name",
+						Portion: 5,
+					},
+					ListIngredients: ingredients,
+					Editor:          user.ID,
+					Taxonomy: &db.RecipeTaxonomy{
+						Course:    sql.NullString{String: "main", Valid: true},
+						TotalTime: sql.NullInt32{Int32: 30, Valid: true},
+					},
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1245,6 +1956,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1293,6 +2005,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1336,6 +2049,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1379,6 +2093,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1422,6 +2137,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1465,6 +2181,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1508,6 +2225,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1553,6 +2271,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1600,6 +2319,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1644,6 +2364,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          admin.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -1688,6 +2409,7 @@ func TestUpdateRecipeAPI(t *testing.T) {
 						},
 					},
 					ListIngredients: ingredients,
+					Editor:          user.ID,
 				}
 				storage.EXPECT().
 					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrPatchNotObject      = errors.New("merge patch must be a json object")
+	ErrInvalidIngredientID = errors.New("ingredient keys must be ingredient ids")
+	ErrPatchNameCase       = errors.New("recipe name must be lowercase")
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+type replaceRecipeUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type replaceRecipeJSON struct {
+	Name    string `json:"name" binding:"required,lowercase"`
+	Portion int32  `json:"portion" binding:"required,number,min=1"`
+	// Only kept for recipes without a step list, the text follows the step list otherwise
+	Steps sql.NullString `json:"steps"`
+	// Full desired ingredient list, ingredients left out are removed from the recipe
+	ListIngredients []db.ListIngredientParam `json:"ingredients" binding:"required,min=1"`
+	// Classification and keywords left out are cleared
+	recipeTaxonomyRequest
+}
+
+func (server *Server) replaceRecipe(ctx *gin.Context) {
+	var reqUri replaceRecipeUri
+	var reqJSON replaceRecipeJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeRecipe(ctx, reqUri.ID) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	server.storeRecipe(ctx, db.ReplaceRecipeParams{
+		Recipe: db.UpdateRecipeParams{
+			ID:      reqUri.ID,
+			Name:    reqJSON.Name,
+			Portion: reqJSON.Portion,
+			Steps:   reqJSON.Steps,
+		},
+		ListIngredients: reqJSON.ListIngredients,
+		Editor:          authPayload.Subject,
+		Taxonomy:        reqJSON.taxonomy(),
+		Keywords:        normalizeKeywords(reqJSON.Keywords),
+	})
+}
+
+// Editable view of a recipe that merge patches apply to, ingredients are keyed by ingredient id
+// so that {"ingredients": {"12": null}} removes a single ingredient
+type recipeDocument struct {
+	Name        string                              `json:"name" binding:"required"`
+	Portion     int32                               `json:"portion" binding:"required,min=1"`
+	Steps       *string                             `json:"steps"`
+	Ingredients map[string]recipeDocumentIngredient `json:"ingredients" binding:"required,min=1,dive"`
+	Cuisine     *string                             `json:"cuisine" binding:"omitempty,lowercase,max=50"`
+	Course      *string                             `json:"course" binding:"omitempty,lowercase,max=50"`
+	Difficulty  *string                             `json:"difficulty" binding:"omitempty,oneof=easy medium hard"`
+	TotalTime   *int32                              `json:"totalTime" binding:"omitempty,min=1"`
+	Keywords    []string                            `json:"keywords" binding:"max=20,dive,required,max=50"`
+}
+
+type recipeDocumentIngredient struct {
+	Amount float32 `json:"amount" binding:"required,gt=0"`
+	UnitID int32   `json:"unitID" binding:"required,min=1"`
+}
+
+func newRecipeDocument(recipe db.Recipe, ingredients []db.GetRecipeIngredientsRow, keywords []string) recipeDocument {
+	doc := recipeDocument{
+		Name:        recipe.Name,
+		Portion:     recipe.Portion,
+		Ingredients: make(map[string]recipeDocumentIngredient, len(ingredients)),
+		Keywords:    keywords,
+	}
+	if recipe.Steps.Valid {
+		doc.Steps = &recipe.Steps.String
+	}
+	if recipe.Cuisine.Valid {
+		doc.Cuisine = &recipe.Cuisine.String
+	}
+	if recipe.Course.Valid {
+		doc.Course = &recipe.Course.String
+	}
+	if recipe.Difficulty.Valid {
+		doc.Difficulty = &recipe.Difficulty.String
+	}
+	if recipe.TotalTime.Valid {
+		doc.TotalTime = &recipe.TotalTime.Int32
+	}
+	for _, ingredient := range ingredients {
+		doc.Ingredients[strconv.Itoa(int(ingredient.IngredientID))] = recipeDocumentIngredient{
+			Amount: ingredient.Amount,
+			UnitID: ingredient.UnitID,
+		}
+	}
+
+	return doc
+}
+
+func (doc recipeDocument) replaceParams(recipeID int64) (db.ReplaceRecipeParams, error) {
+	arg := db.ReplaceRecipeParams{
+		Recipe: db.UpdateRecipeParams{
+			ID:      recipeID,
+			Name:    doc.Name,
+			Portion: doc.Portion,
+		},
+		ListIngredients: make([]db.ListIngredientParam, 0, len(doc.Ingredients)),
+		Keywords:        normalizeKeywords(doc.Keywords),
+	}
+	if doc.Steps != nil {
+		arg.Recipe.Steps = sql.NullString{
+			String: *doc.Steps,
+			Valid:  true,
+		}
+	}
+	if doc.Cuisine != nil {
+		arg.Taxonomy.Cuisine = nullString(*doc.Cuisine)
+	}
+	if doc.Course != nil {
+		arg.Taxonomy.Course = nullString(*doc.Course)
+	}
+	if doc.Difficulty != nil {
+		arg.Taxonomy.Difficulty = nullString(*doc.Difficulty)
+	}
+	if doc.TotalTime != nil {
+		arg.Taxonomy.TotalTime = nullInt32(*doc.TotalTime)
+	}
+
+	for key, ingredient := range doc.Ingredients {
+		id, err := strconv.ParseInt(key, 10, 32)
+		if err != nil || id < 1 {
+			return arg, ErrInvalidIngredientID
+		}
+		arg.ListIngredients = append(arg.ListIngredients, db.ListIngredientParam{
+			ID: sql.NullInt32{
+				Int32: int32(id),
+				Valid: true,
+			},
+			Amount: ingredient.Amount,
+			UnitID: ingredient.UnitID,
+		})
+	}
+	sort.Slice(arg.ListIngredients, func(i, j int) bool {
+		return arg.ListIngredients[i].ID.Int32 < arg.ListIngredients[j].ID.Int32
+	})
+
+	return arg, nil
+}
+
+// Apply a JSON merge patch (RFC 7386) to a decoded JSON value
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+type patchRecipeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) patchRecipe(ctx *gin.Context) {
+	var req patchRecipeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if contentType := ctx.ContentType(); contentType != mergePatchContentType && contentType != binding.MIMEJSON {
+		ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(errors.New("unsupported content type "+contentType)))
+		return
+	}
+
+	body, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var patch interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrPatchNotObject))
+		return
+	}
+	// Stored names may predate the lowercase rule (imports), only a new name has to follow it
+	if name, ok := patchObject["name"].(string); ok && name != strings.ToLower(name) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrPatchNameCase))
+		return
+	}
+
+	recipe, ok := server.editableRecipe(ctx, req.ID)
+	if !ok {
+		return
+	}
+	ingredients, err := server.storage.GetRecipeIngredients(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	keywords, err := server.storage.ListRecipeKeywords(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Round trip the current recipe through plain JSON values to merge the patch into it
+	var current interface{}
+	data, err := json.Marshal(newRecipeDocument(recipe, ingredients, keywords))
+	if err == nil {
+		err = json.Unmarshal(data, &current)
+	}
+	if err == nil {
+		data, err = json.Marshal(mergePatch(current, patch))
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var doc recipeDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := binding.Validator.ValidateStruct(&doc); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg, err := doc.replaceParams(req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg.Editor = authPayload.Subject
+	server.storeRecipe(ctx, arg)
+}
+
+func (server *Server) storeRecipe(ctx *gin.Context, arg db.ReplaceRecipeParams) {
+	recipe, err := server.storage.ReplaceRecipeTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if err == db.ErrDuplicateRecipeIngredient {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		// unknown ingredient, unit, cuisine or course
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
@@ -0,0 +1,578 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestReplaceRecipeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	recipe.Recipe.Name = strings.ToLower(recipe.Recipe.Name)
+
+	body := gin.H{
+		"name":    recipe.Recipe.Name,
+		"portion": recipe.Recipe.Portion,
+		"steps":   recipe.Recipe.Steps,
+		"ingredients": []db.ListIngredientParam{
+			{
+				ID:     sql.NullInt32{Int32: recipe.Ingredients[0].IngredientID, Valid: true},
+				Amount: 250,
+				UnitID: recipe.Ingredients[0].UnitID,
+			},
+			{
+				Name:   "basil",
+				Amount: 5,
+				UnitID: 2,
+			},
+		},
+	}
+	arg := db.ReplaceRecipeParams{
+		Recipe: db.UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    recipe.Recipe.Name,
+			Portion: recipe.Recipe.Portion,
+			Steps:   recipe.Recipe.Steps,
+		},
+		ListIngredients: body["ingredients"].([]db.ListIngredientParam),
+		Editor:          user.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.RecipeResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, recipe.Recipe.ID, result.Recipe.ID)
+			},
+		},
+		{
+			name:   "400 No Ingredients",
+			userID: user.ID,
+			body: gin.H{
+				"name":        recipe.Recipe.Name,
+				"portion":     recipe.Recipe.Portion,
+				"ingredients": []db.ListIngredientParam{},
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "400 Duplicate Ingredient",
+			userID: user.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, db.ErrDuplicateRecipeIngredient)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "403 Forbidden",
+			userID: other.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Unknown Unit",
+			userID: user.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/recipe/%d", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPatchRecipeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	// imported recipes keep the case of their source
+	recipe.Recipe.Name = "Classic Pancakes"
+	for i := range recipe.Ingredients {
+		recipe.Ingredients[i].IngredientID = int32(i + 1)
+	}
+	keywords := []string{"weeknight"}
+
+	testCases := []struct {
+		name          string
+		contentType   string
+		patch         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			contentType: mergePatchContentType,
+			patch:       `{"portion": 8, "steps": null, "ingredients": {"1": null, "3": {"amount": 42}, "7": {"amount": 2, "unitID": 5}}}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+
+				arg := db.ReplaceRecipeParams{
+					Recipe: db.UpdateRecipeParams{
+						ID:      recipe.Recipe.ID,
+						Name:    recipe.Recipe.Name,
+						Portion: 8,
+					},
+					ListIngredients: []db.ListIngredientParam{
+						{
+							ID:     sql.NullInt32{Int32: 2, Valid: true},
+							Amount: recipe.Ingredients[1].Amount,
+							UnitID: recipe.Ingredients[1].UnitID,
+						},
+						{
+							ID:     sql.NullInt32{Int32: 3, Valid: true},
+							Amount: 42,
+							UnitID: recipe.Ingredients[2].UnitID,
+						},
+						{
+							ID:     sql.NullInt32{Int32: 7, Valid: true},
+							Amount: 2,
+							UnitID: 5,
+						},
+					},
+					Editor:   user.ID,
+					Keywords: keywords,
+				}
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "OK Taxonomy",
+			contentType: mergePatchContentType,
+			patch:       `{"cuisine": "italian", "totalTime": 30, "keywords": ["Pasta", "quick", "pasta"]}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+
+				arg := db.ReplaceRecipeParams{
+					Recipe: db.UpdateRecipeParams{
+						ID:      recipe.Recipe.ID,
+						Name:    recipe.Recipe.Name,
+						Portion: recipe.Recipe.Portion,
+						Steps:   recipe.Recipe.Steps,
+					},
+					Editor: user.ID,
+					Taxonomy: db.RecipeTaxonomy{
+						Cuisine:   sql.NullString{String: "italian", Valid: true},
+						TotalTime: sql.NullInt32{Int32: 30, Valid: true},
+					},
+					Keywords: []string{"pasta", "quick"},
+				}
+				for _, ingredient := range recipe.Ingredients {
+					arg.ListIngredients = append(arg.ListIngredients, db.ListIngredientParam{
+						ID:     sql.NullInt32{Int32: ingredient.IngredientID, Valid: true},
+						Amount: ingredient.Amount,
+						UnitID: ingredient.UnitID,
+					})
+				}
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "400 Invalid Difficulty",
+			contentType: mergePatchContentType,
+			patch:       `{"difficulty": "impossible"}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "OK Portion Of Mixed Case Name",
+			contentType: mergePatchContentType,
+			patch:       `{"portion": 2}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+
+				arg := db.ReplaceRecipeParams{
+					Recipe: db.UpdateRecipeParams{
+						ID:      recipe.Recipe.ID,
+						Name:    "Classic Pancakes",
+						Portion: 2,
+						Steps:   recipe.Recipe.Steps,
+					},
+					Editor:   user.ID,
+					Keywords: keywords,
+				}
+				for _, ingredient := range recipe.Ingredients {
+					arg.ListIngredients = append(arg.ListIngredients, db.ListIngredientParam{
+						ID:     sql.NullInt32{Int32: ingredient.IngredientID, Valid: true},
+						Amount: ingredient.Amount,
+						UnitID: ingredient.UnitID,
+					})
+				}
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "400 Uppercase Name",
+			contentType: mergePatchContentType,
+			patch:       `{"name": "Tomato Soup"}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Any()).
+					Times(0)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "400 Not An Object",
+			contentType: mergePatchContentType,
+			patch:       `[{"op": "replace", "path": "/portion", "value": 8}]`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "400 Removes Required Field",
+			contentType: binding.MIMEJSON,
+			patch:       `{"name": null}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "400 New Ingredient Without Unit",
+			contentType: mergePatchContentType,
+			patch:       `{"ingredients": {"9": {"amount": 1}}}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "400 Invalid Ingredient Key",
+			contentType: mergePatchContentType,
+			patch:       `{"ingredients": {"basil": {"amount": 1, "unitID": 2}}}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					GetRecipeIngredients(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Ingredients, nil)
+				storage.EXPECT().
+					ListRecipeKeywords(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(keywords, nil)
+				storage.EXPECT().
+					ReplaceRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "415 Unsupported Media Type",
+			contentType: "text/plain",
+			patch:       `{"portion": 8}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name:        "404 Not Found",
+			contentType: mergePatchContentType,
+			patch:       `{"portion": 8}`,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(db.Recipe{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader([]byte(tc.patch)))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", tc.contentType)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// examples from RFC 7386 appendix A
+	testCases := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tc := range testCases {
+		var target, patch interface{}
+		require.NoError(t, json.Unmarshal([]byte(tc.target), &target))
+		require.NoError(t, json.Unmarshal([]byte(tc.patch), &patch))
+
+		data, err := json.Marshal(mergePatch(target, patch))
+		require.NoError(t, err)
+		require.JSONEq(t, tc.result, string(data))
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+var ErrCookedInFuture = errors.New("a meal can not be cooked in the future")
+
+type recipeReviewUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type upsertRecipeReviewJSON struct {
+	Rating  int32  `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"omitempty,max=2000"`
+}
+
+// Every user has a single review per recipe, reviewing again replaces it
+func (server *Server) upsertRecipeReview(ctx *gin.Context) {
+	var reqUri recipeReviewUri
+	var reqJSON upsertRecipeReviewJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	review, err := server.storage.UpsertRecipeReview(ctx, db.UpsertRecipeReviewParams{
+		RecipeID: reqUri.ID,
+		UserID:   authPayload.Subject,
+		Rating:   reqJSON.Rating,
+		Comment: sql.NullString{
+			String: reqJSON.Comment,
+			Valid:  reqJSON.Comment != "",
+		},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+func (server *Server) deleteRecipeReview(ctx *gin.Context) {
+	var req recipeReviewUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	err := server.storage.DeleteRecipeReview(ctx, db.DeleteRecipeReviewParams{
+		RecipeID: req.ID,
+		UserID:   authPayload.Subject,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+func (server *Server) listRecipeReviews(ctx *gin.Context) {
+	var reqUri recipeReviewUri
+	var reqQuery listPageRequest
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&reqQuery); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reviews, err := server.storage.ListRecipeReviews(ctx, db.ListRecipeReviewsParams{
+		RecipeID: reqUri.ID,
+		Limit:    reqQuery.PageSize,
+		Offset:   (reqQuery.PageNum - 1) * reqQuery.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reviews)
+}
+
+type logRecipeCookedQuery struct {
+	// Day the meal was cooked, today when empty
+	CookedOn time.Time `form:"cookedOn" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// Log a meal cooked outside of a schedule, dated schedule meals are logged once their day has passed
+func (server *Server) logRecipeCooked(ctx *gin.Context) {
+	var reqUri recipeReviewUri
+	var reqQuery logRecipeCookedQuery
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&reqQuery); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if reqQuery.CookedOn.After(time.Now().UTC()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrCookedInFuture))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	cooked, err := server.storage.CreateRecipeCooked(ctx, db.CreateRecipeCookedParams{
+		RecipeID: reqUri.ID,
+		UserID:   authPayload.Subject,
+		CookedOn: sql.NullTime{
+			Time:  reqQuery.CookedOn,
+			Valid: !reqQuery.CookedOn.IsZero(),
+		},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cooked)
+}
+
+type deleteRecipeCookedRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Only meals logged by hand can be deleted, scheduled meals leave the log with their schedule
+func (server *Server) deleteRecipeCooked(ctx *gin.Context) {
+	var req deleteRecipeCookedRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cooked, err := server.storage.GetRecipeCooked(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	if cooked.UserID != authPayload.Subject {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	err = server.storage.DeleteRecipeCooked(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+func (server *Server) listUserCooked(ctx *gin.Context) {
+	var req listPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	cooked, err := server.storage.ListUserCooked(ctx, db.ListUserCookedParams{
+		UserID: authPayload.Subject,
+		Limit:  req.PageSize,
+		Offset: (req.PageNum - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cooked)
+}
@@ -0,0 +1,518 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestUpsertRecipeReviewAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	comment := util.RandomString(30)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"rating":  4,
+				"comment": comment,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.UpsertRecipeReviewParams{
+					RecipeID: recipe.Recipe.ID,
+					UserID:   user.ID,
+					Rating:   4,
+					Comment:  sql.NullString{String: comment, Valid: true},
+				}
+				storage.EXPECT().
+					UpsertRecipeReview(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RecipesReview{
+						RecipeID: arg.RecipeID,
+						UserID:   arg.UserID,
+						Rating:   arg.Rating,
+						Comment:  arg.Comment,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var review db.RecipesReview
+				err := json.Unmarshal(recorder.Body.Bytes(), &review)
+				require.NoError(t, err)
+				require.Equal(t, int32(4), review.Rating)
+				require.Equal(t, comment, review.Comment.String)
+			},
+		},
+		{
+			name: "OK Without Comment",
+			body: gin.H{
+				"rating": 5,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.UpsertRecipeReviewParams{
+					RecipeID: recipe.Recipe.ID,
+					UserID:   user.ID,
+					Rating:   5,
+				}
+				storage.EXPECT().
+					UpsertRecipeReview(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RecipesReview{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Rating Out Of Range",
+			body: gin.H{
+				"rating": 6,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertRecipeReview(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Recipe Not Found",
+			body: gin.H{
+				"rating": 3,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertRecipeReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipesReview{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/recipe/%d/review", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListRecipeReviewsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	reviews := []db.ListRecipeReviewsRow{
+		{
+			RecipeID: recipe.Recipe.ID,
+			UserID:   user.ID,
+			Username: user.Username,
+			Rating:   5,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "pageSize=5&pageNum=2",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeReviews(gomock.Any(), gomock.Eq(db.ListRecipeReviewsParams{
+						RecipeID: recipe.Recipe.ID,
+						Limit:    5,
+						Offset:   5,
+					})).
+					Times(1).
+					Return(reviews, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []db.ListRecipeReviewsRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, reviews, result)
+			},
+		},
+		{
+			name:  "400 Bad Request",
+			query: "pageSize=5",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeReviews(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "500 Internal Server Error",
+			query: "pageSize=5&pageNum=1",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeReviews(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/reviews?%s", recipe.Recipe.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLogRecipeCookedAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	cookedOn := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK Today",
+			query: "",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateRecipeCooked(gomock.Any(), gomock.Eq(db.CreateRecipeCookedParams{
+						RecipeID: recipe.Recipe.ID,
+						UserID:   user.ID,
+					})).
+					Times(1).
+					Return(db.RecipesCooked{ID: 1, RecipeID: recipe.Recipe.ID, UserID: user.ID}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OK Past Day",
+			query: "cookedOn=2026-10-01",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateRecipeCooked(gomock.Any(), gomock.Eq(db.CreateRecipeCookedParams{
+						RecipeID: recipe.Recipe.ID,
+						UserID:   user.ID,
+						CookedOn: sql.NullTime{Time: cookedOn, Valid: true},
+					})).
+					Times(1).
+					Return(db.RecipesCooked{ID: 1, RecipeID: recipe.Recipe.ID, UserID: user.ID, CookedOn: cookedOn}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Future Day",
+			query: "cookedOn=" + time.Now().UTC().AddDate(0, 0, 2).Format("2006-01-02"),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateRecipeCooked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Invalid Day",
+			query: "cookedOn=yesterday",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateRecipeCooked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "404 Recipe Not Found",
+			query: "",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateRecipeCooked(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipesCooked{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/cooked?%s", recipe.Recipe.ID, tc.query)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteRecipeCookedAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	cooked := db.RecipesCooked{
+		ID:       util.RandomInt(1, 1000),
+		RecipeID: util.RandomInt(1, 1000),
+		UserID:   user.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeCooked(gomock.Any(), gomock.Eq(cooked.ID)).
+					Times(1).
+					Return(cooked, nil)
+				storage.EXPECT().
+					DeleteRecipeCooked(gomock.Any(), gomock.Eq(cooked.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "403 Forbidden",
+			userID: other.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeCooked(gomock.Any(), gomock.Eq(cooked.ID)).
+					Times(1).
+					Return(cooked, nil)
+				storage.EXPECT().
+					DeleteRecipeCooked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Not Found",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeCooked(gomock.Any(), gomock.Eq(cooked.ID)).
+					Times(1).
+					Return(db.RecipesCooked{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DeleteRecipeCooked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/cooked/%d", cooked.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListUserCookedAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	cooked := []db.ListUserCookedRow{
+		{
+			ID:       sql.NullInt64{Int64: 3, Valid: true},
+			RecipeID: util.RandomInt(1, 1000),
+			Name:     util.RandomString(10),
+			CookedOn: time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			RecipeID:   util.RandomInt(1, 1000),
+			Name:       util.RandomString(10),
+			CookedOn:   time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+			ScheduleID: sql.NullInt64{Int64: 7, Valid: true},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     bool
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			query:     "pageSize=10&pageNum=1",
+			setupAuth: true,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUserCooked(gomock.Any(), gomock.Eq(db.ListUserCookedParams{
+						UserID: user.ID,
+						Limit:  10,
+						Offset: 0,
+					})).
+					Times(1).
+					Return(cooked, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []db.ListUserCookedRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, cooked, result)
+			},
+		},
+		{
+			name:      "401 Unauthorized",
+			query:     "pageSize=10&pageNum=1",
+			setupAuth: false,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListUserCooked(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := "/user/cooked?" + tc.query
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			if tc.setupAuth {
+				addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			}
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
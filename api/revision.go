@@ -0,0 +1,130 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type listRecipeRevisionsRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listRecipeRevisions(ctx *gin.Context) {
+	var req listRecipeRevisionsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.storage.ListRecipeRevisions(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// every recipe has at least the revision it was created with
+	if len(rows) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	result := make([]db.Revision, len(rows))
+	for i, row := range rows {
+		result[i], err = db.RevisionOf(row)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type diffRecipeRevisionsUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type diffRecipeRevisionsQuery struct {
+	From int32 `form:"from" binding:"required,min=1"`
+	To   int32 `form:"to" binding:"required,min=1"`
+}
+
+func (server *Server) diffRecipeRevisions(ctx *gin.Context) {
+	var reqUri diffRecipeRevisionsUri
+	var query diffRecipeRevisionsQuery
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var revisions [2]db.Revision
+	for i, number := range []int32{query.From, query.To} {
+		row, err := server.storage.GetRecipeRevision(ctx, db.GetRecipeRevisionParams{
+			RecipeID: reqUri.ID,
+			Revision: number,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		revisions[i], err = db.RevisionOf(row)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, db.DiffRevisions(revisions[0], revisions[1]))
+}
+
+type revertRecipeRequest struct {
+	ID       int64 `uri:"id" binding:"required,min=1"`
+	Revision int32 `uri:"revision" binding:"required,min=1"`
+}
+
+func (server *Server) revertRecipe(ctx *gin.Context) {
+	var req revertRecipeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeRecipe(ctx, req.ID) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	recipe, err := server.storage.RevertRecipeTx(ctx, db.RevertRecipeParams{
+		RecipeID: req.ID,
+		Revision: req.Revision,
+		Editor:   authPayload.Subject,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		// an ingredient, cuisine, or course of the revision has been deleted since
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
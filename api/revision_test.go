@@ -0,0 +1,355 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func randomRevision(recipe db.RecipeResult, number int32) db.RecipesRevision {
+	ingredients := make([]db.RevisionIngredient, len(recipe.Ingredients))
+	for i, ingredient := range recipe.Ingredients {
+		ingredients[i] = db.RevisionIngredient{
+			IngredientID: ingredient.IngredientID,
+			Name:         ingredient.Name,
+			Amount:       ingredient.Amount,
+			UnitID:       ingredient.UnitID,
+		}
+	}
+	data, _ := json.Marshal(ingredients)
+
+	return db.RecipesRevision{
+		ID:          util.RandomInt(1, 1000),
+		RecipeID:    recipe.Recipe.ID,
+		Revision:    number,
+		Name:        recipe.Recipe.Name,
+		Portion:     recipe.Recipe.Portion,
+		Steps:       recipe.Recipe.Steps,
+		Ingredients: data,
+		Author: uuid.NullUUID{
+			UUID:  recipe.Recipe.Author,
+			Valid: true,
+		},
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestListRecipeRevisionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	revisions := []db.RecipesRevision{
+		randomRevision(recipe, 2),
+		randomRevision(recipe, 1),
+	}
+
+	testCases := []struct {
+		name          string
+		recipeID      int64
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			recipeID: recipe.Recipe.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeRevisions(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(revisions, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []db.Revision
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Len(t, result, len(revisions))
+				require.Equal(t, int32(2), result[0].Revision)
+				require.Len(t, result[0].Ingredients, len(recipe.Ingredients))
+			},
+		},
+		{
+			name:     "400 Bad Request",
+			recipeID: 0,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeRevisions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "404 Not Found",
+			recipeID: recipe.Recipe.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeRevisions(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return([]db.RecipesRevision{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "500 Internal Server Error",
+			recipeID: recipe.Recipe.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeRevisions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/revisions", tc.recipeID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDiffRecipeRevisionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	from := randomRevision(recipe, 1)
+	to := randomRevision(recipe, 2)
+	to.Name = util.RandomString(12)
+	to.Portion = from.Portion + 1
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from=1&to=2",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeRevision(gomock.Any(), gomock.Eq(db.GetRecipeRevisionParams{
+						RecipeID: recipe.Recipe.ID,
+						Revision: 1,
+					})).
+					Times(1).
+					Return(from, nil)
+				storage.EXPECT().
+					GetRecipeRevision(gomock.Any(), gomock.Eq(db.GetRecipeRevisionParams{
+						RecipeID: recipe.Recipe.ID,
+						Revision: 2,
+					})).
+					Times(1).
+					Return(to, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.RevisionDiff
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, &db.StringChange{Old: from.Name, New: to.Name}, result.Name)
+				require.Equal(t, &db.PortionChange{Old: from.Portion, New: to.Portion}, result.Portion)
+				require.Empty(t, result.Added)
+				require.Empty(t, result.Removed)
+				require.Empty(t, result.Changed)
+			},
+		},
+		{
+			name:  "400 Missing From",
+			query: "to=2",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeRevision(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "404 Not Found",
+			query: "from=1&to=5",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeRevision(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipesRevision{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/diff?%s", recipe.Recipe.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevertRecipeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					RevertRecipeTx(gomock.Any(), gomock.Eq(db.RevertRecipeParams{
+						RecipeID: recipe.Recipe.ID,
+						Revision: 1,
+						Editor:   user.ID,
+					})).
+					Times(1).
+					Return(recipe, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "403 Forbidden",
+			userID: other.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					RevertRecipeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Revision Not Found",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					RevertRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "409 Ingredient Deleted",
+			userID: user.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					RevertRecipeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecipeResult{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/%d/revert/1", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -14,6 +14,10 @@ import (
 type generateGroceriesRequest struct {
 	Author  uuid.NullUUID           `json:"author" binding:"required"`
 	Recipes []db.ScheduleRecipePortion `json:"recipes" binding:"required,min=1"`
+	Restrictions string `json:"restrictions" binding:"omitempty,oneof=exclude warn"`
+	UnitSystem string `json:"unitSystem" binding:"omitempty,oneof=metric imperial"`
+	PinRevisions bool `json:"pinRevisions"`
+	Store string `json:"store" binding:"max=100"`
 }
 
 func (server *Server) generateGroceries(ctx *gin.Context) {
@@ -26,6 +30,20 @@ func (server *Server) generateGroceries(ctx *gin.Context) {
 	arg := db.GenerateGroceriesParam{
 		Author: req.Author,
 		Recipes: req.Recipes,
+		Restrictions: req.Restrictions,
+		UnitSystem: req.UnitSystem,
+		PinRevisions: req.PinRevisions,
+		Store: req.Store,
+	}
+
+	// restrictions belong to the caller, not to the author in the body
+	if req.Restrictions != "" {
+		authPayload, ok := ctx.Get(authPayloadKey)
+		if !ok {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(ErrRestrictionsAuth))
+			return
+		}
+		arg.RestrictionsUser = authPayload.(*auth.Payload).Subject
 	}
 
 	groceries, err := server.storage.GenerateGroceries(ctx, arg)
@@ -208,4 +226,183 @@ func (server *Server) deleteScheduleRecipe(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, nil)
-}
\ No newline at end of file
+}
+
+type getScheduleNutritionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getScheduleNutrition(ctx *gin.Context) {
+	var req getScheduleNutritionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := server.storage.GetSchedule(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// check permission
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if schedule.Author.UUID != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	nutrition, err := server.storage.GetScheduleNutritionTx(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nutrition)
+}
+
+type duplicateScheduleUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type duplicateScheduleRequest struct {
+	// Days to move every scheduled date by, 7 repeats the plan a week later
+	ShiftDays int `json:"shiftDays" binding:"min=-366,max=366"`
+	// Portion multiplier, empty keeps the portions
+	Multiplier float64 `json:"multiplier" binding:"omitempty,gt=0,max=20"`
+	UnitSystem string  `json:"unitSystem" binding:"omitempty,oneof=metric imperial"`
+	Store      string  `json:"store" binding:"max=100"`
+}
+
+// Copy a schedule into a new one of the caller and return its groceries
+func (server *Server) duplicateSchedule(ctx *gin.Context) {
+	var reqUri duplicateScheduleUri
+	var reqJSON duplicateScheduleRequest
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.editableSchedule(ctx, reqUri.ID); !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.DuplicateScheduleParams{
+		ScheduleID: reqUri.ID,
+		Author:     authPayload.Subject,
+		ShiftDays:  reqJSON.ShiftDays,
+		Multiplier: reqJSON.Multiplier,
+		UnitSystem: reqJSON.UnitSystem,
+		Store:      reqJSON.Store,
+	}
+
+	groceries, err := server.storage.DuplicateScheduleTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groceries)
+}
+
+type mergeGroceriesRequest struct {
+	ScheduleIDs []int64 `json:"scheduleIDs" binding:"required,min=1,max=20,dive,min=1"`
+	UnitSystem  string  `json:"unitSystem" binding:"omitempty,oneof=metric imperial"`
+	Store       string  `json:"store" binding:"max=100"`
+}
+
+// One grocery list for several schedules the caller may access
+func (server *Server) mergeGroceries(ctx *gin.Context) {
+	var req mergeGroceriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	scheduleIDs := make([]int64, 0, len(req.ScheduleIDs))
+	seen := map[int64]bool{}
+	for _, id := range req.ScheduleIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		schedule, err := server.storage.GetSchedule(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if schedule.Author.UUID != authPayload.Subject && permit.Role != "admin" {
+			ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+			return
+		}
+		scheduleIDs = append(scheduleIDs, id)
+	}
+
+	arg := db.MergeGroceriesParams{
+		ScheduleIDs: scheduleIDs,
+		UnitSystem:  req.UnitSystem,
+		Store:       req.Store,
+	}
+
+	groceries, err := server.storage.MergeGroceriesTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groceries)
+}
+
+// Load a schedule the user may see and edit, its author or an admin
+func (server *Server) editableSchedule(ctx *gin.Context, scheduleID int64) (db.Schedule, bool) {
+	schedule, err := server.storage.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return schedule, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return schedule, false
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return schedule, false
+	}
+	if schedule.Author.UUID != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return schedule, false
+	}
+
+	return schedule, true
+}
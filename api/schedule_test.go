@@ -15,6 +15,7 @@ import (
 	"github.com/hasnaroihan/grocery-planner/auth"
 	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
 	"github.com/hasnaroihan/grocery-planner/util"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
@@ -30,9 +31,13 @@ func TestGenerateGroceriesAPI(t *testing.T) {
 		})
 	}
 
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+
 	testCases := []struct {
 		name          string
 		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
 		buildStubs    func(storage *dbmock.MockStorage)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
@@ -56,6 +61,112 @@ func TestGenerateGroceriesAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "OK Exclude Restrictions",
+			body: gin.H{
+				"author":       uuid.NullUUID{},
+				"recipes":      scheduleRecipe,
+				"restrictions": "exclude",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.GenerateGroceriesParam{
+					Author:           uuid.NullUUID{},
+					Recipes:          scheduleRecipe,
+					Restrictions:     db.RestrictionsExclude,
+					RestrictionsUser: user.ID,
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), arg).
+					Times(1).
+					Return(schedule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK Restrictions Of Caller Not Author",
+			body: gin.H{
+				"author":       uuid.NullUUID{UUID: other.ID, Valid: true},
+				"recipes":      scheduleRecipe,
+				"restrictions": "warn",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.GenerateGroceriesParam{
+					Author:           uuid.NullUUID{UUID: other.ID, Valid: true},
+					Recipes:          scheduleRecipe,
+					Restrictions:     db.RestrictionsWarn,
+					RestrictionsUser: user.ID,
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), arg).
+					Times(1).
+					Return(schedule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OK Store",
+			body: gin.H{
+				"author":  uuid.NullUUID{},
+				"recipes": scheduleRecipe,
+				"store":   "market",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.GenerateGroceriesParam{
+					Author:  uuid.NullUUID{},
+					Recipes: scheduleRecipe,
+					Store:   "market",
+				}
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), arg).
+					Times(1).
+					Return(schedule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "401 Restrictions Without Auth",
+			body: gin.H{
+				"author":       uuid.NullUUID{UUID: other.ID, Valid: true},
+				"recipes":      scheduleRecipe,
+				"restrictions": "warn",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "400 Unknown Restrictions Mode",
+			body: gin.H{
+				"author":       uuid.NullUUID{},
+				"recipes":      scheduleRecipe,
+				"restrictions": "ignore",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GenerateGroceries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "400 Empty Schedule",
 			body: gin.H{
@@ -117,6 +228,9 @@ func TestGenerateGroceriesAPI(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			if tc.setupAuth != nil {
+				tc.setupAuth(t, request, server.tokenMaker)
+			}
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -956,23 +1070,625 @@ func randomSchedule(Author uuid.NullUUID) db.GenerateGroceriesResult {
 				Portion:    int32(util.RandomInt(1, 5)),
 			},
 		},
-		Groceries: []db.ListGroceriesRow{
+		Groceries: []db.GroceryItem{
 			{
 				ID:   int32(util.RandomInt(1, 100)),
 				Name: util.RandomString(10),
+				Quantities: []measure.Quantity{
+					{Amount: float64(util.RandomInt(1, 500)), Unit: "g"},
+				},
 			},
 			{
 				ID:   int32(util.RandomInt(1, 100)),
 				Name: util.RandomString(10),
+				Quantities: []measure.Quantity{
+					{Amount: float64(util.RandomInt(1, 500)), Unit: "g"},
+				},
 			},
 			{
 				ID:   int32(util.RandomInt(1, 100)),
 				Name: util.RandomString(10),
+				Quantities: []measure.Quantity{
+					{Amount: float64(util.RandomInt(1, 500)), Unit: "g"},
+				},
 			},
 			{
 				ID:   int32(util.RandomInt(1, 100)),
 				Name: util.RandomString(10),
+				Quantities: []measure.Quantity{
+					{Amount: float64(util.RandomInt(1, 500)), Unit: "g"},
+				},
+			},
+		},
+	}
+}
+
+func TestGetScheduleNutritionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	schedule := randomSchedule(uuid.NullUUID{
+		UUID:  user.ID,
+		Valid: true,
+	})
+	nutrition := db.ScheduleNutritionResult{
+		ScheduleID: schedule.Schedule.ID,
+		Days: []db.DailyNutrition{
+			{
+				Date: sql.NullTime{
+					Time:  time.Now().UTC().Truncate(24 * time.Hour),
+					Valid: true,
+				},
+				Total: db.NutritionFacts{
+					Calories: float64(util.RandomInt(100, 3000)),
+				},
+			},
+		},
+		Missing: []int32{},
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int64
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  schedule.Schedule.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					GetScheduleNutritionTx(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(nutrition, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.ScheduleNutritionResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got.Days, 1)
+				require.Equal(t, nutrition.Days[0].Total, got.Days[0].Total)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			uri:  schedule.Schedule.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				id, err := uuid.NewRandom()
+				require.NoError(t, err)
+				addAuthorization(t, req, tokenMaker, authBearerType, id, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Not(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					GetScheduleNutritionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "404 Not Found",
+			uri:  schedule.Schedule.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(db.Schedule{}, sql.ErrNoRows)
+				storage.EXPECT().
+					GetScheduleNutritionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  schedule.Schedule.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(schedule.Schedule, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					GetScheduleNutritionTx(gomock.Any(), gomock.Eq(schedule.Schedule.ID)).
+					Times(1).
+					Return(db.ScheduleNutritionResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/schedule/nutrition/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDuplicateScheduleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	admin, _ := randomAdmin(t)
+	source := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true}).Schedule
+	copied := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true})
+	body := gin.H{
+		"shiftDays":  7,
+		"multiplier": 1.5,
+		"store":      "corner",
+	}
+
+	testCases := []struct {
+		name          string
+		id            int64
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   source.ID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.DuplicateScheduleParams{
+					ScheduleID: source.ID,
+					Author:     user.ID,
+					ShiftDays:  7,
+					Multiplier: 1.5,
+					Store:      "corner",
+				}
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(copied, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.GenerateGroceriesResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, copied.Schedule.ID, got.Schedule.ID)
 			},
 		},
+		{
+			name: "OK Admin",
+			id:   source.ID,
+			body: gin.H{},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.DuplicateScheduleParams{
+					ScheduleID: source.ID,
+					Author:     admin.ID,
+				}
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "admin"}, nil)
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(copied, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Invalid Multiplier",
+			id:   source.ID,
+			body: gin.H{
+				"multiplier": -1,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "401 Unauthorized",
+			id:   source.ID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			id:   source.ID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, other.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "404 Not Found",
+			id:   source.ID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(db.Schedule{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			id:   source.ID,
+			body: body,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					DuplicateScheduleTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GenerateGroceriesResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/schedule/%d/duplicate", tc.id)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestMergeGroceriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	lunch := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true}).Schedule
+	dinner := randomSchedule(uuid.NullUUID{UUID: user.ID, Valid: true}).Schedule
+	dinner.ID = lunch.ID + 1
+	merged := db.MergedGroceriesResult{
+		ScheduleIDs: []int64{lunch.ID, dinner.ID},
+		Groceries: []db.MergedGroceryItem{
+			{
+				GroceryItem: db.GroceryItem{
+					ID:         int32(util.RandomInt(1, 100)),
+					Name:       util.RandomString(10),
+					Quantities: []measure.Quantity{{Amount: 300, Unit: "gram"}},
+				},
+				Schedules: []db.ScheduleGroceryShare{
+					{ScheduleID: lunch.ID, Quantities: []measure.Quantity{{Amount: 100, Unit: "gram"}}},
+					{ScheduleID: dinner.ID, Quantities: []measure.Quantity{{Amount: 200, Unit: "gram"}}},
+				},
+			},
+		},
+		Cost: db.GroceryCost{Missing: []int32{}},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"scheduleIDs": []int64{lunch.ID, dinner.ID, lunch.ID},
+				"unitSystem":  "metric",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.MergeGroceriesParams{
+					ScheduleIDs: []int64{lunch.ID, dinner.ID},
+					UnitSystem:  "metric",
+				}
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(lunch.ID)).
+					Times(1).
+					Return(lunch, nil)
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(dinner.ID)).
+					Times(1).
+					Return(dinner, nil)
+				storage.EXPECT().
+					MergeGroceriesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(merged, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.MergedGroceriesResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, merged, got)
+			},
+		},
+		{
+			name: "400 No Schedules",
+			body: gin.H{
+				"scheduleIDs": []int64{},
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					MergeGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "401 Unauthorized",
+			body: gin.H{
+				"scheduleIDs": []int64{lunch.ID},
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					MergeGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "403 Forbidden",
+			body: gin.H{
+				"scheduleIDs": []int64{lunch.ID},
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, other.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(lunch.ID)).
+					Times(1).
+					Return(lunch, nil)
+				storage.EXPECT().
+					MergeGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "404 Not Found",
+			body: gin.H{
+				"scheduleIDs": []int64{lunch.ID},
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(lunch.ID)).
+					Times(1).
+					Return(db.Schedule{}, sql.ErrNoRows)
+				storage.EXPECT().
+					MergeGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			body: gin.H{
+				"scheduleIDs": []int64{lunch.ID},
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{Role: "common"}, nil)
+				storage.EXPECT().
+					GetSchedule(gomock.Any(), gomock.Eq(lunch.ID)).
+					Times(1).
+					Return(lunch, nil)
+				storage.EXPECT().
+					MergeGroceriesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MergedGroceriesResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/groceries/merge", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
 	}
 }
@@ -5,31 +5,38 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/hasnaroihan/grocery-planner/auth"
+	"github.com/hasnaroihan/grocery-planner/blob"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
 )
 
 var SYM_KEY string
 var ACCESS_TOKEN_DURATION time.Duration
+var PUBLIC_URL string
 
 var (
-	ErrAccessDenied = errors.New("authenticated user does not have access permission")
+	ErrAccessDenied     = errors.New("authenticated user does not have access permission")
+	ErrRestrictionsAuth = errors.New("dietary restrictions require an authenticated user")
 )
 
 type Server struct {
 	storage       db.Storage
 	tokenMaker    auth.TokenMaker
 	tokenDuration time.Duration
-	router        *gin.Engine
+	blobs         blob.BlobStore
+	// Absolute URL the API is reached at, links handed to other apps point there
+	publicURL string
+	router    *gin.Engine
 }
 
 // Server constructor
-func NewServer(storage db.Storage) (*Server, error) {
+func NewServer(storage db.Storage, blobs blob.BlobStore) (*Server, error) {
 	err := configToken()
 	if err != nil {
 		return nil, err
@@ -43,6 +50,8 @@ func NewServer(storage db.Storage) (*Server, error) {
 		storage:       storage,
 		tokenMaker:    tokenMaker,
 		tokenDuration: ACCESS_TOKEN_DURATION,
+		blobs:         blobs,
+		publicURL:     configPublicURL(),
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -63,6 +72,7 @@ func (server *Server) setupRouter() {
 	router := gin.Default()
 	authRouter := router.Group("/").Use(authMiddleware(server.tokenMaker))
 	adminRouter := router.Group("/").Use(authMiddleware(server.tokenMaker), adminMiddleware(server.storage))
+	optionalAuthRouter := router.Group("/").Use(optionalAuthMiddleware(server.tokenMaker))
 
 	// USER
 	router.POST("/register", server.registerUser)
@@ -71,6 +81,13 @@ func (server *Server) setupRouter() {
 	adminRouter.GET("/user/all", server.listUsers)
 	authRouter.GET("/user/:id", server.getUser)
 	authRouter.PATCH("/user/update/:id", server.updateUser)
+	authRouter.PUT("/user/alerts/:id", server.updateExpiryAlert)
+	authRouter.PUT("/user/restrictions/:id", server.setUserRestrictions)
+	authRouter.GET("/user/restrictions/:id", server.getUserRestrictions)
+	authRouter.GET("/user/cooked", server.listUserCooked)
+	authRouter.POST("/user/calendar", server.createCalendarFeed)
+	authRouter.GET("/user/calendar", server.getCalendarFeed)
+	authRouter.DELETE("/user/calendar", server.deleteCalendarFeed)
 	// TODO update verified and update password
 
 	// INGREDIENTS
@@ -83,6 +100,32 @@ func (server *Server) setupRouter() {
 	router.GET("/ingredients/:id", server.getIngredient)
 	router.GET("/ingredients/all", server.listIngredients)
 	router.GET("/ingredients", server.searchIngredients)
+	adminRouter.POST("/ingredients/nutrition/:id", server.upsertNutrition)
+	router.GET("/ingredients/nutrition/:id", server.getNutrition)
+	adminRouter.POST("/ingredients/unit/:id", server.upsertIngredientUnit)
+	adminRouter.DELETE("/ingredients/unit", server.deleteIngredientUnit)
+	router.GET("/ingredients/unit/:id", server.listIngredientUnits)
+	adminRouter.POST("/ingredients/alias/:id", server.createIngredientAlias)
+	adminRouter.DELETE("/ingredients/alias", server.deleteIngredientAlias)
+	router.GET("/ingredients/alias/:id", server.listIngredientAliases)
+	adminRouter.POST("/ingredients/tag/:id", server.createIngredientTag)
+	adminRouter.DELETE("/ingredients/tag", server.deleteIngredientTag)
+	router.GET("/ingredients/tag/:id", server.listIngredientTags)
+	adminRouter.POST("/ingredients/price/:id", server.createIngredientPrice)
+	adminRouter.DELETE("/ingredients/price/delete/:id", server.deleteIngredientPrice)
+	router.GET("/ingredients/price/:id", server.listIngredientPrices)
+	adminRouter.POST("/ingredients/package/:id", server.createIngredientPackage)
+	adminRouter.DELETE("/ingredients/package/delete/:id", server.deleteIngredientPackage)
+	router.GET("/ingredients/package/:id", server.listIngredientPackages)
+	adminRouter.PUT("/ingredients/shelflife/:id", server.upsertIngredientShelfLife)
+	router.GET("/ingredients/shelflife/:id", server.getIngredientShelfLife)
+	adminRouter.PUT("/ingredients/category/:id", server.upsertIngredientCategory)
+	router.GET("/ingredients/category/:id", server.getIngredientCategory)
+
+	// DIETARY TAGS
+	adminRouter.POST("/tags/add", server.createDietaryTag)
+	adminRouter.DELETE("/tags/delete/:name", server.deleteDietaryTag)
+	router.GET("/tags/all", server.listDietaryTags)
 
 	// UNITS
 	adminRouter.POST("/unit/add", server.createUnit)
@@ -93,20 +136,86 @@ func (server *Server) setupRouter() {
 
 	// RECIPES
 	authRouter.POST("/recipe/add", server.newRecipe)
+	authRouter.POST("/recipe/import", server.importRecipe)
 	authRouter.DELETE("/recipe/delete/:id", server.deleteRecipe)
 	authRouter.DELETE("/recipe/delete", server.deleteRecipeIngredient)
 	authRouter.PATCH("/recipe/update/:id", server.updateRecipe)
 	authRouter.GET("/recipe/my", server.listRecipesUser)
 	router.GET("/recipe/:id", server.getRecipe)
-	router.GET("/recipe/all", server.listRecipes)
-	router.GET("/recipe", server.searchRecipe)
+	authRouter.PUT("/recipe/:id", server.replaceRecipe)
+	authRouter.PATCH("/recipe/:id", server.patchRecipe)
+	router.GET("/recipe/:id/export", server.exportRecipe)
+	router.GET("/recipe/:id/revisions", server.listRecipeRevisions)
+	router.GET("/recipe/:id/diff", server.diffRecipeRevisions)
+	authRouter.POST("/recipe/:id/revert/:revision", server.revertRecipe)
+	authRouter.POST("/recipe/:id/fork", server.forkRecipe)
+	authRouter.PUT("/recipe/:id/review", server.upsertRecipeReview)
+	authRouter.DELETE("/recipe/:id/review", server.deleteRecipeReview)
+	router.GET("/recipe/:id/reviews", server.listRecipeReviews)
+	authRouter.POST("/recipe/:id/cooked", server.logRecipeCooked)
+	authRouter.DELETE("/recipe/cooked/:id", server.deleteRecipeCooked)
+	authRouter.POST("/recipe/:id/image", server.uploadRecipeImage)
+	router.GET("/recipe/:id/images", server.listRecipeImages)
+	authRouter.DELETE("/recipe/image/:imageID", server.deleteRecipeImage)
+	router.GET("/recipe/taxonomy", server.listRecipeTaxonomy)
+	router.GET("/recipe/keywords", server.listKeywords)
+	optionalAuthRouter.GET("/recipe/all", server.listRecipes)
+	optionalAuthRouter.GET("/recipe", server.searchRecipe)
+	router.GET("/recipe/steps/:id", server.listRecipeSteps)
+	authRouter.POST("/recipe/steps/:id", server.insertRecipeStep)
+	authRouter.PATCH("/recipe/steps/update/:stepID", server.updateRecipeStep)
+	authRouter.DELETE("/recipe/steps/delete/:stepID", server.deleteRecipeStep)
+	authRouter.PUT("/recipe/steps/order/:id", server.reorderRecipeSteps)
+
+	// FAVORITES & COLLECTIONS
+	authRouter.POST("/favorites/:id", server.createFavorite)
+	authRouter.DELETE("/favorites/:id", server.deleteFavorite)
+	authRouter.GET("/favorites", server.listFavorites)
+	authRouter.POST("/collection/add", server.createCollection)
+	authRouter.GET("/collection/my", server.listCollectionsUser)
+	authRouter.GET("/collection/:id", server.getCollection)
+	router.GET("/collection/shared/:token", server.getSharedCollection)
+	authRouter.PATCH("/collection/update/:id", server.updateCollection)
+	authRouter.DELETE("/collection/delete/:id", server.deleteCollection)
+	authRouter.POST("/collection/:id/recipe", server.addCollectionRecipe)
+	authRouter.DELETE("/collection/:id/recipe/:recipeID", server.removeCollectionRecipe)
+	authRouter.PUT("/collection/:id/order", server.reorderCollection)
+	authRouter.POST("/collection/:id/share", server.shareCollection)
+	authRouter.DELETE("/collection/:id/share", server.unshareCollection)
+	authRouter.POST("/collection/:id/plan", server.planCollection)
+	authRouter.POST("/collection/shared/:token/plan", server.planSharedCollection)
+
+	// IMAGES
+	router.GET(ImagesPath+"/*key", server.getImage)
+
+	// PARSER
+	router.POST("/parse/ingredients", server.parseIngredients)
 
 	// SCHEDULES
-	router.POST("/groceries", server.generateGroceries)
+	optionalAuthRouter.POST("/groceries", server.generateGroceries)
+	authRouter.POST("/groceries/merge", server.mergeGroceries)
+	router.GET("/groceries/categories", server.listGroceryCategories)
 	adminRouter.GET("/schedule/all", server.listSchedules)
 	authRouter.GET("/schedule/list", server.listSchedulesUser)
 	authRouter.DELETE("/schedule/delete/:id", server.deleteSchedule)
 	authRouter.DELETE("/schedule/delete", server.deleteScheduleRecipe)
+	authRouter.GET("/schedule/nutrition/:id", server.getScheduleNutrition)
+	authRouter.POST("/schedule/suggest", server.suggestMealPlan)
+	authRouter.POST("/schedule/:id/duplicate", server.duplicateSchedule)
+	authRouter.GET("/schedule/:id/groceries", server.exportScheduleGroceries)
+	authRouter.GET("/schedule/leftovers/:id", server.listScheduleLeftovers)
+	authRouter.POST("/schedule/leftovers/:id", server.addScheduleLeftovers)
+	authRouter.POST("/schedule/template/new", server.newScheduleTemplate)
+	authRouter.GET("/schedule/template/list", server.listScheduleTemplates)
+	authRouter.DELETE("/schedule/template/delete/:id", server.deleteScheduleTemplate)
+	authRouter.POST("/schedule/template/generate", server.generateWeek)
+	router.GET(calendarPath+"/:feed", server.calendarFeed)
+
+	// PANTRY
+	authRouter.POST("/pantry/add", server.createPantryItem)
+	authRouter.GET("/pantry", server.listPantryItems)
+	authRouter.DELETE("/pantry/delete/:id", server.deletePantryItem)
+	authRouter.GET("/pantry/suggestions", server.suggestPantryRecipes)
 
 	server.router = router
 }
@@ -126,3 +235,14 @@ func configToken() error {
 
 	return nil
 }
+
+// The configured public URL, or the server address when the API is reached directly.
+// Request headers are not trusted for it since any client can set them
+func configPublicURL() string {
+	PUBLIC_URL = os.Getenv("PUBLIC_URL")
+	if PUBLIC_URL == "" {
+		PUBLIC_URL = "http://" + os.Getenv("SERVER_ADDRESS")
+	}
+
+	return strings.TrimSuffix(PUBLIC_URL, "/")
+}
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type recipeStepRequest struct {
+	Text            string  `json:"text" binding:"required"`
+	DurationSeconds int32   `json:"durationSeconds" binding:"omitempty,min=1"`
+	Ingredients     []int32 `json:"ingredients" binding:"omitempty,dive,min=1"`
+}
+
+func (req recipeStepRequest) stepParam() db.StepParam {
+	return db.StepParam{
+		Text: req.Text,
+		DurationSeconds: sql.NullInt32{
+			Int32: req.DurationSeconds,
+			Valid: req.DurationSeconds > 0,
+		},
+		Ingredients: req.Ingredients,
+	}
+}
+
+func stepParams(reqs []recipeStepRequest) []db.StepParam {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	result := make([]db.StepParam, len(reqs))
+	for i, req := range reqs {
+		result[i] = req.stepParam()
+	}
+
+	return result
+}
+
+// Check that the authenticated user is the recipe author or an admin, writes the error response otherwise
+func (server *Server) authorizeRecipe(ctx *gin.Context, recipeID int64) bool {
+	_, ok := server.editableRecipe(ctx, recipeID)
+	return ok
+}
+
+// Same as authorizeRecipe, returning the recipe to edit
+func (server *Server) editableRecipe(ctx *gin.Context, recipeID int64) (db.Recipe, bool) {
+	recipe, err := server.storage.GetRecipe(ctx, recipeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return recipe, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return recipe, false
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return recipe, false
+	}
+	if recipe.Author != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return recipe, false
+	}
+
+	return recipe, true
+}
+
+// Ingredient references that are not part of the recipe are not found, repeated ones are conflicts
+func stepErrorResponse(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23503":
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		case "23505":
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
+
+type listRecipeStepsRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listRecipeStepsQuery struct {
+	Position int32 `form:"position" binding:"omitempty,min=1"`
+}
+
+// A single step for cooking mode
+type cookingStepResponse struct {
+	Step  db.RecipeStep `json:"step"`
+	Total int           `json:"total"`
+}
+
+func (server *Server) listRecipeSteps(ctx *gin.Context) {
+	var req listRecipeStepsRequest
+	var query listRecipeStepsQuery
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	steps, err := server.storage.ListRecipeStepsTx(ctx, req.ID)
+	if err != nil {
+		stepErrorResponse(ctx, err)
+		return
+	}
+
+	if query.Position == 0 {
+		ctx.JSON(http.StatusOK, steps)
+		return
+	}
+
+	if int(query.Position) > len(steps) {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("recipe has no step at this position")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cookingStepResponse{
+		Step:  steps[query.Position-1],
+		Total: len(steps),
+	})
+}
+
+type insertRecipeStepUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type insertRecipeStepJSON struct {
+	recipeStepRequest
+	Position int32 `json:"position" binding:"omitempty,min=1"`
+}
+
+func (server *Server) insertRecipeStep(ctx *gin.Context) {
+	var reqUri insertRecipeStepUri
+	var reqJSON insertRecipeStepJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeRecipe(ctx, reqUri.ID) {
+		return
+	}
+
+	steps, err := server.storage.InsertRecipeStepTx(ctx, db.InsertRecipeStepParams{
+		RecipeID: reqUri.ID,
+		Position: reqJSON.Position,
+		Step:     reqJSON.stepParam(),
+		Editor:   ctx.MustGet(authPayloadKey).(*auth.Payload).Subject,
+	})
+	if err != nil {
+		stepErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, steps)
+}
+
+type recipeStepUri struct {
+	StepID int64 `uri:"stepID" binding:"required,min=1"`
+}
+
+func (server *Server) updateRecipeStep(ctx *gin.Context) {
+	var reqUri recipeStepUri
+	var reqJSON recipeStepRequest
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	step, err := server.storage.GetRecipeStep(ctx, reqUri.StepID)
+	if err != nil {
+		stepErrorResponse(ctx, err)
+		return
+	}
+	if !server.authorizeRecipe(ctx, step.RecipeID) {
+		return
+	}
+
+	steps, err := server.storage.UpdateRecipeStepTx(ctx, db.UpdateRecipeStepTxParams{
+		ID:     reqUri.StepID,
+		Step:   reqJSON.stepParam(),
+		Editor: ctx.MustGet(authPayloadKey).(*auth.Payload).Subject,
+	})
+	if err != nil {
+		stepErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, steps)
+}
+
+func (server *Server) deleteRecipeStep(ctx *gin.Context) {
+	var req recipeStepUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	step, err := server.storage.GetRecipeStep(ctx, req.StepID)
+	if err != nil {
+		stepErrorResponse(ctx, err)
+		return
+	}
+	if !server.authorizeRecipe(ctx, step.RecipeID) {
+		return
+	}
+
+	steps, err := server.storage.DeleteRecipeStepTx(ctx, db.DeleteRecipeStepTxParams{
+		ID:     req.StepID,
+		Editor: ctx.MustGet(authPayloadKey).(*auth.Payload).Subject,
+	})
+	if err != nil {
+		stepErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, steps)
+}
+
+type reorderRecipeStepsUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reorderRecipeStepsJSON struct {
+	StepIDs []int64 `json:"stepIDs" binding:"required,min=1,dive,min=1"`
+}
+
+func (server *Server) reorderRecipeSteps(ctx *gin.Context) {
+	var reqUri reorderRecipeStepsUri
+	var reqJSON reorderRecipeStepsJSON
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeRecipe(ctx, reqUri.ID) {
+		return
+	}
+
+	steps, err := server.storage.ReorderRecipeStepsTx(ctx, db.ReorderRecipeStepsParams{
+		RecipeID: reqUri.ID,
+		StepIDs:  reqJSON.StepIDs,
+		Editor:   ctx.MustGet(authPayloadKey).(*auth.Payload).Subject,
+	})
+	if err != nil {
+		if err == db.ErrInvalidStepOrder {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		stepErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, steps)
+}
@@ -0,0 +1,520 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestListRecipeStepsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	steps := randomSteps(recipe)
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			url:  fmt.Sprintf("/recipe/steps/%d", recipe.Recipe.ID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeStepsTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(steps, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []db.RecipeStep
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, steps, result)
+			},
+		},
+		{
+			name: "OK Cooking Mode",
+			url:  fmt.Sprintf("/recipe/steps/%d?position=2", recipe.Recipe.ID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeStepsTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(steps, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result cookingStepResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, steps[1], result.Step)
+				require.Equal(t, len(steps), result.Total)
+			},
+		},
+		{
+			name: "400 Invalid Position",
+			url:  fmt.Sprintf("/recipe/steps/%d?position=-1", recipe.Recipe.ID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeStepsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Position Out Of Range",
+			url:  fmt.Sprintf("/recipe/steps/%d?position=%d", recipe.Recipe.ID, len(steps)+1),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeStepsTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(steps, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "404 Recipe Not Found",
+			url:  fmt.Sprintf("/recipe/steps/%d", recipe.Recipe.ID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListRecipeStepsTx(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestInsertRecipeStepAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	steps := randomSteps(recipe)
+
+	body := gin.H{
+		"position":        1,
+		"text":            steps[0].Text,
+		"durationSeconds": steps[0].DurationSeconds.Int32,
+		"ingredients":     steps[0].Ingredients,
+	}
+	arg := db.InsertRecipeStepParams{
+		RecipeID: recipe.Recipe.ID,
+		Position: 1,
+		Step: db.StepParam{
+			Text:            steps[0].Text,
+			DurationSeconds: steps[0].DurationSeconds,
+			Ingredients:     steps[0].Ingredients,
+		},
+		Editor: user.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					InsertRecipeStepTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(steps, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "400 Missing Text",
+			userID: user.ID,
+			body: gin.H{
+				"position": 1,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					InsertRecipeStepTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "400 Invalid Duration",
+			userID: user.ID,
+			body: gin.H{
+				"text":            steps[0].Text,
+				"durationSeconds": -10,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					InsertRecipeStepTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "403 Forbidden",
+			userID: other.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					InsertRecipeStepTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Ingredient Not In Recipe",
+			userID: user.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					InsertRecipeStepTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "500 Internal Server Error",
+			userID: user.ID,
+			body:   body,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					InsertRecipeStepTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/recipe/steps/%d", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, tc.userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteRecipeStepAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	steps := randomSteps(recipe)
+	step := db.RecipesStep{
+		ID:       steps[0].ID,
+		RecipeID: recipe.Recipe.ID,
+		Position: steps[0].Position,
+		Text:     steps[0].Text,
+	}
+
+	testCases := []struct {
+		name          string
+		stepID        int64
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			stepID: step.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeStep(gomock.Any(), gomock.Eq(step.ID)).
+					Times(1).
+					Return(step, nil)
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					DeleteRecipeStepTx(gomock.Any(), gomock.Eq(db.DeleteRecipeStepTxParams{
+						ID:     step.ID,
+						Editor: user.ID,
+					})).
+					Times(1).
+					Return(steps[1:], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "400 Bad Request",
+			stepID: 0,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					DeleteRecipeStepTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "404 Step Not Found",
+			stepID: step.ID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipeStep(gomock.Any(), gomock.Eq(step.ID)).
+					Times(1).
+					Return(db.RecipesStep{}, sql.ErrNoRows)
+				storage.EXPECT().
+					DeleteRecipeStepTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/steps/delete/%d", tc.stepID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReorderRecipeStepsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe := randomRecipe(user.ID)
+	steps := randomSteps(recipe)
+	order := []int64{steps[2].ID, steps[0].ID, steps[1].ID}
+	arg := db.ReorderRecipeStepsParams{
+		RecipeID: recipe.Recipe.ID,
+		StepIDs:  order,
+		Editor:   user.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"stepIDs": order},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ReorderRecipeStepsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(steps, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Empty Order",
+			body: gin.H{"stepIDs": []int64{}},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ReorderRecipeStepsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "400 Invalid Order",
+			body: gin.H{"stepIDs": order},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetRecipe(gomock.Any(), gomock.Eq(recipe.Recipe.ID)).
+					Times(1).
+					Return(recipe.Recipe, nil)
+				storage.EXPECT().
+					ReorderRecipeStepsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil, db.ErrInvalidStepOrder)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "common",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/recipe/steps/order/%d", recipe.Recipe.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomSteps(recipe db.RecipeResult) []db.RecipeStep {
+	steps := make([]db.RecipeStep, len(recipe.Ingredients))
+	for i, ingredient := range recipe.Ingredients {
+		steps[i] = db.RecipeStep{
+			ID:       util.RandomInt(1, 1000)*10 + int64(i),
+			Position: int32(i + 1),
+			Text:     util.RandomString(50),
+			DurationSeconds: sql.NullInt32{
+				Int32: int32(util.RandomInt(1, 600)),
+				Valid: true,
+			},
+			Ingredients: []int32{ingredient.IngredientID},
+		}
+	}
+
+	return steps
+}
@@ -0,0 +1,270 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+)
+
+type createDietaryTagRequest struct {
+	Name string `json:"name" binding:"required,lowercase,max=25"`
+	Kind string `json:"kind" binding:"required,oneof=allergen diet"`
+}
+
+func (server *Server) createDietaryTag(ctx *gin.Context) {
+	var req createDietaryTagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateDietaryTagParams{
+		Name: req.Name,
+		Kind: req.Kind,
+	}
+
+	tag, err := server.storage.CreateDietaryTag(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" {
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+func (server *Server) listDietaryTags(ctx *gin.Context) {
+	tags, err := server.storage.ListDietaryTags(ctx)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type deleteDietaryTagRequest struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+func (server *Server) deleteDietaryTag(ctx *gin.Context) {
+	var req deleteDietaryTagRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := server.storage.DeleteDietaryTag(ctx, req.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type createIngredientTagUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type createIngredientTagJSON struct {
+	Tag string `json:"tag" binding:"required"`
+}
+
+func (server *Server) createIngredientTag(ctx *gin.Context) {
+	var reqUri createIngredientTagUri
+	var reqJSON createIngredientTagJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateIngredientTagParams{
+		IngredientID: reqUri.ID,
+		Tag:          reqJSON.Tag,
+	}
+
+	tag, err := server.storage.CreateIngredientTag(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "23505":
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+type listIngredientTagsRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listIngredientTags(ctx *gin.Context) {
+	var req listIngredientTagsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tags, err := server.storage.ListIngredientTags(ctx, req.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type deleteIngredientTagRequest struct {
+	IngredientID int32  `form:"ingredientID" binding:"required,min=1"`
+	Tag          string `form:"tag" binding:"required"`
+}
+
+func (server *Server) deleteIngredientTag(ctx *gin.Context) {
+	var req deleteIngredientTagRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteIngredientTagParams{
+		IngredientID: req.IngredientID,
+		Tag:          req.Tag,
+	}
+
+	err := server.storage.DeleteIngredientTag(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type userRestrictionsUri struct {
+	ID string `uri:"id" binding:"required,uuid4"`
+}
+
+type setUserRestrictionsJSON struct {
+	Tags []string `json:"tags" binding:"required"`
+}
+
+func (server *Server) setUserRestrictions(ctx *gin.Context) {
+	var reqUri userRestrictionsUri
+	var reqJSON setUserRestrictionsJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := util.ConvertUUIDString(reqUri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Check permission
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if id != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	arg := db.SetUserRestrictionsParams{
+		UserID: id,
+		Tags:   reqJSON.Tags,
+	}
+
+	restrictions, err := server.storage.SetUserRestrictionsTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "23505":
+				ctx.JSON(http.StatusBadRequest, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, restrictions)
+}
+
+func (server *Server) getUserRestrictions(ctx *gin.Context) {
+	var req userRestrictionsUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := util.ConvertUUIDString(req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Check permission
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if id != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	restrictions, err := server.storage.ListUserRestrictions(ctx, id)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, restrictions)
+}
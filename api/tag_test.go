@@ -0,0 +1,365 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCreateDietaryTagAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	tag := db.DietaryTag{
+		Name: "mustard",
+		Kind: db.TagKindAllergen,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name": tag.Name,
+				"kind": tag.Kind,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				arg := db.CreateDietaryTagParams{
+					Name: tag.Name,
+					Kind: tag.Kind,
+				}
+				storage.EXPECT().
+					CreateDietaryTag(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(tag, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Unknown Kind",
+			body: gin.H{
+				"name": tag.Name,
+				"kind": "taste",
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateDietaryTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "409 Unique Violation",
+			body: gin.H{
+				"name": tag.Name,
+				"kind": tag.Kind,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateDietaryTag(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DietaryTag{}, error(&pq.Error{
+						Code: "23505",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "admin",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/tags/add", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateIngredientTagAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	ingredientTag := db.IngredientsTag{
+		IngredientID: int32(util.RandomInt(1, 300)),
+		Tag:          "gluten",
+	}
+	arg := db.CreateIngredientTagParams{
+		IngredientID: ingredientTag.IngredientID,
+		Tag:          ingredientTag.Tag,
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  ingredientTag.IngredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientTag(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(ingredientTag, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Bad Request",
+			uri:  0,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Foreign Key Violation",
+			uri:  ingredientTag.IngredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientTag(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsTag{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  ingredientTag.IngredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					CreateIngredientTag(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsTag{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "admin",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"tag": ingredientTag.Tag})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/tag/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetUserRestrictionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	arg := db.SetUserRestrictionsParams{
+		UserID: user.ID,
+		Tags:   []string{"gluten", "vegan"},
+	}
+	restrictions := []db.ListUserRestrictionsRow{
+		{Tag: "gluten", Kind: db.TagKindAllergen},
+		{Tag: "vegan", Kind: db.TagKindDiet},
+	}
+
+	testCases := []struct {
+		name          string
+		uri           string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker)
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  user.ID.String(),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					SetUserRestrictionsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(restrictions, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ListUserRestrictionsRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, restrictions, got)
+			},
+		},
+		{
+			name: "403 Other User",
+			uri:  user.ID.String(),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, other.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					SetUserRestrictionsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "404 Unknown Tag",
+			uri:  user.ID.String(),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+				addAuthorization(t, req, tokenMaker, authBearerType, user.ID, time.Minute)
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					GetPermission(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.GetPermissionRow{
+						Role:       "common",
+						VerifiedAt: sql.NullTime{},
+					}, nil)
+				storage.EXPECT().
+					SetUserRestrictionsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "401 Unauthorized",
+			uri:  user.ID.String(),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					SetUserRestrictionsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"tags": arg.Tags})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/user/restrictions/%s", tc.uri)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
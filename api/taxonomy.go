@@ -0,0 +1,135 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+)
+
+// Classification and free-form keywords of a recipe, accepted when writing one
+type recipeTaxonomyRequest struct {
+	Cuisine    string `json:"cuisine" binding:"omitempty,lowercase,max=50"`
+	Course     string `json:"course" binding:"omitempty,lowercase,max=50"`
+	Difficulty string `json:"difficulty" binding:"omitempty,oneof=easy medium hard"`
+	// Preparation and cooking time in minutes
+	TotalTime int32    `json:"totalTime" binding:"omitempty,min=1"`
+	Keywords  []string `json:"keywords" binding:"omitempty,max=20,dive,required,max=50"`
+}
+
+func (req recipeTaxonomyRequest) taxonomy() db.RecipeTaxonomy {
+	return db.RecipeTaxonomy{
+		Cuisine:    nullString(req.Cuisine),
+		Course:     nullString(req.Course),
+		Difficulty: nullString(req.Difficulty),
+		TotalTime:  nullInt32(req.TotalTime),
+	}
+}
+
+// Classification for an update, nil when none of its fields is given so the
+// recipe keeps its classification
+func (req recipeTaxonomyRequest) taxonomyUpdate() *db.RecipeTaxonomy {
+	if req.Cuisine == "" && req.Course == "" && req.Difficulty == "" && req.TotalTime == 0 {
+		return nil
+	}
+
+	taxonomy := req.taxonomy()
+	return &taxonomy
+}
+
+// Filters shared by recipe listing and search, recipes have to match every given filter
+type recipeFilterQuery struct {
+	Cuisine      string `form:"cuisine" binding:"omitempty,lowercase"`
+	Course       string `form:"course" binding:"omitempty,lowercase"`
+	Difficulty   string `form:"difficulty" binding:"omitempty,oneof=easy medium hard"`
+	MaxTotalTime int32  `form:"maxTotalTime" binding:"omitempty,min=1"`
+	// Repeated keyword parameters, recipes have to carry all of them
+	Keywords []string `form:"keyword" binding:"omitempty,max=10,dive,required"`
+}
+
+// Lowercase and deduplicate keywords, nil stays nil so that updates can tell a missing list from an empty one
+func normalizeKeywords(keywords []string) []string {
+	if keywords == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(keywords))
+	seen := make(map[string]bool, len(keywords))
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		result = append(result, keyword)
+	}
+
+	return result
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
+func nullInt32(n int32) sql.NullInt32 {
+	return sql.NullInt32{
+		Int32: n,
+		Valid: n != 0,
+	}
+}
+
+type recipeTaxonomyResponse struct {
+	Cuisines     []db.ListCuisinesRow `json:"cuisines"`
+	Courses      []db.ListCoursesRow  `json:"courses"`
+	Difficulties []string             `json:"difficulties"`
+}
+
+// Values accepted for the controlled recipe classifications, with the number of recipes using each
+func (server *Server) listRecipeTaxonomy(ctx *gin.Context) {
+	cuisines, err := server.storage.ListCuisines(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	courses, err := server.storage.ListCourses(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipeTaxonomyResponse{
+		Cuisines: cuisines,
+		Courses:  courses,
+		Difficulties: []string{
+			db.DifficultyEasy,
+			db.DifficultyMedium,
+			db.DifficultyHard,
+		},
+	})
+}
+
+// Keywords in use, most used first
+func (server *Server) listKeywords(ctx *gin.Context) {
+	var req listPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	keywords, err := server.storage.ListKeywords(ctx, db.ListKeywordsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageNum - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, keywords)
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dbmock "github.com/hasnaroihan/grocery-planner/db/mock"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestListRecipeTaxonomyAPI(t *testing.T) {
+	cuisines := []db.ListCuisinesRow{
+		{Name: "italian", RecipeCount: 4},
+		{Name: "thai", RecipeCount: 0},
+	}
+	courses := []db.ListCoursesRow{
+		{Name: "main", RecipeCount: 3},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListCuisines(gomock.Any()).
+					Times(1).
+					Return(cuisines, nil)
+				storage.EXPECT().
+					ListCourses(gomock.Any()).
+					Times(1).
+					Return(courses, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result recipeTaxonomyResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, cuisines, result.Cuisines)
+				require.Equal(t, courses, result.Courses)
+				require.Equal(t, []string{"easy", "medium", "hard"}, result.Difficulties)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListCuisines(gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+				storage.EXPECT().
+					ListCourses(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/recipe/taxonomy", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListKeywordsAPI(t *testing.T) {
+	keywords := []db.ListKeywordsRow{
+		{Keyword: "weeknight", RecipeCount: 7},
+		{Keyword: "pasta", RecipeCount: 2},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "pageSize=5&pageNum=2",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListKeywords(gomock.Any(), gomock.Eq(db.ListKeywordsParams{
+						Limit:  5,
+						Offset: 5,
+					})).
+					Times(1).
+					Return(keywords, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result []db.ListKeywordsRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, keywords, result)
+			},
+		},
+		{
+			name:  "400 Missing Page",
+			query: "pageSize=5",
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListKeywords(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/recipe/keywords?%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestNormalizeKeywords(t *testing.T) {
+	require.Nil(t, normalizeKeywords(nil))
+	require.Equal(t, []string{}, normalizeKeywords([]string{}))
+	require.Equal(t, []string{"pasta", "quick"}, normalizeKeywords([]string{" Pasta", "quick", "pasta", ""}))
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hasnaroihan/grocery-planner/auth"
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/recur"
+	"github.com/lib/pq"
+)
+
+type templateRecipeRequest struct {
+	RecipeID int64 `json:"recipe_id" binding:"required,min=1"`
+	Portion  int32 `json:"portion" binding:"required,min=1"`
+}
+
+type newScheduleTemplateRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// RRULE subset, e.g. FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR or FREQ=WEEKLY;INTERVAL=2;BYDAY=SU
+	Rule string `json:"rule" binding:"required,max=200"`
+	// Day the recurrence counts from, empty for today
+	StartsOn string                  `json:"startsOn" binding:"omitempty,datetime=2006-01-02"`
+	Recipes  []templateRecipeRequest `json:"recipes" binding:"required,min=1,dive"`
+}
+
+func (server *Server) newScheduleTemplate(ctx *gin.Context) {
+	var req newScheduleTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	startsOn := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.StartsOn != "" {
+		startsOn, _ = time.Parse("2006-01-02", req.StartsOn)
+	}
+
+	recipes := make([]db.TemplateRecipePortion, len(req.Recipes))
+	for i, recipe := range req.Recipes {
+		recipes[i] = db.TemplateRecipePortion{
+			RecipeID: recipe.RecipeID,
+			Portion:  recipe.Portion,
+		}
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.NewScheduleTemplateParams{
+		Author:   authPayload.Subject,
+		Name:     req.Name,
+		Rule:     req.Rule,
+		StartsOn: startsOn,
+		Recipes:  recipes,
+	}
+
+	template, err := server.storage.NewScheduleTemplateTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, recur.ErrInvalidRule) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "23505":
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (server *Server) listScheduleTemplates(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+
+	templates, err := server.storage.ListScheduleTemplatesTx(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+
+type deleteScheduleTemplateRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteScheduleTemplate(ctx *gin.Context) {
+	var req deleteScheduleTemplateRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	template, err := server.storage.GetScheduleTemplate(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// check permission
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if template.Author != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	err = server.storage.DeleteScheduleTemplate(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type generateWeekRequest struct {
+	// Any day of the week to plan, empty for the current week
+	WeekOf string `json:"weekOf" binding:"omitempty,datetime=2006-01-02"`
+	// Templates to use, empty for all of the user's templates
+	TemplateIDs  []int64                    `json:"templateIDs" binding:"omitempty,dive,min=1"`
+	Recipes      []db.ScheduleRecipePortion `json:"recipes"`
+	Restrictions string                     `json:"restrictions" binding:"omitempty,oneof=exclude warn"`
+	UnitSystem   string                     `json:"unitSystem" binding:"omitempty,oneof=metric imperial"`
+	PinRevisions bool                       `json:"pinRevisions"`
+	Store        string                     `json:"store" binding:"max=100"`
+}
+
+// Schedule a week from the user's templates and the ad-hoc recipes, then
+// generate its groceries like /groceries does
+func (server *Server) generateWeek(ctx *gin.Context) {
+	var req generateWeekRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	weekOf := time.Now().UTC()
+	if req.WeekOf != "" {
+		weekOf, _ = time.Parse("2006-01-02", req.WeekOf)
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	arg := db.GenerateWeekParams{
+		Author:       authPayload.Subject,
+		WeekOf:       weekOf,
+		TemplateIDs:  req.TemplateIDs,
+		Recipes:      req.Recipes,
+		Restrictions: req.Restrictions,
+		UnitSystem:   req.UnitSystem,
+		PinRevisions: req.PinRevisions,
+		Store:        req.Store,
+	}
+
+	groceries, err := server.storage.GenerateWeekTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if err == db.ErrEmptyWeek {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groceries)
+}
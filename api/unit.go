@@ -11,7 +11,8 @@ import (
 )
 
 type createUnitRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name  string          `json:"name" binding:"required"`
+	Grams sql.NullFloat64 `json:"grams"`
 }
 
 func (server *Server) createUnit(ctx *gin.Context) {
@@ -22,7 +23,12 @@ func (server *Server) createUnit(ctx *gin.Context) {
 		return
 	}
 
-	unit, err := server.storage.CreateUnit(ctx, req.Name)
+	arg := db.CreateUnitParams{
+		Name:  req.Name,
+		Grams: req.Grams,
+	}
+
+	unit, err := server.storage.CreateUnit(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			if pqErr.Code == "23505" {
@@ -103,6 +109,7 @@ type updateUnitUri struct {
 type updateUnitJSON struct {
 	ID			int32		  `json:"id" binding:"required,min=1"`
 	Name        string        `json:"name" binding:"required"`
+	Grams       sql.NullFloat64 `json:"grams"`
 }
 
 func (server *Server) updateUnit(ctx *gin.Context) {
@@ -127,6 +134,7 @@ func (server *Server) updateUnit(ctx *gin.Context) {
 	arg := db.UpdateUnitParams{
 		ID: reqUri.ID,
 		Name: reqJSON.Name,
+		Grams: reqJSON.Grams,
 	}
 
 	unit, err := server.storage.UpdateUnit(ctx, arg)
@@ -139,4 +147,94 @@ func (server *Server) updateUnit(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, unit)
-}
\ No newline at end of file
+}
+
+type upsertIngredientUnitUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type upsertIngredientUnitJSON struct {
+	UnitID int32   `json:"unitID" binding:"required,min=1"`
+	Grams  float32 `json:"grams" binding:"required,gt=0"`
+}
+
+func (server *Server) upsertIngredientUnit(ctx *gin.Context) {
+	var reqUri upsertIngredientUnitUri
+	var reqJSON upsertIngredientUnitJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertIngredientUnitParams{
+		IngredientID: reqUri.ID,
+		UnitID:       reqJSON.UnitID,
+		Grams:        reqJSON.Grams,
+	}
+
+	ingredientUnit, err := server.storage.UpsertIngredientUnit(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredientUnit)
+}
+
+type listIngredientUnitsRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listIngredientUnits(ctx *gin.Context) {
+	var req listIngredientUnitsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ingredientUnits, err := server.storage.ListIngredientUnits(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredientUnits)
+}
+
+type deleteIngredientUnitRequest struct {
+	IngredientID int32 `form:"ingredientID" binding:"required,min=1"`
+	UnitID       int32 `form:"unitID" binding:"required,min=1"`
+}
+
+func (server *Server) deleteIngredientUnit(ctx *gin.Context) {
+	var req deleteIngredientUnitRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteIngredientUnitParams{
+		IngredientID: req.IngredientID,
+		UnitID:       req.UnitID,
+	}
+
+	err := server.storage.DeleteIngredientUnit(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
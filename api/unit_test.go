@@ -23,6 +23,10 @@ import (
 func TestCreateUnitAPI(t *testing.T) {
 	admin, _ := randomAdmin(t)
 	unit := randomUnit()
+	arg := db.CreateUnitParams{
+		Name:  unit.Name,
+		Grams: unit.Grams,
+	}
 
 	testCase := []struct {
 		name          string
@@ -34,7 +38,8 @@ func TestCreateUnitAPI(t *testing.T) {
 		{
 			name: "OK",
 			body: gin.H{
-				"name": unit.Name,
+				"name":  unit.Name,
+				"grams": unit.Grams,
 			},
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
 				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
@@ -48,7 +53,7 @@ func TestCreateUnitAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					CreateUnit(gomock.Any(), gomock.Eq(unit.Name)).
+					CreateUnit(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(unit, nil)
 			},
@@ -71,7 +76,7 @@ func TestCreateUnitAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					CreateUnit(gomock.Any(), gomock.Eq(unit.Name)).
+					CreateUnit(gomock.Any(), gomock.Eq(arg)).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -81,7 +86,8 @@ func TestCreateUnitAPI(t *testing.T) {
 		{
 			name: "500 Connection Done",
 			body: gin.H{
-				"name": unit.Name,
+				"name":  unit.Name,
+				"grams": unit.Grams,
 			},
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
 				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
@@ -95,7 +101,7 @@ func TestCreateUnitAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					CreateUnit(gomock.Any(), gomock.Eq(unit.Name)).
+					CreateUnit(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(db.Unit{}, sql.ErrConnDone)
 			},
@@ -106,7 +112,8 @@ func TestCreateUnitAPI(t *testing.T) {
 		{
 			name: "409 Unique Violation",
 			body: gin.H{
-				"name": unit.Name,
+				"name":  unit.Name,
+				"grams": unit.Grams,
 			},
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker auth.TokenMaker) {
 				addAuthorization(t, req, tokenMaker, authBearerType, admin.ID, time.Minute)
@@ -120,7 +127,7 @@ func TestCreateUnitAPI(t *testing.T) {
 						VerifiedAt: sql.NullTime{},
 					}, nil)
 				storage.EXPECT().
-					CreateUnit(gomock.Any(), gomock.Eq(unit.Name)).
+					CreateUnit(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(db.Unit{}, error(&pq.Error{
 						Code: "23505",
@@ -656,5 +663,297 @@ func randomUnit() db.Unit {
 	return db.Unit{
 		ID:   int32(util.RandomInt(1, 100)),
 		Name: util.RandomUnit(),
+		Grams: sql.NullFloat64{
+			Float64: float64(util.RandomInt(1, 1000)),
+			Valid:   true,
+		},
+	}
+}
+
+func TestUpsertIngredientUnitAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	ingredientUnit := db.IngredientsUnit{
+		IngredientID: int32(util.RandomInt(1, 300)),
+		UnitID:       int32(util.RandomInt(1, 100)),
+		Grams:        float32(util.RandomInt(1, 500)),
+	}
+	arg := db.UpsertIngredientUnitParams{
+		IngredientID: ingredientUnit.IngredientID,
+		UnitID:       ingredientUnit.UnitID,
+		Grams:        ingredientUnit.Grams,
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		body          gin.H
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  ingredientUnit.IngredientID,
+			body: gin.H{
+				"unitID": ingredientUnit.UnitID,
+				"grams":  ingredientUnit.Grams,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientUnit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(ingredientUnit, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Zero Grams",
+			uri:  ingredientUnit.IngredientID,
+			body: gin.H{
+				"unitID": ingredientUnit.UnitID,
+				"grams":  0,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientUnit(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "404 Foreign Key Violation",
+			uri:  ingredientUnit.IngredientID,
+			body: gin.H{
+				"unitID": ingredientUnit.UnitID,
+				"grams":  ingredientUnit.Grams,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientUnit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsUnit{}, error(&pq.Error{
+						Code: "23503",
+					}))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  ingredientUnit.IngredientID,
+			body: gin.H{
+				"unitID": ingredientUnit.UnitID,
+				"grams":  ingredientUnit.Grams,
+			},
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					UpsertIngredientUnit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientsUnit{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				AnyTimes().
+				Return(db.GetPermissionRow{
+					Role:       "admin",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/ingredients/unit/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListIngredientUnitsAPI(t *testing.T) {
+	ingredientID := int32(util.RandomInt(1, 300))
+	ingredientUnits := []db.IngredientsUnit{
+		{
+			IngredientID: ingredientID,
+			UnitID:       int32(util.RandomInt(1, 100)),
+			Grams:        float32(util.RandomInt(1, 500)),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		uri           int32
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			uri:  ingredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientUnits(gomock.Any(), gomock.Eq(ingredientID)).
+					Times(1).
+					Return(ingredientUnits, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "400 Bad Request",
+			uri:  -1,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientUnits(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "500 Internal Server Error",
+			uri:  ingredientID,
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					ListIngredientUnits(gomock.Any(), gomock.Eq(ingredientID)).
+					Times(1).
+					Return([]db.IngredientsUnit{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/unit/%d", tc.uri)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteIngredientUnitAPI(t *testing.T) {
+	admin, _ := randomAdmin(t)
+	arg := db.DeleteIngredientUnitParams{
+		IngredientID: int32(util.RandomInt(1, 300)),
+		UnitID:       int32(util.RandomInt(1, 100)),
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(storage *dbmock.MockStorage)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("ingredientID=%d&unitID=%d", arg.IngredientID, arg.UnitID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					DeleteIngredientUnit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "400 Missing Unit",
+			query: fmt.Sprintf("ingredientID=%d", arg.IngredientID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					DeleteIngredientUnit(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "500 Internal Server Error",
+			query: fmt.Sprintf("ingredientID=%d&unitID=%d", arg.IngredientID, arg.UnitID),
+			buildStubs: func(storage *dbmock.MockStorage) {
+				storage.EXPECT().
+					DeleteIngredientUnit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storage := dbmock.NewMockStorage(ctrl)
+			storage.EXPECT().
+				GetPermission(gomock.Any(), gomock.Eq(admin.ID)).
+				Times(1).
+				Return(db.GetPermissionRow{
+					Role:       "admin",
+					VerifiedAt: sql.NullTime{},
+				}, nil)
+			tc.buildStubs(storage)
+
+			server := newTestServer(t, storage)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/ingredients/unit?%s", tc.query)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authBearerType, admin.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
 	}
 }
@@ -30,6 +30,8 @@ type userResponse struct {
 	CreatedAt  time.Time    `json:"createdAt"`
 	Role       string       `json:"role"`
 	VerifiedAt sql.NullTime `json:"verifiedAt"`
+	// Days before expiry pantry alerts are sent, null when alerts are off
+	ExpiryAlertDays sql.NullInt32 `json:"expiryAlertDays"`
 }
 
 var usernameValidator validator.Func = func(fl validator.FieldLevel) bool {
@@ -75,12 +77,13 @@ func (server *Server) registerUser(ctx *gin.Context) {
 	}
 
 	response := userResponse{
-		ID:         user.ID,
-		Username:   user.Username,
-		Email:      user.Email,
-		CreatedAt:  user.CreatedAt,
-		VerifiedAt: user.VerifiedAt,
-		Role:       user.Role,
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		CreatedAt:       user.CreatedAt,
+		VerifiedAt:      user.VerifiedAt,
+		Role:            user.Role,
+		ExpiryAlertDays: user.ExpiryAlertDays,
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -212,12 +215,13 @@ func (server *Server) getUser(ctx *gin.Context) {
 	}
 
 	response := userResponse{
-		ID:         user.ID,
-		Username:   user.Username,
-		Email:      user.Email,
-		CreatedAt:  user.CreatedAt,
-		VerifiedAt: user.VerifiedAt,
-		Role:       user.Role,
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		CreatedAt:       user.CreatedAt,
+		VerifiedAt:      user.VerifiedAt,
+		Role:            user.Role,
+		ExpiryAlertDays: user.ExpiryAlertDays,
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -309,3 +313,72 @@ func (server *Server) updateUser(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, user)
 }
+
+type updateExpiryAlertUri struct {
+	ID string `uri:"id" binding:"required,uuid4"`
+}
+
+type updateExpiryAlertJSON struct {
+	// Zero turns the alerts off
+	Days int32 `json:"days" binding:"min=0,max=90"`
+}
+
+func (server *Server) updateExpiryAlert(ctx *gin.Context) {
+	var reqUri updateExpiryAlertUri
+	var reqJSON updateExpiryAlertJSON
+
+	if err := ctx.ShouldBindUri(&reqUri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := util.ConvertUUIDString(reqUri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Check permission
+	authPayload := ctx.MustGet(authPayloadKey).(*auth.Payload)
+	permit, err := server.storage.GetPermission(ctx, authPayload.Subject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if id != authPayload.Subject && permit.Role != "admin" {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrAccessDenied))
+		return
+	}
+
+	arg := db.UpdateExpiryAlertParams{
+		ID:              id,
+		ExpiryAlertDays: nullInt32(reqJSON.Days),
+	}
+
+	user, err := server.storage.UpdateExpiryAlert(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := userResponse{
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		CreatedAt:       user.CreatedAt,
+		VerifiedAt:      user.VerifiedAt,
+		Role:            user.Role,
+		ExpiryAlertDays: user.ExpiryAlertDays,
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
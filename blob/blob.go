@@ -0,0 +1,37 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("blob key has to be a clean relative path")
+)
+
+// Storage for uploaded files, keys are slash separated relative paths like "recipes/1/photo.jpg"
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, r io.Reader) error
+	// Returns ErrNotFound when there is no blob for the key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	// URL the blob is served from
+	URL(key string) string
+}
+
+// Keys can not escape the store, so no absolute paths, backslashes or dot segments
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	if path.Clean(key) != key {
+		return false
+	}
+
+	return key != ".." && !strings.HasPrefix(key, "../")
+}
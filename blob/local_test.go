@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir(), "/images/")
+	require.NoError(t, err)
+
+	key := "recipes/1/photo.jpg"
+	err = store.Put(ctx, key, "image/jpeg", strings.NewReader("pixels"))
+	require.NoError(t, err)
+
+	rc, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, "pixels", string(data))
+
+	// overwrite
+	err = store.Put(ctx, key, "image/jpeg", strings.NewReader("other"))
+	require.NoError(t, err)
+	rc, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err = io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, "other", string(data))
+
+	require.Equal(t, "/images/recipes/1/photo.jpg", store.URL(key))
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// deleting twice is fine
+	require.NoError(t, store.Delete(ctx, key))
+}
+
+func TestLocalStoreInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir(), "/images")
+	require.NoError(t, err)
+
+	keys := []string{
+		"",
+		"/etc/passwd",
+		"../outside.jpg",
+		"recipes/../../outside.jpg",
+		"recipes//1.jpg",
+		"recipes\\1.jpg",
+		"..",
+	}
+	for _, key := range keys {
+		err := store.Put(ctx, key, "image/jpeg", strings.NewReader("pixels"))
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+
+		_, err = store.Get(ctx, key)
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+
+		err = store.Delete(ctx, key)
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
+
+func TestNewLocalStoreNoRoot(t *testing.T) {
+	_, err := NewLocalStore("", "/images")
+	require.Error(t, err)
+}
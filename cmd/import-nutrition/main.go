@@ -0,0 +1,122 @@
+// Import ingredient nutrition facts from a USDA FoodData Central dump on disk.
+//
+//	go run ./cmd/import-nutrition -csv ./FoodData_Central_foundation_food_csv
+//	go run ./cmd/import-nutrition -json ./foundationDownload.json -dry-run
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/fdc"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+const (
+	envPath  = ".env"
+	dbDriver = "postgres"
+)
+
+func main() {
+	csvDir := flag.String("csv", "", "directory of an extracted FoodData Central CSV dump")
+	jsonFile := flag.String("json", "", "FoodData Central JSON dump file")
+	dryRun := flag.Bool("dry-run", false, "only report the matches, do not save anything")
+	flag.Parse()
+
+	if (*csvDir == "") == (*jsonFile == "") {
+		log.Fatal("Exactly one of -csv or -json is required")
+	}
+
+	var foods []fdc.Food
+	var err error
+	if *csvDir != "" {
+		foods, err = fdc.ReadCSV(*csvDir)
+	} else {
+		foods, err = fdc.ReadJSONFile(*jsonFile)
+	}
+	if err != nil {
+		log.Fatal("Cannot read FoodData Central dump: ", err)
+	}
+
+	conn, err := sql.Open(dbDriver, dbSource())
+	if err != nil {
+		log.Fatal("Cannot connect to the database", err)
+	}
+	storage := db.NewStorage(conn)
+	ctx := context.Background()
+
+	ingredients, err := storage.ListIngredients(ctx)
+	if err != nil {
+		log.Fatal("Cannot list ingredients: ", err)
+	}
+	aliases, err := storage.ListAllIngredientAliases(ctx)
+	if err != nil {
+		log.Fatal("Cannot list ingredient aliases: ", err)
+	}
+
+	report := fdc.MatchFoods(foods, ingredients, aliases)
+	printReport(ingredients, report)
+
+	if *dryRun || len(report.Matched) == 0 {
+		return
+	}
+
+	arg := make([]db.UpsertNutritionParams, 0, len(report.Matched))
+	for _, m := range report.Matched {
+		arg = append(arg, db.UpsertNutritionParams{
+			IngredientID: m.IngredientID,
+			Calories:     m.Food.Calories,
+			Protein:      m.Food.Protein,
+			Fat:          m.Food.Fat,
+			Carbs:        m.Food.Carbs,
+			Fiber:        m.Food.Fiber,
+			Sodium:       m.Food.Sodium,
+		})
+	}
+
+	result, err := storage.ImportNutritionTx(ctx, arg)
+	if err != nil {
+		log.Fatal("Cannot import nutrition: ", err)
+	}
+	fmt.Printf("Imported nutrition of %d ingredients\n", len(result))
+}
+
+func printReport(ingredients []db.Ingredient, report fdc.Report) {
+	names := map[int32]string{}
+	for _, ingredient := range ingredients {
+		names[ingredient.ID] = ingredient.Name
+	}
+
+	fmt.Printf("Matched %d foods:\n", len(report.Matched))
+	for _, m := range report.Matched {
+		fmt.Printf("  %s <- %d %s\n", names[m.IngredientID], m.Food.FdcID, m.Food.Description)
+	}
+
+	fmt.Printf("Skipped %d foods matching an already matched ingredient:\n", len(report.Duplicates))
+	for _, food := range report.Duplicates {
+		fmt.Printf("  %d %s\n", food.FdcID, food.Description)
+	}
+
+	fmt.Printf("Unmatched %d foods:\n", len(report.Unmatched))
+	for _, food := range report.Unmatched {
+		fmt.Printf("  %d %s\n", food.FdcID, food.Description)
+	}
+}
+
+func dbSource() string {
+	err := godotenv.Load(envPath)
+	if err != nil {
+		log.Fatalf("Error loading environment variables. Err: %s", err)
+	}
+
+	return fmt.Sprintf("postgresql://%s:%s@%v:5432/grocery-planner?sslmode=disable",
+		os.Getenv("POSTGRES_USER"),
+		os.Getenv("POSTGRES_PASSWORD"),
+		os.Getenv("HOST"))
+}
@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS public.idx_schedules_recipes_date;
+
+DELETE FROM public.schedules_recipes AS a
+USING public.schedules_recipes AS b
+WHERE a.schedule_id = b.schedule_id
+    AND a.recipe_id = b.recipe_id
+    AND a.ctid > b.ctid;
+
+ALTER TABLE IF EXISTS public.schedules_recipes
+    DROP COLUMN IF EXISTS scheduled_date;
+
+ALTER TABLE IF EXISTS public.schedules_recipes
+    ADD PRIMARY KEY (schedule_id, recipe_id);
+
+DROP TABLE IF EXISTS public.nutrition;
+DROP TABLE IF EXISTS public.ingredients_units;
+
+ALTER TABLE IF EXISTS public.units
+    DROP COLUMN IF EXISTS grams;
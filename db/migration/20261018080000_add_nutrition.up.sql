@@ -0,0 +1,52 @@
+ALTER TABLE IF EXISTS public.units
+    ADD COLUMN grams real DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS public.ingredients_units
+(
+    ingredient_id integer NOT NULL,
+    unit_id integer NOT NULL,
+    grams real NOT NULL,
+    PRIMARY KEY (ingredient_id, unit_id)
+);
+
+CREATE TABLE IF NOT EXISTS public.nutrition
+(
+    ingredient_id integer NOT NULL,
+    calories real NOT NULL DEFAULT 0,
+    protein real NOT NULL DEFAULT 0,
+    fat real NOT NULL DEFAULT 0,
+    carbs real NOT NULL DEFAULT 0,
+    fiber real NOT NULL DEFAULT 0,
+    sodium real NOT NULL DEFAULT 0,
+    modified_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (ingredient_id)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_units
+    ADD CONSTRAINT fk_ingredients_units_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.ingredients_units
+    ADD CONSTRAINT fk_ingredients_units_unit FOREIGN KEY (unit_id)
+    REFERENCES public.units (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.nutrition
+    ADD CONSTRAINT fk_nutrition_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+-- Schedule entries may be planned for a specific day, and the same recipe
+-- can appear on several days of one schedule.
+ALTER TABLE IF EXISTS public.schedules_recipes
+    DROP CONSTRAINT IF EXISTS schedules_recipes_pkey;
+
+ALTER TABLE IF EXISTS public.schedules_recipes
+    ADD COLUMN scheduled_date date DEFAULT NULL;
+
+CREATE UNIQUE INDEX idx_schedules_recipes_date on public.schedules_recipes
+    (schedule_id, recipe_id, COALESCE(scheduled_date, '-infinity'::date));
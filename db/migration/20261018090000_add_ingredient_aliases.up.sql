@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.ingredients_aliases
+(
+    ingredient_id integer NOT NULL,
+    alias character varying(100) NOT NULL,
+    PRIMARY KEY (alias)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_aliases
+    ADD CONSTRAINT fk_ingredients_aliases_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_ingredients_aliases on public.ingredients_aliases (ingredient_id);
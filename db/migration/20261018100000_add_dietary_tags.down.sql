@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS public.recipes_conflicts;
+DROP VIEW IF EXISTS public.recipes_tags;
+DROP TABLE IF EXISTS public.users_restrictions;
+DROP TABLE IF EXISTS public.ingredients_tags;
+DROP TABLE IF EXISTS public.dietary_tags;
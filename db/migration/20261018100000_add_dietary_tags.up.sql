@@ -0,0 +1,98 @@
+-- Allergen tags mark ingredients containing the allergen, diet tags mark
+-- ingredients compatible with the diet.
+CREATE TABLE IF NOT EXISTS public.dietary_tags
+(
+    name character varying(25) NOT NULL,
+    kind character varying(10) NOT NULL,
+    PRIMARY KEY (name),
+    CONSTRAINT check_dietary_tags_kind CHECK (kind IN ('allergen', 'diet'))
+);
+
+CREATE TABLE IF NOT EXISTS public.ingredients_tags
+(
+    ingredient_id integer NOT NULL,
+    tag character varying(25) NOT NULL,
+    PRIMARY KEY (ingredient_id, tag)
+);
+
+CREATE TABLE IF NOT EXISTS public.users_restrictions
+(
+    user_id uuid NOT NULL,
+    tag character varying(25) NOT NULL,
+    PRIMARY KEY (user_id, tag)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_tags
+    ADD CONSTRAINT fk_ingredients_tags_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.ingredients_tags
+    ADD CONSTRAINT fk_ingredients_tags_tag FOREIGN KEY (tag)
+    REFERENCES public.dietary_tags (name) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.users_restrictions
+    ADD CONSTRAINT fk_users_restrictions_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.users_restrictions
+    ADD CONSTRAINT fk_users_restrictions_tag FOREIGN KEY (tag)
+    REFERENCES public.dietary_tags (name) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_ingredients_tags_tag on public.ingredients_tags (tag);
+
+INSERT INTO public.dietary_tags (name, kind) VALUES
+    ('gluten', 'allergen'),
+    ('dairy', 'allergen'),
+    ('egg', 'allergen'),
+    ('nuts', 'allergen'),
+    ('peanuts', 'allergen'),
+    ('soy', 'allergen'),
+    ('fish', 'allergen'),
+    ('shellfish', 'allergen'),
+    ('sesame', 'allergen'),
+    ('vegan', 'diet'),
+    ('vegetarian', 'diet'),
+    ('halal', 'diet');
+
+-- A recipe contains every allergen of its ingredients and is compatible
+-- with a diet only when all of its ingredients are.
+CREATE VIEW public.recipes_tags AS
+    SELECT DISTINCT ri.recipe_id, it.tag, dt.kind
+    FROM public.recipes_ingredients AS ri
+    INNER JOIN public.ingredients_tags AS it ON it.ingredient_id = ri.ingredient_id
+    INNER JOIN public.dietary_tags AS dt ON dt.name = it.tag
+    WHERE dt.kind = 'allergen'
+    UNION
+    SELECT ri.recipe_id, it.tag, dt.kind
+    FROM public.recipes_ingredients AS ri
+    INNER JOIN public.ingredients_tags AS it ON it.ingredient_id = ri.ingredient_id
+    INNER JOIN public.dietary_tags AS dt ON dt.name = it.tag
+    WHERE dt.kind = 'diet'
+    GROUP BY ri.recipe_id, it.tag, dt.kind
+    HAVING count(*) = (
+        SELECT count(*) FROM public.recipes_ingredients AS r
+        WHERE r.recipe_id = ri.recipe_id
+    );
+
+-- Restrictions of a user violated by a recipe
+CREATE VIEW public.recipes_conflicts AS
+    SELECT r.id AS recipe_id, ur.user_id, ur.tag
+    FROM public.recipes AS r
+    CROSS JOIN public.users_restrictions AS ur
+    INNER JOIN public.dietary_tags AS dt ON dt.name = ur.tag
+    WHERE (dt.kind = 'allergen' AND EXISTS (
+            SELECT 1 FROM public.recipes_tags AS rt
+            WHERE rt.recipe_id = r.id AND rt.tag = ur.tag
+        ))
+        OR (dt.kind = 'diet' AND NOT EXISTS (
+            SELECT 1 FROM public.recipes_tags AS rt
+            WHERE rt.recipe_id = r.id AND rt.tag = ur.tag
+        ));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.recipes_steps_ingredients;
+DROP TABLE IF EXISTS public.recipes_steps;
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS public.recipes_steps
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    recipe_id bigint NOT NULL,
+    "position" integer NOT NULL,
+    text text NOT NULL,
+    duration_seconds integer DEFAULT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT check_recipes_steps_duration CHECK (duration_seconds > 0)
+);
+
+-- Ingredients used in a step, they have to be ingredients of the step's recipe
+CREATE TABLE IF NOT EXISTS public.recipes_steps_ingredients
+(
+    step_id bigint NOT NULL,
+    recipe_id bigint NOT NULL,
+    ingredient_id integer NOT NULL,
+    PRIMARY KEY (step_id, ingredient_id)
+);
+
+ALTER TABLE IF EXISTS public.recipes_steps
+    ADD CONSTRAINT fk_recipes_steps_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+-- Deferred so steps can be reordered inside a transaction
+ALTER TABLE IF EXISTS public.recipes_steps
+    ADD CONSTRAINT unique_recipes_steps_position UNIQUE (recipe_id, "position")
+    DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE IF EXISTS public.recipes_steps_ingredients
+    ADD CONSTRAINT fk_recipes_steps_ingredients_step FOREIGN KEY (step_id)
+    REFERENCES public.recipes_steps (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.recipes_steps_ingredients
+    ADD CONSTRAINT fk_recipes_steps_ingredients_ingredient FOREIGN KEY (ingredient_id, recipe_id)
+    REFERENCES public.recipes_ingredients (ingredient_id, recipe_id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+-- Every non empty line of the old free text steps becomes a step
+INSERT INTO public.recipes_steps (recipe_id, "position", text)
+SELECT r.id, row_number() OVER (PARTITION BY r.id ORDER BY l.n), trim(l.line)
+FROM public.recipes AS r
+CROSS JOIN LATERAL regexp_split_to_table(r.steps, E'\\r?\\n') WITH ORDINALITY AS l(line, n)
+WHERE r.steps IS NOT NULL AND trim(l.line) <> '';
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS public.schedules_recipes
+    DROP COLUMN IF EXISTS revision_id;
+
+DROP TABLE IF EXISTS public.recipes_revisions;
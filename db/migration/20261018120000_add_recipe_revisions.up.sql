@@ -0,0 +1,55 @@
+-- Immutable snapshots of a recipe, one for every change
+CREATE TABLE IF NOT EXISTS public.recipes_revisions
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    recipe_id bigint NOT NULL,
+    revision integer NOT NULL,
+    name character varying(255) NOT NULL,
+    portion integer NOT NULL,
+    steps text DEFAULT NULL,
+    ingredients jsonb NOT NULL DEFAULT '[]',
+    author uuid DEFAULT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id),
+    CONSTRAINT unique_recipes_revisions UNIQUE (recipe_id, revision)
+);
+
+ALTER TABLE IF EXISTS public.recipes_revisions
+    ADD CONSTRAINT fk_recipes_revisions_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.recipes_revisions
+    ADD CONSTRAINT fk_recipes_revisions_author FOREIGN KEY (author)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE SET NULL;
+
+-- Schedules planned with a pinned revision use its ingredients for groceries
+ALTER TABLE IF EXISTS public.schedules_recipes
+    ADD COLUMN revision_id bigint DEFAULT NULL;
+
+ALTER TABLE IF EXISTS public.schedules_recipes
+    ADD CONSTRAINT fk_schedules_recipes_revision FOREIGN KEY (revision_id)
+    REFERENCES public.recipes_revisions (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE SET NULL;
+
+-- The current state of every recipe is its first revision
+INSERT INTO public.recipes_revisions (recipe_id, revision, name, portion, steps, ingredients, author, created_at)
+SELECT r.id, 1, r.name, r.portion, r.steps,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object(
+            'ingredientID', ri.ingredient_id,
+            'name', i.name,
+            'amount', ri.amount,
+            'unitID', ri.unit_id
+        ) ORDER BY ri.ingredient_id)
+        FROM public.recipes_ingredients AS ri
+        INNER JOIN public.ingredients AS i
+        ON ri.ingredient_id = i.id
+        WHERE ri.recipe_id = r.id
+    ), '[]'),
+    r.author, r.modified_at
+FROM public.recipes AS r;
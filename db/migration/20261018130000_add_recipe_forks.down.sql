@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.idx_recipes_forked_from;
+
+ALTER TABLE IF EXISTS public.recipes
+    DROP COLUMN IF EXISTS forked_from;
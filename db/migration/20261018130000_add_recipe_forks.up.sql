@@ -0,0 +1,12 @@
+-- Recipe a forked recipe was copied from, kept when the fork outlives its parent
+ALTER TABLE IF EXISTS public.recipes
+    ADD COLUMN forked_from bigint DEFAULT NULL;
+
+ALTER TABLE IF EXISTS public.recipes
+    ADD CONSTRAINT fk_recipes_forked_from FOREIGN KEY (forked_from)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_recipes_forked_from
+    ON public.recipes(forked_from);
@@ -0,0 +1,7 @@
+DROP VIEW IF EXISTS public.recipes_stats;
+
+DROP VIEW IF EXISTS public.recipes_cooked_log;
+
+DROP TABLE IF EXISTS public.recipes_cooked;
+
+DROP TABLE IF EXISTS public.recipes_reviews;
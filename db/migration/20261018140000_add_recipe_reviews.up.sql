@@ -0,0 +1,79 @@
+-- One rating with an optional comment for every user and recipe
+CREATE TABLE IF NOT EXISTS public.recipes_reviews
+(
+    recipe_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    rating integer NOT NULL,
+    comment text DEFAULT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    modified_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (recipe_id, user_id),
+    CONSTRAINT check_recipes_reviews_rating CHECK (rating BETWEEN 1 AND 5)
+);
+
+ALTER TABLE IF EXISTS public.recipes_reviews
+    ADD CONSTRAINT fk_recipes_reviews_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.recipes_reviews
+    ADD CONSTRAINT fk_recipes_reviews_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+-- Meals logged by hand
+CREATE TABLE IF NOT EXISTS public.recipes_cooked
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    recipe_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    cooked_on date NOT NULL DEFAULT (now() at time zone 'utc')::date,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE IF EXISTS public.recipes_cooked
+    ADD CONSTRAINT fk_recipes_cooked_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.recipes_cooked
+    ADD CONSTRAINT fk_recipes_cooked_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_recipes_cooked_user
+    ON public.recipes_cooked(user_id, cooked_on);
+
+-- Every cooked meal, logged by hand or planned in a schedule for a day that has passed
+CREATE VIEW public.recipes_cooked_log AS
+    SELECT rc.id, rc.recipe_id, rc.user_id, rc.cooked_on, NULL::bigint AS schedule_id
+    FROM public.recipes_cooked AS rc
+    UNION ALL
+    SELECT NULL::bigint, sr.recipe_id, s.author, sr.scheduled_date, sr.schedule_id
+    FROM public.schedules_recipes AS sr
+    INNER JOIN public.schedules AS s ON s.id = sr.schedule_id
+    WHERE s.author IS NOT NULL
+        AND sr.scheduled_date < (now() at time zone 'utc')::date;
+
+-- Rating and popularity aggregates of every recipe
+CREATE VIEW public.recipes_stats AS
+    SELECT r.id AS recipe_id,
+        rv.rating_avg,
+        COALESCE(rv.rating_count, 0)::bigint AS rating_count,
+        COALESCE(cl.cooked_count, 0)::bigint AS cooked_count
+    FROM public.recipes AS r
+    LEFT JOIN (
+        SELECT recipe_id, avg(rating)::double precision AS rating_avg, count(*) AS rating_count
+        FROM public.recipes_reviews
+        GROUP BY recipe_id
+    ) AS rv ON rv.recipe_id = r.id
+    LEFT JOIN (
+        SELECT recipe_id, count(*) AS cooked_count
+        FROM public.recipes_cooked_log
+        GROUP BY recipe_id
+    ) AS cl ON cl.recipe_id = r.id;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS public.collections_recipes;
+
+DROP TABLE IF EXISTS public.collections;
+
+DROP TABLE IF EXISTS public.users_favorites;
@@ -0,0 +1,64 @@
+CREATE TABLE IF NOT EXISTS public.users_favorites
+(
+    user_id uuid NOT NULL,
+    recipe_id bigint NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (user_id, recipe_id)
+);
+
+ALTER TABLE IF EXISTS public.users_favorites
+    ADD CONSTRAINT fk_users_favorites_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.users_favorites
+    ADD CONSTRAINT fk_users_favorites_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+-- Named lists of recipes, anyone with the share token can read a collection
+CREATE TABLE IF NOT EXISTS public.collections
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    owner uuid NOT NULL,
+    name character varying(255) NOT NULL,
+    share_token uuid DEFAULT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    modified_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id),
+    CONSTRAINT unique_collections_name UNIQUE (owner, name),
+    CONSTRAINT unique_collections_share_token UNIQUE (share_token)
+);
+
+ALTER TABLE IF EXISTS public.collections
+    ADD CONSTRAINT fk_collections_owner FOREIGN KEY (owner)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS public.collections_recipes
+(
+    collection_id bigint NOT NULL,
+    recipe_id bigint NOT NULL,
+    "position" integer NOT NULL,
+    PRIMARY KEY (collection_id, recipe_id)
+);
+
+ALTER TABLE IF EXISTS public.collections_recipes
+    ADD CONSTRAINT fk_collections_recipes_collection FOREIGN KEY (collection_id)
+    REFERENCES public.collections (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.collections_recipes
+    ADD CONSTRAINT fk_collections_recipes_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+-- Deferred so recipes can be reordered inside a transaction
+ALTER TABLE IF EXISTS public.collections_recipes
+    ADD CONSTRAINT unique_collections_recipes_position UNIQUE (collection_id, "position")
+    DEFERRABLE INITIALLY DEFERRED;
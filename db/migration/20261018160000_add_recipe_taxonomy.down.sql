@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS public.recipes_keywords;
+
+DROP INDEX IF EXISTS public.idx_recipes_course;
+
+DROP INDEX IF EXISTS public.idx_recipes_cuisine;
+
+ALTER TABLE IF EXISTS public.recipes
+    DROP COLUMN IF EXISTS total_time,
+    DROP COLUMN IF EXISTS difficulty,
+    DROP COLUMN IF EXISTS course,
+    DROP COLUMN IF EXISTS cuisine;
+
+DROP TABLE IF EXISTS public.courses;
+
+DROP TABLE IF EXISTS public.cuisines;
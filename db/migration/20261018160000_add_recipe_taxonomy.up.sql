@@ -0,0 +1,86 @@
+-- Controlled vocabularies a recipe can be classified with
+CREATE TABLE IF NOT EXISTS public.cuisines
+(
+    name character varying(50) NOT NULL,
+    PRIMARY KEY (name)
+);
+
+CREATE TABLE IF NOT EXISTS public.courses
+(
+    name character varying(50) NOT NULL,
+    PRIMARY KEY (name)
+);
+
+INSERT INTO public.cuisines (name) VALUES
+    ('american'),
+    ('chinese'),
+    ('french'),
+    ('indian'),
+    ('indonesian'),
+    ('italian'),
+    ('japanese'),
+    ('korean'),
+    ('mediterranean'),
+    ('mexican'),
+    ('middle eastern'),
+    ('thai'),
+    ('vietnamese');
+
+INSERT INTO public.courses (name) VALUES
+    ('breakfast'),
+    ('appetizer'),
+    ('soup'),
+    ('salad'),
+    ('main'),
+    ('side'),
+    ('dessert'),
+    ('snack'),
+    ('drink');
+
+ALTER TABLE IF EXISTS public.recipes
+    ADD COLUMN cuisine character varying(50) DEFAULT NULL,
+    ADD COLUMN course character varying(50) DEFAULT NULL,
+    ADD COLUMN difficulty character varying(10) DEFAULT NULL,
+    -- Preparation and cooking time in minutes
+    ADD COLUMN total_time integer DEFAULT NULL;
+
+ALTER TABLE IF EXISTS public.recipes
+    ADD CONSTRAINT fk_recipes_cuisine FOREIGN KEY (cuisine)
+    REFERENCES public.cuisines (name) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+ALTER TABLE IF EXISTS public.recipes
+    ADD CONSTRAINT fk_recipes_course FOREIGN KEY (course)
+    REFERENCES public.courses (name) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+ALTER TABLE IF EXISTS public.recipes
+    ADD CONSTRAINT check_recipes_difficulty CHECK (difficulty IN ('easy', 'medium', 'hard'));
+
+ALTER TABLE IF EXISTS public.recipes
+    ADD CONSTRAINT check_recipes_total_time CHECK (total_time > 0);
+
+CREATE INDEX IF NOT EXISTS idx_recipes_cuisine
+    ON public.recipes(cuisine);
+
+CREATE INDEX IF NOT EXISTS idx_recipes_course
+    ON public.recipes(course);
+
+-- Free-form tags given by the recipe author
+CREATE TABLE IF NOT EXISTS public.recipes_keywords
+(
+    recipe_id bigint NOT NULL,
+    keyword character varying(50) NOT NULL,
+    PRIMARY KEY (recipe_id, keyword)
+);
+
+ALTER TABLE IF EXISTS public.recipes_keywords
+    ADD CONSTRAINT fk_recipes_keywords_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_recipes_keywords_keyword
+    ON public.recipes_keywords(keyword);
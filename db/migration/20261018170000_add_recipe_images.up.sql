@@ -0,0 +1,32 @@
+-- Uploaded recipe photos, optionally attached to a step. The files live in the blob store under the keys,
+-- images of a deleted step stay with the recipe
+CREATE TABLE IF NOT EXISTS public.recipes_images
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    recipe_id bigint NOT NULL,
+    step_id bigint DEFAULT NULL,
+    blob_key character varying NOT NULL,
+    thumbnail_key character varying NOT NULL,
+    content_type character varying NOT NULL,
+    size bigint NOT NULL,
+    width integer NOT NULL,
+    height integer NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id),
+    CONSTRAINT unique_recipes_images_blob_key UNIQUE (blob_key),
+    CONSTRAINT check_recipes_images_size CHECK (size > 0)
+);
+
+ALTER TABLE IF EXISTS public.recipes_images
+    ADD CONSTRAINT fk_recipes_images_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.recipes_images
+    ADD CONSTRAINT fk_recipes_images_step FOREIGN KEY (step_id)
+    REFERENCES public.recipes_steps (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_recipes_images on public.recipes_images (recipe_id);
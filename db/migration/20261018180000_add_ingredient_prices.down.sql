@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS public.ingredients_current_prices;
+
+DROP TABLE IF EXISTS public.ingredients_prices;
@@ -0,0 +1,41 @@
+-- Observed ingredient prices, kept as a history. A price is for one unit of the
+-- ingredient, a null unit means one piece. Prices are in the deployment's currency
+CREATE TABLE IF NOT EXISTS public.ingredients_prices
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    ingredient_id integer NOT NULL,
+    unit_id integer DEFAULT NULL,
+    price double precision NOT NULL,
+    store character varying(100) DEFAULT NULL,
+    observed_on date NOT NULL DEFAULT (now() at time zone 'utc')::date,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id),
+    CONSTRAINT check_ingredients_prices_price CHECK (price >= 0)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_prices
+    ADD CONSTRAINT fk_ingredients_prices_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.ingredients_prices
+    ADD CONSTRAINT fk_ingredients_prices_unit FOREIGN KEY (unit_id)
+    REFERENCES public.units (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_ingredients_prices on public.ingredients_prices (ingredient_id, observed_on);
+
+-- Latest price of every ingredient with what is needed to convert its unit
+CREATE VIEW public.ingredients_current_prices AS
+    SELECT DISTINCT ON (ip.ingredient_id)
+        ip.ingredient_id, ip.price, ip.unit_id, u.name AS unit_name,
+        u.grams AS unit_grams, iu.grams AS ingredient_unit_grams,
+        ip.store, ip.observed_on
+    FROM public.ingredients_prices AS ip
+    LEFT JOIN public.units AS u
+    ON ip.unit_id = u.id
+    LEFT JOIN public.ingredients_units AS iu
+    ON ip.ingredient_id = iu.ingredient_id AND ip.unit_id = iu.unit_id
+    ORDER BY ip.ingredient_id, ip.observed_on DESC, ip.id DESC;
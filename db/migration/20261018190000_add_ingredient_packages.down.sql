@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.ingredients_packages;
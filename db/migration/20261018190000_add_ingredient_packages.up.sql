@@ -0,0 +1,28 @@
+-- Sizes an ingredient is sold in, per store or for any store when the store is
+-- null. A null unit means the package holds a number of pieces
+CREATE TABLE IF NOT EXISTS public.ingredients_packages
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    ingredient_id integer NOT NULL,
+    amount double precision NOT NULL,
+    unit_id integer DEFAULT NULL,
+    store character varying(100) DEFAULT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id),
+    CONSTRAINT check_ingredients_packages_amount CHECK (amount > 0)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_packages
+    ADD CONSTRAINT fk_ingredients_packages_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.ingredients_packages
+    ADD CONSTRAINT fk_ingredients_packages_unit FOREIGN KEY (unit_id)
+    REFERENCES public.units (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_ingredients_packages on public.ingredients_packages
+    (ingredient_id, amount, COALESCE(unit_id, 0), COALESCE(store, ''));
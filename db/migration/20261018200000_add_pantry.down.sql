@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.pantry_items;
+
+DROP TABLE IF EXISTS public.ingredients_shelf_lives;
@@ -0,0 +1,59 @@
+-- Days an ingredient keeps once bought, used to estimate when pantry items expire
+CREATE TABLE IF NOT EXISTS public.ingredients_shelf_lives
+(
+    ingredient_id integer NOT NULL,
+    days integer NOT NULL,
+    modified_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (ingredient_id),
+    CONSTRAINT check_ingredients_shelf_lives_days CHECK (days > 0)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_shelf_lives
+    ADD CONSTRAINT fk_ingredients_shelf_lives_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+-- Ingredients a user has at home. Leftovers of a schedule keep a reference to it
+-- so they are only added once
+CREATE TABLE IF NOT EXISTS public.pantry_items
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    user_id uuid NOT NULL,
+    ingredient_id integer NOT NULL,
+    amount double precision NOT NULL,
+    unit_id integer DEFAULT NULL,
+    expires_on date DEFAULT NULL,
+    schedule_id bigint DEFAULT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id),
+    CONSTRAINT check_pantry_items_amount CHECK (amount > 0)
+);
+
+ALTER TABLE IF EXISTS public.pantry_items
+    ADD CONSTRAINT fk_pantry_items_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.pantry_items
+    ADD CONSTRAINT fk_pantry_items_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.pantry_items
+    ADD CONSTRAINT fk_pantry_items_unit FOREIGN KEY (unit_id)
+    REFERENCES public.units (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.pantry_items
+    ADD CONSTRAINT fk_pantry_items_schedule FOREIGN KEY (schedule_id)
+    REFERENCES public.schedules (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_pantry_items on public.pantry_items (user_id, expires_on);
+
+CREATE UNIQUE INDEX idx_pantry_items_schedule on public.pantry_items (schedule_id, ingredient_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.pantry_items_alerts;
+
+ALTER TABLE IF EXISTS public.users
+    DROP COLUMN IF EXISTS expiry_alert_days;
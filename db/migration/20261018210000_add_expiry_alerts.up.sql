@@ -0,0 +1,20 @@
+-- Days before a pantry item expires that the user wants to be alerted, NULL turns alerts off
+ALTER TABLE IF EXISTS public.users
+    ADD COLUMN expiry_alert_days integer DEFAULT NULL;
+
+ALTER TABLE IF EXISTS public.users
+    ADD CONSTRAINT check_users_expiry_alert_days CHECK (expiry_alert_days BETWEEN 1 AND 90);
+
+-- Pantry items an expiry alert was already sent for, so every item is only reported once
+CREATE TABLE IF NOT EXISTS public.pantry_items_alerts
+(
+    pantry_item_id bigint NOT NULL,
+    sent_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (pantry_item_id)
+);
+
+ALTER TABLE IF EXISTS public.pantry_items_alerts
+    ADD CONSTRAINT fk_pantry_items_alerts_item FOREIGN KEY (pantry_item_id)
+    REFERENCES public.pantry_items (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.schedule_templates_recipes;
+
+DROP TABLE IF EXISTS public.schedule_templates;
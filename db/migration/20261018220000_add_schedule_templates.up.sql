@@ -0,0 +1,41 @@
+-- Recipes that repeat, like the same breakfast every weekday. The recurrence is an
+-- RRULE subset anchored at starts_on, templates are materialized into schedules per week
+CREATE TABLE IF NOT EXISTS public.schedule_templates
+(
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 ),
+    author uuid NOT NULL,
+    name character varying(100) NOT NULL,
+    rrule character varying(200) NOT NULL,
+    starts_on date NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE IF EXISTS public.schedule_templates
+    ADD CONSTRAINT fk_schedule_templates_author FOREIGN KEY (author)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_schedule_templates on public.schedule_templates (author);
+
+CREATE TABLE IF NOT EXISTS public.schedule_templates_recipes
+(
+    template_id bigint NOT NULL,
+    recipe_id bigint NOT NULL,
+    portion integer NOT NULL DEFAULT 1,
+    PRIMARY KEY (template_id, recipe_id),
+    CONSTRAINT check_schedule_templates_recipes_portion CHECK (portion > 0)
+);
+
+ALTER TABLE IF EXISTS public.schedule_templates_recipes
+    ADD CONSTRAINT fk_schedule_templates_recipes_template FOREIGN KEY (template_id)
+    REFERENCES public.schedule_templates (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.schedule_templates_recipes
+    ADD CONSTRAINT fk_schedule_templates_recipes_recipe FOREIGN KEY (recipe_id)
+    REFERENCES public.recipes (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.ingredients_categories;
+
+DROP TABLE IF EXISTS public.grocery_categories;
@@ -0,0 +1,40 @@
+-- Sections of a store, position is the order they are walked through
+CREATE TABLE IF NOT EXISTS public.grocery_categories
+(
+    name character varying(50) NOT NULL,
+    position integer NOT NULL,
+    PRIMARY KEY (name)
+);
+
+INSERT INTO public.grocery_categories (name, position) VALUES
+    ('produce', 1),
+    ('bakery', 2),
+    ('meat & fish', 3),
+    ('dairy & eggs', 4),
+    ('frozen', 5),
+    ('pantry', 6),
+    ('spices', 7),
+    ('drinks', 8),
+    ('household', 9);
+
+-- Category the groceries of an ingredient are listed under, uncategorized
+-- ingredients end up in "other"
+CREATE TABLE IF NOT EXISTS public.ingredients_categories
+(
+    ingredient_id integer NOT NULL,
+    category character varying(50) NOT NULL,
+    modified_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (ingredient_id)
+);
+
+ALTER TABLE IF EXISTS public.ingredients_categories
+    ADD CONSTRAINT fk_ingredients_categories_ingredient FOREIGN KEY (ingredient_id)
+    REFERENCES public.ingredients (id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS public.ingredients_categories
+    ADD CONSTRAINT fk_ingredients_categories_category FOREIGN KEY (category)
+    REFERENCES public.grocery_categories (name) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
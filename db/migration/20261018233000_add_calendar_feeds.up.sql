@@ -0,0 +1,17 @@
+-- Secret token of a user's iCalendar feed. Whoever knows the token can read the
+-- feed, so it is kept out of the users table and replaced to revoke old links
+CREATE TABLE IF NOT EXISTS public.calendar_feeds
+(
+    user_id uuid NOT NULL,
+    token uuid NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (user_id)
+);
+
+ALTER TABLE IF EXISTS public.calendar_feeds
+    ADD CONSTRAINT fk_calendar_feeds_user FOREIGN KEY (user_id)
+    REFERENCES public.users (id) MATCH SIMPLE
+    ON UPDATE RESTRICT
+    ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_calendar_feeds_token on public.calendar_feeds (token);
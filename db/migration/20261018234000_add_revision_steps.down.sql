@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public.recipes_revisions
+    DROP COLUMN IF EXISTS step_list;
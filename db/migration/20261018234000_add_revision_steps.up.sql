@@ -0,0 +1,36 @@
+-- Structured steps of the recipe at the revision, with the ingredients every step uses
+ALTER TABLE IF EXISTS public.recipes_revisions
+    ADD COLUMN step_list jsonb NOT NULL DEFAULT '[]';
+
+-- Older revisions only kept the free text steps, every non empty line becomes a step
+UPDATE public.recipes_revisions AS rv
+SET step_list = (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object(
+        'text', trim(l.line),
+        'durationSeconds', 0,
+        'ingredients', '[]'::jsonb
+    ) ORDER BY l.n), '[]')
+    FROM regexp_split_to_table(rv.steps, E'\\r?\\n') WITH ORDINALITY AS l(line, n)
+    WHERE trim(l.line) <> ''
+)
+WHERE rv.steps IS NOT NULL;
+
+-- The latest revision of every recipe is its current state
+UPDATE public.recipes_revisions AS rv
+SET step_list = COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+        'text', s.text,
+        'durationSeconds', COALESCE(s.duration_seconds, 0),
+        'ingredients', COALESCE((
+            SELECT jsonb_agg(si.ingredient_id ORDER BY si.ingredient_id)
+            FROM public.recipes_steps_ingredients AS si
+            WHERE si.step_id = s.id
+        ), '[]')
+    ) ORDER BY s.position)
+    FROM public.recipes_steps AS s
+    WHERE s.recipe_id = rv.recipe_id
+), '[]')
+WHERE rv.revision = (
+    SELECT max(latest.revision) FROM public.recipes_revisions AS latest
+    WHERE latest.recipe_id = rv.recipe_id
+);
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS public.recipes_revisions
+    DROP COLUMN IF EXISTS cuisine,
+    DROP COLUMN IF EXISTS course,
+    DROP COLUMN IF EXISTS difficulty,
+    DROP COLUMN IF EXISTS total_time,
+    DROP COLUMN IF EXISTS keywords;
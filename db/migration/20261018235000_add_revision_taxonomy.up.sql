@@ -0,0 +1,22 @@
+-- Classification and keywords of the recipe at the revision, cuisine and
+-- course are copies without foreign keys like the ingredient names
+ALTER TABLE IF EXISTS public.recipes_revisions
+    ADD COLUMN cuisine character varying(50) DEFAULT NULL,
+    ADD COLUMN course character varying(50) DEFAULT NULL,
+    ADD COLUMN difficulty character varying(10) DEFAULT NULL,
+    ADD COLUMN total_time integer DEFAULT NULL,
+    ADD COLUMN keywords character varying(50)[] NOT NULL DEFAULT '{}';
+
+-- Classification changes were not recorded before, the current one is the best known
+UPDATE public.recipes_revisions AS rv
+SET cuisine = r.cuisine,
+    course = r.course,
+    difficulty = r.difficulty,
+    total_time = r.total_time,
+    keywords = COALESCE((
+        SELECT array_agg(k.keyword ORDER BY k.keyword)
+        FROM public.recipes_keywords AS k
+        WHERE k.recipe_id = r.id
+    ), '{}')
+FROM public.recipes AS r
+WHERE rv.recipe_id = r.id;
@@ -0,0 +1 @@
+DROP VIEW IF EXISTS public.ingredients_store_prices;
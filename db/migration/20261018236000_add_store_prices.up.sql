@@ -0,0 +1,13 @@
+-- Latest price of every ingredient at every store, groceries bought from a store
+-- prefer its prices over the latest ones from anywhere
+CREATE VIEW public.ingredients_store_prices AS
+    SELECT DISTINCT ON (ip.ingredient_id, ip.store)
+        ip.id, ip.ingredient_id, ip.price, ip.unit_id, u.name AS unit_name,
+        u.grams AS unit_grams, iu.grams AS ingredient_unit_grams,
+        ip.store, ip.observed_on
+    FROM public.ingredients_prices AS ip
+    LEFT JOIN public.units AS u
+    ON ip.unit_id = u.id
+    LEFT JOIN public.ingredients_units AS iu
+    ON ip.ingredient_id = iu.ingredient_id AND ip.unit_id = iu.unit_id
+    ORDER BY ip.ingredient_id, ip.store, ip.observed_on DESC, ip.id DESC;
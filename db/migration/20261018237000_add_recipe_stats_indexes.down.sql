@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_schedules_recipes_recipe;
+
+DROP INDEX IF EXISTS public.idx_recipes_cooked_recipe;
@@ -0,0 +1,6 @@
+-- Ratings and cooked counts are aggregated per listed recipe, look them up by recipe
+CREATE INDEX IF NOT EXISTS idx_recipes_cooked_recipe
+    ON public.recipes_cooked(recipe_id);
+
+CREATE INDEX IF NOT EXISTS idx_schedules_recipes_recipe
+    ON public.schedules_recipes(recipe_id);
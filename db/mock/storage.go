@@ -11,7 +11,9 @@ package dbmock
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
@@ -41,6 +43,123 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AddScheduleLeftoversTx mocks base method.
+func (m *MockStorage) AddScheduleLeftoversTx(arg0 context.Context, arg1 db.ScheduleLeftoversParams) ([]db.PantryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddScheduleLeftoversTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.PantryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddScheduleLeftoversTx indicates an expected call of AddScheduleLeftoversTx.
+func (mr *MockStorageMockRecorder) AddScheduleLeftoversTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScheduleLeftoversTx", reflect.TypeOf((*MockStorage)(nil).AddScheduleLeftoversTx), arg0, arg1)
+}
+
+// CopyRecipeIngredients mocks base method.
+func (m *MockStorage) CopyRecipeIngredients(arg0 context.Context, arg1 db.CopyRecipeIngredientsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyRecipeIngredients", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyRecipeIngredients indicates an expected call of CopyRecipeIngredients.
+func (mr *MockStorageMockRecorder) CopyRecipeIngredients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyRecipeIngredients", reflect.TypeOf((*MockStorage)(nil).CopyRecipeIngredients), arg0, arg1)
+}
+
+// CopyRecipeKeywords mocks base method.
+func (m *MockStorage) CopyRecipeKeywords(arg0 context.Context, arg1 db.CopyRecipeKeywordsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyRecipeKeywords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyRecipeKeywords indicates an expected call of CopyRecipeKeywords.
+func (mr *MockStorageMockRecorder) CopyRecipeKeywords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyRecipeKeywords", reflect.TypeOf((*MockStorage)(nil).CopyRecipeKeywords), arg0, arg1)
+}
+
+// CountRecipeSteps mocks base method.
+func (m *MockStorage) CountRecipeSteps(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecipeSteps", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecipeSteps indicates an expected call of CountRecipeSteps.
+func (mr *MockStorageMockRecorder) CountRecipeSteps(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecipeSteps", reflect.TypeOf((*MockStorage)(nil).CountRecipeSteps), arg0, arg1)
+}
+
+// CreateCollection mocks base method.
+func (m *MockStorage) CreateCollection(arg0 context.Context, arg1 db.CreateCollectionParams) (db.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1)
+	ret0, _ := ret[0].(db.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockStorageMockRecorder) CreateCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockStorage)(nil).CreateCollection), arg0, arg1)
+}
+
+// CreateCollectionRecipe mocks base method.
+func (m *MockStorage) CreateCollectionRecipe(arg0 context.Context, arg1 db.CreateCollectionRecipeParams) (db.CollectionsRecipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollectionRecipe", arg0, arg1)
+	ret0, _ := ret[0].(db.CollectionsRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollectionRecipe indicates an expected call of CreateCollectionRecipe.
+func (mr *MockStorageMockRecorder) CreateCollectionRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollectionRecipe", reflect.TypeOf((*MockStorage)(nil).CreateCollectionRecipe), arg0, arg1)
+}
+
+// CreateDietaryTag mocks base method.
+func (m *MockStorage) CreateDietaryTag(arg0 context.Context, arg1 db.CreateDietaryTagParams) (db.DietaryTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDietaryTag", arg0, arg1)
+	ret0, _ := ret[0].(db.DietaryTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDietaryTag indicates an expected call of CreateDietaryTag.
+func (mr *MockStorageMockRecorder) CreateDietaryTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDietaryTag", reflect.TypeOf((*MockStorage)(nil).CreateDietaryTag), arg0, arg1)
+}
+
+// CreateFavorite mocks base method.
+func (m *MockStorage) CreateFavorite(arg0 context.Context, arg1 db.CreateFavoriteParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFavorite", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFavorite indicates an expected call of CreateFavorite.
+func (mr *MockStorageMockRecorder) CreateFavorite(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFavorite", reflect.TypeOf((*MockStorage)(nil).CreateFavorite), arg0, arg1)
+}
+
 // CreateIngredient mocks base method.
 func (m *MockStorage) CreateIngredient(arg0 context.Context, arg1 db.CreateIngredientParams) (db.Ingredient, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +175,81 @@ func (mr *MockStorageMockRecorder) CreateIngredient(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockStorage)(nil).CreateIngredient), arg0, arg1)
 }
 
+// CreateIngredientAlias mocks base method.
+func (m *MockStorage) CreateIngredientAlias(arg0 context.Context, arg1 db.CreateIngredientAliasParams) (db.IngredientsAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientAlias", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientAlias indicates an expected call of CreateIngredientAlias.
+func (mr *MockStorageMockRecorder) CreateIngredientAlias(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientAlias", reflect.TypeOf((*MockStorage)(nil).CreateIngredientAlias), arg0, arg1)
+}
+
+// CreateIngredientPackage mocks base method.
+func (m *MockStorage) CreateIngredientPackage(arg0 context.Context, arg1 db.CreateIngredientPackageParams) (db.IngredientsPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientPackage", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientPackage indicates an expected call of CreateIngredientPackage.
+func (mr *MockStorageMockRecorder) CreateIngredientPackage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientPackage", reflect.TypeOf((*MockStorage)(nil).CreateIngredientPackage), arg0, arg1)
+}
+
+// CreateIngredientPrice mocks base method.
+func (m *MockStorage) CreateIngredientPrice(arg0 context.Context, arg1 db.CreateIngredientPriceParams) (db.IngredientsPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientPrice", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientPrice indicates an expected call of CreateIngredientPrice.
+func (mr *MockStorageMockRecorder) CreateIngredientPrice(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientPrice", reflect.TypeOf((*MockStorage)(nil).CreateIngredientPrice), arg0, arg1)
+}
+
+// CreateIngredientTag mocks base method.
+func (m *MockStorage) CreateIngredientTag(arg0 context.Context, arg1 db.CreateIngredientTagParams) (db.IngredientsTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientTag", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientTag indicates an expected call of CreateIngredientTag.
+func (mr *MockStorageMockRecorder) CreateIngredientTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientTag", reflect.TypeOf((*MockStorage)(nil).CreateIngredientTag), arg0, arg1)
+}
+
+// CreatePantryItem mocks base method.
+func (m *MockStorage) CreatePantryItem(arg0 context.Context, arg1 db.CreatePantryItemParams) (db.PantryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePantryItem", arg0, arg1)
+	ret0, _ := ret[0].(db.PantryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePantryItem indicates an expected call of CreatePantryItem.
+func (mr *MockStorageMockRecorder) CreatePantryItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePantryItem", reflect.TypeOf((*MockStorage)(nil).CreatePantryItem), arg0, arg1)
+}
+
 // CreateRecipe mocks base method.
 func (m *MockStorage) CreateRecipe(arg0 context.Context, arg1 db.CreateRecipeParams) (db.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +265,36 @@ func (mr *MockStorageMockRecorder) CreateRecipe(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipe", reflect.TypeOf((*MockStorage)(nil).CreateRecipe), arg0, arg1)
 }
 
+// CreateRecipeCooked mocks base method.
+func (m *MockStorage) CreateRecipeCooked(arg0 context.Context, arg1 db.CreateRecipeCookedParams) (db.RecipesCooked, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeCooked", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesCooked)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeCooked indicates an expected call of CreateRecipeCooked.
+func (mr *MockStorageMockRecorder) CreateRecipeCooked(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeCooked", reflect.TypeOf((*MockStorage)(nil).CreateRecipeCooked), arg0, arg1)
+}
+
+// CreateRecipeImage mocks base method.
+func (m *MockStorage) CreateRecipeImage(arg0 context.Context, arg1 db.CreateRecipeImageParams) (db.RecipesImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeImage", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeImage indicates an expected call of CreateRecipeImage.
+func (mr *MockStorageMockRecorder) CreateRecipeImage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeImage", reflect.TypeOf((*MockStorage)(nil).CreateRecipeImage), arg0, arg1)
+}
+
 // CreateRecipeIngredient mocks base method.
 func (m *MockStorage) CreateRecipeIngredient(arg0 context.Context, arg1 db.CreateRecipeIngredientParams) (db.RecipesIngredient, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +310,65 @@ func (mr *MockStorageMockRecorder) CreateRecipeIngredient(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeIngredient", reflect.TypeOf((*MockStorage)(nil).CreateRecipeIngredient), arg0, arg1)
 }
 
+// CreateRecipeKeyword mocks base method.
+func (m *MockStorage) CreateRecipeKeyword(arg0 context.Context, arg1 db.CreateRecipeKeywordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeKeyword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecipeKeyword indicates an expected call of CreateRecipeKeyword.
+func (mr *MockStorageMockRecorder) CreateRecipeKeyword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeKeyword", reflect.TypeOf((*MockStorage)(nil).CreateRecipeKeyword), arg0, arg1)
+}
+
+// CreateRecipeRevision mocks base method.
+func (m *MockStorage) CreateRecipeRevision(arg0 context.Context, arg1 db.CreateRecipeRevisionParams) (db.RecipesRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeRevision indicates an expected call of CreateRecipeRevision.
+func (mr *MockStorageMockRecorder) CreateRecipeRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeRevision", reflect.TypeOf((*MockStorage)(nil).CreateRecipeRevision), arg0, arg1)
+}
+
+// CreateRecipeStep mocks base method.
+func (m *MockStorage) CreateRecipeStep(arg0 context.Context, arg1 db.CreateRecipeStepParams) (db.RecipesStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeStep", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeStep indicates an expected call of CreateRecipeStep.
+func (mr *MockStorageMockRecorder) CreateRecipeStep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeStep", reflect.TypeOf((*MockStorage)(nil).CreateRecipeStep), arg0, arg1)
+}
+
+// CreateRecipeStepIngredient mocks base method.
+func (m *MockStorage) CreateRecipeStepIngredient(arg0 context.Context, arg1 db.CreateRecipeStepIngredientParams) (db.RecipesStepsIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeStepIngredient", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesStepsIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeStepIngredient indicates an expected call of CreateRecipeStepIngredient.
+func (mr *MockStorageMockRecorder) CreateRecipeStepIngredient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeStepIngredient", reflect.TypeOf((*MockStorage)(nil).CreateRecipeStepIngredient), arg0, arg1)
+}
+
 // CreateSchedule mocks base method.
 func (m *MockStorage) CreateSchedule(arg0 context.Context, arg1 uuid.NullUUID) (db.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -116,8 +399,38 @@ func (mr *MockStorageMockRecorder) CreateScheduleRecipe(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduleRecipe", reflect.TypeOf((*MockStorage)(nil).CreateScheduleRecipe), arg0, arg1)
 }
 
+// CreateScheduleTemplate mocks base method.
+func (m *MockStorage) CreateScheduleTemplate(arg0 context.Context, arg1 db.CreateScheduleTemplateParams) (db.ScheduleTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduleTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduleTemplate indicates an expected call of CreateScheduleTemplate.
+func (mr *MockStorageMockRecorder) CreateScheduleTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduleTemplate", reflect.TypeOf((*MockStorage)(nil).CreateScheduleTemplate), arg0, arg1)
+}
+
+// CreateScheduleTemplateRecipe mocks base method.
+func (m *MockStorage) CreateScheduleTemplateRecipe(arg0 context.Context, arg1 db.CreateScheduleTemplateRecipeParams) (db.ScheduleTemplatesRecipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduleTemplateRecipe", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleTemplatesRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduleTemplateRecipe indicates an expected call of CreateScheduleTemplateRecipe.
+func (mr *MockStorageMockRecorder) CreateScheduleTemplateRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduleTemplateRecipe", reflect.TypeOf((*MockStorage)(nil).CreateScheduleTemplateRecipe), arg0, arg1)
+}
+
 // CreateUnit mocks base method.
-func (m *MockStorage) CreateUnit(arg0 context.Context, arg1 string) (db.Unit, error) {
+func (m *MockStorage) CreateUnit(arg0 context.Context, arg1 db.CreateUnitParams) (db.Unit, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateUnit", arg0, arg1)
 	ret0, _ := ret[0].(db.Unit)
@@ -146,136 +459,685 @@ func (mr *MockStorageMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStorage)(nil).CreateUser), arg0, arg1)
 }
 
-// DeleteIngredient mocks base method.
-func (m *MockStorage) DeleteIngredient(arg0 context.Context, arg1 int32) error {
+// CreateUserRestriction mocks base method.
+func (m *MockStorage) CreateUserRestriction(arg0 context.Context, arg1 db.CreateUserRestrictionParams) (db.UsersRestriction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteIngredient", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateUserRestriction", arg0, arg1)
+	ret0, _ := ret[0].(db.UsersRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserRestriction indicates an expected call of CreateUserRestriction.
+func (mr *MockStorageMockRecorder) CreateUserRestriction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserRestriction", reflect.TypeOf((*MockStorage)(nil).CreateUserRestriction), arg0, arg1)
+}
+
+// DeleteCalendarFeed mocks base method.
+func (m *MockStorage) DeleteCalendarFeed(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarFeed", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteIngredient indicates an expected call of DeleteIngredient.
-func (mr *MockStorageMockRecorder) DeleteIngredient(arg0, arg1 any) *gomock.Call {
+// DeleteCalendarFeed indicates an expected call of DeleteCalendarFeed.
+func (mr *MockStorageMockRecorder) DeleteCalendarFeed(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockStorage)(nil).DeleteIngredient), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarFeed", reflect.TypeOf((*MockStorage)(nil).DeleteCalendarFeed), arg0, arg1)
 }
 
-// DeleteRecipe mocks base method.
-func (m *MockStorage) DeleteRecipe(arg0 context.Context, arg1 int64) error {
+// DeleteCollection mocks base method.
+func (m *MockStorage) DeleteCollection(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRecipe", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteRecipe indicates an expected call of DeleteRecipe.
-func (mr *MockStorageMockRecorder) DeleteRecipe(arg0, arg1 any) *gomock.Call {
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockStorageMockRecorder) DeleteCollection(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipe", reflect.TypeOf((*MockStorage)(nil).DeleteRecipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockStorage)(nil).DeleteCollection), arg0, arg1)
 }
 
-// DeleteRecipeIngredient mocks base method.
-func (m *MockStorage) DeleteRecipeIngredient(arg0 context.Context, arg1 db.DeleteRecipeIngredientParams) error {
+// DeleteCollectionRecipe mocks base method.
+func (m *MockStorage) DeleteCollectionRecipe(arg0 context.Context, arg1 db.DeleteCollectionRecipeParams) (db.CollectionsRecipe, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRecipeIngredient", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteCollectionRecipe", arg0, arg1)
+	ret0, _ := ret[0].(db.CollectionsRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectionRecipe indicates an expected call of DeleteCollectionRecipe.
+func (mr *MockStorageMockRecorder) DeleteCollectionRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionRecipe", reflect.TypeOf((*MockStorage)(nil).DeleteCollectionRecipe), arg0, arg1)
+}
+
+// DeleteDietaryTag mocks base method.
+func (m *MockStorage) DeleteDietaryTag(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDietaryTag", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteRecipeIngredient indicates an expected call of DeleteRecipeIngredient.
-func (mr *MockStorageMockRecorder) DeleteRecipeIngredient(arg0, arg1 any) *gomock.Call {
+// DeleteDietaryTag indicates an expected call of DeleteDietaryTag.
+func (mr *MockStorageMockRecorder) DeleteDietaryTag(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeIngredient", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeIngredient), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDietaryTag", reflect.TypeOf((*MockStorage)(nil).DeleteDietaryTag), arg0, arg1)
 }
 
-// DeleteSchedule mocks base method.
-func (m *MockStorage) DeleteSchedule(arg0 context.Context, arg1 int64) error {
+// DeleteFavorite mocks base method.
+func (m *MockStorage) DeleteFavorite(arg0 context.Context, arg1 db.DeleteFavoriteParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSchedule", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteFavorite", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteSchedule indicates an expected call of DeleteSchedule.
-func (mr *MockStorageMockRecorder) DeleteSchedule(arg0, arg1 any) *gomock.Call {
+// DeleteFavorite indicates an expected call of DeleteFavorite.
+func (mr *MockStorageMockRecorder) DeleteFavorite(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockStorage)(nil).DeleteSchedule), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFavorite", reflect.TypeOf((*MockStorage)(nil).DeleteFavorite), arg0, arg1)
 }
 
-// DeleteScheduleRecipe mocks base method.
-func (m *MockStorage) DeleteScheduleRecipe(arg0 context.Context, arg1 db.DeleteScheduleRecipeParams) error {
+// DeleteIngredient mocks base method.
+func (m *MockStorage) DeleteIngredient(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteScheduleRecipe", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteIngredient", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteScheduleRecipe indicates an expected call of DeleteScheduleRecipe.
-func (mr *MockStorageMockRecorder) DeleteScheduleRecipe(arg0, arg1 any) *gomock.Call {
+// DeleteIngredient indicates an expected call of DeleteIngredient.
+func (mr *MockStorageMockRecorder) DeleteIngredient(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduleRecipe", reflect.TypeOf((*MockStorage)(nil).DeleteScheduleRecipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockStorage)(nil).DeleteIngredient), arg0, arg1)
 }
 
-// DeleteUnit mocks base method.
-func (m *MockStorage) DeleteUnit(arg0 context.Context, arg1 int32) error {
+// DeleteIngredientAlias mocks base method.
+func (m *MockStorage) DeleteIngredientAlias(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUnit", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteIngredientAlias", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteUnit indicates an expected call of DeleteUnit.
-func (mr *MockStorageMockRecorder) DeleteUnit(arg0, arg1 any) *gomock.Call {
+// DeleteIngredientAlias indicates an expected call of DeleteIngredientAlias.
+func (mr *MockStorageMockRecorder) DeleteIngredientAlias(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnit", reflect.TypeOf((*MockStorage)(nil).DeleteUnit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredientAlias", reflect.TypeOf((*MockStorage)(nil).DeleteIngredientAlias), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStorage) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+// DeleteIngredientPackage mocks base method.
+func (m *MockStorage) DeleteIngredientPackage(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteIngredientPackage", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStorageMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+// DeleteIngredientPackage indicates an expected call of DeleteIngredientPackage.
+func (mr *MockStorageMockRecorder) DeleteIngredientPackage(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStorage)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredientPackage", reflect.TypeOf((*MockStorage)(nil).DeleteIngredientPackage), arg0, arg1)
 }
 
-// GenerateGroceries mocks base method.
-func (m *MockStorage) GenerateGroceries(arg0 context.Context, arg1 db.GenerateGroceriesParam) (db.GenerateGroceriesResult, error) {
+// DeleteIngredientPrice mocks base method.
+func (m *MockStorage) DeleteIngredientPrice(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateGroceries", arg0, arg1)
-	ret0, _ := ret[0].(db.GenerateGroceriesResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteIngredientPrice", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GenerateGroceries indicates an expected call of GenerateGroceries.
-func (mr *MockStorageMockRecorder) GenerateGroceries(arg0, arg1 any) *gomock.Call {
+// DeleteIngredientPrice indicates an expected call of DeleteIngredientPrice.
+func (mr *MockStorageMockRecorder) DeleteIngredientPrice(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateGroceries", reflect.TypeOf((*MockStorage)(nil).GenerateGroceries), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredientPrice", reflect.TypeOf((*MockStorage)(nil).DeleteIngredientPrice), arg0, arg1)
 }
 
-// GetIngredient mocks base method.
-func (m *MockStorage) GetIngredient(arg0 context.Context, arg1 int32) (db.Ingredient, error) {
+// DeleteIngredientTag mocks base method.
+func (m *MockStorage) DeleteIngredientTag(arg0 context.Context, arg1 db.DeleteIngredientTagParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetIngredient", arg0, arg1)
-	ret0, _ := ret[0].(db.Ingredient)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteIngredientTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetIngredient indicates an expected call of GetIngredient.
-func (mr *MockStorageMockRecorder) GetIngredient(arg0, arg1 any) *gomock.Call {
+// DeleteIngredientTag indicates an expected call of DeleteIngredientTag.
+func (mr *MockStorageMockRecorder) DeleteIngredientTag(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredient", reflect.TypeOf((*MockStorage)(nil).GetIngredient), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredientTag", reflect.TypeOf((*MockStorage)(nil).DeleteIngredientTag), arg0, arg1)
 }
 
-// GetLogin mocks base method.
-func (m *MockStorage) GetLogin(arg0 context.Context, arg1 string) (db.User, error) {
+// DeleteIngredientUnit mocks base method.
+func (m *MockStorage) DeleteIngredientUnit(arg0 context.Context, arg1 db.DeleteIngredientUnitParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngredientUnit", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIngredientUnit indicates an expected call of DeleteIngredientUnit.
+func (mr *MockStorageMockRecorder) DeleteIngredientUnit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredientUnit", reflect.TypeOf((*MockStorage)(nil).DeleteIngredientUnit), arg0, arg1)
+}
+
+// DeleteNutrition mocks base method.
+func (m *MockStorage) DeleteNutrition(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNutrition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNutrition indicates an expected call of DeleteNutrition.
+func (mr *MockStorageMockRecorder) DeleteNutrition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNutrition", reflect.TypeOf((*MockStorage)(nil).DeleteNutrition), arg0, arg1)
+}
+
+// DeletePantryItem mocks base method.
+func (m *MockStorage) DeletePantryItem(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePantryItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePantryItem indicates an expected call of DeletePantryItem.
+func (mr *MockStorageMockRecorder) DeletePantryItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePantryItem", reflect.TypeOf((*MockStorage)(nil).DeletePantryItem), arg0, arg1)
+}
+
+// DeleteRecipe mocks base method.
+func (m *MockStorage) DeleteRecipe(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipe indicates an expected call of DeleteRecipe.
+func (mr *MockStorageMockRecorder) DeleteRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipe", reflect.TypeOf((*MockStorage)(nil).DeleteRecipe), arg0, arg1)
+}
+
+// DeleteRecipeCooked mocks base method.
+func (m *MockStorage) DeleteRecipeCooked(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeCooked", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeCooked indicates an expected call of DeleteRecipeCooked.
+func (mr *MockStorageMockRecorder) DeleteRecipeCooked(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeCooked", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeCooked), arg0, arg1)
+}
+
+// DeleteRecipeImage mocks base method.
+func (m *MockStorage) DeleteRecipeImage(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeImage indicates an expected call of DeleteRecipeImage.
+func (mr *MockStorageMockRecorder) DeleteRecipeImage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeImage", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeImage), arg0, arg1)
+}
+
+// DeleteRecipeIngredient mocks base method.
+func (m *MockStorage) DeleteRecipeIngredient(arg0 context.Context, arg1 db.DeleteRecipeIngredientParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeIngredient", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeIngredient indicates an expected call of DeleteRecipeIngredient.
+func (mr *MockStorageMockRecorder) DeleteRecipeIngredient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeIngredient", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeIngredient), arg0, arg1)
+}
+
+// DeleteRecipeIngredientTx mocks base method.
+func (m *MockStorage) DeleteRecipeIngredientTx(arg0 context.Context, arg1 db.DeleteRecipeIngredientTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeIngredientTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeIngredientTx indicates an expected call of DeleteRecipeIngredientTx.
+func (mr *MockStorageMockRecorder) DeleteRecipeIngredientTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeIngredientTx", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeIngredientTx), arg0, arg1)
+}
+
+// DeleteRecipeKeywords mocks base method.
+func (m *MockStorage) DeleteRecipeKeywords(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeKeywords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeKeywords indicates an expected call of DeleteRecipeKeywords.
+func (mr *MockStorageMockRecorder) DeleteRecipeKeywords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeKeywords", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeKeywords), arg0, arg1)
+}
+
+// DeleteRecipeReview mocks base method.
+func (m *MockStorage) DeleteRecipeReview(arg0 context.Context, arg1 db.DeleteRecipeReviewParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeReview", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeReview indicates an expected call of DeleteRecipeReview.
+func (mr *MockStorageMockRecorder) DeleteRecipeReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeReview", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeReview), arg0, arg1)
+}
+
+// DeleteRecipeStep mocks base method.
+func (m *MockStorage) DeleteRecipeStep(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeStep", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeStep indicates an expected call of DeleteRecipeStep.
+func (mr *MockStorageMockRecorder) DeleteRecipeStep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeStep", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeStep), arg0, arg1)
+}
+
+// DeleteRecipeStepIngredients mocks base method.
+func (m *MockStorage) DeleteRecipeStepIngredients(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeStepIngredients", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeStepIngredients indicates an expected call of DeleteRecipeStepIngredients.
+func (mr *MockStorageMockRecorder) DeleteRecipeStepIngredients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeStepIngredients", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeStepIngredients), arg0, arg1)
+}
+
+// DeleteRecipeStepTx mocks base method.
+func (m *MockStorage) DeleteRecipeStepTx(arg0 context.Context, arg1 db.DeleteRecipeStepTxParams) ([]db.RecipeStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeStepTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipeStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRecipeStepTx indicates an expected call of DeleteRecipeStepTx.
+func (mr *MockStorageMockRecorder) DeleteRecipeStepTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeStepTx", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeStepTx), arg0, arg1)
+}
+
+// DeleteRecipeSteps mocks base method.
+func (m *MockStorage) DeleteRecipeSteps(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeSteps", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeSteps indicates an expected call of DeleteRecipeSteps.
+func (mr *MockStorageMockRecorder) DeleteRecipeSteps(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeSteps", reflect.TypeOf((*MockStorage)(nil).DeleteRecipeSteps), arg0, arg1)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockStorage) DeleteSchedule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockStorageMockRecorder) DeleteSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockStorage)(nil).DeleteSchedule), arg0, arg1)
+}
+
+// DeleteScheduleRecipe mocks base method.
+func (m *MockStorage) DeleteScheduleRecipe(arg0 context.Context, arg1 db.DeleteScheduleRecipeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduleRecipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduleRecipe indicates an expected call of DeleteScheduleRecipe.
+func (mr *MockStorageMockRecorder) DeleteScheduleRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduleRecipe", reflect.TypeOf((*MockStorage)(nil).DeleteScheduleRecipe), arg0, arg1)
+}
+
+// DeleteScheduleTemplate mocks base method.
+func (m *MockStorage) DeleteScheduleTemplate(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduleTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduleTemplate indicates an expected call of DeleteScheduleTemplate.
+func (mr *MockStorageMockRecorder) DeleteScheduleTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduleTemplate", reflect.TypeOf((*MockStorage)(nil).DeleteScheduleTemplate), arg0, arg1)
+}
+
+// DeleteUnit mocks base method.
+func (m *MockStorage) DeleteUnit(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnit", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUnit indicates an expected call of DeleteUnit.
+func (mr *MockStorageMockRecorder) DeleteUnit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnit", reflect.TypeOf((*MockStorage)(nil).DeleteUnit), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStorage) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStorageMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStorage)(nil).DeleteUser), arg0, arg1)
+}
+
+// DeleteUserRestrictions mocks base method.
+func (m *MockStorage) DeleteUserRestrictions(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRestrictions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRestrictions indicates an expected call of DeleteUserRestrictions.
+func (mr *MockStorageMockRecorder) DeleteUserRestrictions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRestrictions", reflect.TypeOf((*MockStorage)(nil).DeleteUserRestrictions), arg0, arg1)
+}
+
+// DuplicateScheduleTx mocks base method.
+func (m *MockStorage) DuplicateScheduleTx(arg0 context.Context, arg1 db.DuplicateScheduleParams) (db.GenerateGroceriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicateScheduleTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GenerateGroceriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicateScheduleTx indicates an expected call of DuplicateScheduleTx.
+func (mr *MockStorageMockRecorder) DuplicateScheduleTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateScheduleTx", reflect.TypeOf((*MockStorage)(nil).DuplicateScheduleTx), arg0, arg1)
+}
+
+// ForkRecipe mocks base method.
+func (m *MockStorage) ForkRecipe(arg0 context.Context, arg1 db.ForkRecipeParams) (db.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkRecipe", arg0, arg1)
+	ret0, _ := ret[0].(db.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkRecipe indicates an expected call of ForkRecipe.
+func (mr *MockStorageMockRecorder) ForkRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkRecipe", reflect.TypeOf((*MockStorage)(nil).ForkRecipe), arg0, arg1)
+}
+
+// ForkRecipeTx mocks base method.
+func (m *MockStorage) ForkRecipeTx(arg0 context.Context, arg1 db.ForkRecipeParams) (db.RecipeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkRecipeTx indicates an expected call of ForkRecipeTx.
+func (mr *MockStorageMockRecorder) ForkRecipeTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkRecipeTx", reflect.TypeOf((*MockStorage)(nil).ForkRecipeTx), arg0, arg1)
+}
+
+// GenerateGroceries mocks base method.
+func (m *MockStorage) GenerateGroceries(arg0 context.Context, arg1 db.GenerateGroceriesParam) (db.GenerateGroceriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateGroceries", arg0, arg1)
+	ret0, _ := ret[0].(db.GenerateGroceriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateGroceries indicates an expected call of GenerateGroceries.
+func (mr *MockStorageMockRecorder) GenerateGroceries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateGroceries", reflect.TypeOf((*MockStorage)(nil).GenerateGroceries), arg0, arg1)
+}
+
+// GenerateWeekTx mocks base method.
+func (m *MockStorage) GenerateWeekTx(arg0 context.Context, arg1 db.GenerateWeekParams) (db.GenerateGroceriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateWeekTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GenerateGroceriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateWeekTx indicates an expected call of GenerateWeekTx.
+func (mr *MockStorageMockRecorder) GenerateWeekTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateWeekTx", reflect.TypeOf((*MockStorage)(nil).GenerateWeekTx), arg0, arg1)
+}
+
+// GetCalendarFeed mocks base method.
+func (m *MockStorage) GetCalendarFeed(arg0 context.Context, arg1 uuid.UUID) (db.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeed", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeed indicates an expected call of GetCalendarFeed.
+func (mr *MockStorageMockRecorder) GetCalendarFeed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeed", reflect.TypeOf((*MockStorage)(nil).GetCalendarFeed), arg0, arg1)
+}
+
+// GetCalendarFeedByToken mocks base method.
+func (m *MockStorage) GetCalendarFeedByToken(arg0 context.Context, arg1 uuid.UUID) (db.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeedByToken", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeedByToken indicates an expected call of GetCalendarFeedByToken.
+func (mr *MockStorageMockRecorder) GetCalendarFeedByToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedByToken", reflect.TypeOf((*MockStorage)(nil).GetCalendarFeedByToken), arg0, arg1)
+}
+
+// GetCalendarFeedTx mocks base method.
+func (m *MockStorage) GetCalendarFeedTx(arg0 context.Context, arg1 db.CalendarFeedParams) ([]db.CalendarEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeedTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.CalendarEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeedTx indicates an expected call of GetCalendarFeedTx.
+func (mr *MockStorageMockRecorder) GetCalendarFeedTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedTx", reflect.TypeOf((*MockStorage)(nil).GetCalendarFeedTx), arg0, arg1)
+}
+
+// GetCollection mocks base method.
+func (m *MockStorage) GetCollection(arg0 context.Context, arg1 int64) (db.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", arg0, arg1)
+	ret0, _ := ret[0].(db.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockStorageMockRecorder) GetCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockStorage)(nil).GetCollection), arg0, arg1)
+}
+
+// GetCollectionByShareToken mocks base method.
+func (m *MockStorage) GetCollectionByShareToken(arg0 context.Context, arg1 uuid.NullUUID) (db.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionByShareToken", arg0, arg1)
+	ret0, _ := ret[0].(db.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionByShareToken indicates an expected call of GetCollectionByShareToken.
+func (mr *MockStorageMockRecorder) GetCollectionByShareToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionByShareToken", reflect.TypeOf((*MockStorage)(nil).GetCollectionByShareToken), arg0, arg1)
+}
+
+// GetIngredient mocks base method.
+func (m *MockStorage) GetIngredient(arg0 context.Context, arg1 int32) (db.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredient", arg0, arg1)
+	ret0, _ := ret[0].(db.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredient indicates an expected call of GetIngredient.
+func (mr *MockStorageMockRecorder) GetIngredient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredient", reflect.TypeOf((*MockStorage)(nil).GetIngredient), arg0, arg1)
+}
+
+// GetIngredientCategory mocks base method.
+func (m *MockStorage) GetIngredientCategory(arg0 context.Context, arg1 int32) (db.IngredientsCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientCategory indicates an expected call of GetIngredientCategory.
+func (mr *MockStorageMockRecorder) GetIngredientCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientCategory", reflect.TypeOf((*MockStorage)(nil).GetIngredientCategory), arg0, arg1)
+}
+
+// GetIngredientPackage mocks base method.
+func (m *MockStorage) GetIngredientPackage(arg0 context.Context, arg1 int64) (db.IngredientsPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientPackage", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientPackage indicates an expected call of GetIngredientPackage.
+func (mr *MockStorageMockRecorder) GetIngredientPackage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientPackage", reflect.TypeOf((*MockStorage)(nil).GetIngredientPackage), arg0, arg1)
+}
+
+// GetIngredientPrice mocks base method.
+func (m *MockStorage) GetIngredientPrice(arg0 context.Context, arg1 int64) (db.IngredientsPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientPrice", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientPrice indicates an expected call of GetIngredientPrice.
+func (mr *MockStorageMockRecorder) GetIngredientPrice(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientPrice", reflect.TypeOf((*MockStorage)(nil).GetIngredientPrice), arg0, arg1)
+}
+
+// GetIngredientShelfLife mocks base method.
+func (m *MockStorage) GetIngredientShelfLife(arg0 context.Context, arg1 int32) (db.IngredientsShelfLife, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientShelfLife", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsShelfLife)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientShelfLife indicates an expected call of GetIngredientShelfLife.
+func (mr *MockStorageMockRecorder) GetIngredientShelfLife(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientShelfLife", reflect.TypeOf((*MockStorage)(nil).GetIngredientShelfLife), arg0, arg1)
+}
+
+// GetLatestRecipeRevision mocks base method.
+func (m *MockStorage) GetLatestRecipeRevision(arg0 context.Context, arg1 int64) (db.RecipesRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestRecipeRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestRecipeRevision indicates an expected call of GetLatestRecipeRevision.
+func (mr *MockStorageMockRecorder) GetLatestRecipeRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestRecipeRevision", reflect.TypeOf((*MockStorage)(nil).GetLatestRecipeRevision), arg0, arg1)
+}
+
+// GetLogin mocks base method.
+func (m *MockStorage) GetLogin(arg0 context.Context, arg1 string) (db.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetLogin", arg0, arg1)
 	ret0, _ := ret[0].(db.User)
@@ -283,310 +1145,1461 @@ func (m *MockStorage) GetLogin(arg0 context.Context, arg1 string) (db.User, erro
 	return ret0, ret1
 }
 
-// GetLogin indicates an expected call of GetLogin.
-func (mr *MockStorageMockRecorder) GetLogin(arg0, arg1 any) *gomock.Call {
+// GetLogin indicates an expected call of GetLogin.
+func (mr *MockStorageMockRecorder) GetLogin(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogin", reflect.TypeOf((*MockStorage)(nil).GetLogin), arg0, arg1)
+}
+
+// GetNutrition mocks base method.
+func (m *MockStorage) GetNutrition(arg0 context.Context, arg1 int32) (db.Nutrition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNutrition", arg0, arg1)
+	ret0, _ := ret[0].(db.Nutrition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNutrition indicates an expected call of GetNutrition.
+func (mr *MockStorageMockRecorder) GetNutrition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNutrition", reflect.TypeOf((*MockStorage)(nil).GetNutrition), arg0, arg1)
+}
+
+// GetPantryItem mocks base method.
+func (m *MockStorage) GetPantryItem(arg0 context.Context, arg1 int64) (db.PantryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPantryItem", arg0, arg1)
+	ret0, _ := ret[0].(db.PantryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPantryItem indicates an expected call of GetPantryItem.
+func (mr *MockStorageMockRecorder) GetPantryItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPantryItem", reflect.TypeOf((*MockStorage)(nil).GetPantryItem), arg0, arg1)
+}
+
+// GetPermission mocks base method.
+func (m *MockStorage) GetPermission(arg0 context.Context, arg1 uuid.UUID) (db.GetPermissionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermission", arg0, arg1)
+	ret0, _ := ret[0].(db.GetPermissionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermission indicates an expected call of GetPermission.
+func (mr *MockStorageMockRecorder) GetPermission(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermission", reflect.TypeOf((*MockStorage)(nil).GetPermission), arg0, arg1)
+}
+
+// GetRecipe mocks base method.
+func (m *MockStorage) GetRecipe(arg0 context.Context, arg1 int64) (db.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipe", arg0, arg1)
+	ret0, _ := ret[0].(db.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipe indicates an expected call of GetRecipe.
+func (mr *MockStorageMockRecorder) GetRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipe", reflect.TypeOf((*MockStorage)(nil).GetRecipe), arg0, arg1)
+}
+
+// GetRecipeCooked mocks base method.
+func (m *MockStorage) GetRecipeCooked(arg0 context.Context, arg1 int64) (db.RecipesCooked, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeCooked", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesCooked)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeCooked indicates an expected call of GetRecipeCooked.
+func (mr *MockStorageMockRecorder) GetRecipeCooked(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeCooked", reflect.TypeOf((*MockStorage)(nil).GetRecipeCooked), arg0, arg1)
+}
+
+// GetRecipeImage mocks base method.
+func (m *MockStorage) GetRecipeImage(arg0 context.Context, arg1 int64) (db.RecipesImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeImage", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeImage indicates an expected call of GetRecipeImage.
+func (mr *MockStorageMockRecorder) GetRecipeImage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeImage", reflect.TypeOf((*MockStorage)(nil).GetRecipeImage), arg0, arg1)
+}
+
+// GetRecipeIngredients mocks base method.
+func (m *MockStorage) GetRecipeIngredients(arg0 context.Context, arg1 int64) ([]db.GetRecipeIngredientsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetRecipeIngredientsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeIngredients indicates an expected call of GetRecipeIngredients.
+func (mr *MockStorageMockRecorder) GetRecipeIngredients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeIngredients", reflect.TypeOf((*MockStorage)(nil).GetRecipeIngredients), arg0, arg1)
+}
+
+// GetRecipeRevision mocks base method.
+func (m *MockStorage) GetRecipeRevision(arg0 context.Context, arg1 db.GetRecipeRevisionParams) (db.RecipesRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeRevision indicates an expected call of GetRecipeRevision.
+func (mr *MockStorageMockRecorder) GetRecipeRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeRevision", reflect.TypeOf((*MockStorage)(nil).GetRecipeRevision), arg0, arg1)
+}
+
+// GetRecipeStats mocks base method.
+func (m *MockStorage) GetRecipeStats(arg0 context.Context, arg1 int64) (db.RecipesStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeStats", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeStats indicates an expected call of GetRecipeStats.
+func (mr *MockStorageMockRecorder) GetRecipeStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeStats", reflect.TypeOf((*MockStorage)(nil).GetRecipeStats), arg0, arg1)
+}
+
+// GetRecipeStep mocks base method.
+func (m *MockStorage) GetRecipeStep(arg0 context.Context, arg1 int64) (db.RecipesStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeStep", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeStep indicates an expected call of GetRecipeStep.
+func (mr *MockStorageMockRecorder) GetRecipeStep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeStep", reflect.TypeOf((*MockStorage)(nil).GetRecipeStep), arg0, arg1)
+}
+
+// GetRecipeTx mocks base method.
+func (m *MockStorage) GetRecipeTx(arg0 context.Context, arg1 int64) (db.RecipeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeTx indicates an expected call of GetRecipeTx.
+func (mr *MockStorageMockRecorder) GetRecipeTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeTx", reflect.TypeOf((*MockStorage)(nil).GetRecipeTx), arg0, arg1)
+}
+
+// GetSchedule mocks base method.
+func (m *MockStorage) GetSchedule(arg0 context.Context, arg1 int64) (db.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockStorageMockRecorder) GetSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockStorage)(nil).GetSchedule), arg0, arg1)
+}
+
+// GetScheduleGroceriesTx mocks base method.
+func (m *MockStorage) GetScheduleGroceriesTx(arg0 context.Context, arg1 db.ScheduleGroceriesParams) (db.ScheduleGroceriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleGroceriesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleGroceriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleGroceriesTx indicates an expected call of GetScheduleGroceriesTx.
+func (mr *MockStorageMockRecorder) GetScheduleGroceriesTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleGroceriesTx", reflect.TypeOf((*MockStorage)(nil).GetScheduleGroceriesTx), arg0, arg1)
+}
+
+// GetScheduleNutritionTx mocks base method.
+func (m *MockStorage) GetScheduleNutritionTx(arg0 context.Context, arg1 int64) (db.ScheduleNutritionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleNutritionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleNutritionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleNutritionTx indicates an expected call of GetScheduleNutritionTx.
+func (mr *MockStorageMockRecorder) GetScheduleNutritionTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleNutritionTx", reflect.TypeOf((*MockStorage)(nil).GetScheduleNutritionTx), arg0, arg1)
+}
+
+// GetScheduleRecipe mocks base method.
+func (m *MockStorage) GetScheduleRecipe(arg0 context.Context, arg1 int64) ([]db.GetScheduleRecipeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleRecipe", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetScheduleRecipeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleRecipe indicates an expected call of GetScheduleRecipe.
+func (mr *MockStorageMockRecorder) GetScheduleRecipe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleRecipe", reflect.TypeOf((*MockStorage)(nil).GetScheduleRecipe), arg0, arg1)
+}
+
+// GetScheduleTemplate mocks base method.
+func (m *MockStorage) GetScheduleTemplate(arg0 context.Context, arg1 int64) (db.ScheduleTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleTemplate indicates an expected call of GetScheduleTemplate.
+func (mr *MockStorageMockRecorder) GetScheduleTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleTemplate", reflect.TypeOf((*MockStorage)(nil).GetScheduleTemplate), arg0, arg1)
+}
+
+// GetUnit mocks base method.
+func (m *MockStorage) GetUnit(arg0 context.Context, arg1 int32) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnit indicates an expected call of GetUnit.
+func (mr *MockStorageMockRecorder) GetUnit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnit", reflect.TypeOf((*MockStorage)(nil).GetUnit), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStorage) GetUser(arg0 context.Context, arg1 uuid.UUID) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStorageMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStorage)(nil).GetUser), arg0, arg1)
+}
+
+// ImportNutritionTx mocks base method.
+func (m *MockStorage) ImportNutritionTx(arg0 context.Context, arg1 []db.UpsertNutritionParams) ([]db.Nutrition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportNutritionTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Nutrition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportNutritionTx indicates an expected call of ImportNutritionTx.
+func (mr *MockStorageMockRecorder) ImportNutritionTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportNutritionTx", reflect.TypeOf((*MockStorage)(nil).ImportNutritionTx), arg0, arg1)
+}
+
+// InsertRecipeStepTx mocks base method.
+func (m *MockStorage) InsertRecipeStepTx(arg0 context.Context, arg1 db.InsertRecipeStepParams) ([]db.RecipeStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRecipeStepTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipeStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRecipeStepTx indicates an expected call of InsertRecipeStepTx.
+func (mr *MockStorageMockRecorder) InsertRecipeStepTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRecipeStepTx", reflect.TypeOf((*MockStorage)(nil).InsertRecipeStepTx), arg0, arg1)
+}
+
+// ListAllIngredientAliases mocks base method.
+func (m *MockStorage) ListAllIngredientAliases(arg0 context.Context) ([]db.IngredientsAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllIngredientAliases", arg0)
+	ret0, _ := ret[0].([]db.IngredientsAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllIngredientAliases indicates an expected call of ListAllIngredientAliases.
+func (mr *MockStorageMockRecorder) ListAllIngredientAliases(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllIngredientAliases", reflect.TypeOf((*MockStorage)(nil).ListAllIngredientAliases), arg0)
+}
+
+// ListCalendarEntries mocks base method.
+func (m *MockStorage) ListCalendarEntries(arg0 context.Context, arg1 db.ListCalendarEntriesParams) ([]db.ListCalendarEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCalendarEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCalendarEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCalendarEntries indicates an expected call of ListCalendarEntries.
+func (mr *MockStorageMockRecorder) ListCalendarEntries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCalendarEntries", reflect.TypeOf((*MockStorage)(nil).ListCalendarEntries), arg0, arg1)
+}
+
+// ListCalendarEntryIngredients mocks base method.
+func (m *MockStorage) ListCalendarEntryIngredients(arg0 context.Context, arg1 db.ListCalendarEntryIngredientsParams) ([]db.ListCalendarEntryIngredientsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCalendarEntryIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCalendarEntryIngredientsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCalendarEntryIngredients indicates an expected call of ListCalendarEntryIngredients.
+func (mr *MockStorageMockRecorder) ListCalendarEntryIngredients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCalendarEntryIngredients", reflect.TypeOf((*MockStorage)(nil).ListCalendarEntryIngredients), arg0, arg1)
+}
+
+// ListCollectionRecipes mocks base method.
+func (m *MockStorage) ListCollectionRecipes(arg0 context.Context, arg1 int64) ([]db.ListCollectionRecipesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionRecipes", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCollectionRecipesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionRecipes indicates an expected call of ListCollectionRecipes.
+func (mr *MockStorageMockRecorder) ListCollectionRecipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionRecipes", reflect.TypeOf((*MockStorage)(nil).ListCollectionRecipes), arg0, arg1)
+}
+
+// ListCollectionsUser mocks base method.
+func (m *MockStorage) ListCollectionsUser(arg0 context.Context, arg1 uuid.UUID) ([]db.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionsUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionsUser indicates an expected call of ListCollectionsUser.
+func (mr *MockStorageMockRecorder) ListCollectionsUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionsUser", reflect.TypeOf((*MockStorage)(nil).ListCollectionsUser), arg0, arg1)
+}
+
+// ListCourses mocks base method.
+func (m *MockStorage) ListCourses(arg0 context.Context) ([]db.ListCoursesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCourses", arg0)
+	ret0, _ := ret[0].([]db.ListCoursesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCourses indicates an expected call of ListCourses.
+func (mr *MockStorageMockRecorder) ListCourses(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourses", reflect.TypeOf((*MockStorage)(nil).ListCourses), arg0)
+}
+
+// ListCuisines mocks base method.
+func (m *MockStorage) ListCuisines(arg0 context.Context) ([]db.ListCuisinesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCuisines", arg0)
+	ret0, _ := ret[0].([]db.ListCuisinesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCuisines indicates an expected call of ListCuisines.
+func (mr *MockStorageMockRecorder) ListCuisines(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCuisines", reflect.TypeOf((*MockStorage)(nil).ListCuisines), arg0)
+}
+
+// ListDietaryTags mocks base method.
+func (m *MockStorage) ListDietaryTags(arg0 context.Context) ([]db.DietaryTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDietaryTags", arg0)
+	ret0, _ := ret[0].([]db.DietaryTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDietaryTags indicates an expected call of ListDietaryTags.
+func (mr *MockStorageMockRecorder) ListDietaryTags(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDietaryTags", reflect.TypeOf((*MockStorage)(nil).ListDietaryTags), arg0)
+}
+
+// ListExpiringPantryItems mocks base method.
+func (m *MockStorage) ListExpiringPantryItems(arg0 context.Context, arg1 time.Time) ([]db.ListExpiringPantryItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiringPantryItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExpiringPantryItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringPantryItems indicates an expected call of ListExpiringPantryItems.
+func (mr *MockStorageMockRecorder) ListExpiringPantryItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringPantryItems", reflect.TypeOf((*MockStorage)(nil).ListExpiringPantryItems), arg0, arg1)
+}
+
+// ListFavorites mocks base method.
+func (m *MockStorage) ListFavorites(arg0 context.Context, arg1 db.ListFavoritesParams) ([]db.ListFavoritesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavorites", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFavoritesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFavorites indicates an expected call of ListFavorites.
+func (mr *MockStorageMockRecorder) ListFavorites(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavorites", reflect.TypeOf((*MockStorage)(nil).ListFavorites), arg0, arg1)
+}
+
+// ListGroceries mocks base method.
+func (m *MockStorage) ListGroceries(arg0 context.Context, arg1 int64) ([]db.ListGroceriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroceries", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGroceriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroceries indicates an expected call of ListGroceries.
+func (mr *MockStorageMockRecorder) ListGroceries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroceries", reflect.TypeOf((*MockStorage)(nil).ListGroceries), arg0, arg1)
+}
+
+// ListGroceryAmounts mocks base method.
+func (m *MockStorage) ListGroceryAmounts(arg0 context.Context, arg1 db.ListGroceryAmountsParams) ([]db.ListGroceryAmountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroceryAmounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGroceryAmountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroceryAmounts indicates an expected call of ListGroceryAmounts.
+func (mr *MockStorageMockRecorder) ListGroceryAmounts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroceryAmounts", reflect.TypeOf((*MockStorage)(nil).ListGroceryAmounts), arg0, arg1)
+}
+
+// ListGroceryCategories mocks base method.
+func (m *MockStorage) ListGroceryCategories(arg0 context.Context) ([]db.GroceryCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroceryCategories", arg0)
+	ret0, _ := ret[0].([]db.GroceryCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroceryCategories indicates an expected call of ListGroceryCategories.
+func (mr *MockStorageMockRecorder) ListGroceryCategories(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroceryCategories", reflect.TypeOf((*MockStorage)(nil).ListGroceryCategories), arg0)
+}
+
+// ListGroceryPackages mocks base method.
+func (m *MockStorage) ListGroceryPackages(arg0 context.Context, arg1 db.ListGroceryPackagesParams) ([]db.ListGroceryPackagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroceryPackages", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGroceryPackagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroceryPackages indicates an expected call of ListGroceryPackages.
+func (mr *MockStorageMockRecorder) ListGroceryPackages(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroceryPackages", reflect.TypeOf((*MockStorage)(nil).ListGroceryPackages), arg0, arg1)
+}
+
+// ListIngredientAliases mocks base method.
+func (m *MockStorage) ListIngredientAliases(arg0 context.Context, arg1 int32) ([]db.IngredientsAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientAliases", arg0, arg1)
+	ret0, _ := ret[0].([]db.IngredientsAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientAliases indicates an expected call of ListIngredientAliases.
+func (mr *MockStorageMockRecorder) ListIngredientAliases(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientAliases", reflect.TypeOf((*MockStorage)(nil).ListIngredientAliases), arg0, arg1)
+}
+
+// ListIngredientCategories mocks base method.
+func (m *MockStorage) ListIngredientCategories(arg0 context.Context, arg1 []int32) ([]db.IngredientsCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.IngredientsCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientCategories indicates an expected call of ListIngredientCategories.
+func (mr *MockStorageMockRecorder) ListIngredientCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientCategories", reflect.TypeOf((*MockStorage)(nil).ListIngredientCategories), arg0, arg1)
+}
+
+// ListIngredientPackages mocks base method.
+func (m *MockStorage) ListIngredientPackages(arg0 context.Context, arg1 int32) ([]db.ListIngredientPackagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientPackages", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListIngredientPackagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientPackages indicates an expected call of ListIngredientPackages.
+func (mr *MockStorageMockRecorder) ListIngredientPackages(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientPackages", reflect.TypeOf((*MockStorage)(nil).ListIngredientPackages), arg0, arg1)
+}
+
+// ListIngredientPrices mocks base method.
+func (m *MockStorage) ListIngredientPrices(arg0 context.Context, arg1 db.ListIngredientPricesParams) ([]db.ListIngredientPricesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientPrices", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListIngredientPricesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientPrices indicates an expected call of ListIngredientPrices.
+func (mr *MockStorageMockRecorder) ListIngredientPrices(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientPrices", reflect.TypeOf((*MockStorage)(nil).ListIngredientPrices), arg0, arg1)
+}
+
+// ListIngredientShelfLives mocks base method.
+func (m *MockStorage) ListIngredientShelfLives(arg0 context.Context, arg1 []int32) ([]db.IngredientsShelfLife, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientShelfLives", arg0, arg1)
+	ret0, _ := ret[0].([]db.IngredientsShelfLife)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientShelfLives indicates an expected call of ListIngredientShelfLives.
+func (mr *MockStorageMockRecorder) ListIngredientShelfLives(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientShelfLives", reflect.TypeOf((*MockStorage)(nil).ListIngredientShelfLives), arg0, arg1)
+}
+
+// ListIngredientTags mocks base method.
+func (m *MockStorage) ListIngredientTags(arg0 context.Context, arg1 int32) ([]db.ListIngredientTagsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListIngredientTagsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientTags indicates an expected call of ListIngredientTags.
+func (mr *MockStorageMockRecorder) ListIngredientTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientTags", reflect.TypeOf((*MockStorage)(nil).ListIngredientTags), arg0, arg1)
+}
+
+// ListIngredientUnits mocks base method.
+func (m *MockStorage) ListIngredientUnits(arg0 context.Context, arg1 int32) ([]db.IngredientsUnit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientUnits", arg0, arg1)
+	ret0, _ := ret[0].([]db.IngredientsUnit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientUnits indicates an expected call of ListIngredientUnits.
+func (mr *MockStorageMockRecorder) ListIngredientUnits(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientUnits", reflect.TypeOf((*MockStorage)(nil).ListIngredientUnits), arg0, arg1)
+}
+
+// ListIngredients mocks base method.
+func (m *MockStorage) ListIngredients(arg0 context.Context) ([]db.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredients", arg0)
+	ret0, _ := ret[0].([]db.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredients indicates an expected call of ListIngredients.
+func (mr *MockStorageMockRecorder) ListIngredients(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockStorage)(nil).ListIngredients), arg0)
+}
+
+// ListKeywords mocks base method.
+func (m *MockStorage) ListKeywords(arg0 context.Context, arg1 db.ListKeywordsParams) ([]db.ListKeywordsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeywords", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListKeywordsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeywords indicates an expected call of ListKeywords.
+func (mr *MockStorageMockRecorder) ListKeywords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeywords", reflect.TypeOf((*MockStorage)(nil).ListKeywords), arg0, arg1)
+}
+
+// ListPantryItems mocks base method.
+func (m *MockStorage) ListPantryItems(arg0 context.Context, arg1 uuid.UUID) ([]db.ListPantryItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPantryItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPantryItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPantryItems indicates an expected call of ListPantryItems.
+func (mr *MockStorageMockRecorder) ListPantryItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPantryItems", reflect.TypeOf((*MockStorage)(nil).ListPantryItems), arg0, arg1)
+}
+
+// ListPantryRecipes mocks base method.
+func (m *MockStorage) ListPantryRecipes(arg0 context.Context, arg1 uuid.UUID) ([]db.ListPantryRecipesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPantryRecipes", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPantryRecipesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPantryRecipes indicates an expected call of ListPantryRecipes.
+func (mr *MockStorageMockRecorder) ListPantryRecipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPantryRecipes", reflect.TypeOf((*MockStorage)(nil).ListPantryRecipes), arg0, arg1)
+}
+
+// ListPlanCandidates mocks base method.
+func (m *MockStorage) ListPlanCandidates(arg0 context.Context, arg1 db.ListPlanCandidatesParams) ([]db.ListPlanCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlanCandidates", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPlanCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlanCandidates indicates an expected call of ListPlanCandidates.
+func (mr *MockStorageMockRecorder) ListPlanCandidates(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlanCandidates", reflect.TypeOf((*MockStorage)(nil).ListPlanCandidates), arg0, arg1)
+}
+
+// ListRecipeConflicts mocks base method.
+func (m *MockStorage) ListRecipeConflicts(arg0 context.Context, arg1 db.ListRecipeConflictsParams) ([]db.ListRecipeConflictsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeConflicts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeConflictsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeConflicts indicates an expected call of ListRecipeConflicts.
+func (mr *MockStorageMockRecorder) ListRecipeConflicts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeConflicts", reflect.TypeOf((*MockStorage)(nil).ListRecipeConflicts), arg0, arg1)
+}
+
+// ListRecipeForks mocks base method.
+func (m *MockStorage) ListRecipeForks(arg0 context.Context, arg1 sql.NullInt64) ([]db.ListRecipeForksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeForks", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeForksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeForks indicates an expected call of ListRecipeForks.
+func (mr *MockStorageMockRecorder) ListRecipeForks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeForks", reflect.TypeOf((*MockStorage)(nil).ListRecipeForks), arg0, arg1)
+}
+
+// ListRecipeImages mocks base method.
+func (m *MockStorage) ListRecipeImages(arg0 context.Context, arg1 int64) ([]db.RecipesImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeImages", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipesImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeImages indicates an expected call of ListRecipeImages.
+func (mr *MockStorageMockRecorder) ListRecipeImages(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeImages", reflect.TypeOf((*MockStorage)(nil).ListRecipeImages), arg0, arg1)
+}
+
+// ListRecipeIngredientAmounts mocks base method.
+func (m *MockStorage) ListRecipeIngredientAmounts(arg0 context.Context, arg1 int64) ([]db.ListRecipeIngredientAmountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeIngredientAmounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeIngredientAmountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeIngredientAmounts indicates an expected call of ListRecipeIngredientAmounts.
+func (mr *MockStorageMockRecorder) ListRecipeIngredientAmounts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeIngredientAmounts", reflect.TypeOf((*MockStorage)(nil).ListRecipeIngredientAmounts), arg0, arg1)
+}
+
+// ListRecipeKeywords mocks base method.
+func (m *MockStorage) ListRecipeKeywords(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeKeywords", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeKeywords indicates an expected call of ListRecipeKeywords.
+func (mr *MockStorageMockRecorder) ListRecipeKeywords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeKeywords", reflect.TypeOf((*MockStorage)(nil).ListRecipeKeywords), arg0, arg1)
+}
+
+// ListRecipeNutrition mocks base method.
+func (m *MockStorage) ListRecipeNutrition(arg0 context.Context, arg1 int64) ([]db.ListRecipeNutritionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeNutrition", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeNutritionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeNutrition indicates an expected call of ListRecipeNutrition.
+func (mr *MockStorageMockRecorder) ListRecipeNutrition(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogin", reflect.TypeOf((*MockStorage)(nil).GetLogin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeNutrition", reflect.TypeOf((*MockStorage)(nil).ListRecipeNutrition), arg0, arg1)
+}
+
+// ListRecipePrices mocks base method.
+func (m *MockStorage) ListRecipePrices(arg0 context.Context, arg1 int64) ([]db.ListRecipePricesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipePrices", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipePricesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipePrices indicates an expected call of ListRecipePrices.
+func (mr *MockStorageMockRecorder) ListRecipePrices(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipePrices", reflect.TypeOf((*MockStorage)(nil).ListRecipePrices), arg0, arg1)
+}
+
+// ListRecipeReviews mocks base method.
+func (m *MockStorage) ListRecipeReviews(arg0 context.Context, arg1 db.ListRecipeReviewsParams) ([]db.ListRecipeReviewsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeReviews", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeReviewsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeReviews indicates an expected call of ListRecipeReviews.
+func (mr *MockStorageMockRecorder) ListRecipeReviews(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeReviews", reflect.TypeOf((*MockStorage)(nil).ListRecipeReviews), arg0, arg1)
+}
+
+// ListRecipeRevisions mocks base method.
+func (m *MockStorage) ListRecipeRevisions(arg0 context.Context, arg1 int64) ([]db.RecipesRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipesRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeRevisions indicates an expected call of ListRecipeRevisions.
+func (mr *MockStorageMockRecorder) ListRecipeRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeRevisions", reflect.TypeOf((*MockStorage)(nil).ListRecipeRevisions), arg0, arg1)
+}
+
+// ListRecipeSteps mocks base method.
+func (m *MockStorage) ListRecipeSteps(arg0 context.Context, arg1 int64) ([]db.RecipesStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeSteps", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipesStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeSteps indicates an expected call of ListRecipeSteps.
+func (mr *MockStorageMockRecorder) ListRecipeSteps(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeSteps", reflect.TypeOf((*MockStorage)(nil).ListRecipeSteps), arg0, arg1)
+}
+
+// ListRecipeStepsIngredients mocks base method.
+func (m *MockStorage) ListRecipeStepsIngredients(arg0 context.Context, arg1 int64) ([]db.ListRecipeStepsIngredientsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeStepsIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeStepsIngredientsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeStepsIngredients indicates an expected call of ListRecipeStepsIngredients.
+func (mr *MockStorageMockRecorder) ListRecipeStepsIngredients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeStepsIngredients", reflect.TypeOf((*MockStorage)(nil).ListRecipeStepsIngredients), arg0, arg1)
+}
+
+// ListRecipeStepsTx mocks base method.
+func (m *MockStorage) ListRecipeStepsTx(arg0 context.Context, arg1 int64) ([]db.RecipeStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeStepsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipeStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeStepsTx indicates an expected call of ListRecipeStepsTx.
+func (mr *MockStorageMockRecorder) ListRecipeStepsTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeStepsTx", reflect.TypeOf((*MockStorage)(nil).ListRecipeStepsTx), arg0, arg1)
+}
+
+// ListRecipeTags mocks base method.
+func (m *MockStorage) ListRecipeTags(arg0 context.Context, arg1 int64) ([]db.ListRecipeTagsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRecipeTagsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeTags indicates an expected call of ListRecipeTags.
+func (mr *MockStorageMockRecorder) ListRecipeTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeTags", reflect.TypeOf((*MockStorage)(nil).ListRecipeTags), arg0, arg1)
+}
+
+// ListRecipes mocks base method.
+func (m *MockStorage) ListRecipes(arg0 context.Context, arg1 db.ListRecipesParams) ([]db.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipes", arg0, arg1)
+	ret0, _ := ret[0].([]db.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipes indicates an expected call of ListRecipes.
+func (mr *MockStorageMockRecorder) ListRecipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipes", reflect.TypeOf((*MockStorage)(nil).ListRecipes), arg0, arg1)
+}
+
+// ListRecipesAllowed mocks base method.
+func (m *MockStorage) ListRecipesAllowed(arg0 context.Context, arg1 db.ListRecipesAllowedParams) ([]db.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipesAllowed", arg0, arg1)
+	ret0, _ := ret[0].([]db.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipesAllowed indicates an expected call of ListRecipesAllowed.
+func (mr *MockStorageMockRecorder) ListRecipesAllowed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipesAllowed", reflect.TypeOf((*MockStorage)(nil).ListRecipesAllowed), arg0, arg1)
+}
+
+// ListRecipesUser mocks base method.
+func (m *MockStorage) ListRecipesUser(arg0 context.Context, arg1 db.ListRecipesUserParams) ([]db.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipesUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipesUser indicates an expected call of ListRecipesUser.
+func (mr *MockStorageMockRecorder) ListRecipesUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipesUser", reflect.TypeOf((*MockStorage)(nil).ListRecipesUser), arg0, arg1)
+}
+
+// ListScheduleLeftoversTx mocks base method.
+func (m *MockStorage) ListScheduleLeftoversTx(arg0 context.Context, arg1 db.ScheduleLeftoversParams) ([]db.ScheduleLeftover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduleLeftoversTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScheduleLeftover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduleLeftoversTx indicates an expected call of ListScheduleLeftoversTx.
+func (mr *MockStorageMockRecorder) ListScheduleLeftoversTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduleLeftoversTx", reflect.TypeOf((*MockStorage)(nil).ListScheduleLeftoversTx), arg0, arg1)
+}
+
+// ListScheduleNutrition mocks base method.
+func (m *MockStorage) ListScheduleNutrition(arg0 context.Context, arg1 int64) ([]db.ListScheduleNutritionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduleNutrition", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListScheduleNutritionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduleNutrition indicates an expected call of ListScheduleNutrition.
+func (mr *MockStorageMockRecorder) ListScheduleNutrition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduleNutrition", reflect.TypeOf((*MockStorage)(nil).ListScheduleNutrition), arg0, arg1)
+}
+
+// ListScheduleTemplateRecipes mocks base method.
+func (m *MockStorage) ListScheduleTemplateRecipes(arg0 context.Context, arg1 []int64) ([]db.ListScheduleTemplateRecipesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduleTemplateRecipes", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListScheduleTemplateRecipesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduleTemplateRecipes indicates an expected call of ListScheduleTemplateRecipes.
+func (mr *MockStorageMockRecorder) ListScheduleTemplateRecipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduleTemplateRecipes", reflect.TypeOf((*MockStorage)(nil).ListScheduleTemplateRecipes), arg0, arg1)
+}
+
+// ListScheduleTemplates mocks base method.
+func (m *MockStorage) ListScheduleTemplates(arg0 context.Context, arg1 uuid.UUID) ([]db.ScheduleTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduleTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScheduleTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduleTemplates indicates an expected call of ListScheduleTemplates.
+func (mr *MockStorageMockRecorder) ListScheduleTemplates(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduleTemplates", reflect.TypeOf((*MockStorage)(nil).ListScheduleTemplates), arg0, arg1)
+}
+
+// ListScheduleTemplatesTx mocks base method.
+func (m *MockStorage) ListScheduleTemplatesTx(arg0 context.Context, arg1 uuid.UUID) ([]db.ScheduleTemplateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduleTemplatesTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScheduleTemplateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduleTemplatesTx indicates an expected call of ListScheduleTemplatesTx.
+func (mr *MockStorageMockRecorder) ListScheduleTemplatesTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduleTemplatesTx", reflect.TypeOf((*MockStorage)(nil).ListScheduleTemplatesTx), arg0, arg1)
+}
+
+// ListSchedules mocks base method.
+func (m *MockStorage) ListSchedules(arg0 context.Context, arg1 db.ListSchedulesParams) ([]db.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", arg0, arg1)
+	ret0, _ := ret[0].([]db.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockStorageMockRecorder) ListSchedules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockStorage)(nil).ListSchedules), arg0, arg1)
+}
+
+// ListSchedulesUser mocks base method.
+func (m *MockStorage) ListSchedulesUser(arg0 context.Context, arg1 db.ListSchedulesUserParams) ([]db.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedulesUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedulesUser indicates an expected call of ListSchedulesUser.
+func (mr *MockStorageMockRecorder) ListSchedulesUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedulesUser", reflect.TypeOf((*MockStorage)(nil).ListSchedulesUser), arg0, arg1)
+}
+
+// ListUnits mocks base method.
+func (m *MockStorage) ListUnits(arg0 context.Context) ([]db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnits", arg0)
+	ret0, _ := ret[0].([]db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnits indicates an expected call of ListUnits.
+func (mr *MockStorageMockRecorder) ListUnits(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnits", reflect.TypeOf((*MockStorage)(nil).ListUnits), arg0)
+}
+
+// ListUserCooked mocks base method.
+func (m *MockStorage) ListUserCooked(arg0 context.Context, arg1 db.ListUserCookedParams) ([]db.ListUserCookedRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCooked", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserCookedRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCooked indicates an expected call of ListUserCooked.
+func (mr *MockStorageMockRecorder) ListUserCooked(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCooked", reflect.TypeOf((*MockStorage)(nil).ListUserCooked), arg0, arg1)
+}
+
+// ListUserRestrictions mocks base method.
+func (m *MockStorage) ListUserRestrictions(arg0 context.Context, arg1 uuid.UUID) ([]db.ListUserRestrictionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRestrictions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserRestrictionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRestrictions indicates an expected call of ListUserRestrictions.
+func (mr *MockStorageMockRecorder) ListUserRestrictions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRestrictions", reflect.TypeOf((*MockStorage)(nil).ListUserRestrictions), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockStorage) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStorageMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStorage)(nil).ListUsers), arg0, arg1)
+}
+
+// MarkPantryItemsAlerted mocks base method.
+func (m *MockStorage) MarkPantryItemsAlerted(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPantryItemsAlerted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPantryItemsAlerted indicates an expected call of MarkPantryItemsAlerted.
+func (mr *MockStorageMockRecorder) MarkPantryItemsAlerted(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPantryItemsAlerted", reflect.TypeOf((*MockStorage)(nil).MarkPantryItemsAlerted), arg0, arg1)
+}
+
+// MergeGroceriesTx mocks base method.
+func (m *MockStorage) MergeGroceriesTx(arg0 context.Context, arg1 db.MergeGroceriesParams) (db.MergedGroceriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGroceriesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MergedGroceriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeGroceriesTx indicates an expected call of MergeGroceriesTx.
+func (mr *MockStorageMockRecorder) MergeGroceriesTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGroceriesTx", reflect.TypeOf((*MockStorage)(nil).MergeGroceriesTx), arg0, arg1)
+}
+
+// NewRecipeTx mocks base method.
+func (m *MockStorage) NewRecipeTx(arg0 context.Context, arg1 db.NewRecipeParams) (db.RecipeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRecipeTx indicates an expected call of NewRecipeTx.
+func (mr *MockStorageMockRecorder) NewRecipeTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRecipeTx", reflect.TypeOf((*MockStorage)(nil).NewRecipeTx), arg0, arg1)
+}
+
+// NewScheduleTemplateTx mocks base method.
+func (m *MockStorage) NewScheduleTemplateTx(arg0 context.Context, arg1 db.NewScheduleTemplateParams) (db.ScheduleTemplateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewScheduleTemplateTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleTemplateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewScheduleTemplateTx indicates an expected call of NewScheduleTemplateTx.
+func (mr *MockStorageMockRecorder) NewScheduleTemplateTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewScheduleTemplateTx", reflect.TypeOf((*MockStorage)(nil).NewScheduleTemplateTx), arg0, arg1)
 }
 
-// GetPermission mocks base method.
-func (m *MockStorage) GetPermission(arg0 context.Context, arg1 uuid.UUID) (db.GetPermissionRow, error) {
+// RemoveCollectionRecipeTx mocks base method.
+func (m *MockStorage) RemoveCollectionRecipeTx(arg0 context.Context, arg1 db.RemoveCollectionRecipeParams) ([]db.ListCollectionRecipesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPermission", arg0, arg1)
-	ret0, _ := ret[0].(db.GetPermissionRow)
+	ret := m.ctrl.Call(m, "RemoveCollectionRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCollectionRecipesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPermission indicates an expected call of GetPermission.
-func (mr *MockStorageMockRecorder) GetPermission(arg0, arg1 any) *gomock.Call {
+// RemoveCollectionRecipeTx indicates an expected call of RemoveCollectionRecipeTx.
+func (mr *MockStorageMockRecorder) RemoveCollectionRecipeTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermission", reflect.TypeOf((*MockStorage)(nil).GetPermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollectionRecipeTx", reflect.TypeOf((*MockStorage)(nil).RemoveCollectionRecipeTx), arg0, arg1)
 }
 
-// GetRecipe mocks base method.
-func (m *MockStorage) GetRecipe(arg0 context.Context, arg1 int64) (db.Recipe, error) {
+// ReorderCollectionTx mocks base method.
+func (m *MockStorage) ReorderCollectionTx(arg0 context.Context, arg1 db.ReorderCollectionParams) ([]db.ListCollectionRecipesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecipe", arg0, arg1)
-	ret0, _ := ret[0].(db.Recipe)
+	ret := m.ctrl.Call(m, "ReorderCollectionTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCollectionRecipesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecipe indicates an expected call of GetRecipe.
-func (mr *MockStorageMockRecorder) GetRecipe(arg0, arg1 any) *gomock.Call {
+// ReorderCollectionTx indicates an expected call of ReorderCollectionTx.
+func (mr *MockStorageMockRecorder) ReorderCollectionTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipe", reflect.TypeOf((*MockStorage)(nil).GetRecipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCollectionTx", reflect.TypeOf((*MockStorage)(nil).ReorderCollectionTx), arg0, arg1)
 }
 
-// GetRecipeIngredients mocks base method.
-func (m *MockStorage) GetRecipeIngredients(arg0 context.Context, arg1 int64) ([]db.GetRecipeIngredientsRow, error) {
+// ReorderRecipeStepsTx mocks base method.
+func (m *MockStorage) ReorderRecipeStepsTx(arg0 context.Context, arg1 db.ReorderRecipeStepsParams) ([]db.RecipeStep, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecipeIngredients", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetRecipeIngredientsRow)
+	ret := m.ctrl.Call(m, "ReorderRecipeStepsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipeStep)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecipeIngredients indicates an expected call of GetRecipeIngredients.
-func (mr *MockStorageMockRecorder) GetRecipeIngredients(arg0, arg1 any) *gomock.Call {
+// ReorderRecipeStepsTx indicates an expected call of ReorderRecipeStepsTx.
+func (mr *MockStorageMockRecorder) ReorderRecipeStepsTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeIngredients", reflect.TypeOf((*MockStorage)(nil).GetRecipeIngredients), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRecipeStepsTx", reflect.TypeOf((*MockStorage)(nil).ReorderRecipeStepsTx), arg0, arg1)
 }
 
-// GetRecipeTx mocks base method.
-func (m *MockStorage) GetRecipeTx(arg0 context.Context, arg1 int64) (db.RecipeResult, error) {
+// ReplaceRecipeTx mocks base method.
+func (m *MockStorage) ReplaceRecipeTx(arg0 context.Context, arg1 db.ReplaceRecipeParams) (db.RecipeResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecipeTx", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReplaceRecipeTx", arg0, arg1)
 	ret0, _ := ret[0].(db.RecipeResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecipeTx indicates an expected call of GetRecipeTx.
-func (mr *MockStorageMockRecorder) GetRecipeTx(arg0, arg1 any) *gomock.Call {
+// ReplaceRecipeTx indicates an expected call of ReplaceRecipeTx.
+func (mr *MockStorageMockRecorder) ReplaceRecipeTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeTx", reflect.TypeOf((*MockStorage)(nil).GetRecipeTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecipeTx", reflect.TypeOf((*MockStorage)(nil).ReplaceRecipeTx), arg0, arg1)
 }
 
-// GetSchedule mocks base method.
-func (m *MockStorage) GetSchedule(arg0 context.Context, arg1 int64) (db.Schedule, error) {
+// RevertRecipeTx mocks base method.
+func (m *MockStorage) RevertRecipeTx(arg0 context.Context, arg1 db.RevertRecipeParams) (db.RecipeResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSchedule", arg0, arg1)
-	ret0, _ := ret[0].(db.Schedule)
+	ret := m.ctrl.Call(m, "RevertRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipeResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSchedule indicates an expected call of GetSchedule.
-func (mr *MockStorageMockRecorder) GetSchedule(arg0, arg1 any) *gomock.Call {
+// RevertRecipeTx indicates an expected call of RevertRecipeTx.
+func (mr *MockStorageMockRecorder) RevertRecipeTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockStorage)(nil).GetSchedule), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertRecipeTx", reflect.TypeOf((*MockStorage)(nil).RevertRecipeTx), arg0, arg1)
 }
 
-// GetScheduleRecipe mocks base method.
-func (m *MockStorage) GetScheduleRecipe(arg0 context.Context, arg1 int64) ([]db.GetScheduleRecipeRow, error) {
+// ScaleRecipeTx mocks base method.
+func (m *MockStorage) ScaleRecipeTx(arg0 context.Context, arg1 db.ScaleRecipeParams) (db.ScaledRecipeResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetScheduleRecipe", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetScheduleRecipeRow)
+	ret := m.ctrl.Call(m, "ScaleRecipeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScaledRecipeResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetScheduleRecipe indicates an expected call of GetScheduleRecipe.
-func (mr *MockStorageMockRecorder) GetScheduleRecipe(arg0, arg1 any) *gomock.Call {
+// ScaleRecipeTx indicates an expected call of ScaleRecipeTx.
+func (mr *MockStorageMockRecorder) ScaleRecipeTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleRecipe", reflect.TypeOf((*MockStorage)(nil).GetScheduleRecipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleRecipeTx", reflect.TypeOf((*MockStorage)(nil).ScaleRecipeTx), arg0, arg1)
 }
 
-// GetUnit mocks base method.
-func (m *MockStorage) GetUnit(arg0 context.Context, arg1 int32) (db.Unit, error) {
+// SearchIngredientName mocks base method.
+func (m *MockStorage) SearchIngredientName(arg0 context.Context, arg1 string) (db.Ingredient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnit", arg0, arg1)
-	ret0, _ := ret[0].(db.Unit)
+	ret := m.ctrl.Call(m, "SearchIngredientName", arg0, arg1)
+	ret0, _ := ret[0].(db.Ingredient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUnit indicates an expected call of GetUnit.
-func (mr *MockStorageMockRecorder) GetUnit(arg0, arg1 any) *gomock.Call {
+// SearchIngredientName indicates an expected call of SearchIngredientName.
+func (mr *MockStorageMockRecorder) SearchIngredientName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnit", reflect.TypeOf((*MockStorage)(nil).GetUnit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIngredientName", reflect.TypeOf((*MockStorage)(nil).SearchIngredientName), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStorage) GetUser(arg0 context.Context, arg1 uuid.UUID) (db.User, error) {
+// SearchIngredients mocks base method.
+func (m *MockStorage) SearchIngredients(arg0 context.Context, arg1 string) ([]db.SearchIngredientsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SearchIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchIngredientsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStorageMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+// SearchIngredients indicates an expected call of SearchIngredients.
+func (mr *MockStorageMockRecorder) SearchIngredients(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStorage)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIngredients", reflect.TypeOf((*MockStorage)(nil).SearchIngredients), arg0, arg1)
 }
 
-// ListGroceries mocks base method.
-func (m *MockStorage) ListGroceries(arg0 context.Context, arg1 int64) ([]db.ListGroceriesRow, error) {
+// SearchRecipe mocks base method.
+func (m *MockStorage) SearchRecipe(arg0 context.Context, arg1 db.SearchRecipeParams) ([]db.SearchRecipeRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGroceries", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListGroceriesRow)
+	ret := m.ctrl.Call(m, "SearchRecipe", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchRecipeRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGroceries indicates an expected call of ListGroceries.
-func (mr *MockStorageMockRecorder) ListGroceries(arg0, arg1 any) *gomock.Call {
+// SearchRecipe indicates an expected call of SearchRecipe.
+func (mr *MockStorageMockRecorder) SearchRecipe(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroceries", reflect.TypeOf((*MockStorage)(nil).ListGroceries), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipe", reflect.TypeOf((*MockStorage)(nil).SearchRecipe), arg0, arg1)
 }
 
-// ListIngredients mocks base method.
-func (m *MockStorage) ListIngredients(arg0 context.Context) ([]db.Ingredient, error) {
+// SearchRecipeAllowed mocks base method.
+func (m *MockStorage) SearchRecipeAllowed(arg0 context.Context, arg1 db.SearchRecipeAllowedParams) ([]db.SearchRecipeAllowedRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListIngredients", arg0)
-	ret0, _ := ret[0].([]db.Ingredient)
+	ret := m.ctrl.Call(m, "SearchRecipeAllowed", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchRecipeAllowedRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListIngredients indicates an expected call of ListIngredients.
-func (mr *MockStorageMockRecorder) ListIngredients(arg0 any) *gomock.Call {
+// SearchRecipeAllowed indicates an expected call of SearchRecipeAllowed.
+func (mr *MockStorageMockRecorder) SearchRecipeAllowed(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockStorage)(nil).ListIngredients), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipeAllowed", reflect.TypeOf((*MockStorage)(nil).SearchRecipeAllowed), arg0, arg1)
 }
 
-// ListRecipes mocks base method.
-func (m *MockStorage) ListRecipes(arg0 context.Context, arg1 db.ListRecipesParams) ([]db.Recipe, error) {
+// SetUserRestrictionsTx mocks base method.
+func (m *MockStorage) SetUserRestrictionsTx(arg0 context.Context, arg1 db.SetUserRestrictionsParams) ([]db.ListUserRestrictionsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRecipes", arg0, arg1)
-	ret0, _ := ret[0].([]db.Recipe)
+	ret := m.ctrl.Call(m, "SetUserRestrictionsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserRestrictionsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListRecipes indicates an expected call of ListRecipes.
-func (mr *MockStorageMockRecorder) ListRecipes(arg0, arg1 any) *gomock.Call {
+// SetUserRestrictionsTx indicates an expected call of SetUserRestrictionsTx.
+func (mr *MockStorageMockRecorder) SetUserRestrictionsTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipes", reflect.TypeOf((*MockStorage)(nil).ListRecipes), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRestrictionsTx", reflect.TypeOf((*MockStorage)(nil).SetUserRestrictionsTx), arg0, arg1)
 }
 
-// ListRecipesUser mocks base method.
-func (m *MockStorage) ListRecipesUser(arg0 context.Context, arg1 db.ListRecipesUserParams) ([]db.Recipe, error) {
+// ShiftCollectionRecipes mocks base method.
+func (m *MockStorage) ShiftCollectionRecipes(arg0 context.Context, arg1 db.ShiftCollectionRecipesParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRecipesUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.Recipe)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ShiftCollectionRecipes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListRecipesUser indicates an expected call of ListRecipesUser.
-func (mr *MockStorageMockRecorder) ListRecipesUser(arg0, arg1 any) *gomock.Call {
+// ShiftCollectionRecipes indicates an expected call of ShiftCollectionRecipes.
+func (mr *MockStorageMockRecorder) ShiftCollectionRecipes(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipesUser", reflect.TypeOf((*MockStorage)(nil).ListRecipesUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShiftCollectionRecipes", reflect.TypeOf((*MockStorage)(nil).ShiftCollectionRecipes), arg0, arg1)
 }
 
-// ListSchedules mocks base method.
-func (m *MockStorage) ListSchedules(arg0 context.Context, arg1 db.ListSchedulesParams) ([]db.Schedule, error) {
+// ShiftRecipeSteps mocks base method.
+func (m *MockStorage) ShiftRecipeSteps(arg0 context.Context, arg1 db.ShiftRecipeStepsParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSchedules", arg0, arg1)
-	ret0, _ := ret[0].([]db.Schedule)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ShiftRecipeSteps", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListSchedules indicates an expected call of ListSchedules.
-func (mr *MockStorageMockRecorder) ListSchedules(arg0, arg1 any) *gomock.Call {
+// ShiftRecipeSteps indicates an expected call of ShiftRecipeSteps.
+func (mr *MockStorageMockRecorder) ShiftRecipeSteps(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockStorage)(nil).ListSchedules), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShiftRecipeSteps", reflect.TypeOf((*MockStorage)(nil).ShiftRecipeSteps), arg0, arg1)
 }
 
-// ListSchedulesUser mocks base method.
-func (m *MockStorage) ListSchedulesUser(arg0 context.Context, arg1 db.ListSchedulesUserParams) ([]db.Schedule, error) {
+// SuggestMealPlanTx mocks base method.
+func (m *MockStorage) SuggestMealPlanTx(arg0 context.Context, arg1 db.SuggestMealPlanParams) (db.MealPlanSuggestion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSchedulesUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.Schedule)
+	ret := m.ctrl.Call(m, "SuggestMealPlanTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MealPlanSuggestion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSchedulesUser indicates an expected call of ListSchedulesUser.
-func (mr *MockStorageMockRecorder) ListSchedulesUser(arg0, arg1 any) *gomock.Call {
+// SuggestMealPlanTx indicates an expected call of SuggestMealPlanTx.
+func (mr *MockStorageMockRecorder) SuggestMealPlanTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedulesUser", reflect.TypeOf((*MockStorage)(nil).ListSchedulesUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestMealPlanTx", reflect.TypeOf((*MockStorage)(nil).SuggestMealPlanTx), arg0, arg1)
 }
 
-// ListUnits mocks base method.
-func (m *MockStorage) ListUnits(arg0 context.Context) ([]db.Unit, error) {
+// SuggestPantryRecipesTx mocks base method.
+func (m *MockStorage) SuggestPantryRecipesTx(arg0 context.Context, arg1 db.SuggestPantryRecipesParams) ([]db.PantrySuggestion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUnits", arg0)
-	ret0, _ := ret[0].([]db.Unit)
+	ret := m.ctrl.Call(m, "SuggestPantryRecipesTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.PantrySuggestion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUnits indicates an expected call of ListUnits.
-func (mr *MockStorageMockRecorder) ListUnits(arg0 any) *gomock.Call {
+// SuggestPantryRecipesTx indicates an expected call of SuggestPantryRecipesTx.
+func (mr *MockStorageMockRecorder) SuggestPantryRecipesTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnits", reflect.TypeOf((*MockStorage)(nil).ListUnits), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestPantryRecipesTx", reflect.TypeOf((*MockStorage)(nil).SuggestPantryRecipesTx), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockStorage) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+// SyncRecipeStepsText mocks base method.
+func (m *MockStorage) SyncRecipeStepsText(arg0 context.Context, arg1 int64) (db.Recipe, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "SyncRecipeStepsText", arg0, arg1)
+	ret0, _ := ret[0].(db.Recipe)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStorageMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
+// SyncRecipeStepsText indicates an expected call of SyncRecipeStepsText.
+func (mr *MockStorageMockRecorder) SyncRecipeStepsText(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStorage)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRecipeStepsText", reflect.TypeOf((*MockStorage)(nil).SyncRecipeStepsText), arg0, arg1)
 }
 
-// NewRecipeTx mocks base method.
-func (m *MockStorage) NewRecipeTx(arg0 context.Context, arg1 db.NewRecipeParams) (db.RecipeResult, error) {
+// UpdateCollectionName mocks base method.
+func (m *MockStorage) UpdateCollectionName(arg0 context.Context, arg1 db.UpdateCollectionNameParams) (db.Collection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewRecipeTx", arg0, arg1)
-	ret0, _ := ret[0].(db.RecipeResult)
+	ret := m.ctrl.Call(m, "UpdateCollectionName", arg0, arg1)
+	ret0, _ := ret[0].(db.Collection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// NewRecipeTx indicates an expected call of NewRecipeTx.
-func (mr *MockStorageMockRecorder) NewRecipeTx(arg0, arg1 any) *gomock.Call {
+// UpdateCollectionName indicates an expected call of UpdateCollectionName.
+func (mr *MockStorageMockRecorder) UpdateCollectionName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRecipeTx", reflect.TypeOf((*MockStorage)(nil).NewRecipeTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollectionName", reflect.TypeOf((*MockStorage)(nil).UpdateCollectionName), arg0, arg1)
 }
 
-// SearchIngredientName mocks base method.
-func (m *MockStorage) SearchIngredientName(arg0 context.Context, arg1 string) (db.Ingredient, error) {
+// UpdateCollectionRecipePosition mocks base method.
+func (m *MockStorage) UpdateCollectionRecipePosition(arg0 context.Context, arg1 db.UpdateCollectionRecipePositionParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchIngredientName", arg0, arg1)
-	ret0, _ := ret[0].(db.Ingredient)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateCollectionRecipePosition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SearchIngredientName indicates an expected call of SearchIngredientName.
-func (mr *MockStorageMockRecorder) SearchIngredientName(arg0, arg1 any) *gomock.Call {
+// UpdateCollectionRecipePosition indicates an expected call of UpdateCollectionRecipePosition.
+func (mr *MockStorageMockRecorder) UpdateCollectionRecipePosition(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIngredientName", reflect.TypeOf((*MockStorage)(nil).SearchIngredientName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollectionRecipePosition", reflect.TypeOf((*MockStorage)(nil).UpdateCollectionRecipePosition), arg0, arg1)
 }
 
-// SearchIngredients mocks base method.
-func (m *MockStorage) SearchIngredients(arg0 context.Context, arg1 string) ([]db.SearchIngredientsRow, error) {
+// UpdateCollectionShareToken mocks base method.
+func (m *MockStorage) UpdateCollectionShareToken(arg0 context.Context, arg1 db.UpdateCollectionShareTokenParams) (db.Collection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchIngredients", arg0, arg1)
-	ret0, _ := ret[0].([]db.SearchIngredientsRow)
+	ret := m.ctrl.Call(m, "UpdateCollectionShareToken", arg0, arg1)
+	ret0, _ := ret[0].(db.Collection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchIngredients indicates an expected call of SearchIngredients.
-func (mr *MockStorageMockRecorder) SearchIngredients(arg0, arg1 any) *gomock.Call {
+// UpdateCollectionShareToken indicates an expected call of UpdateCollectionShareToken.
+func (mr *MockStorageMockRecorder) UpdateCollectionShareToken(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIngredients", reflect.TypeOf((*MockStorage)(nil).SearchIngredients), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollectionShareToken", reflect.TypeOf((*MockStorage)(nil).UpdateCollectionShareToken), arg0, arg1)
 }
 
-// SearchRecipe mocks base method.
-func (m *MockStorage) SearchRecipe(arg0 context.Context, arg1 db.SearchRecipeParams) ([]db.SearchRecipeRow, error) {
+// UpdateExpiryAlert mocks base method.
+func (m *MockStorage) UpdateExpiryAlert(arg0 context.Context, arg1 db.UpdateExpiryAlertParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchRecipe", arg0, arg1)
-	ret0, _ := ret[0].([]db.SearchRecipeRow)
+	ret := m.ctrl.Call(m, "UpdateExpiryAlert", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchRecipe indicates an expected call of SearchRecipe.
-func (mr *MockStorageMockRecorder) SearchRecipe(arg0, arg1 any) *gomock.Call {
+// UpdateExpiryAlert indicates an expected call of UpdateExpiryAlert.
+func (mr *MockStorageMockRecorder) UpdateExpiryAlert(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipe", reflect.TypeOf((*MockStorage)(nil).SearchRecipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExpiryAlert", reflect.TypeOf((*MockStorage)(nil).UpdateExpiryAlert), arg0, arg1)
 }
 
 // UpdateIngredient mocks base method.
@@ -649,6 +2662,65 @@ func (mr *MockStorageMockRecorder) UpdateRecipeIngredient(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeIngredient", reflect.TypeOf((*MockStorage)(nil).UpdateRecipeIngredient), arg0, arg1)
 }
 
+// UpdateRecipeStep mocks base method.
+func (m *MockStorage) UpdateRecipeStep(arg0 context.Context, arg1 db.UpdateRecipeStepParams) (db.RecipesStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeStep", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecipeStep indicates an expected call of UpdateRecipeStep.
+func (mr *MockStorageMockRecorder) UpdateRecipeStep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeStep", reflect.TypeOf((*MockStorage)(nil).UpdateRecipeStep), arg0, arg1)
+}
+
+// UpdateRecipeStepPosition mocks base method.
+func (m *MockStorage) UpdateRecipeStepPosition(arg0 context.Context, arg1 db.UpdateRecipeStepPositionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeStepPosition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipeStepPosition indicates an expected call of UpdateRecipeStepPosition.
+func (mr *MockStorageMockRecorder) UpdateRecipeStepPosition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeStepPosition", reflect.TypeOf((*MockStorage)(nil).UpdateRecipeStepPosition), arg0, arg1)
+}
+
+// UpdateRecipeStepTx mocks base method.
+func (m *MockStorage) UpdateRecipeStepTx(arg0 context.Context, arg1 db.UpdateRecipeStepTxParams) ([]db.RecipeStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeStepTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecipeStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecipeStepTx indicates an expected call of UpdateRecipeStepTx.
+func (mr *MockStorageMockRecorder) UpdateRecipeStepTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeStepTx", reflect.TypeOf((*MockStorage)(nil).UpdateRecipeStepTx), arg0, arg1)
+}
+
+// UpdateRecipeTaxonomy mocks base method.
+func (m *MockStorage) UpdateRecipeTaxonomy(arg0 context.Context, arg1 db.UpdateRecipeTaxonomyParams) (db.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeTaxonomy", arg0, arg1)
+	ret0, _ := ret[0].(db.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecipeTaxonomy indicates an expected call of UpdateRecipeTaxonomy.
+func (mr *MockStorageMockRecorder) UpdateRecipeTaxonomy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeTaxonomy", reflect.TypeOf((*MockStorage)(nil).UpdateRecipeTaxonomy), arg0, arg1)
+}
+
 // UpdateRecipeTx mocks base method.
 func (m *MockStorage) UpdateRecipeTx(arg0 context.Context, arg1 db.TxUpdateRecipeParams) (db.RecipeResult, error) {
 	m.ctrl.T.Helper()
@@ -708,3 +2780,93 @@ func (mr *MockStorageMockRecorder) UpdateVerified(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerified", reflect.TypeOf((*MockStorage)(nil).UpdateVerified), arg0, arg1)
 }
+
+// UpsertCalendarFeed mocks base method.
+func (m *MockStorage) UpsertCalendarFeed(arg0 context.Context, arg1 db.UpsertCalendarFeedParams) (db.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCalendarFeed", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCalendarFeed indicates an expected call of UpsertCalendarFeed.
+func (mr *MockStorageMockRecorder) UpsertCalendarFeed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCalendarFeed", reflect.TypeOf((*MockStorage)(nil).UpsertCalendarFeed), arg0, arg1)
+}
+
+// UpsertIngredientCategory mocks base method.
+func (m *MockStorage) UpsertIngredientCategory(arg0 context.Context, arg1 db.UpsertIngredientCategoryParams) (db.IngredientsCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertIngredientCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertIngredientCategory indicates an expected call of UpsertIngredientCategory.
+func (mr *MockStorageMockRecorder) UpsertIngredientCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertIngredientCategory", reflect.TypeOf((*MockStorage)(nil).UpsertIngredientCategory), arg0, arg1)
+}
+
+// UpsertIngredientShelfLife mocks base method.
+func (m *MockStorage) UpsertIngredientShelfLife(arg0 context.Context, arg1 db.UpsertIngredientShelfLifeParams) (db.IngredientsShelfLife, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertIngredientShelfLife", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsShelfLife)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertIngredientShelfLife indicates an expected call of UpsertIngredientShelfLife.
+func (mr *MockStorageMockRecorder) UpsertIngredientShelfLife(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertIngredientShelfLife", reflect.TypeOf((*MockStorage)(nil).UpsertIngredientShelfLife), arg0, arg1)
+}
+
+// UpsertIngredientUnit mocks base method.
+func (m *MockStorage) UpsertIngredientUnit(arg0 context.Context, arg1 db.UpsertIngredientUnitParams) (db.IngredientsUnit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertIngredientUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientsUnit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertIngredientUnit indicates an expected call of UpsertIngredientUnit.
+func (mr *MockStorageMockRecorder) UpsertIngredientUnit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertIngredientUnit", reflect.TypeOf((*MockStorage)(nil).UpsertIngredientUnit), arg0, arg1)
+}
+
+// UpsertNutrition mocks base method.
+func (m *MockStorage) UpsertNutrition(arg0 context.Context, arg1 db.UpsertNutritionParams) (db.Nutrition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNutrition", arg0, arg1)
+	ret0, _ := ret[0].(db.Nutrition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNutrition indicates an expected call of UpsertNutrition.
+func (mr *MockStorageMockRecorder) UpsertNutrition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNutrition", reflect.TypeOf((*MockStorage)(nil).UpsertNutrition), arg0, arg1)
+}
+
+// UpsertRecipeReview mocks base method.
+func (m *MockStorage) UpsertRecipeReview(arg0 context.Context, arg1 db.UpsertRecipeReviewParams) (db.RecipesReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRecipeReview", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipesReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRecipeReview indicates an expected call of UpsertRecipeReview.
+func (mr *MockStorageMockRecorder) UpsertRecipeReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRecipeReview", reflect.TypeOf((*MockStorage)(nil).UpsertRecipeReview), arg0, arg1)
+}
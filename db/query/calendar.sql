@@ -0,0 +1,75 @@
+-- name: UpsertCalendarFeed :one
+INSERT INTO calendar_feeds (
+    user_id,
+    token
+) VALUES (
+    $1, $2
+) ON CONFLICT (user_id) DO UPDATE
+    set token = EXCLUDED.token,
+    created_at = (now() at time zone 'utc')
+RETURNING *;
+
+-- name: GetCalendarFeed :one
+SELECT * from calendar_feeds
+WHERE user_id = $1 LIMIT 1;
+
+-- name: GetCalendarFeedByToken :one
+SELECT * from calendar_feeds
+WHERE token = $1 LIMIT 1;
+
+-- name: DeleteCalendarFeed :exec
+DELETE FROM calendar_feeds
+WHERE user_id = $1;
+
+-- name: ListCalendarEntries :many
+SELECT sr.schedule_id, sr.recipe_id, COALESCE(rv.name, r.name)::varchar AS name,
+    sr.portion, sr.scheduled_date, r.total_time,
+    COALESCE((
+        SELECT SUM(st.duration_seconds) FROM recipes_steps AS st
+        WHERE st.recipe_id = sr.recipe_id
+    ), 0)::int AS steps_seconds,
+    s.created_at
+FROM schedules AS s
+INNER JOIN schedules_recipes AS sr
+ON s.id = sr.schedule_id
+INNER JOIN recipes AS r
+ON sr.recipe_id = r.id
+LEFT JOIN recipes_revisions AS rv
+ON sr.revision_id = rv.id
+WHERE s.author = sqlc.arg(author) AND sr.scheduled_date >= sqlc.arg(since)::date
+ORDER BY sr.scheduled_date, sr.schedule_id, name;
+
+-- name: ListCalendarEntryIngredients :many
+WITH entry_ingredients AS (
+    SELECT sr.schedule_id, sr.recipe_id, sr.scheduled_date,
+        ri.ingredient_id, ri.amount, ri.unit_id,
+        r.portion AS recipe_portion, sr.portion AS schedule_portion
+    FROM schedules AS s
+    INNER JOIN schedules_recipes AS sr
+    ON s.id = sr.schedule_id
+    INNER JOIN recipes AS r
+    ON sr.recipe_id = r.id
+    INNER JOIN recipes_ingredients AS ri
+    ON sr.recipe_id = ri.recipe_id
+    WHERE s.author = sqlc.arg(author) AND sr.scheduled_date >= sqlc.arg(since)::date
+        AND sr.revision_id IS NULL
+    UNION ALL
+    SELECT sr.schedule_id, sr.recipe_id, sr.scheduled_date,
+        (e->>'ingredientID')::int, (e->>'amount')::real, (e->>'unitID')::int,
+        rv.portion, sr.portion
+    FROM schedules AS s
+    INNER JOIN schedules_recipes AS sr
+    ON s.id = sr.schedule_id
+    INNER JOIN recipes_revisions AS rv
+    ON sr.revision_id = rv.id
+    CROSS JOIN LATERAL jsonb_array_elements(rv.ingredients) AS e
+    WHERE s.author = sqlc.arg(author) AND sr.scheduled_date >= sqlc.arg(since)::date
+)
+SELECT ei.schedule_id, ei.recipe_id, ei.scheduled_date, i.name, ei.amount,
+    u.name AS unit_name, ei.recipe_portion, ei.schedule_portion
+FROM entry_ingredients AS ei
+INNER JOIN ingredients AS i
+ON ei.ingredient_id = i.id
+LEFT JOIN units AS u
+ON ei.unit_id = u.id
+ORDER BY ei.schedule_id, ei.recipe_id, i.name;
@@ -0,0 +1,22 @@
+-- name: ListGroceryCategories :many
+SELECT * from grocery_categories
+ORDER BY position;
+
+-- name: UpsertIngredientCategory :one
+INSERT INTO ingredients_categories (
+    ingredient_id,
+    category
+) VALUES (
+    $1, $2
+) ON CONFLICT (ingredient_id) DO UPDATE
+    set category = EXCLUDED.category,
+    modified_at = (now() at time zone 'utc')
+RETURNING *;
+
+-- name: GetIngredientCategory :one
+SELECT * from ingredients_categories
+WHERE ingredient_id = $1;
+
+-- name: ListIngredientCategories :many
+SELECT * from ingredients_categories
+WHERE ingredient_id = ANY(sqlc.arg(ingredient_ids)::int[]);
@@ -0,0 +1,95 @@
+-- name: CreateFavorite :exec
+INSERT INTO users_favorites (
+    user_id,
+    recipe_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteFavorite :exec
+DELETE FROM users_favorites
+WHERE user_id = $1 AND recipe_id = $2;
+
+-- name: ListFavorites :many
+SELECT r.id, r.name, r.author, r.modified_at, f.created_at AS favorited_at
+FROM users_favorites AS f
+INNER JOIN recipes AS r
+ON f.recipe_id = r.id
+WHERE f.user_id = $1
+ORDER BY f.created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: CreateCollection :one
+INSERT INTO collections (
+    owner,
+    name
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetCollection :one
+SELECT * from collections
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCollectionByShareToken :one
+SELECT * from collections
+WHERE share_token = $1 LIMIT 1;
+
+-- name: ListCollectionsUser :many
+SELECT * from collections
+WHERE owner = $1
+ORDER BY name;
+
+-- name: UpdateCollectionName :one
+UPDATE collections
+    set name = $2,
+    modified_at = (now() at time zone 'utc')
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateCollectionShareToken :one
+UPDATE collections
+    set share_token = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCollection :exec
+DELETE FROM collections
+WHERE id = $1;
+
+-- name: CreateCollectionRecipe :one
+INSERT INTO collections_recipes (
+    collection_id,
+    recipe_id,
+    "position"
+)
+SELECT sqlc.arg(collection_id), sqlc.arg(recipe_id), COALESCE(max(cr.position), 0) + 1
+FROM collections_recipes AS cr
+WHERE cr.collection_id = sqlc.arg(collection_id)
+RETURNING *;
+
+-- name: DeleteCollectionRecipe :one
+DELETE FROM collections_recipes
+WHERE collection_id = $1 AND recipe_id = $2
+RETURNING *;
+
+-- name: ShiftCollectionRecipes :exec
+UPDATE collections_recipes
+    set position = position - 1
+WHERE collection_id = $1 AND position > $2;
+
+-- name: UpdateCollectionRecipePosition :exec
+UPDATE collections_recipes
+    set position = $3
+WHERE collection_id = $1 AND recipe_id = $2;
+
+-- name: ListCollectionRecipes :many
+SELECT cr.recipe_id, r.name, r.author, r.portion, cr.position
+FROM collections_recipes AS cr
+INNER JOIN recipes AS r
+ON cr.recipe_id = r.id
+WHERE cr.collection_id = $1
+ORDER BY cr.position;
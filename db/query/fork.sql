@@ -0,0 +1,41 @@
+-- name: ForkRecipe :one
+INSERT INTO recipes (
+    name,
+    author,
+    portion,
+    steps,
+    forked_from,
+    cuisine,
+    course,
+    difficulty,
+    total_time
+)
+SELECT r.name, sqlc.arg(author), r.portion, r.steps, r.id, r.cuisine, r.course, r.difficulty, r.total_time
+FROM recipes AS r
+WHERE r.id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CopyRecipeIngredients :exec
+INSERT INTO recipes_ingredients (
+    ingredient_id,
+    recipe_id,
+    amount,
+    unit_id
+)
+SELECT ri.ingredient_id, sqlc.arg(recipe_id), ri.amount, ri.unit_id
+FROM recipes_ingredients AS ri
+WHERE ri.recipe_id = sqlc.arg(source_id);
+
+-- name: ListRecipeForks :many
+SELECT id, name, author, modified_at from recipes
+WHERE forked_from = $1
+ORDER BY created_at;
+
+-- name: CopyRecipeKeywords :exec
+INSERT INTO recipes_keywords (
+    recipe_id,
+    keyword
+)
+SELECT sqlc.arg(recipe_id), rk.keyword
+FROM recipes_keywords AS rk
+WHERE rk.recipe_id = sqlc.arg(source_id);
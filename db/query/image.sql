@@ -0,0 +1,27 @@
+-- name: CreateRecipeImage :one
+INSERT INTO recipes_images (
+    recipe_id,
+    step_id,
+    blob_key,
+    thumbnail_key,
+    content_type,
+    size,
+    width,
+    height
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetRecipeImage :one
+SELECT * FROM recipes_images
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRecipeImages :many
+SELECT * FROM recipes_images
+WHERE recipe_id = $1
+ORDER BY id;
+
+-- name: DeleteRecipeImage :exec
+DELETE FROM recipes_images
+WHERE id = $1;
@@ -32,4 +32,25 @@ RETURNING *;
 
 -- name: DeleteIngredient :exec
 DELETE FROM ingredients
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: CreateIngredientAlias :one
+INSERT INTO ingredients_aliases (
+    ingredient_id, alias
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: ListIngredientAliases :many
+SELECT * from ingredients_aliases
+WHERE ingredient_id = $1
+ORDER BY alias;
+
+-- name: ListAllIngredientAliases :many
+SELECT * from ingredients_aliases
+ORDER BY alias;
+
+-- name: DeleteIngredientAlias :exec
+DELETE FROM ingredients_aliases
+WHERE alias = $1;
@@ -0,0 +1,74 @@
+-- name: UpsertNutrition :one
+INSERT INTO nutrition (
+    ingredient_id,
+    calories,
+    protein,
+    fat,
+    carbs,
+    fiber,
+    sodium
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (ingredient_id) DO UPDATE
+    set calories = EXCLUDED.calories,
+    protein = EXCLUDED.protein,
+    fat = EXCLUDED.fat,
+    carbs = EXCLUDED.carbs,
+    fiber = EXCLUDED.fiber,
+    sodium = EXCLUDED.sodium,
+    modified_at = (now() at time zone 'utc')
+RETURNING *;
+
+-- name: GetNutrition :one
+SELECT * from nutrition
+WHERE ingredient_id = $1;
+
+-- name: DeleteNutrition :exec
+DELETE FROM nutrition
+WHERE ingredient_id = $1;
+
+-- name: ListRecipeNutrition :many
+SELECT ri.ingredient_id, ri.amount, ri.unit_id,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams,
+    n.calories, n.protein, n.fat, n.carbs, n.fiber, n.sodium
+FROM recipes_ingredients AS ri
+LEFT JOIN units AS u
+ON ri.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON ri.ingredient_id = iu.ingredient_id AND ri.unit_id = iu.unit_id
+LEFT JOIN nutrition AS n
+ON ri.ingredient_id = n.ingredient_id
+WHERE ri.recipe_id = $1
+FOR SHARE OF ri;
+
+-- name: ListScheduleNutrition :many
+WITH schedule_ingredients AS (
+    SELECT sr.scheduled_date, sr.portion AS schedule_portion, r.portion AS recipe_portion,
+        ri.ingredient_id, ri.amount, ri.unit_id
+    FROM schedules_recipes AS sr
+    INNER JOIN recipes AS r
+    ON sr.recipe_id = r.id
+    INNER JOIN recipes_ingredients AS ri
+    ON sr.recipe_id = ri.recipe_id
+    WHERE sr.schedule_id = $1 AND sr.revision_id IS NULL
+    UNION ALL
+    SELECT sr.scheduled_date, sr.portion, rv.portion,
+        (e->>'ingredientID')::int, (e->>'amount')::real, (e->>'unitID')::int
+    FROM schedules_recipes AS sr
+    INNER JOIN recipes_revisions AS rv
+    ON sr.revision_id = rv.id
+    CROSS JOIN LATERAL jsonb_array_elements(rv.ingredients) AS e
+    WHERE sr.schedule_id = $1
+)
+SELECT si.scheduled_date, si.schedule_portion, si.recipe_portion,
+    si.ingredient_id, si.amount, si.unit_id,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams,
+    n.calories, n.protein, n.fat, n.carbs, n.fiber, n.sodium
+FROM schedule_ingredients AS si
+LEFT JOIN units AS u
+ON si.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON si.ingredient_id = iu.ingredient_id AND si.unit_id = iu.unit_id
+LEFT JOIN nutrition AS n
+ON si.ingredient_id = n.ingredient_id
+ORDER BY si.scheduled_date;
@@ -0,0 +1,39 @@
+-- name: CreateIngredientPackage :one
+INSERT INTO ingredients_packages (
+    ingredient_id,
+    amount,
+    unit_id,
+    store
+) VALUES (
+    sqlc.arg(ingredient_id), sqlc.arg(amount), sqlc.narg(unit_id), sqlc.narg(store)
+)
+RETURNING *;
+
+-- name: GetIngredientPackage :one
+SELECT * FROM ingredients_packages
+WHERE id = $1 LIMIT 1;
+
+-- name: DeleteIngredientPackage :exec
+DELETE FROM ingredients_packages
+WHERE id = $1;
+
+-- name: ListIngredientPackages :many
+SELECT ip.id, ip.ingredient_id, ip.amount, ip.unit_id, u.name AS unit_name,
+    ip.store, ip.created_at
+FROM ingredients_packages AS ip
+LEFT JOIN units AS u
+ON ip.unit_id = u.id
+WHERE ip.ingredient_id = $1
+ORDER BY ip.store NULLS FIRST, ip.amount, ip.id;
+
+-- name: ListGroceryPackages :many
+SELECT ip.id, ip.ingredient_id, ip.amount, ip.unit_id, u.name AS unit_name,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams, ip.store
+FROM ingredients_packages AS ip
+LEFT JOIN units AS u
+ON ip.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON ip.ingredient_id = iu.ingredient_id AND ip.unit_id = iu.unit_id
+WHERE ip.ingredient_id = ANY(sqlc.arg(ingredient_ids)::int[])
+    AND (ip.store IS NULL OR ip.store = sqlc.narg(store))
+ORDER BY ip.ingredient_id, ip.id;
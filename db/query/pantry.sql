@@ -0,0 +1,86 @@
+-- name: UpsertIngredientShelfLife :one
+INSERT INTO ingredients_shelf_lives (
+    ingredient_id,
+    days
+) VALUES (
+    $1, $2
+) ON CONFLICT (ingredient_id) DO UPDATE
+    set days = EXCLUDED.days,
+    modified_at = (now() at time zone 'utc')
+RETURNING *;
+
+-- name: GetIngredientShelfLife :one
+SELECT * from ingredients_shelf_lives
+WHERE ingredient_id = $1;
+
+-- name: ListIngredientShelfLives :many
+SELECT * from ingredients_shelf_lives
+WHERE ingredient_id = ANY(sqlc.arg(ingredient_ids)::int[]);
+
+-- name: CreatePantryItem :one
+INSERT INTO pantry_items (
+    user_id,
+    ingredient_id,
+    amount,
+    unit_id,
+    expires_on,
+    schedule_id
+) VALUES (
+    sqlc.arg(user_id), sqlc.arg(ingredient_id), sqlc.arg(amount),
+    sqlc.narg(unit_id), sqlc.narg(expires_on), sqlc.narg(schedule_id)
+)
+RETURNING *;
+
+-- name: GetPantryItem :one
+SELECT * from pantry_items
+WHERE id = $1 LIMIT 1;
+
+-- name: DeletePantryItem :exec
+DELETE FROM pantry_items
+WHERE id = $1;
+
+-- name: ListPantryItems :many
+SELECT p.id, p.ingredient_id, i.name, p.amount, p.unit_id, u.name AS unit_name,
+    p.expires_on, p.schedule_id, p.created_at
+FROM pantry_items AS p
+INNER JOIN ingredients AS i
+ON p.ingredient_id = i.id
+LEFT JOIN units AS u
+ON p.unit_id = u.id
+WHERE p.user_id = $1
+ORDER BY p.expires_on NULLS LAST, p.id;
+
+-- name: ListPantryRecipes :many
+SELECT r.id AS recipe_id, r.name AS recipe_name, p.ingredient_id, p.expires_on
+FROM pantry_items AS p
+INNER JOIN recipes_ingredients AS ri
+ON p.ingredient_id = ri.ingredient_id
+INNER JOIN recipes AS r
+ON ri.recipe_id = r.id
+WHERE p.user_id = $1
+    AND (p.expires_on IS NULL OR p.expires_on >= (now() at time zone 'utc')::date)
+ORDER BY r.id, p.ingredient_id, p.expires_on NULLS LAST;
+
+-- name: ListExpiringPantryItems :many
+SELECT p.id, p.user_id, us.username, us.email, p.ingredient_id, i.name,
+    p.amount, u.name AS unit_name, p.expires_on
+FROM pantry_items AS p
+INNER JOIN users AS us
+ON p.user_id = us.id
+INNER JOIN ingredients AS i
+ON p.ingredient_id = i.id
+LEFT JOIN units AS u
+ON p.unit_id = u.id
+WHERE us.expiry_alert_days IS NOT NULL
+    AND p.expires_on >= sqlc.arg(today)::date
+    AND p.expires_on <= sqlc.arg(today)::date + us.expiry_alert_days
+    AND NOT EXISTS (
+        SELECT 1 FROM pantry_items_alerts AS a
+        WHERE a.pantry_item_id = p.id
+    )
+ORDER BY p.user_id, p.expires_on, p.id;
+
+-- name: MarkPantryItemsAlerted :exec
+INSERT INTO pantry_items_alerts (pantry_item_id)
+SELECT unnest(sqlc.arg(ids)::bigint[])
+ON CONFLICT (pantry_item_id) DO NOTHING;
@@ -0,0 +1,30 @@
+-- name: ListPlanCandidates :many
+WITH candidates AS (
+    SELECT r.id, r.name, r.portion from recipes AS r
+    WHERE (sqlc.narg(user_id)::uuid IS NULL OR NOT EXISTS (
+        SELECT 1 from recipes_conflicts as rc
+        WHERE rc.recipe_id = r.id AND rc.user_id = sqlc.narg(user_id)
+    ))
+        AND (sqlc.narg(cuisine)::text IS NULL OR r.cuisine = sqlc.narg(cuisine))
+        AND (sqlc.narg(course)::text IS NULL OR r.course = sqlc.narg(course))
+    ORDER BY (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) DESC NULLS LAST, r.id
+    LIMIT sqlc.arg(pool_size)
+)
+SELECT c.id AS recipe_id, c.name AS recipe_name, c.portion AS recipe_portion,
+    ri.ingredient_id, ri.amount, u.name AS unit_name,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams,
+    p.price, p.unit_name AS price_unit_name,
+    p.unit_grams AS price_unit_grams, p.ingredient_unit_grams AS price_ingredient_unit_grams
+FROM candidates AS c
+INNER JOIN recipes_ingredients AS ri
+ON ri.recipe_id = c.id
+LEFT JOIN units AS u
+ON ri.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON ri.ingredient_id = iu.ingredient_id AND ri.unit_id = iu.unit_id
+LEFT JOIN ingredients_current_prices AS p
+ON ri.ingredient_id = p.ingredient_id
+ORDER BY c.id, ri.ingredient_id;
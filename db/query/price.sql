@@ -0,0 +1,46 @@
+-- name: CreateIngredientPrice :one
+INSERT INTO ingredients_prices (
+    ingredient_id,
+    unit_id,
+    price,
+    store,
+    observed_on
+) VALUES (
+    sqlc.arg(ingredient_id), sqlc.narg(unit_id), sqlc.arg(price), sqlc.narg(store),
+    COALESCE(sqlc.narg(observed_on)::date, (now() at time zone 'utc')::date)
+)
+RETURNING *;
+
+-- name: GetIngredientPrice :one
+SELECT * FROM ingredients_prices
+WHERE id = $1 LIMIT 1;
+
+-- name: DeleteIngredientPrice :exec
+DELETE FROM ingredients_prices
+WHERE id = $1;
+
+-- name: ListIngredientPrices :many
+SELECT ip.id, ip.ingredient_id, ip.price, ip.unit_id, u.name AS unit_name,
+    ip.store, ip.observed_on, ip.created_at
+FROM ingredients_prices AS ip
+LEFT JOIN units AS u
+ON ip.unit_id = u.id
+WHERE ip.ingredient_id = sqlc.arg(ingredient_id)
+    AND (sqlc.narg(store)::varchar IS NULL OR ip.store = sqlc.narg(store))
+ORDER BY ip.observed_on DESC, ip.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListRecipePrices :many
+SELECT ri.ingredient_id, ri.amount, u.name AS unit_name,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams,
+    p.price, p.unit_name AS price_unit_name,
+    p.unit_grams AS price_unit_grams, p.ingredient_unit_grams AS price_ingredient_unit_grams
+FROM recipes_ingredients AS ri
+LEFT JOIN units AS u
+ON ri.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON ri.ingredient_id = iu.ingredient_id AND ri.unit_id = iu.unit_id
+LEFT JOIN ingredients_current_prices AS p
+ON ri.ingredient_id = p.ingredient_id
+WHERE ri.recipe_id = $1;
@@ -3,18 +3,37 @@ SELECT * from recipes
 WHERE id = $1 LIMIT 1;
 
 -- name: GetRecipeIngredients :many
-SELECT ri.recipe_id, ri.ingredient_id, i.name, ri. amount, ri.unit_id
+SELECT ri.recipe_id, ri.ingredient_id, i.name, ri. amount, ri.unit_id, u.name AS unit_name
 from recipes_ingredients as ri
 INNER JOIN ingredients as i
 ON ri.ingredient_id = i.id
+LEFT JOIN units as u
+ON ri.unit_id = u.id
 WHERE recipe_id = $1
-FOR SHARE;
+FOR SHARE OF ri, i;
 
 -- name: ListRecipes :many
-SELECT * from recipes
-ORDER BY modified_at
-LIMIT $1
-OFFSET $2;
+SELECT r.* from recipes AS r
+WHERE (sqlc.narg(cuisine)::text IS NULL OR r.cuisine = sqlc.narg(cuisine))
+    AND (sqlc.narg(course)::text IS NULL OR r.course = sqlc.narg(course))
+    AND (sqlc.narg(difficulty)::text IS NULL OR r.difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(max_total_time)::int IS NULL OR r.total_time <= sqlc.narg(max_total_time))
+    AND COALESCE(cardinality(sqlc.arg(keywords)::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY(sqlc.arg(keywords)::text[])
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: ListRecipesUser :many
 SELECT * from recipes
@@ -24,19 +43,41 @@ LIMIT $2
 OFFSET $3;
 
 -- name: SearchRecipe :many
-SELECT id, name, author, modified_at from recipes
-WHERE name LIKE $1
-LIMIT $2
-OFFSET $3;
+SELECT r.id, r.name, r.author, r.modified_at from recipes AS r
+WHERE r.name LIKE sqlc.arg(name)
+    AND (sqlc.narg(cuisine)::text IS NULL OR r.cuisine = sqlc.narg(cuisine))
+    AND (sqlc.narg(course)::text IS NULL OR r.course = sqlc.narg(course))
+    AND (sqlc.narg(difficulty)::text IS NULL OR r.difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(max_total_time)::int IS NULL OR r.total_time <= sqlc.narg(max_total_time))
+    AND COALESCE(cardinality(sqlc.arg(keywords)::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY(sqlc.arg(keywords)::text[])
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: CreateRecipe :one
 INSERT INTO recipes (
     name,
     author,
     portion,
-    steps
+    steps,
+    cuisine,
+    course,
+    difficulty,
+    total_time
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 
@@ -73,4 +114,69 @@ WHERE id = $1;
 
 -- name: DeleteRecipeIngredient :exec
 DELETE FROM recipes_ingredients
-WHERE recipe_id = $1 AND ingredient_id = $2;
\ No newline at end of file
+WHERE recipe_id = $1 AND ingredient_id = $2;
+
+-- name: ListRecipesAllowed :many
+SELECT r.* from recipes as r
+WHERE NOT EXISTS (
+    SELECT 1 from recipes_conflicts as rc
+    WHERE rc.recipe_id = r.id AND rc.user_id = sqlc.arg(user_id)
+)
+    AND (sqlc.narg(cuisine)::text IS NULL OR r.cuisine = sqlc.narg(cuisine))
+    AND (sqlc.narg(course)::text IS NULL OR r.course = sqlc.narg(course))
+    AND (sqlc.narg(difficulty)::text IS NULL OR r.difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(max_total_time)::int IS NULL OR r.total_time <= sqlc.narg(max_total_time))
+    AND COALESCE(cardinality(sqlc.arg(keywords)::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY(sqlc.arg(keywords)::text[])
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: SearchRecipeAllowed :many
+SELECT r.id, r.name, r.author, r.modified_at from recipes as r
+WHERE r.name LIKE sqlc.arg(name) AND NOT EXISTS (
+    SELECT 1 from recipes_conflicts as rc
+    WHERE rc.recipe_id = r.id AND rc.user_id = sqlc.arg(user_id)
+)
+    AND (sqlc.narg(cuisine)::text IS NULL OR r.cuisine = sqlc.narg(cuisine))
+    AND (sqlc.narg(course)::text IS NULL OR r.course = sqlc.narg(course))
+    AND (sqlc.narg(difficulty)::text IS NULL OR r.difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(max_total_time)::int IS NULL OR r.total_time <= sqlc.narg(max_total_time))
+    AND COALESCE(cardinality(sqlc.arg(keywords)::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY(sqlc.arg(keywords)::text[])
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+
+-- name: ListRecipeIngredientAmounts :many
+SELECT ri.ingredient_id, i.name, ri.amount, ri.unit_id, u.name AS unit_name
+from recipes_ingredients as ri
+INNER JOIN ingredients as i
+ON ri.ingredient_id = i.id
+LEFT JOIN units as u
+ON ri.unit_id = u.id
+WHERE ri.recipe_id = $1
+ORDER BY i.name;
@@ -0,0 +1,62 @@
+-- name: UpsertRecipeReview :one
+INSERT INTO recipes_reviews (
+    recipe_id,
+    user_id,
+    rating,
+    comment
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (recipe_id, user_id) DO UPDATE
+SET rating = EXCLUDED.rating,
+    comment = EXCLUDED.comment,
+    modified_at = (now() at time zone 'utc')
+RETURNING *;
+
+-- name: DeleteRecipeReview :exec
+DELETE FROM recipes_reviews
+WHERE recipe_id = $1 AND user_id = $2;
+
+-- name: ListRecipeReviews :many
+SELECT rv.recipe_id, rv.user_id, u.username, rv.rating, rv.comment, rv.created_at, rv.modified_at
+FROM recipes_reviews AS rv
+INNER JOIN users AS u
+ON rv.user_id = u.id
+WHERE rv.recipe_id = $1
+ORDER BY rv.modified_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: GetRecipeStats :one
+SELECT * from recipes_stats
+WHERE recipe_id = $1 LIMIT 1;
+
+-- name: CreateRecipeCooked :one
+INSERT INTO recipes_cooked (
+    recipe_id,
+    user_id,
+    cooked_on
+) VALUES (
+    sqlc.arg(recipe_id),
+    sqlc.arg(user_id),
+    COALESCE(sqlc.narg(cooked_on)::date, (now() at time zone 'utc')::date)
+)
+RETURNING *;
+
+-- name: GetRecipeCooked :one
+SELECT * from recipes_cooked
+WHERE id = $1 LIMIT 1;
+
+-- name: DeleteRecipeCooked :exec
+DELETE FROM recipes_cooked
+WHERE id = $1;
+
+-- name: ListUserCooked :many
+SELECT cl.id, cl.recipe_id, r.name, cl.cooked_on, cl.schedule_id
+FROM recipes_cooked_log AS cl
+INNER JOIN recipes AS r
+ON cl.recipe_id = r.id
+WHERE cl.user_id = $1
+ORDER BY cl.cooked_on DESC
+LIMIT $2
+OFFSET $3;
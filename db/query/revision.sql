@@ -0,0 +1,72 @@
+-- name: CreateRecipeRevision :one
+INSERT INTO recipes_revisions (
+    recipe_id,
+    revision,
+    name,
+    portion,
+    steps,
+    ingredients,
+    step_list,
+    cuisine,
+    course,
+    difficulty,
+    total_time,
+    keywords,
+    author
+)
+SELECT r.id,
+    COALESCE((
+        SELECT max(rv.revision) FROM recipes_revisions AS rv
+        WHERE rv.recipe_id = r.id
+    ), 0) + 1,
+    r.name, r.portion, r.steps,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object(
+            'ingredientID', ri.ingredient_id,
+            'name', i.name,
+            'amount', ri.amount,
+            'unitID', ri.unit_id
+        ) ORDER BY ri.ingredient_id)
+        FROM recipes_ingredients AS ri
+        INNER JOIN ingredients AS i
+        ON ri.ingredient_id = i.id
+        WHERE ri.recipe_id = r.id
+    ), '[]'),
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object(
+            'text', s.text,
+            'durationSeconds', COALESCE(s.duration_seconds, 0),
+            'ingredients', COALESCE((
+                SELECT jsonb_agg(si.ingredient_id ORDER BY si.ingredient_id)
+                FROM recipes_steps_ingredients AS si
+                WHERE si.step_id = s.id
+            ), '[]')
+        ) ORDER BY s.position)
+        FROM recipes_steps AS s
+        WHERE s.recipe_id = r.id
+    ), '[]'),
+    r.cuisine, r.course, r.difficulty, r.total_time,
+    COALESCE((
+        SELECT array_agg(k.keyword ORDER BY k.keyword)
+        FROM recipes_keywords AS k
+        WHERE k.recipe_id = r.id
+    ), '{}'),
+    sqlc.arg(author)
+FROM recipes AS r
+WHERE r.id = sqlc.arg(recipe_id)
+RETURNING *;
+
+-- name: GetRecipeRevision :one
+SELECT * from recipes_revisions
+WHERE recipe_id = $1 AND revision = $2 LIMIT 1;
+
+-- name: GetLatestRecipeRevision :one
+SELECT * from recipes_revisions
+WHERE recipe_id = $1
+ORDER BY revision DESC
+LIMIT 1;
+
+-- name: ListRecipeRevisions :many
+SELECT * from recipes_revisions
+WHERE recipe_id = $1
+ORDER BY revision DESC;
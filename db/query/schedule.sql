@@ -16,11 +16,12 @@ LIMIT $2
 OFFSET $3;
 
 -- name: GetScheduleRecipe :many
-SELECT sr.schedule_id, sr.recipe_id, r.name, sr.portion
+SELECT sr.schedule_id, sr.recipe_id, r.name, sr.portion, sr.scheduled_date, sr.revision_id
 from schedules_recipes as sr 
 INNER JOIN recipes as r
 ON sr.recipe_id = r.id
-WHERE sr.schedule_id = $1;
+WHERE sr.schedule_id = $1
+ORDER BY sr.scheduled_date;
 
 -- name: CreateSchedule :one
 INSERT INTO schedules (
@@ -33,9 +34,11 @@ INSERT INTO schedules (
 INSERT INTO schedules_recipes (
     schedule_id,
     recipe_id,
-    portion
+    portion,
+    scheduled_date,
+    revision_id
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: DeleteSchedule :exec
@@ -55,4 +58,44 @@ INNER JOIN ingredients AS i
 ON ri.ingredient_id = i.id
 WHERE sr.schedule_id = $1
 GROUP BY i.id
-ORDER BY i.name;
\ No newline at end of file
+ORDER BY i.name;
+
+-- name: ListGroceryAmounts :many
+WITH schedule_ingredients AS (
+    SELECT sr.schedule_id, ri.ingredient_id, ri.amount, ri.unit_id,
+        r.portion AS recipe_portion, sr.portion AS schedule_portion
+    FROM schedules_recipes AS sr
+    INNER JOIN recipes AS r
+    ON sr.recipe_id = r.id
+    INNER JOIN recipes_ingredients AS ri
+    ON sr.recipe_id = ri.recipe_id
+    WHERE sr.schedule_id = ANY(sqlc.arg(schedule_ids)::bigint[]) AND sr.revision_id IS NULL
+    UNION ALL
+    SELECT sr.schedule_id, (e->>'ingredientID')::int, (e->>'amount')::real, (e->>'unitID')::int,
+        rv.portion, sr.portion
+    FROM schedules_recipes AS sr
+    INNER JOIN recipes_revisions AS rv
+    ON sr.revision_id = rv.id
+    CROSS JOIN LATERAL jsonb_array_elements(rv.ingredients) AS e
+    WHERE sr.schedule_id = ANY(sqlc.arg(schedule_ids)::bigint[])
+)
+SELECT si.schedule_id, i.id, i.name, si.amount, u.name AS unit_name,
+    si.recipe_portion, si.schedule_portion,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams,
+    p.price, p.unit_name AS price_unit_name,
+    p.unit_grams AS price_unit_grams, p.ingredient_unit_grams AS price_ingredient_unit_grams
+FROM schedule_ingredients AS si
+INNER JOIN ingredients AS i
+ON si.ingredient_id = i.id
+LEFT JOIN units AS u
+ON si.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON si.ingredient_id = iu.ingredient_id AND si.unit_id = iu.unit_id
+LEFT JOIN LATERAL (
+    SELECT sp.price, sp.unit_name, sp.unit_grams, sp.ingredient_unit_grams
+    FROM ingredients_store_prices AS sp
+    WHERE sp.ingredient_id = si.ingredient_id
+    ORDER BY COALESCE(sp.store = sqlc.arg(store), false) DESC, sp.observed_on DESC, sp.id DESC
+    LIMIT 1
+) AS p ON true
+ORDER BY i.name, i.id, si.schedule_id;
@@ -0,0 +1,81 @@
+-- name: GetRecipeStep :one
+SELECT * from recipes_steps
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRecipeSteps :many
+SELECT * from recipes_steps
+WHERE recipe_id = $1
+ORDER BY position;
+
+-- name: CountRecipeSteps :one
+SELECT count(*) from recipes_steps
+WHERE recipe_id = $1;
+
+-- name: CreateRecipeStep :one
+INSERT INTO recipes_steps (
+    recipe_id,
+    position,
+    text,
+    duration_seconds
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UpdateRecipeStep :one
+UPDATE recipes_steps
+    set text = $2,
+    duration_seconds = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateRecipeStepPosition :exec
+UPDATE recipes_steps
+    set position = $2
+WHERE id = $1;
+
+-- name: ShiftRecipeSteps :exec
+UPDATE recipes_steps
+    set position = position + sqlc.arg(shift)::int
+WHERE recipe_id = sqlc.arg(recipe_id) AND position >= sqlc.arg(from_position)::int;
+
+-- name: DeleteRecipeStep :exec
+DELETE FROM recipes_steps
+WHERE id = $1;
+
+-- name: DeleteRecipeSteps :exec
+DELETE FROM recipes_steps
+WHERE recipe_id = $1;
+
+-- name: CreateRecipeStepIngredient :one
+INSERT INTO recipes_steps_ingredients (
+    step_id,
+    recipe_id,
+    ingredient_id
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListRecipeStepsIngredients :many
+SELECT si.step_id, si.ingredient_id
+from recipes_steps_ingredients as si
+INNER JOIN recipes_steps as s
+ON si.step_id = s.id
+WHERE s.recipe_id = $1
+ORDER BY s.position, si.ingredient_id;
+
+-- name: DeleteRecipeStepIngredients :exec
+DELETE FROM recipes_steps_ingredients
+WHERE step_id = $1;
+
+-- name: SyncRecipeStepsText :one
+UPDATE recipes
+    set steps = (
+        SELECT string_agg(s.text, E'\n' ORDER BY s.position)
+        FROM recipes_steps AS s
+        WHERE s.recipe_id = recipes.id
+    ),
+    modified_at = (now() at time zone 'utc')
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,65 @@
+-- name: CreateDietaryTag :one
+INSERT INTO dietary_tags (
+    name, kind
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: ListDietaryTags :many
+SELECT * from dietary_tags
+ORDER BY kind, name;
+
+-- name: DeleteDietaryTag :exec
+DELETE FROM dietary_tags
+WHERE name = $1;
+
+-- name: CreateIngredientTag :one
+INSERT INTO ingredients_tags (
+    ingredient_id, tag
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: ListIngredientTags :many
+SELECT it.tag, dt.kind
+from ingredients_tags as it
+INNER JOIN dietary_tags as dt
+ON it.tag = dt.name
+WHERE it.ingredient_id = $1
+ORDER BY dt.kind, it.tag;
+
+-- name: DeleteIngredientTag :exec
+DELETE FROM ingredients_tags
+WHERE ingredient_id = $1 AND tag = $2;
+
+-- name: ListRecipeTags :many
+SELECT tag, kind from recipes_tags
+WHERE recipe_id = $1
+ORDER BY kind, tag;
+
+-- name: CreateUserRestriction :one
+INSERT INTO users_restrictions (
+    user_id, tag
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: ListUserRestrictions :many
+SELECT ur.tag, dt.kind
+from users_restrictions as ur
+INNER JOIN dietary_tags as dt
+ON ur.tag = dt.name
+WHERE ur.user_id = $1
+ORDER BY dt.kind, ur.tag;
+
+-- name: DeleteUserRestrictions :exec
+DELETE FROM users_restrictions
+WHERE user_id = $1;
+
+-- name: ListRecipeConflicts :many
+SELECT recipe_id, tag from recipes_conflicts
+WHERE user_id = $1 AND recipe_id = ANY(sqlc.arg(recipe_ids)::bigint[])
+ORDER BY recipe_id, tag;
@@ -0,0 +1,51 @@
+-- name: ListCuisines :many
+SELECT c.name, count(r.id) AS recipe_count
+from cuisines as c
+LEFT JOIN recipes as r
+ON r.cuisine = c.name
+GROUP BY c.name
+ORDER BY c.name;
+
+-- name: ListCourses :many
+SELECT c.name, count(r.id) AS recipe_count
+from courses as c
+LEFT JOIN recipes as r
+ON r.course = c.name
+GROUP BY c.name
+ORDER BY c.name;
+
+-- name: UpdateRecipeTaxonomy :one
+UPDATE recipes
+    set cuisine = $2,
+    course = $3,
+    difficulty = $4,
+    total_time = $5,
+    modified_at = (now() at time zone 'utc')
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateRecipeKeyword :exec
+INSERT INTO recipes_keywords (
+    recipe_id,
+    keyword
+) VALUES (
+    $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteRecipeKeywords :exec
+DELETE FROM recipes_keywords
+WHERE recipe_id = $1;
+
+-- name: ListRecipeKeywords :many
+SELECT keyword from recipes_keywords
+WHERE recipe_id = $1
+ORDER BY keyword;
+
+-- name: ListKeywords :many
+SELECT keyword, count(*) AS recipe_count
+from recipes_keywords
+GROUP BY keyword
+ORDER BY recipe_count DESC, keyword
+LIMIT $1
+OFFSET $2;
@@ -0,0 +1,39 @@
+-- name: CreateScheduleTemplate :one
+INSERT INTO schedule_templates (
+    author,
+    name,
+    rrule,
+    starts_on
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetScheduleTemplate :one
+SELECT * from schedule_templates
+WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduleTemplates :many
+SELECT * from schedule_templates
+WHERE author = $1
+ORDER BY id;
+
+-- name: DeleteScheduleTemplate :exec
+DELETE FROM schedule_templates
+WHERE id = $1;
+
+-- name: CreateScheduleTemplateRecipe :one
+INSERT INTO schedule_templates_recipes (
+    template_id,
+    recipe_id,
+    portion
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListScheduleTemplateRecipes :many
+SELECT tr.template_id, tr.recipe_id, r.name, tr.portion
+FROM schedule_templates_recipes AS tr
+INNER JOIN recipes AS r
+ON tr.recipe_id = r.id
+WHERE tr.template_id = ANY(sqlc.arg(template_ids)::bigint[])
+ORDER BY tr.template_id, tr.recipe_id;
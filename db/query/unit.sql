@@ -1,8 +1,9 @@
 -- name: CreateUnit :one
 INSERT INTO units (
-    name
+    name,
+    grams
 ) VALUES (
-    $1
+    $1, $2
 ) RETURNING *;
 
 -- name: GetUnit :one
@@ -14,10 +15,30 @@ SELECT * from units;
 
 -- name: UpdateUnit :one
 UPDATE units
-    set name = $2
+    set name = $2,
+    grams = $3
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteUnit :exec
 DELETE FROM units
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: UpsertIngredientUnit :one
+INSERT INTO ingredients_units (
+    ingredient_id,
+    unit_id,
+    grams
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (ingredient_id, unit_id) DO UPDATE
+    set grams = EXCLUDED.grams
+RETURNING *;
+
+-- name: ListIngredientUnits :many
+SELECT * from ingredients_units
+WHERE ingredient_id = $1;
+
+-- name: DeleteIngredientUnit :exec
+DELETE FROM ingredients_units
+WHERE ingredient_id = $1 AND unit_id = $2;
\ No newline at end of file
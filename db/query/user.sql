@@ -42,6 +42,12 @@ UPDATE users
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateExpiryAlert :one
+UPDATE users
+  set expiry_alert_days = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdatePassword :one
 UPDATE users
   set password = $2
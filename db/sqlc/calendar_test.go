@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	feed, err := testQueries.UpsertCalendarFeed(context.Background(), UpsertCalendarFeedParams{
+		UserID: user.ID,
+		Token:  uuid.New(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, feed.UserID)
+
+	// a new token replaces the old one
+	renewed, err := testQueries.UpsertCalendarFeed(context.Background(), UpsertCalendarFeedParams{
+		UserID: user.ID,
+		Token:  uuid.New(),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, feed.Token, renewed.Token)
+
+	_, err = testQueries.GetCalendarFeedByToken(context.Background(), feed.Token)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	got, err := testQueries.GetCalendarFeedByToken(context.Background(), renewed.Token)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, got.UserID)
+
+	err = testQueries.DeleteCalendarFeed(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetCalendarFeed(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetCalendarFeedTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, _ := CreateRandomRecipeIngredient(t)
+	user := CreateRandomUser(t)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	_, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+		Author: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: recipe.ID, Portion: recipe.Portion * 2, ScheduledDate: sql.NullTime{Time: today, Valid: true}},
+			{RecipeID: recipe.ID, Portion: recipe.Portion, ScheduledDate: sql.NullTime{Time: today.AddDate(0, 0, -60), Valid: true}},
+			{RecipeID: recipe.ID, Portion: recipe.Portion},
+		},
+	})
+	require.NoError(t, err)
+
+	feed, err := testQueries.UpsertCalendarFeed(context.Background(), UpsertCalendarFeedParams{
+		UserID: user.ID,
+		Token:  uuid.New(),
+	})
+	require.NoError(t, err)
+
+	entries, err := storage.GetCalendarFeedTx(context.Background(), CalendarFeedParams{
+		Token: feed.Token,
+		Since: today.AddDate(0, 0, -30),
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, recipe.Name, entries[0].Name)
+	require.Equal(t, recipe.Portion*2, entries[0].Portion)
+	require.NotEmpty(t, entries[0].Ingredients)
+
+	_, err = storage.GetCalendarFeedTx(context.Background(), CalendarFeedParams{
+		Token: uuid.New(),
+		Since: today,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCalendarEntries(t *testing.T) {
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	entries := []ListCalendarEntriesRow{
+		{ScheduleID: 1, RecipeID: 2, Name: "soup", Portion: 4, ScheduledDate: sql.NullTime{Time: monday, Valid: true}},
+		{ScheduleID: 1, RecipeID: 2, Name: "soup", Portion: 2, ScheduledDate: sql.NullTime{Time: monday.AddDate(0, 0, 1), Valid: true}},
+		{ScheduleID: 1, RecipeID: 3, Name: "toast", Portion: 1, ScheduledDate: sql.NullTime{Time: monday, Valid: true}},
+	}
+	ingredients := []ListCalendarEntryIngredientsRow{
+		{
+			ScheduleID: 1, RecipeID: 2, ScheduledDate: sql.NullTime{Time: monday, Valid: true},
+			Name: "onion", Amount: 1, UnitName: sql.NullString{String: "cup", Valid: true},
+			RecipePortion: 2, SchedulePortion: 4,
+		},
+		{
+			ScheduleID: 1, RecipeID: 2, ScheduledDate: sql.NullTime{Time: monday.AddDate(0, 0, 1), Valid: true},
+			Name: "onion", Amount: 1, UnitName: sql.NullString{String: "cup", Valid: true},
+			RecipePortion: 2, SchedulePortion: 2,
+		},
+	}
+
+	result := calendarEntries(entries, ingredients)
+	require.Len(t, result, 3)
+	require.Equal(t, []CalendarIngredient{{Name: "onion", Quantity: measure.Quantity{Amount: 2, Unit: "cup"}}}, result[0].Ingredients)
+	require.Equal(t, []CalendarIngredient{{Name: "onion", Quantity: measure.Quantity{Amount: 1, Unit: "cup"}}}, result[1].Ingredients)
+	require.Empty(t, result[2].Ingredients)
+	require.NotNil(t, result[2].Ingredients)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGroceryCategories(t *testing.T) {
+	categories, err := testQueries.ListGroceryCategories(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, categories)
+	require.Equal(t, "produce", categories[0].Name)
+
+	for i := 1; i < len(categories); i++ {
+		require.Less(t, categories[i-1].Position, categories[i].Position)
+	}
+}
+
+func TestUpsertIngredientCategory(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+
+	category, err := testQueries.UpsertIngredientCategory(context.Background(), UpsertIngredientCategoryParams{
+		IngredientID: ingredient.ID,
+		Category:     "produce",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "produce", category.Category)
+
+	category, err = testQueries.UpsertIngredientCategory(context.Background(), UpsertIngredientCategoryParams{
+		IngredientID: ingredient.ID,
+		Category:     "pantry",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pantry", category.Category)
+
+	got, err := testQueries.GetIngredientCategory(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Equal(t, category, got)
+
+	// only known categories
+	_, err = testQueries.UpsertIngredientCategory(context.Background(), UpsertIngredientCategoryParams{
+		IngredientID: ingredient.ID,
+		Category:     "garden",
+	})
+	require.Error(t, err)
+}
+
+func TestGetScheduleGroceriesTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+
+	_, err := testQueries.UpsertIngredientCategory(context.Background(), UpsertIngredientCategoryParams{
+		IngredientID: ingredients[0].IngredientID,
+		Category:     "produce",
+	})
+	require.NoError(t, err)
+
+	author := CreateRandomUser(t)
+	generated, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+		Author: uuid.NullUUID{UUID: author.ID, Valid: true},
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: recipe.ID, Portion: recipe.Portion},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := storage.GetScheduleGroceriesTx(context.Background(), ScheduleGroceriesParams{
+		ScheduleID: generated.Schedule.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, generated.Schedule, result.Schedule)
+	require.Equal(t, generated.Recipes, result.Recipes)
+	require.Equal(t, generated.Groceries, result.Groceries)
+	require.NotEmpty(t, result.Categories)
+
+	categorized := false
+	for _, item := range result.Groceries {
+		if item.ID == ingredients[0].IngredientID {
+			categorized = item.Category == "produce"
+		}
+	}
+	require.True(t, categorized)
+
+	_, err = storage.GetScheduleGroceriesTx(context.Background(), ScheduleGroceriesParams{
+		ScheduleID: generated.Schedule.ID + 1000000,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCollection(t *testing.T, owner User, recipes []Recipe) Collection {
+	collection, err := testQueries.CreateCollection(context.Background(), CreateCollectionParams{
+		Owner: owner.ID,
+		Name:  util.RandomString(12),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, collection.ID)
+	require.False(t, collection.ShareToken.Valid)
+
+	for i, recipe := range recipes {
+		item, err := testQueries.CreateCollectionRecipe(context.Background(), CreateCollectionRecipeParams{
+			CollectionID: collection.ID,
+			RecipeID:     recipe.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(i+1), item.Position)
+	}
+
+	return collection
+}
+
+func TestFavorites(t *testing.T) {
+	user := CreateRandomUser(t)
+	first := CreateRandomRecipe(t)
+	second := CreateRandomRecipe(t)
+
+	for _, recipe := range []Recipe{first, second, first} {
+		err := testQueries.CreateFavorite(context.Background(), CreateFavoriteParams{
+			UserID:   user.ID,
+			RecipeID: recipe.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	favorites, err := testQueries.ListFavorites(context.Background(), ListFavoritesParams{
+		UserID: user.ID,
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, favorites, 2)
+
+	err = testQueries.DeleteFavorite(context.Background(), DeleteFavoriteParams{
+		UserID:   user.ID,
+		RecipeID: first.ID,
+	})
+	require.NoError(t, err)
+
+	favorites, err = testQueries.ListFavorites(context.Background(), ListFavoritesParams{
+		UserID: user.ID,
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, favorites, 1)
+	require.Equal(t, second.ID, favorites[0].ID)
+}
+
+func TestCollectionShareToken(t *testing.T) {
+	user := CreateRandomUser(t)
+	collection := createRandomCollection(t, user, nil)
+
+	token := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	shared, err := testQueries.UpdateCollectionShareToken(context.Background(), UpdateCollectionShareTokenParams{
+		ID:         collection.ID,
+		ShareToken: token,
+	})
+	require.NoError(t, err)
+	require.Equal(t, token, shared.ShareToken)
+
+	found, err := testQueries.GetCollectionByShareToken(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, collection.ID, found.ID)
+
+	_, err = testQueries.UpdateCollectionShareToken(context.Background(), UpdateCollectionShareTokenParams{
+		ID: collection.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetCollectionByShareToken(context.Background(), token)
+	require.Error(t, err)
+
+	// Names are unique per owner
+	_, err = testQueries.CreateCollection(context.Background(), CreateCollectionParams{
+		Owner: user.ID,
+		Name:  collection.Name,
+	})
+	require.Error(t, err)
+}
+
+func TestRemoveCollectionRecipeTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	user := CreateRandomUser(t)
+	recipes := []Recipe{CreateRandomRecipe(t), CreateRandomRecipe(t), CreateRandomRecipe(t)}
+	collection := createRandomCollection(t, user, recipes)
+
+	result, err := storage.RemoveCollectionRecipeTx(context.Background(), RemoveCollectionRecipeParams{
+		CollectionID: collection.ID,
+		RecipeID:     recipes[0].ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	for i, item := range result {
+		require.Equal(t, recipes[i+1].ID, item.RecipeID)
+		require.Equal(t, int32(i+1), item.Position)
+	}
+
+	// Appending continues after the last position
+	item, err := testQueries.CreateCollectionRecipe(context.Background(), CreateCollectionRecipeParams{
+		CollectionID: collection.ID,
+		RecipeID:     recipes[0].ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), item.Position)
+}
+
+func TestReorderCollectionTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	user := CreateRandomUser(t)
+	recipes := []Recipe{CreateRandomRecipe(t), CreateRandomRecipe(t), CreateRandomRecipe(t)}
+	collection := createRandomCollection(t, user, recipes)
+
+	order := []int64{recipes[2].ID, recipes[0].ID, recipes[1].ID}
+	result, err := storage.ReorderCollectionTx(context.Background(), ReorderCollectionParams{
+		CollectionID: collection.ID,
+		RecipeIDs:    order,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, len(order))
+	for i, item := range result {
+		require.Equal(t, order[i], item.RecipeID)
+		require.Equal(t, int32(i+1), item.Position)
+	}
+
+	_, err = storage.ReorderCollectionTx(context.Background(), ReorderCollectionParams{
+		CollectionID: collection.ID,
+		RecipeIDs:    []int64{recipes[0].ID, recipes[0].ID, recipes[1].ID},
+	})
+	require.ErrorIs(t, err, ErrInvalidCollectionOrder)
+
+	_, err = storage.ReorderCollectionTx(context.Background(), ReorderCollectionParams{
+		CollectionID: collection.ID,
+		RecipeIDs:    order[:2],
+	})
+	require.ErrorIs(t, err, ErrInvalidCollectionOrder)
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"database/sql"
+	"math"
+
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+// Costs are estimated from the latest recorded price of every ingredient, groceries
+// prefer the latest price at their store. Costs are in the currency the prices were
+// recorded in and rounded to cents
+type RecipeCost struct {
+	Total      float64 `json:"total"`
+	PerPortion float64 `json:"perPortion"`
+	// Ingredients left out of the totals because they have no price or their
+	// amount can not be converted to the unit of the price
+	Missing []int32 `json:"missing"`
+}
+
+type GroceryCost struct {
+	Total   float64 `json:"total"`
+	Missing []int32 `json:"missing"`
+}
+
+// A recipe amount and the latest price of its ingredient
+type pricedAmount struct {
+	Amount                   float64
+	UnitName                 sql.NullString
+	IngredientUnitGrams      sql.NullFloat64
+	UnitGrams                sql.NullFloat64
+	Price                    sql.NullFloat64
+	PriceUnitName            sql.NullString
+	PriceIngredientUnitGrams sql.NullFloat64
+	PriceUnitGrams           sql.NullFloat64
+}
+
+// Cost of the amount at its ingredient's price
+func (a pricedAmount) cost() (float64, bool) {
+	if !a.Price.Valid {
+		return 0, false
+	}
+
+	amount, ok := convertAmount(
+		a.Amount,
+		a.UnitName, a.IngredientUnitGrams, a.UnitGrams,
+		a.PriceUnitName, a.PriceIngredientUnitGrams, a.PriceUnitGrams,
+	)
+	if !ok {
+		return 0, false
+	}
+
+	return amount * a.Price.Float64, true
+}
+
+// Convert an amount of an ingredient to another unit. Units of the same dimension
+// convert directly, other units through their weight in grams.
+func convertAmount(
+	amount float64,
+	fromUnit sql.NullString, fromIngredientUnitGrams, fromUnitGrams sql.NullFloat64,
+	toUnit sql.NullString, toIngredientUnitGrams, toUnitGrams sql.NullFloat64,
+) (float64, bool) {
+	if ratio, ok := measure.Ratio(fromUnit.String, toUnit.String); ok {
+		return amount * ratio, true
+	}
+
+	grams, okFrom := amountToGrams(1, fromIngredientUnitGrams, fromUnitGrams)
+	toGrams, okTo := amountToGrams(1, toIngredientUnitGrams, toUnitGrams)
+	if !okFrom || !okTo || toGrams <= 0 {
+		return 0, false
+	}
+
+	return amount * grams / toGrams, true
+}
+
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
+
+func computeRecipeCost(rows []ListRecipePricesRow, portion int32) RecipeCost {
+	result := RecipeCost{
+		Missing: []int32{},
+	}
+
+	for _, row := range rows {
+		cost, ok := pricedAmount{
+			Amount:                   float64(row.Amount),
+			UnitName:                 row.UnitName,
+			IngredientUnitGrams:      row.IngredientUnitGrams,
+			UnitGrams:                row.UnitGrams,
+			Price:                    row.Price,
+			PriceUnitName:            row.PriceUnitName,
+			PriceIngredientUnitGrams: row.PriceIngredientUnitGrams,
+			PriceUnitGrams:           row.PriceUnitGrams,
+		}.cost()
+		if !ok {
+			result.Missing = appendMissing(result.Missing, row.IngredientID)
+			continue
+		}
+		result.Total += cost
+	}
+
+	if portion > 0 {
+		result.PerPortion = roundCost(result.Total / float64(portion))
+	}
+	result.Total = roundCost(result.Total)
+
+	return result
+}
+
+// Set the cost of every grocery line and sum them up. Lines of which only some
+// amounts could be priced get the partial cost and are listed as missing.
+func estimateGroceryCost(rows []ListGroceryAmountsRow, items []GroceryItem) GroceryCost {
+	result := GroceryCost{
+		Missing: []int32{},
+	}
+
+	costs := map[int32]float64{}
+	for _, row := range rows {
+		cost, ok := pricedAmount{
+			Amount:                   measure.Scale(float64(row.Amount), row.RecipePortion, row.SchedulePortion),
+			UnitName:                 row.UnitName,
+			IngredientUnitGrams:      row.IngredientUnitGrams,
+			UnitGrams:                row.UnitGrams,
+			Price:                    row.Price,
+			PriceUnitName:            row.PriceUnitName,
+			PriceIngredientUnitGrams: row.PriceIngredientUnitGrams,
+			PriceUnitGrams:           row.PriceUnitGrams,
+		}.cost()
+		if !ok {
+			result.Missing = appendMissing(result.Missing, row.ID)
+			continue
+		}
+		costs[row.ID] += cost
+		result.Total += cost
+	}
+
+	for i := range items {
+		if cost, ok := costs[items[i].ID]; ok {
+			rounded := roundCost(cost)
+			items[i].Cost = &rounded
+		}
+	}
+	result.Total = roundCost(result.Total)
+
+	return result
+}
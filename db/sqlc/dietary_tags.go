@@ -0,0 +1,37 @@
+package db
+
+const (
+	TagKindAllergen = "allergen"
+	TagKindDiet     = "diet"
+)
+
+// How conflicts with the user's dietary restrictions are handled
+const (
+	RestrictionsExclude = "exclude"
+	RestrictionsWarn    = "warn"
+)
+
+type RecipeTags struct {
+	// Allergens contained by any of the ingredients
+	Allergens []string `json:"allergens"`
+	// Diets all of the ingredients are compatible with
+	Diets []string `json:"diets"`
+}
+
+func recipeTags(rows []ListRecipeTagsRow) RecipeTags {
+	result := RecipeTags{
+		Allergens: []string{},
+		Diets:     []string{},
+	}
+
+	for _, row := range rows {
+		switch row.Kind {
+		case TagKindAllergen:
+			result.Allergens = append(result.Allergens, row.Tag)
+		case TagKindDiet:
+			result.Diets = append(result.Diets, row.Tag)
+		}
+	}
+
+	return result
+}
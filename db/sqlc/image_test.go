@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRecipeImage(t *testing.T, recipeID int64, stepID sql.NullInt64) RecipesImage {
+	name := util.RandomString(16)
+	arg := CreateRecipeImageParams{
+		RecipeID:     recipeID,
+		StepID:       stepID,
+		BlobKey:      fmt.Sprintf("recipes/%d/%s.jpg", recipeID, name),
+		ThumbnailKey: fmt.Sprintf("recipes/%d/%s_thumb.jpg", recipeID, name),
+		ContentType:  "image/jpeg",
+		Size:         util.RandomInt(1, 10000),
+		Width:        int32(util.RandomInt(1, 4000)),
+		Height:       int32(util.RandomInt(1, 4000)),
+	}
+
+	image, err := testQueries.CreateRecipeImage(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, image.ID)
+	require.Equal(t, arg.RecipeID, image.RecipeID)
+	require.Equal(t, arg.StepID, image.StepID)
+	require.Equal(t, arg.BlobKey, image.BlobKey)
+	require.Equal(t, arg.ThumbnailKey, image.ThumbnailKey)
+	require.Equal(t, arg.Size, image.Size)
+	require.NotZero(t, image.CreatedAt)
+
+	return image
+}
+
+func TestCreateRecipeImage(t *testing.T) {
+	recipe := CreateRandomRecipe(t)
+	createRandomRecipeImage(t, recipe.ID, sql.NullInt64{})
+
+	// unknown recipe
+	_, err := testQueries.CreateRecipeImage(context.Background(), CreateRecipeImageParams{
+		RecipeID:     -1,
+		BlobKey:      util.RandomString(20),
+		ThumbnailKey: util.RandomString(20),
+		ContentType:  "image/png",
+		Size:         1,
+		Width:        1,
+		Height:       1,
+	})
+	require.Error(t, err)
+}
+
+func TestListRecipeImages(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, _ := CreateRandomRecipeIngredient(t)
+	steps := insertRandomStep(t, storage, recipe.ID, 1, nil)
+
+	first := createRandomRecipeImage(t, recipe.ID, sql.NullInt64{})
+	second := createRandomRecipeImage(t, recipe.ID, sql.NullInt64{Int64: steps[0].ID, Valid: true})
+
+	images, err := testQueries.ListRecipeImages(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, []RecipesImage{first, second}, images)
+
+	result, err := storage.GetRecipeTx(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Images, 2)
+	require.Equal(t, second, result.Images[1].RecipesImage)
+
+	// images of a deleted step stay with the recipe
+	_, err = storage.DeleteRecipeStepTx(context.Background(), DeleteRecipeStepTxParams{ID: steps[0].ID})
+	require.NoError(t, err)
+	image, err := testQueries.GetRecipeImage(context.Background(), second.ID)
+	require.NoError(t, err)
+	require.False(t, image.StepID.Valid)
+}
+
+func TestDeleteRecipeImage(t *testing.T) {
+	recipe := CreateRandomRecipe(t)
+	image := createRandomRecipeImage(t, recipe.ID, sql.NullInt64{})
+
+	err := testQueries.DeleteRecipeImage(context.Background(), image.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetRecipeImage(context.Background(), image.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// images go with their recipe
+	image = createRandomRecipeImage(t, recipe.ID, sql.NullInt64{})
+	err = testQueries.DeleteRecipe(context.Background(), recipe.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetRecipeImage(context.Background(), image.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,17 @@
+package db
+
+// Recipe image with the URLs it is served from. The URLs depend on the blob store so the API fills them in
+type RecipeImage struct {
+	RecipesImage
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailURL"`
+}
+
+func recipeImages(rows []RecipesImage) []RecipeImage {
+	images := make([]RecipeImage, len(rows))
+	for i, row := range rows {
+		images[i] = RecipeImage{RecipesImage: row}
+	}
+
+	return images
+}
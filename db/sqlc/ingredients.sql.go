@@ -36,6 +36,27 @@ func (q *Queries) CreateIngredient(ctx context.Context, arg CreateIngredientPara
 	return i, err
 }
 
+const createIngredientAlias = `-- name: CreateIngredientAlias :one
+INSERT INTO ingredients_aliases (
+    ingredient_id, alias
+) VALUES (
+    $1, $2
+)
+RETURNING ingredient_id, alias
+`
+
+type CreateIngredientAliasParams struct {
+	IngredientID int32  `json:"ingredientID"`
+	Alias        string `json:"alias"`
+}
+
+func (q *Queries) CreateIngredientAlias(ctx context.Context, arg CreateIngredientAliasParams) (IngredientsAlias, error) {
+	row := q.db.QueryRowContext(ctx, createIngredientAlias, arg.IngredientID, arg.Alias)
+	var i IngredientsAlias
+	err := row.Scan(&i.IngredientID, &i.Alias)
+	return i, err
+}
+
 const deleteIngredient = `-- name: DeleteIngredient :exec
 DELETE FROM ingredients
 WHERE id = $1
@@ -46,6 +67,16 @@ func (q *Queries) DeleteIngredient(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteIngredientAlias = `-- name: DeleteIngredientAlias :exec
+DELETE FROM ingredients_aliases
+WHERE alias = $1
+`
+
+func (q *Queries) DeleteIngredientAlias(ctx context.Context, alias string) error {
+	_, err := q.db.ExecContext(ctx, deleteIngredientAlias, alias)
+	return err
+}
+
 const getIngredient = `-- name: GetIngredient :one
 SELECT id, name, created_at, default_unit from ingredients
 WHERE id = $1
@@ -63,6 +94,63 @@ func (q *Queries) GetIngredient(ctx context.Context, id int32) (Ingredient, erro
 	return i, err
 }
 
+const listAllIngredientAliases = `-- name: ListAllIngredientAliases :many
+SELECT ingredient_id, alias from ingredients_aliases
+ORDER BY alias
+`
+
+func (q *Queries) ListAllIngredientAliases(ctx context.Context) ([]IngredientsAlias, error) {
+	rows, err := q.db.QueryContext(ctx, listAllIngredientAliases)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []IngredientsAlias{}
+	for rows.Next() {
+		var i IngredientsAlias
+		if err := rows.Scan(&i.IngredientID, &i.Alias); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngredientAliases = `-- name: ListIngredientAliases :many
+SELECT ingredient_id, alias from ingredients_aliases
+WHERE ingredient_id = $1
+ORDER BY alias
+`
+
+func (q *Queries) ListIngredientAliases(ctx context.Context, ingredientID int32) ([]IngredientsAlias, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientAliases, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []IngredientsAlias{}
+	for rows.Next() {
+		var i IngredientsAlias
+		if err := rows.Scan(&i.IngredientID, &i.Alias); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listIngredients = `-- name: ListIngredients :many
 SELECT id, name, created_at, default_unit from ingredients
 ORDER BY name
@@ -180,4 +180,66 @@ func TestUpdateIngredient(t *testing.T) {
 	require.Equal(t, arg.Name, ingredient.Name)
 	require.WithinDuration(t, ingredientNew.CreatedAt, ingredient.CreatedAt, time.Second)
 	require.Equal(t, arg.DefaultUnit, ingredient.DefaultUnit)
-}
\ No newline at end of file
+}
+func CreateRandomIngredientAlias(t *testing.T, ingredient Ingredient) IngredientsAlias {
+	arg := CreateIngredientAliasParams{
+		IngredientID: ingredient.ID,
+		Alias:        util.RandomIngredient(),
+	}
+
+	alias, err := testQueries.CreateIngredientAlias(
+		context.Background(),
+		arg,
+	)
+	require.NoError(t, err)
+	require.Equal(t, arg.IngredientID, alias.IngredientID)
+	require.Equal(t, arg.Alias, alias.Alias)
+
+	return alias
+}
+
+func TestCreateIngredientAlias(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	alias := CreateRandomIngredientAlias(t, ingredient)
+
+	// An alias can only point to one ingredient
+	other := CreateRandomIngredient(t)
+	_, err := testQueries.CreateIngredientAlias(
+		context.Background(),
+		CreateIngredientAliasParams{
+			IngredientID: other.ID,
+			Alias:        alias.Alias,
+		},
+	)
+	require.Error(t, err)
+}
+
+func TestListIngredientAliases(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	for i := 0; i < 3; i++ {
+		CreateRandomIngredientAlias(t, ingredient)
+	}
+
+	aliases, err := testQueries.ListIngredientAliases(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Len(t, aliases, 3)
+	for _, alias := range aliases {
+		require.Equal(t, ingredient.ID, alias.IngredientID)
+	}
+
+	all, err := testQueries.ListAllIngredientAliases(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(all), 3)
+}
+
+func TestDeleteIngredientAlias(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	alias := CreateRandomIngredientAlias(t, ingredient)
+
+	err := testQueries.DeleteIngredientAlias(context.Background(), alias.Alias)
+	require.NoError(t, err)
+
+	aliases, err := testQueries.ListIngredientAliases(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Empty(t, aliases)
+}
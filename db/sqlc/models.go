@@ -6,11 +6,43 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type CalendarFeed struct {
+	UserID    uuid.UUID `json:"userID"`
+	Token     uuid.UUID `json:"token"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Collection struct {
+	ID         int64         `json:"id"`
+	Owner      uuid.UUID     `json:"owner"`
+	Name       string        `json:"name"`
+	ShareToken uuid.NullUUID `json:"shareToken"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ModifiedAt time.Time     `json:"modifiedAt"`
+}
+
+type CollectionsRecipe struct {
+	CollectionID int64 `json:"collectionID"`
+	RecipeID     int64 `json:"recipeID"`
+	Position     int32 `json:"position"`
+}
+
+type DietaryTag struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type GroceryCategory struct {
+	Name     string `json:"name"`
+	Position int32  `json:"position"`
+}
+
 type Ingredient struct {
 	ID          int32         `json:"id"`
 	Name        string        `json:"name"`
@@ -18,6 +50,91 @@ type Ingredient struct {
 	DefaultUnit sql.NullInt32 `json:"defaultUnit"`
 }
 
+type IngredientsAlias struct {
+	IngredientID int32  `json:"ingredientID"`
+	Alias        string `json:"alias"`
+}
+
+type IngredientsCategory struct {
+	IngredientID int32     `json:"ingredientID"`
+	Category     string    `json:"category"`
+	ModifiedAt   time.Time `json:"modifiedAt"`
+}
+
+type IngredientsCurrentPrice struct {
+	IngredientID        int32           `json:"ingredientID"`
+	Price               float64         `json:"price"`
+	UnitID              sql.NullInt32   `json:"unitID"`
+	UnitName            sql.NullString  `json:"unitName"`
+	UnitGrams           sql.NullFloat64 `json:"unitGrams"`
+	IngredientUnitGrams sql.NullFloat64 `json:"ingredientUnitGrams"`
+	Store               sql.NullString  `json:"store"`
+	ObservedOn          time.Time       `json:"observedOn"`
+}
+
+type IngredientsPackage struct {
+	ID           int64          `json:"id"`
+	IngredientID int32          `json:"ingredientID"`
+	Amount       float64        `json:"amount"`
+	UnitID       sql.NullInt32  `json:"unitID"`
+	Store        sql.NullString `json:"store"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+type IngredientsPrice struct {
+	ID           int64          `json:"id"`
+	IngredientID int32          `json:"ingredientID"`
+	UnitID       sql.NullInt32  `json:"unitID"`
+	Price        float64        `json:"price"`
+	Store        sql.NullString `json:"store"`
+	ObservedOn   time.Time      `json:"observedOn"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+type IngredientsShelfLife struct {
+	IngredientID int32     `json:"ingredientID"`
+	Days         int32     `json:"days"`
+	ModifiedAt   time.Time `json:"modifiedAt"`
+}
+
+type IngredientsTag struct {
+	IngredientID int32  `json:"ingredientID"`
+	Tag          string `json:"tag"`
+}
+
+type IngredientsUnit struct {
+	IngredientID int32   `json:"ingredientID"`
+	UnitID       int32   `json:"unitID"`
+	Grams        float32 `json:"grams"`
+}
+
+type Nutrition struct {
+	IngredientID int32     `json:"ingredientID"`
+	Calories     float32   `json:"calories"`
+	Protein      float32   `json:"protein"`
+	Fat          float32   `json:"fat"`
+	Carbs        float32   `json:"carbs"`
+	Fiber        float32   `json:"fiber"`
+	Sodium       float32   `json:"sodium"`
+	ModifiedAt   time.Time `json:"modifiedAt"`
+}
+
+type PantryItem struct {
+	ID           int64         `json:"id"`
+	UserID       uuid.UUID     `json:"userID"`
+	IngredientID int32         `json:"ingredientID"`
+	Amount       float64       `json:"amount"`
+	UnitID       sql.NullInt32 `json:"unitID"`
+	ExpiresOn    sql.NullTime  `json:"expiresOn"`
+	ScheduleID   sql.NullInt64 `json:"scheduleID"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+type PantryItemsAlert struct {
+	PantryItemID int64     `json:"pantryItemID"`
+	SentAt       time.Time `json:"sentAt"`
+}
+
 type Recipe struct {
 	ID         int64          `json:"id"`
 	Name       string         `json:"name"`
@@ -26,6 +143,46 @@ type Recipe struct {
 	Steps      sql.NullString `json:"steps"`
 	CreatedAt  time.Time      `json:"createdAt"`
 	ModifiedAt time.Time      `json:"modifiedAt"`
+	ForkedFrom sql.NullInt64  `json:"forkedFrom"`
+	Cuisine    sql.NullString `json:"cuisine"`
+	Course     sql.NullString `json:"course"`
+	Difficulty sql.NullString `json:"difficulty"`
+	TotalTime  sql.NullInt32  `json:"totalTime"`
+}
+
+type RecipesConflict struct {
+	RecipeID int64     `json:"recipeID"`
+	UserID   uuid.UUID `json:"userID"`
+	Tag      string    `json:"tag"`
+}
+
+type RecipesCooked struct {
+	ID        int64     `json:"id"`
+	RecipeID  int64     `json:"recipeID"`
+	UserID    uuid.UUID `json:"userID"`
+	CookedOn  time.Time `json:"cookedOn"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type RecipesCookedLog struct {
+	ID         sql.NullInt64 `json:"id"`
+	RecipeID   int64         `json:"recipeID"`
+	UserID     uuid.UUID     `json:"userID"`
+	CookedOn   time.Time     `json:"cookedOn"`
+	ScheduleID sql.NullInt64 `json:"scheduleID"`
+}
+
+type RecipesImage struct {
+	ID           int64         `json:"id"`
+	RecipeID     int64         `json:"recipeID"`
+	StepID       sql.NullInt64 `json:"stepID"`
+	BlobKey      string        `json:"blobKey"`
+	ThumbnailKey string        `json:"thumbnailKey"`
+	ContentType  string        `json:"contentType"`
+	Size         int64         `json:"size"`
+	Width        int32         `json:"width"`
+	Height       int32         `json:"height"`
+	CreatedAt    time.Time     `json:"createdAt"`
 }
 
 type RecipesIngredient struct {
@@ -35,29 +192,113 @@ type RecipesIngredient struct {
 	UnitID       int32   `json:"unitID"`
 }
 
+type RecipesReview struct {
+	RecipeID   int64          `json:"recipeID"`
+	UserID     uuid.UUID      `json:"userID"`
+	Rating     int32          `json:"rating"`
+	Comment    sql.NullString `json:"comment"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	ModifiedAt time.Time      `json:"modifiedAt"`
+}
+
+type RecipesRevision struct {
+	ID          int64           `json:"id"`
+	RecipeID    int64           `json:"recipeID"`
+	Revision    int32           `json:"revision"`
+	Name        string          `json:"name"`
+	Portion     int32           `json:"portion"`
+	Steps       sql.NullString  `json:"steps"`
+	Ingredients json.RawMessage `json:"ingredients"`
+	Author      uuid.NullUUID   `json:"author"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	StepList    json.RawMessage `json:"stepList"`
+	Cuisine     sql.NullString  `json:"cuisine"`
+	Course      sql.NullString  `json:"course"`
+	Difficulty  sql.NullString  `json:"difficulty"`
+	TotalTime   sql.NullInt32   `json:"totalTime"`
+	Keywords    []string        `json:"keywords"`
+}
+
+type RecipesStat struct {
+	RecipeID    int64           `json:"recipeID"`
+	RatingAvg   sql.NullFloat64 `json:"ratingAvg"`
+	RatingCount int64           `json:"ratingCount"`
+	CookedCount int64           `json:"cookedCount"`
+}
+
+type RecipesStep struct {
+	ID              int64         `json:"id"`
+	RecipeID        int64         `json:"recipeID"`
+	Position        int32         `json:"position"`
+	Text            string        `json:"text"`
+	DurationSeconds sql.NullInt32 `json:"durationSeconds"`
+}
+
+type RecipesStepsIngredient struct {
+	StepID       int64 `json:"stepID"`
+	RecipeID     int64 `json:"recipeID"`
+	IngredientID int32 `json:"ingredientID"`
+}
+
+type RecipesTag struct {
+	RecipeID int64  `json:"recipeID"`
+	Tag      string `json:"tag"`
+	Kind     string `json:"kind"`
+}
+
 type Schedule struct {
 	ID        int64         `json:"id"`
 	Author    uuid.NullUUID `json:"author"`
 	CreatedAt time.Time     `json:"createdAt"`
 }
 
-type SchedulesRecipe struct {
-	ScheduleID int64 `json:"scheduleID"`
+type ScheduleTemplate struct {
+	ID        int64     `json:"id"`
+	Author    uuid.UUID `json:"author"`
+	Name      string    `json:"name"`
+	Rrule     string    `json:"rrule"`
+	StartsOn  time.Time `json:"startsOn"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ScheduleTemplatesRecipe struct {
+	TemplateID int64 `json:"templateID"`
 	RecipeID   int64 `json:"recipeID"`
 	Portion    int32 `json:"portion"`
 }
 
+type SchedulesRecipe struct {
+	ScheduleID    int64         `json:"scheduleID"`
+	RecipeID      int64         `json:"recipeID"`
+	Portion       int32         `json:"portion"`
+	ScheduledDate sql.NullTime  `json:"scheduledDate"`
+	RevisionID    sql.NullInt64 `json:"revisionID"`
+}
+
 type Unit struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID    int32           `json:"id"`
+	Name  string          `json:"name"`
+	Grams sql.NullFloat64 `json:"grams"`
 }
 
 type User struct {
-	ID         uuid.UUID    `json:"id"`
-	Username   string       `json:"username"`
-	Email      string       `json:"email"`
-	Password   string       `json:"password"`
-	CreatedAt  time.Time    `json:"createdAt"`
-	Role       string       `json:"role"`
-	VerifiedAt sql.NullTime `json:"verifiedAt"`
+	ID              uuid.UUID     `json:"id"`
+	Username        string        `json:"username"`
+	Email           string        `json:"email"`
+	Password        string        `json:"password"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	Role            string        `json:"role"`
+	VerifiedAt      sql.NullTime  `json:"verifiedAt"`
+	ExpiryAlertDays sql.NullInt32 `json:"expiryAlertDays"`
+}
+
+type UsersFavorite struct {
+	UserID    uuid.UUID `json:"userID"`
+	RecipeID  int64     `json:"recipeID"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type UsersRestriction struct {
+	UserID uuid.UUID `json:"userID"`
+	Tag    string    `json:"tag"`
 }
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Nutrition facts are stored per 100 g of an ingredient, sodium is in mg
+const nutritionBaseGrams = 100
+
+type NutritionFacts struct {
+	Calories float64 `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Fat      float64 `json:"fat"`
+	Carbs    float64 `json:"carbs"`
+	Fiber    float64 `json:"fiber"`
+	Sodium   float64 `json:"sodium"`
+}
+
+type RecipeNutrition struct {
+	Total      NutritionFacts `json:"total"`
+	PerPortion NutritionFacts `json:"perPortion"`
+	// Ingredients left out of the totals because their amount can not be
+	// converted to grams or they have no nutrition data
+	Missing []int32 `json:"missing"`
+}
+
+type DailyNutrition struct {
+	Date  sql.NullTime   `json:"date"`
+	Total NutritionFacts `json:"total"`
+}
+
+type ScheduleNutritionResult struct {
+	ScheduleID int64            `json:"scheduleID"`
+	Days       []DailyNutrition `json:"days"`
+	Missing    []int32          `json:"missing"`
+}
+
+func (n *NutritionFacts) add(facts NutritionFacts, factor float64) {
+	n.Calories += facts.Calories * factor
+	n.Protein += facts.Protein * factor
+	n.Fat += facts.Fat * factor
+	n.Carbs += facts.Carbs * factor
+	n.Fiber += facts.Fiber * factor
+	n.Sodium += facts.Sodium * factor
+}
+
+// Convert an ingredient amount to grams. Ingredient specific conversions
+// (1 cup of flour) take precedence over the unit mass (1 kg).
+func amountToGrams(amount float32, ingredientUnitGrams, unitGrams sql.NullFloat64) (float64, bool) {
+	if ingredientUnitGrams.Valid {
+		return float64(amount) * ingredientUnitGrams.Float64, true
+	}
+	if unitGrams.Valid {
+		return float64(amount) * unitGrams.Float64, true
+	}
+
+	return 0, false
+}
+
+func nutritionFacts(calories, protein, fat, carbs, fiber, sodium sql.NullFloat64) (NutritionFacts, bool) {
+	// nutrition columns are NOT NULL, so a null calories means the row is missing
+	if !calories.Valid {
+		return NutritionFacts{}, false
+	}
+
+	return NutritionFacts{
+		Calories: calories.Float64,
+		Protein:  protein.Float64,
+		Fat:      fat.Float64,
+		Carbs:    carbs.Float64,
+		Fiber:    fiber.Float64,
+		Sodium:   sodium.Float64,
+	}, true
+}
+
+func appendMissing(missing []int32, id int32) []int32 {
+	for _, m := range missing {
+		if m == id {
+			return missing
+		}
+	}
+
+	return append(missing, id)
+}
+
+func computeRecipeNutrition(rows []ListRecipeNutritionRow, portion int32) RecipeNutrition {
+	result := RecipeNutrition{
+		Missing: []int32{},
+	}
+
+	for _, row := range rows {
+		grams, ok := amountToGrams(row.Amount, row.IngredientUnitGrams, row.UnitGrams)
+		if !ok {
+			result.Missing = appendMissing(result.Missing, row.IngredientID)
+			continue
+		}
+		facts, ok := nutritionFacts(row.Calories, row.Protein, row.Fat, row.Carbs, row.Fiber, row.Sodium)
+		if !ok {
+			result.Missing = appendMissing(result.Missing, row.IngredientID)
+			continue
+		}
+
+		result.Total.add(facts, grams/nutritionBaseGrams)
+	}
+
+	if portion > 0 {
+		result.PerPortion.add(result.Total, 1/float64(portion))
+	}
+
+	return result
+}
+
+func computeScheduleNutrition(scheduleID int64, rows []ListScheduleNutritionRow) ScheduleNutritionResult {
+	result := ScheduleNutritionResult{
+		ScheduleID: scheduleID,
+		Days:       []DailyNutrition{},
+		Missing:    []int32{},
+	}
+
+	// rows are ordered by scheduled date, undated entries come last
+	for _, row := range rows {
+		date := truncateDate(row.ScheduledDate)
+		if len(result.Days) == 0 || result.Days[len(result.Days)-1].Date != date {
+			result.Days = append(result.Days, DailyNutrition{Date: date})
+		}
+		day := &result.Days[len(result.Days)-1]
+
+		grams, ok := amountToGrams(row.Amount, row.IngredientUnitGrams, row.UnitGrams)
+		if !ok {
+			result.Missing = appendMissing(result.Missing, row.IngredientID)
+			continue
+		}
+		facts, ok := nutritionFacts(row.Calories, row.Protein, row.Fat, row.Carbs, row.Fiber, row.Sodium)
+		if !ok {
+			result.Missing = appendMissing(result.Missing, row.IngredientID)
+			continue
+		}
+
+		// scale the recipe amount to the scheduled number of portions
+		factor := grams / nutritionBaseGrams
+		if row.RecipePortion > 0 {
+			factor *= float64(row.SchedulePortion) / float64(row.RecipePortion)
+		}
+		day.Total.add(facts, factor)
+	}
+
+	return result
+}
+
+func truncateDate(date sql.NullTime) sql.NullTime {
+	if !date.Valid {
+		return sql.NullTime{}
+	}
+	y, m, d := date.Time.Date()
+
+	return sql.NullTime{
+		Time:  time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+		Valid: true,
+	}
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomNutrition(t *testing.T, ingredient Ingredient) Nutrition {
+	arg := UpsertNutritionParams{
+		IngredientID: ingredient.ID,
+		Calories:     float32(util.RandomInt(0, 900)),
+		Protein:      float32(util.RandomInt(0, 100)),
+		Fat:          float32(util.RandomInt(0, 100)),
+		Carbs:        float32(util.RandomInt(0, 100)),
+		Fiber:        float32(util.RandomInt(0, 30)),
+		Sodium:       float32(util.RandomInt(0, 2000)),
+	}
+
+	nutrition, err := testQueries.UpsertNutrition(
+		context.Background(),
+		arg,
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, nutrition)
+
+	require.Equal(t, arg.IngredientID, nutrition.IngredientID)
+	require.Equal(t, arg.Calories, nutrition.Calories)
+	require.Equal(t, arg.Protein, nutrition.Protein)
+	require.Equal(t, arg.Fat, nutrition.Fat)
+	require.Equal(t, arg.Carbs, nutrition.Carbs)
+	require.Equal(t, arg.Fiber, nutrition.Fiber)
+	require.Equal(t, arg.Sodium, nutrition.Sodium)
+	require.NotZero(t, nutrition.ModifiedAt)
+
+	return nutrition
+}
+
+func TestUpsertNutrition(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	nutritionNew := CreateRandomNutrition(t, ingredient)
+
+	// Update existing nutrition
+	arg := UpsertNutritionParams{
+		IngredientID: ingredient.ID,
+		Calories:     nutritionNew.Calories + 1,
+		Protein:      nutritionNew.Protein,
+		Fat:          nutritionNew.Fat,
+		Carbs:        nutritionNew.Carbs,
+		Fiber:        nutritionNew.Fiber,
+		Sodium:       nutritionNew.Sodium,
+	}
+
+	nutrition, err := testQueries.UpsertNutrition(
+		context.Background(),
+		arg,
+	)
+	require.NoError(t, err)
+	require.Equal(t, arg.Calories, nutrition.Calories)
+	require.WithinDuration(t, time.Now().UTC(), nutrition.ModifiedAt, time.Second)
+}
+
+func TestGetNutrition(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	nutritionNew := CreateRandomNutrition(t, ingredient)
+
+	nutrition, err := testQueries.GetNutrition(
+		context.Background(),
+		ingredient.ID,
+	)
+	require.NoError(t, err)
+	require.Equal(t, nutritionNew.IngredientID, nutrition.IngredientID)
+	require.Equal(t, nutritionNew.Calories, nutrition.Calories)
+	require.WithinDuration(t, nutritionNew.ModifiedAt, nutrition.ModifiedAt, time.Second)
+}
+
+func TestDeleteNutrition(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	CreateRandomNutrition(t, ingredient)
+
+	err := testQueries.DeleteNutrition(
+		context.Background(),
+		ingredient.ID,
+	)
+	require.NoError(t, err)
+
+	nutrition, err := testQueries.GetNutrition(
+		context.Background(),
+		ingredient.ID,
+	)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, nutrition)
+}
+
+func TestListRecipeNutrition(t *testing.T) {
+	recipe, recipeIngredients := CreateRandomRecipeIngredient(t)
+	ingredient, err := testQueries.GetIngredient(context.Background(), recipeIngredients[0].IngredientID)
+	require.NoError(t, err)
+	nutrition := CreateRandomNutrition(t, ingredient)
+
+	rows, err := testQueries.ListRecipeNutrition(
+		context.Background(),
+		recipe.ID,
+	)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	require.Equal(t, recipeIngredients[0].IngredientID, rows[0].IngredientID)
+	require.Equal(t, recipeIngredients[0].Amount, rows[0].Amount)
+	require.True(t, rows[0].UnitGrams.Valid)
+	require.False(t, rows[0].IngredientUnitGrams.Valid)
+	require.True(t, rows[0].Calories.Valid)
+	require.InDelta(t, nutrition.Calories, rows[0].Calories.Float64, 0.01)
+}
+
+func TestComputeRecipeNutrition(t *testing.T) {
+	rows := []ListRecipeNutritionRow{
+		{
+			// 2 pieces of 50 g
+			IngredientID:        1,
+			Amount:              2,
+			IngredientUnitGrams: sql.NullFloat64{Float64: 50, Valid: true},
+			UnitGrams:           sql.NullFloat64{},
+			Calories:            sql.NullFloat64{Float64: 100, Valid: true},
+			Protein:             sql.NullFloat64{Float64: 10, Valid: true},
+			Fat:                 sql.NullFloat64{Float64: 5, Valid: true},
+			Carbs:               sql.NullFloat64{Float64: 1, Valid: true},
+			Fiber:               sql.NullFloat64{Float64: 0, Valid: true},
+			Sodium:              sql.NullFloat64{Float64: 100, Valid: true},
+		},
+		{
+			// 0.5 kg
+			IngredientID: 2,
+			Amount:       0.5,
+			UnitGrams:    sql.NullFloat64{Float64: 1000, Valid: true},
+			Calories:     sql.NullFloat64{Float64: 50, Valid: true},
+			Protein:      sql.NullFloat64{Float64: 1, Valid: true},
+			Fat:          sql.NullFloat64{Float64: 0, Valid: true},
+			Carbs:        sql.NullFloat64{Float64: 10, Valid: true},
+			Fiber:        sql.NullFloat64{Float64: 2, Valid: true},
+			Sodium:       sql.NullFloat64{Float64: 0, Valid: true},
+		},
+		{
+			// unit without mass
+			IngredientID: 3,
+			Amount:       1,
+			Calories:     sql.NullFloat64{Float64: 50, Valid: true},
+		},
+		{
+			// no nutrition data
+			IngredientID: 4,
+			Amount:       10,
+			UnitGrams:    sql.NullFloat64{Float64: 1, Valid: true},
+		},
+	}
+
+	result := computeRecipeNutrition(rows, 2)
+	require.InDelta(t, 350, result.Total.Calories, 0.001)
+	require.InDelta(t, 15, result.Total.Protein, 0.001)
+	require.InDelta(t, 5, result.Total.Fat, 0.001)
+	require.InDelta(t, 51, result.Total.Carbs, 0.001)
+	require.InDelta(t, 10, result.Total.Fiber, 0.001)
+	require.InDelta(t, 100, result.Total.Sodium, 0.001)
+	require.InDelta(t, 175, result.PerPortion.Calories, 0.001)
+	require.Equal(t, []int32{3, 4}, result.Missing)
+}
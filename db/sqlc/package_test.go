@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomIngredientPackage(t *testing.T, ingredientID int32, unitID sql.NullInt32, store sql.NullString) IngredientsPackage {
+	arg := CreateIngredientPackageParams{
+		IngredientID: ingredientID,
+		Amount:       float64(util.RandomInt(1, 20)) * 50,
+		UnitID:       unitID,
+		Store:        store,
+	}
+
+	pkg, err := testQueries.CreateIngredientPackage(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, pkg.ID)
+	require.Equal(t, arg.IngredientID, pkg.IngredientID)
+	require.Equal(t, arg.Amount, pkg.Amount)
+	require.Equal(t, arg.UnitID, pkg.UnitID)
+	require.Equal(t, arg.Store, pkg.Store)
+	require.NotZero(t, pkg.CreatedAt)
+
+	return pkg
+}
+
+func TestCreateIngredientPackage(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	unit := CreateRandomUnit(t)
+	pkg := createRandomIngredientPackage(t, ingredient.ID, sql.NullInt32{Int32: unit.ID, Valid: true}, sql.NullString{})
+
+	// the same size can only be defined once per store
+	_, err := testQueries.CreateIngredientPackage(context.Background(), CreateIngredientPackageParams{
+		IngredientID: ingredient.ID,
+		Amount:       pkg.Amount,
+		UnitID:       pkg.UnitID,
+	})
+	require.Error(t, err)
+
+	_, err = testQueries.CreateIngredientPackage(context.Background(), CreateIngredientPackageParams{
+		IngredientID: ingredient.ID,
+		Amount:       pkg.Amount,
+		UnitID:       pkg.UnitID,
+		Store:        sql.NullString{String: util.RandomString(8), Valid: true},
+	})
+	require.NoError(t, err)
+}
+
+func TestListIngredientPackages(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	unit := CreateRandomUnit(t)
+	unitID := sql.NullInt32{Int32: unit.ID, Valid: true}
+
+	stored := createRandomIngredientPackage(t, ingredient.ID, unitID, sql.NullString{String: util.RandomString(8), Valid: true})
+	anywhere := createRandomIngredientPackage(t, ingredient.ID, unitID, sql.NullString{})
+
+	packages, err := testQueries.ListIngredientPackages(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+	require.Equal(t, anywhere.ID, packages[0].ID)
+	require.Equal(t, stored.ID, packages[1].ID)
+	require.Equal(t, sql.NullString{String: unit.Name, Valid: true}, packages[0].UnitName)
+}
+
+func TestDeleteIngredientPackage(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	pkg := createRandomIngredientPackage(t, ingredient.ID, sql.NullInt32{}, sql.NullString{})
+
+	err := testQueries.DeleteIngredientPackage(context.Background(), pkg.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIngredientPackage(context.Background(), pkg.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGenerateGroceriesPackages(t *testing.T) {
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+	ingredient := ingredients[0]
+	store := sql.NullString{String: util.RandomString(8), Valid: true}
+	unitID := sql.NullInt32{Int32: ingredient.UnitID, Valid: true}
+
+	anywhere := createRandomIngredientPackage(t, ingredient.IngredientID, unitID, sql.NullString{})
+	stored := createRandomIngredientPackage(t, ingredient.IngredientID, unitID, store)
+
+	author := CreateRandomUser(t)
+	arg := GenerateGroceriesParam{
+		Author: uuid.NullUUID{UUID: author.ID, Valid: true},
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: recipe.ID, Portion: recipe.Portion},
+		},
+	}
+
+	result, err := NewStorage(testDB).GenerateGroceries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Groceries, 1)
+	if ingredient.Amount == 0 {
+		require.Nil(t, result.Groceries[0].Purchase)
+		return
+	}
+	require.NotNil(t, result.Groceries[0].Purchase)
+	require.Equal(t, anywhere.ID, result.Groceries[0].Purchase.PackageID)
+
+	arg.Store = store.String
+	result, err = NewStorage(testDB).GenerateGroceries(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.Groceries[0].Purchase)
+	require.Equal(t, stored.ID, result.Groceries[0].Purchase.PackageID)
+	require.Equal(t, store.String, result.Groceries[0].Purchase.Store)
+}
+
+func TestPackGroceries(t *testing.T) {
+	g := sql.NullString{String: "g", Valid: true}
+	rows := []ListGroceryAmountsRow{
+		// 150 g of butter
+		{ID: 1, Amount: 150, UnitName: g, RecipePortion: 2, SchedulePortion: 2},
+		// 3 eggs for 2 portions, twice
+		{ID: 2, Amount: 3, RecipePortion: 2, SchedulePortion: 4},
+		// 2 cups of flour of 120 g
+		{ID: 3, Amount: 2, UnitName: sql.NullString{String: "cup", Valid: true}, IngredientUnitGrams: sql.NullFloat64{Float64: 120, Valid: true}, RecipePortion: 1, SchedulePortion: 1},
+		// a pinch of salt
+		{ID: 4, Amount: 1, UnitName: sql.NullString{String: "pinch", Valid: true}, RecipePortion: 1, SchedulePortion: 1},
+	}
+	packages := []ListGroceryPackagesRow{
+		{ID: 1, IngredientID: 1, Amount: 125, UnitName: g},
+		{ID: 2, IngredientID: 1, Amount: 250, UnitName: g},
+		{ID: 3, IngredientID: 2, Amount: 6},
+		{ID: 4, IngredientID: 2, Amount: 10, Store: sql.NullString{String: "market", Valid: true}},
+		{ID: 5, IngredientID: 3, Amount: 1, UnitName: sql.NullString{String: "kg", Valid: true}, UnitGrams: sql.NullFloat64{Float64: 1000, Valid: true}},
+		{ID: 6, IngredientID: 4, Amount: 500, UnitName: g, UnitGrams: sql.NullFloat64{Float64: 1, Valid: true}},
+	}
+	items := []GroceryItem{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	packGroceries(rows, packages, items, "market")
+
+	// two 125 g blocks leave as much as one 250 g block
+	require.Equal(t, &GroceryPurchase{
+		PackageID: 2,
+		Size:      measure.Quantity{Amount: 250, Unit: "g"},
+		Count:     1,
+		Leftover:  measure.Quantity{Amount: 100, Unit: "g"},
+	}, items[0].Purchase)
+	// the store's own package wins
+	require.Equal(t, &GroceryPurchase{
+		PackageID: 4,
+		Store:     "market",
+		Size:      measure.Quantity{Amount: 10},
+		Count:     1,
+		Leftover:  measure.Quantity{Amount: 4},
+	}, items[1].Purchase)
+	require.Equal(t, int64(5), items[2].Purchase.PackageID)
+	require.Equal(t, 1, items[2].Purchase.Count)
+	require.Equal(t, measure.Quantity{Amount: 0.76, Unit: "kg"}, items[2].Purchase.Leftover)
+	// a pinch can not be converted to grams
+	require.Nil(t, items[3].Purchase)
+}
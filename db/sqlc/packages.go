@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+// Whole packages to buy for a grocery line
+type GroceryPurchase struct {
+	PackageID int64            `json:"packageID"`
+	Store     string           `json:"store,omitempty"`
+	Size      measure.Quantity `json:"size"`
+	Count     int              `json:"count"`
+	// Bought but not needed by the schedule, left for the pantry
+	Leftover measure.Quantity `json:"leftover"`
+}
+
+// Round the amount of every grocery line up to whole packages. Packages of the
+// store are used when the ingredient has some, packages for any store otherwise.
+// Of the sizes the whole amount can be converted to, the one leaving the smallest
+// share of what is bought unused is picked, then the one needing fewer packages.
+func packGroceries(rows []ListGroceryAmountsRow, packages []ListGroceryPackagesRow, items []GroceryItem, store string) {
+	amounts := map[int32][]ListGroceryAmountsRow{}
+	for _, row := range rows {
+		amounts[row.ID] = append(amounts[row.ID], row)
+	}
+
+	storePackages := map[int32][]ListGroceryPackagesRow{}
+	anyPackages := map[int32][]ListGroceryPackagesRow{}
+	for _, p := range packages {
+		if p.Store.Valid {
+			if store != "" && p.Store.String == store {
+				storePackages[p.IngredientID] = append(storePackages[p.IngredientID], p)
+			}
+			continue
+		}
+		anyPackages[p.IngredientID] = append(anyPackages[p.IngredientID], p)
+	}
+
+	for i := range items {
+		candidates := storePackages[items[i].ID]
+		if len(candidates) == 0 {
+			candidates = anyPackages[items[i].ID]
+		}
+
+		var best *GroceryPurchase
+		bestWaste := math.Inf(1)
+		for _, p := range candidates {
+			needed, ok := packageAmount(amounts[items[i].ID], p)
+			if !ok || needed <= 0 {
+				continue
+			}
+
+			count := int(math.Ceil(needed/p.Amount - 1e-9))
+			bought := float64(count) * p.Amount
+			waste := (bought - needed) / bought
+			if best != nil && (waste > bestWaste+1e-9 || (math.Abs(waste-bestWaste) <= 1e-9 && count >= best.Count)) {
+				continue
+			}
+
+			best = &GroceryPurchase{
+				PackageID: p.ID,
+				Store:     p.Store.String,
+				Size:      measure.Quantity{Amount: p.Amount, Unit: p.UnitName.String},
+				Count:     count,
+				Leftover:  measure.Round(measure.Quantity{Amount: bought - needed, Unit: p.UnitName.String}),
+			}
+			bestWaste = waste
+		}
+
+		items[i].Purchase = best
+	}
+}
+
+// Total amount of the rows in the unit of the package, not ok when one of the
+// amounts can not be converted
+func packageAmount(rows []ListGroceryAmountsRow, p ListGroceryPackagesRow) (float64, bool) {
+	var total float64
+	for _, row := range rows {
+		amount, ok := convertAmount(
+			measure.Scale(float64(row.Amount), row.RecipePortion, row.SchedulePortion),
+			row.UnitName, row.IngredientUnitGrams, row.UnitGrams,
+			p.UnitName, p.IngredientUnitGrams, p.UnitGrams,
+		)
+		if !ok {
+			return 0, false
+		}
+		total += amount
+	}
+
+	return total, true
+}
+
+// Load the package sizes of the grocery lines and round them up to packages
+func (q *Queries) purchaseGroceries(ctx context.Context, rows []ListGroceryAmountsRow, items []GroceryItem, store string) ([]ListGroceryPackagesRow, error) {
+	ingredientIDs := make([]int32, 0, len(items))
+	for _, item := range items {
+		ingredientIDs = append(ingredientIDs, item.ID)
+	}
+
+	packages, err := q.ListGroceryPackages(ctx, ListGroceryPackagesParams{
+		IngredientIds: ingredientIDs,
+		Store: sql.NullString{
+			String: store,
+			Valid:  store != "",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	packGroceries(rows, packages, items, store)
+
+	return packages, nil
+}
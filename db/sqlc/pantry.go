@@ -0,0 +1,107 @@
+package db
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+)
+
+// Part of the packages bought for a schedule that its recipes do not use
+type ScheduleLeftover struct {
+	IngredientID int32         `json:"ingredientID"`
+	Name         string        `json:"name"`
+	Amount       float64       `json:"amount"`
+	Unit         string        `json:"unit"`
+	UnitID       sql.NullInt32 `json:"unitID"`
+	// Unset when the ingredient has no known shelf life
+	ExpiresOn sql.NullTime `json:"expiresOn"`
+}
+
+// A recipe using ingredients of the pantry
+type PantrySuggestion struct {
+	RecipeID    int64   `json:"recipeID"`
+	RecipeName  string  `json:"recipeName"`
+	Ingredients []int32 `json:"ingredients"`
+	// Earliest expiry of the pantry items the recipe uses
+	ExpiresOn sql.NullTime `json:"expiresOn"`
+}
+
+// Leftovers of the packages picked for the grocery lines, expiring a shelf life
+// after the schedule is cooked
+func scheduleLeftovers(items []GroceryItem, packages []ListGroceryPackagesRow, shelfLives []IngredientsShelfLife, cookedOn time.Time) []ScheduleLeftover {
+	result := []ScheduleLeftover{}
+
+	units := map[int64]sql.NullInt32{}
+	for _, p := range packages {
+		units[p.ID] = p.UnitID
+	}
+	days := map[int32]int32{}
+	for _, s := range shelfLives {
+		days[s.IngredientID] = s.Days
+	}
+
+	for _, item := range items {
+		if item.Purchase == nil || item.Purchase.Leftover.Amount <= 0 {
+			continue
+		}
+
+		leftover := ScheduleLeftover{
+			IngredientID: item.ID,
+			Name:         item.Name,
+			Amount:       item.Purchase.Leftover.Amount,
+			Unit:         item.Purchase.Leftover.Unit,
+			UnitID:       units[item.Purchase.PackageID],
+		}
+		if d, ok := days[item.ID]; ok {
+			leftover.ExpiresOn = sql.NullTime{
+				Time:  cookedOn.AddDate(0, 0, int(d)),
+				Valid: true,
+			}
+		}
+		result = append(result, leftover)
+	}
+
+	return result
+}
+
+// Group the pantry rows per recipe, recipes using the items expiring first come
+// first, then the ones using more of the pantry
+func rankPantryRecipes(rows []ListPantryRecipesRow, limit int) []PantrySuggestion {
+	result := []PantrySuggestion{}
+
+	// rows are ordered by recipe and ingredient
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].RecipeID != row.RecipeID {
+			result = append(result, PantrySuggestion{
+				RecipeID:    row.RecipeID,
+				RecipeName:  row.RecipeName,
+				Ingredients: []int32{},
+			})
+		}
+		s := &result[len(result)-1]
+
+		if n := len(s.Ingredients); n == 0 || s.Ingredients[n-1] != row.IngredientID {
+			s.Ingredients = append(s.Ingredients, row.IngredientID)
+		}
+		if row.ExpiresOn.Valid && (!s.ExpiresOn.Valid || row.ExpiresOn.Time.Before(s.ExpiresOn.Time)) {
+			s.ExpiresOn = row.ExpiresOn
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.ExpiresOn.Valid != b.ExpiresOn.Valid {
+			return a.ExpiresOn.Valid
+		}
+		if a.ExpiresOn.Valid && !a.ExpiresOn.Time.Equal(b.ExpiresOn.Time) {
+			return a.ExpiresOn.Time.Before(b.ExpiresOn.Time)
+		}
+		return len(a.Ingredients) > len(b.Ingredients)
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/hasnaroihan/grocery-planner/planner"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertIngredientShelfLife(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+
+	shelfLife, err := testQueries.UpsertIngredientShelfLife(context.Background(), UpsertIngredientShelfLifeParams{
+		IngredientID: ingredient.ID,
+		Days:         3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), shelfLife.Days)
+
+	shelfLife, err = testQueries.UpsertIngredientShelfLife(context.Background(), UpsertIngredientShelfLifeParams{
+		IngredientID: ingredient.ID,
+		Days:         7,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(7), shelfLife.Days)
+
+	got, err := testQueries.GetIngredientShelfLife(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Equal(t, shelfLife, got)
+}
+
+func TestPantryItems(t *testing.T) {
+	user := CreateRandomUser(t)
+	ingredient := CreateRandomIngredient(t)
+
+	later, err := testQueries.CreatePantryItem(context.Background(), CreatePantryItemParams{
+		UserID:       user.ID,
+		IngredientID: ingredient.ID,
+		Amount:       float64(util.RandomInt(1, 500)),
+		ExpiresOn:    sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, 7), Valid: true},
+	})
+	require.NoError(t, err)
+	sooner, err := testQueries.CreatePantryItem(context.Background(), CreatePantryItemParams{
+		UserID:       user.ID,
+		IngredientID: ingredient.ID,
+		Amount:       float64(util.RandomInt(1, 500)),
+		ExpiresOn:    sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, 1), Valid: true},
+	})
+	require.NoError(t, err)
+
+	items, err := testQueries.ListPantryItems(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, sooner.ID, items[0].ID)
+	require.Equal(t, later.ID, items[1].ID)
+	require.Equal(t, ingredient.Name, items[0].Name)
+
+	err = testQueries.DeletePantryItem(context.Background(), sooner.ID)
+	require.NoError(t, err)
+	_, err = testQueries.GetPantryItem(context.Background(), sooner.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestExpiringPantryItems(t *testing.T) {
+	user := CreateRandomUser(t)
+	ingredient := CreateRandomIngredient(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	_, err := testQueries.UpdateExpiryAlert(context.Background(), UpdateExpiryAlertParams{
+		ID:              user.ID,
+		ExpiryAlertDays: sql.NullInt32{Int32: 2, Valid: true},
+	})
+	require.NoError(t, err)
+
+	expiring, err := testQueries.CreatePantryItem(context.Background(), CreatePantryItemParams{
+		UserID:       user.ID,
+		IngredientID: ingredient.ID,
+		Amount:       float64(util.RandomInt(1, 500)),
+		ExpiresOn:    sql.NullTime{Time: today.AddDate(0, 0, 2), Valid: true},
+	})
+	require.NoError(t, err)
+	// outside of the alert window
+	_, err = testQueries.CreatePantryItem(context.Background(), CreatePantryItemParams{
+		UserID:       user.ID,
+		IngredientID: ingredient.ID,
+		Amount:       float64(util.RandomInt(1, 500)),
+		ExpiresOn:    sql.NullTime{Time: today.AddDate(0, 0, 3), Valid: true},
+	})
+	require.NoError(t, err)
+
+	userItems := func() []ListExpiringPantryItemsRow {
+		rows, err := testQueries.ListExpiringPantryItems(context.Background(), today)
+		require.NoError(t, err)
+
+		result := []ListExpiringPantryItemsRow{}
+		for _, row := range rows {
+			if row.UserID == user.ID {
+				result = append(result, row)
+			}
+		}
+		return result
+	}
+
+	items := userItems()
+	require.Len(t, items, 1)
+	require.Equal(t, expiring.ID, items[0].ID)
+	require.Equal(t, user.Email, items[0].Email)
+	require.Equal(t, ingredient.Name, items[0].Name)
+
+	err = testQueries.MarkPantryItemsAlerted(context.Background(), []int64{expiring.ID})
+	require.NoError(t, err)
+	// marking twice is not an error
+	err = testQueries.MarkPantryItemsAlerted(context.Background(), []int64{expiring.ID})
+	require.NoError(t, err)
+	require.Empty(t, userItems())
+}
+
+func TestAddScheduleLeftoversTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+	ingredient := ingredients[0]
+	if ingredient.Amount == 0 {
+		t.Skip("recipe ingredient without an amount leaves no leftovers")
+	}
+
+	_, err := testQueries.CreateIngredientPackage(context.Background(), CreateIngredientPackageParams{
+		IngredientID: ingredient.IngredientID,
+		Amount:       float64(ingredient.Amount) + 50,
+		UnitID:       sql.NullInt32{Int32: ingredient.UnitID, Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = testQueries.UpsertIngredientShelfLife(context.Background(), UpsertIngredientShelfLifeParams{
+		IngredientID: ingredient.IngredientID,
+		Days:         4,
+	})
+	require.NoError(t, err)
+
+	author := CreateRandomUser(t)
+	cookedOn := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	schedule, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+		Author: uuid.NullUUID{UUID: author.ID, Valid: true},
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: recipe.ID, Portion: recipe.Portion, ScheduledDate: sql.NullTime{Time: cookedOn, Valid: true}},
+		},
+	})
+	require.NoError(t, err)
+
+	arg := ScheduleLeftoversParams{
+		ScheduleID: schedule.Schedule.ID,
+		UserID:     author.ID,
+	}
+	leftovers, err := storage.ListScheduleLeftoversTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, leftovers, 1)
+	require.Equal(t, 50.0, leftovers[0].Amount)
+	require.Equal(t, cookedOn.AddDate(0, 0, 4), leftovers[0].ExpiresOn.Time.UTC())
+
+	items, err := storage.AddScheduleLeftoversTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, author.ID, items[0].UserID)
+	require.Equal(t, sql.NullInt64{Int64: schedule.Schedule.ID, Valid: true}, items[0].ScheduleID)
+
+	// leftovers of a schedule are only added once
+	_, err = storage.AddScheduleLeftoversTx(context.Background(), arg)
+	require.Error(t, err)
+
+	suggestions, err := storage.SuggestPantryRecipesTx(context.Background(), SuggestPantryRecipesParams{
+		UserID: author.ID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+}
+
+func TestScheduleLeftovers(t *testing.T) {
+	cookedOn := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	items := []GroceryItem{
+		{
+			ID:   1,
+			Name: "butter",
+			Purchase: &GroceryPurchase{
+				PackageID: 10,
+				Count:     1,
+				Leftover:  measure.Quantity{Amount: 100, Unit: "g"},
+			},
+		},
+		// used up
+		{ID: 2, Name: "eggs", Purchase: &GroceryPurchase{PackageID: 11, Count: 1}},
+		// no package
+		{ID: 3, Name: "salt"},
+		{
+			ID:   4,
+			Name: "rice",
+			Purchase: &GroceryPurchase{
+				PackageID: 12,
+				Count:     1,
+				Leftover:  measure.Quantity{Amount: 0.5, Unit: "kg"},
+			},
+		},
+	}
+	packages := []ListGroceryPackagesRow{
+		{ID: 10, IngredientID: 1, UnitID: sql.NullInt32{Int32: 1, Valid: true}},
+		{ID: 11, IngredientID: 2},
+		{ID: 12, IngredientID: 4, UnitID: sql.NullInt32{Int32: 2, Valid: true}},
+	}
+	shelfLives := []IngredientsShelfLife{{IngredientID: 1, Days: 14}}
+
+	leftovers := scheduleLeftovers(items, packages, shelfLives, cookedOn)
+	require.Equal(t, []ScheduleLeftover{
+		{
+			IngredientID: 1,
+			Name:         "butter",
+			Amount:       100,
+			Unit:         "g",
+			UnitID:       sql.NullInt32{Int32: 1, Valid: true},
+			ExpiresOn:    sql.NullTime{Time: cookedOn.AddDate(0, 0, 14), Valid: true},
+		},
+		{
+			IngredientID: 4,
+			Name:         "rice",
+			Amount:       0.5,
+			Unit:         "kg",
+			UnitID:       sql.NullInt32{Int32: 2, Valid: true},
+		},
+	}, leftovers)
+}
+
+func TestRankPantryRecipes(t *testing.T) {
+	soon := sql.NullTime{Time: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), Valid: true}
+	later := sql.NullTime{Time: time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC), Valid: true}
+	rows := []ListPantryRecipesRow{
+		{RecipeID: 1, RecipeName: "soup", IngredientID: 1},
+		{RecipeID: 1, RecipeName: "soup", IngredientID: 2},
+		{RecipeID: 2, RecipeName: "stew", IngredientID: 3, ExpiresOn: later},
+		{RecipeID: 3, RecipeName: "salad", IngredientID: 4, ExpiresOn: soon},
+		{RecipeID: 3, RecipeName: "salad", IngredientID: 4, ExpiresOn: later},
+		{RecipeID: 4, RecipeName: "bake", IngredientID: 3, ExpiresOn: later},
+		{RecipeID: 4, RecipeName: "bake", IngredientID: 5, ExpiresOn: later},
+	}
+
+	suggestions := rankPantryRecipes(rows, 0)
+	require.Len(t, suggestions, 4)
+	// expiring first, then using more of the pantry, items without expiry last
+	require.Equal(t, int64(3), suggestions[0].RecipeID)
+	require.Equal(t, []int32{4}, suggestions[0].Ingredients)
+	require.Equal(t, soon, suggestions[0].ExpiresOn)
+	require.Equal(t, int64(4), suggestions[1].RecipeID)
+	require.Equal(t, int64(2), suggestions[2].RecipeID)
+	require.Equal(t, int64(1), suggestions[3].RecipeID)
+	require.False(t, suggestions[3].ExpiresOn.Valid)
+
+	require.Len(t, rankPantryRecipes(rows, 2), 2)
+}
+
+func TestPlannerPantry(t *testing.T) {
+	start := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	items := []ListPantryItemsRow{
+		{IngredientID: 1, ExpiresOn: sql.NullTime{Time: start.AddDate(0, 0, 2), Valid: true}},
+		{IngredientID: 2},
+		{IngredientID: 3, ExpiresOn: sql.NullTime{Time: start.AddDate(0, 0, -1), Valid: true}},
+	}
+
+	require.Equal(t, []planner.PantryItem{
+		{IngredientID: 1, LastDay: 2},
+		{IngredientID: 2, LastDay: 6},
+	}, plannerPantry(items, start, 7))
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestMealPlanTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := newTaxonomyRecipe(t, storage, RecipeTaxonomy{}, nil)
+	for _, ingredient := range recipe.Ingredients {
+		createRandomIngredientPrice(t, ingredient.IngredientID, sql.NullInt32{Int32: ingredient.UnitID, Valid: true}, time.Now().UTC())
+	}
+
+	user := CreateRandomUser(t)
+	startDate := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	arg := SuggestMealPlanParams{
+		Author:       uuid.NullUUID{UUID: user.ID, Valid: true},
+		Restrictions: RestrictionsExclude,
+		Budget:       1000000,
+		Days:         1,
+		MealsPerDay:  1,
+		Servings:     2,
+		StartDate:    startDate,
+	}
+
+	suggestion, err := storage.SuggestMealPlanTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, suggestion.Meals, 1)
+	require.Len(t, suggestion.Recipes, 1)
+	require.LessOrEqual(t, suggestion.Cost, arg.Budget)
+	require.Equal(t, startDate, suggestion.Recipes[0].ScheduledDate.Time)
+	require.Equal(t, arg.Servings, suggestion.Recipes[0].Portion)
+
+	// the draft is accepted as a schedule
+	result, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+		Author:  arg.Author,
+		Recipes: suggestion.Recipes,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Recipes, 1)
+}
+
+func TestPlanCandidates(t *testing.T) {
+	kg := sql.NullString{String: "kg", Valid: true}
+	rows := []ListPlanCandidatesRow{
+		{
+			// 500 g at 4 per kg and 2 eggs at 0.5 for 2 portions
+			RecipeID:      1,
+			RecipeName:    "omelette",
+			RecipePortion: 2,
+			IngredientID:  1,
+			Amount:        500,
+			UnitName:      sql.NullString{String: "g", Valid: true},
+			Price:         sql.NullFloat64{Float64: 4, Valid: true},
+			PriceUnitName: kg,
+		},
+		{
+			RecipeID:      1,
+			RecipeName:    "omelette",
+			RecipePortion: 2,
+			IngredientID:  2,
+			Amount:        2,
+			Price:         sql.NullFloat64{Float64: 0.5, Valid: true},
+		},
+		{
+			// one ingredient without a price
+			RecipeID:      2,
+			RecipeName:    "salad",
+			RecipePortion: 1,
+			IngredientID:  2,
+			Amount:        1,
+			Price:         sql.NullFloat64{Float64: 0.5, Valid: true},
+		},
+		{
+			RecipeID:      2,
+			RecipeName:    "salad",
+			RecipePortion: 1,
+			IngredientID:  3,
+			Amount:        1,
+		},
+		{
+			RecipeID:      3,
+			RecipeName:    "toast",
+			RecipePortion: 1,
+			IngredientID:  4,
+			Amount:        1,
+			Price:         sql.NullFloat64{Float64: 0.25, Valid: true},
+		},
+	}
+
+	candidates, names, unpriced := planCandidates(rows)
+	require.Len(t, candidates, 2)
+	require.Equal(t, int64(1), candidates[0].RecipeID)
+	require.InDelta(t, 1.5, candidates[0].PortionCost, 1e-9)
+	require.Equal(t, []int32{1, 2}, candidates[0].Ingredients)
+	require.Equal(t, int64(3), candidates[1].RecipeID)
+	require.InDelta(t, 0.25, candidates[1].PortionCost, 1e-9)
+	require.Equal(t, []int64{2}, unpriced)
+	require.Equal(t, "salad", names[2])
+}
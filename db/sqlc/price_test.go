@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomIngredientPrice(t *testing.T, ingredientID int32, unitID sql.NullInt32, observedOn time.Time) IngredientsPrice {
+	arg := CreateIngredientPriceParams{
+		IngredientID: ingredientID,
+		UnitID:       unitID,
+		Price:        float64(util.RandomInt(1, 2000)) / 100,
+		Store:        sql.NullString{String: util.RandomString(8), Valid: true},
+		ObservedOn:   sql.NullTime{Time: observedOn, Valid: true},
+	}
+
+	price, err := testQueries.CreateIngredientPrice(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, price.ID)
+	require.Equal(t, arg.IngredientID, price.IngredientID)
+	require.Equal(t, arg.UnitID, price.UnitID)
+	require.Equal(t, arg.Price, price.Price)
+	require.Equal(t, arg.Store, price.Store)
+	require.Equal(t, observedOn.Format("2006-01-02"), price.ObservedOn.Format("2006-01-02"))
+
+	return price
+}
+
+func TestCreateIngredientPrice(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	unit := CreateRandomUnit(t)
+	createRandomIngredientPrice(t, ingredient.ID, sql.NullInt32{Int32: unit.ID, Valid: true}, time.Now().UTC())
+
+	// observed today when no date is given
+	price, err := testQueries.CreateIngredientPrice(context.Background(), CreateIngredientPriceParams{
+		IngredientID: ingredient.ID,
+		Price:        1.5,
+	})
+	require.NoError(t, err)
+	require.False(t, price.UnitID.Valid)
+	require.False(t, price.Store.Valid)
+	require.WithinDuration(t, time.Now().UTC(), price.ObservedOn, 48*time.Hour)
+}
+
+func TestListIngredientPrices(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	unit := CreateRandomUnit(t)
+	unitID := sql.NullInt32{Int32: unit.ID, Valid: true}
+
+	older := createRandomIngredientPrice(t, ingredient.ID, unitID, time.Now().UTC().AddDate(0, -1, 0))
+	newer := createRandomIngredientPrice(t, ingredient.ID, unitID, time.Now().UTC())
+
+	prices, err := testQueries.ListIngredientPrices(context.Background(), ListIngredientPricesParams{
+		IngredientID: ingredient.ID,
+		Limit:        5,
+		Offset:       0,
+	})
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	require.Equal(t, newer.ID, prices[0].ID)
+	require.Equal(t, older.ID, prices[1].ID)
+	require.Equal(t, sql.NullString{String: unit.Name, Valid: true}, prices[0].UnitName)
+
+	prices, err = testQueries.ListIngredientPrices(context.Background(), ListIngredientPricesParams{
+		IngredientID: ingredient.ID,
+		Store:        older.Store,
+		Limit:        5,
+		Offset:       0,
+	})
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	require.Equal(t, older.ID, prices[0].ID)
+}
+
+func TestDeleteIngredientPrice(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	price := createRandomIngredientPrice(t, ingredient.ID, sql.NullInt32{}, time.Now().UTC())
+
+	err := testQueries.DeleteIngredientPrice(context.Background(), price.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIngredientPrice(context.Background(), price.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListRecipePrices(t *testing.T) {
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+	priced := ingredients[0]
+
+	older := createRandomIngredientPrice(t, priced.IngredientID, sql.NullInt32{}, time.Now().UTC().AddDate(0, 0, -7))
+	latest := createRandomIngredientPrice(t, priced.IngredientID, sql.NullInt32{}, time.Now().UTC())
+	require.NotEqual(t, older.ID, latest.ID)
+
+	rows, err := testQueries.ListRecipePrices(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, len(ingredients))
+	for _, row := range rows {
+		if row.IngredientID == priced.IngredientID {
+			require.Equal(t, sql.NullFloat64{Float64: latest.Price, Valid: true}, row.Price)
+		} else {
+			require.False(t, row.Price.Valid)
+		}
+	}
+
+	result, err := NewStorage(testDB).GetRecipeTx(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.NotNil(t, result.Cost)
+}
+
+func TestListGroceryAmountsStorePrices(t *testing.T) {
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+	schedule := createRandomSchedule(t)
+	_, err := testQueries.CreateScheduleRecipe(context.Background(), CreateScheduleRecipeParams{
+		ScheduleID: schedule.ID,
+		RecipeID:   recipe.ID,
+		Portion:    recipe.Portion,
+	})
+	require.NoError(t, err)
+
+	market := util.RandomString(8)
+	prices := []CreateIngredientPriceParams{
+		{
+			IngredientID: ingredients[0].IngredientID,
+			Price:        1.5,
+			Store:        sql.NullString{String: market, Valid: true},
+			ObservedOn:   sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, -7), Valid: true},
+		},
+		{
+			IngredientID: ingredients[0].IngredientID,
+			Price:        3,
+			Store:        sql.NullString{String: util.RandomString(8), Valid: true},
+			ObservedOn:   sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		},
+	}
+	for _, price := range prices {
+		_, err := testQueries.CreateIngredientPrice(context.Background(), price)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name  string
+		store string
+		price float64
+	}{
+		{"Store Price", market, 1.5},
+		{"Latest Without Store", "", 3},
+		{"Latest For Unknown Store", util.RandomString(8), 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, err := testQueries.ListGroceryAmounts(context.Background(), ListGroceryAmountsParams{
+				ScheduleIds: []int64{schedule.ID},
+				Store:       tc.store,
+			})
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+			require.Equal(t, sql.NullFloat64{Float64: tc.price, Valid: true}, rows[0].Price)
+		})
+	}
+}
+
+func TestComputeRecipeCost(t *testing.T) {
+	kg := sql.NullString{String: "kg", Valid: true}
+	rows := []ListRecipePricesRow{
+		{
+			// 500 g at 4 per kg
+			IngredientID:  1,
+			Amount:        500,
+			UnitName:      sql.NullString{String: "g", Valid: true},
+			UnitGrams:     sql.NullFloat64{Float64: 1, Valid: true},
+			Price:         sql.NullFloat64{Float64: 4, Valid: true},
+			PriceUnitName: kg,
+		},
+		{
+			// 3 eggs at 0.3 per piece
+			IngredientID: 2,
+			Amount:       3,
+			Price:        sql.NullFloat64{Float64: 0.3, Valid: true},
+		},
+		{
+			// 2 cups of flour of 120 g at 1.5 per kg
+			IngredientID:             3,
+			Amount:                   2,
+			UnitName:                 sql.NullString{String: "cup", Valid: true},
+			IngredientUnitGrams:      sql.NullFloat64{Float64: 120, Valid: true},
+			Price:                    sql.NullFloat64{Float64: 1.5, Valid: true},
+			PriceUnitName:            kg,
+			PriceIngredientUnitGrams: sql.NullFloat64{},
+			PriceUnitGrams:           sql.NullFloat64{Float64: 1000, Valid: true},
+		},
+		{
+			// no price
+			IngredientID: 4,
+			Amount:       1,
+		},
+		{
+			// a pinch can not be converted to kg
+			IngredientID:  5,
+			Amount:        1,
+			UnitName:      sql.NullString{String: "pinch", Valid: true},
+			Price:         sql.NullFloat64{Float64: 10, Valid: true},
+			PriceUnitName: kg,
+		},
+	}
+
+	result := computeRecipeCost(rows, 4)
+	require.Equal(t, 3.26, result.Total)
+	require.Equal(t, 0.82, result.PerPortion)
+	require.Equal(t, []int32{4, 5}, result.Missing)
+}
+
+func TestEstimateGroceryCost(t *testing.T) {
+	price := sql.NullFloat64{Float64: 2, Valid: true}
+	rows := []ListGroceryAmountsRow{
+		// 1 kg for 4 portions scaled to 2
+		{ID: 1, Name: "flour", Amount: 1, UnitName: sql.NullString{String: "kg", Valid: true}, RecipePortion: 4, SchedulePortion: 2, Price: price, PriceUnitName: sql.NullString{String: "kg", Valid: true}},
+		{ID: 1, Name: "flour", Amount: 250, UnitName: sql.NullString{String: "g", Valid: true}, RecipePortion: 1, SchedulePortion: 1, Price: price, PriceUnitName: sql.NullString{String: "kg", Valid: true}},
+		{ID: 1, Name: "flour", Amount: 1, UnitName: sql.NullString{String: "pinch", Valid: true}, RecipePortion: 1, SchedulePortion: 1, Price: price, PriceUnitName: sql.NullString{String: "kg", Valid: true}},
+		{ID: 2, Name: "salt", Amount: 1, RecipePortion: 1, SchedulePortion: 1},
+	}
+	items := aggregateGroceries(rows, "")
+
+	result := estimateGroceryCost(rows, items)
+	require.Equal(t, 1.5, result.Total)
+	require.Equal(t, []int32{1, 2}, result.Missing)
+	require.NotNil(t, items[0].Cost)
+	require.Equal(t, 1.5, *items[0].Cost)
+	require.Nil(t, items[1].Cost)
+}
@@ -6,52 +6,174 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	CopyRecipeIngredients(ctx context.Context, arg CopyRecipeIngredientsParams) error
+	CopyRecipeKeywords(ctx context.Context, arg CopyRecipeKeywordsParams) error
+	CountRecipeSteps(ctx context.Context, recipeID int64) (int64, error)
+	CreateCollection(ctx context.Context, arg CreateCollectionParams) (Collection, error)
+	CreateCollectionRecipe(ctx context.Context, arg CreateCollectionRecipeParams) (CollectionsRecipe, error)
+	CreateDietaryTag(ctx context.Context, arg CreateDietaryTagParams) (DietaryTag, error)
+	CreateFavorite(ctx context.Context, arg CreateFavoriteParams) error
 	CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error)
+	CreateIngredientAlias(ctx context.Context, arg CreateIngredientAliasParams) (IngredientsAlias, error)
+	CreateIngredientPackage(ctx context.Context, arg CreateIngredientPackageParams) (IngredientsPackage, error)
+	CreateIngredientPrice(ctx context.Context, arg CreateIngredientPriceParams) (IngredientsPrice, error)
+	CreateIngredientTag(ctx context.Context, arg CreateIngredientTagParams) (IngredientsTag, error)
+	CreatePantryItem(ctx context.Context, arg CreatePantryItemParams) (PantryItem, error)
 	CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Recipe, error)
+	CreateRecipeCooked(ctx context.Context, arg CreateRecipeCookedParams) (RecipesCooked, error)
+	CreateRecipeImage(ctx context.Context, arg CreateRecipeImageParams) (RecipesImage, error)
 	CreateRecipeIngredient(ctx context.Context, arg CreateRecipeIngredientParams) (RecipesIngredient, error)
+	CreateRecipeKeyword(ctx context.Context, arg CreateRecipeKeywordParams) error
+	CreateRecipeRevision(ctx context.Context, arg CreateRecipeRevisionParams) (RecipesRevision, error)
+	CreateRecipeStep(ctx context.Context, arg CreateRecipeStepParams) (RecipesStep, error)
+	CreateRecipeStepIngredient(ctx context.Context, arg CreateRecipeStepIngredientParams) (RecipesStepsIngredient, error)
 	CreateSchedule(ctx context.Context, author uuid.NullUUID) (Schedule, error)
 	CreateScheduleRecipe(ctx context.Context, arg CreateScheduleRecipeParams) (SchedulesRecipe, error)
-	CreateUnit(ctx context.Context, name string) (Unit, error)
+	CreateScheduleTemplate(ctx context.Context, arg CreateScheduleTemplateParams) (ScheduleTemplate, error)
+	CreateScheduleTemplateRecipe(ctx context.Context, arg CreateScheduleTemplateRecipeParams) (ScheduleTemplatesRecipe, error)
+	CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserRestriction(ctx context.Context, arg CreateUserRestrictionParams) (UsersRestriction, error)
+	DeleteCalendarFeed(ctx context.Context, userID uuid.UUID) error
+	DeleteCollection(ctx context.Context, id int64) error
+	DeleteCollectionRecipe(ctx context.Context, arg DeleteCollectionRecipeParams) (CollectionsRecipe, error)
+	DeleteDietaryTag(ctx context.Context, name string) error
+	DeleteFavorite(ctx context.Context, arg DeleteFavoriteParams) error
 	DeleteIngredient(ctx context.Context, id int32) error
+	DeleteIngredientAlias(ctx context.Context, alias string) error
+	DeleteIngredientPackage(ctx context.Context, id int64) error
+	DeleteIngredientPrice(ctx context.Context, id int64) error
+	DeleteIngredientTag(ctx context.Context, arg DeleteIngredientTagParams) error
+	DeleteIngredientUnit(ctx context.Context, arg DeleteIngredientUnitParams) error
+	DeleteNutrition(ctx context.Context, ingredientID int32) error
+	DeletePantryItem(ctx context.Context, id int64) error
 	DeleteRecipe(ctx context.Context, id int64) error
+	DeleteRecipeCooked(ctx context.Context, id int64) error
+	DeleteRecipeImage(ctx context.Context, id int64) error
 	DeleteRecipeIngredient(ctx context.Context, arg DeleteRecipeIngredientParams) error
+	DeleteRecipeKeywords(ctx context.Context, recipeID int64) error
+	DeleteRecipeReview(ctx context.Context, arg DeleteRecipeReviewParams) error
+	DeleteRecipeStep(ctx context.Context, id int64) error
+	DeleteRecipeStepIngredients(ctx context.Context, stepID int64) error
+	DeleteRecipeSteps(ctx context.Context, recipeID int64) error
 	DeleteSchedule(ctx context.Context, id int64) error
 	DeleteScheduleRecipe(ctx context.Context, arg DeleteScheduleRecipeParams) error
+	DeleteScheduleTemplate(ctx context.Context, id int64) error
 	DeleteUnit(ctx context.Context, id int32) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserRestrictions(ctx context.Context, userID uuid.UUID) error
+	ForkRecipe(ctx context.Context, arg ForkRecipeParams) (Recipe, error)
+	GetCalendarFeed(ctx context.Context, userID uuid.UUID) (CalendarFeed, error)
+	GetCalendarFeedByToken(ctx context.Context, token uuid.UUID) (CalendarFeed, error)
+	GetCollection(ctx context.Context, id int64) (Collection, error)
+	GetCollectionByShareToken(ctx context.Context, shareToken uuid.NullUUID) (Collection, error)
 	GetIngredient(ctx context.Context, id int32) (Ingredient, error)
+	GetIngredientCategory(ctx context.Context, ingredientID int32) (IngredientsCategory, error)
+	GetIngredientPackage(ctx context.Context, id int64) (IngredientsPackage, error)
+	GetIngredientPrice(ctx context.Context, id int64) (IngredientsPrice, error)
+	GetIngredientShelfLife(ctx context.Context, ingredientID int32) (IngredientsShelfLife, error)
+	GetLatestRecipeRevision(ctx context.Context, recipeID int64) (RecipesRevision, error)
 	GetLogin(ctx context.Context, username string) (User, error)
+	GetNutrition(ctx context.Context, ingredientID int32) (Nutrition, error)
+	GetPantryItem(ctx context.Context, id int64) (PantryItem, error)
 	GetPermission(ctx context.Context, id uuid.UUID) (GetPermissionRow, error)
 	GetRecipe(ctx context.Context, id int64) (Recipe, error)
+	GetRecipeCooked(ctx context.Context, id int64) (RecipesCooked, error)
+	GetRecipeImage(ctx context.Context, id int64) (RecipesImage, error)
 	GetRecipeIngredients(ctx context.Context, recipeID int64) ([]GetRecipeIngredientsRow, error)
+	GetRecipeRevision(ctx context.Context, arg GetRecipeRevisionParams) (RecipesRevision, error)
+	GetRecipeStats(ctx context.Context, recipeID int64) (RecipesStat, error)
+	GetRecipeStep(ctx context.Context, id int64) (RecipesStep, error)
 	GetSchedule(ctx context.Context, id int64) (Schedule, error)
 	GetScheduleRecipe(ctx context.Context, scheduleID int64) ([]GetScheduleRecipeRow, error)
+	GetScheduleTemplate(ctx context.Context, id int64) (ScheduleTemplate, error)
 	GetUnit(ctx context.Context, id int32) (Unit, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
+	ListAllIngredientAliases(ctx context.Context) ([]IngredientsAlias, error)
+	ListCalendarEntries(ctx context.Context, arg ListCalendarEntriesParams) ([]ListCalendarEntriesRow, error)
+	ListCalendarEntryIngredients(ctx context.Context, arg ListCalendarEntryIngredientsParams) ([]ListCalendarEntryIngredientsRow, error)
+	ListCollectionRecipes(ctx context.Context, collectionID int64) ([]ListCollectionRecipesRow, error)
+	ListCollectionsUser(ctx context.Context, owner uuid.UUID) ([]Collection, error)
+	ListCourses(ctx context.Context) ([]ListCoursesRow, error)
+	ListCuisines(ctx context.Context) ([]ListCuisinesRow, error)
+	ListDietaryTags(ctx context.Context) ([]DietaryTag, error)
+	ListExpiringPantryItems(ctx context.Context, today time.Time) ([]ListExpiringPantryItemsRow, error)
+	ListFavorites(ctx context.Context, arg ListFavoritesParams) ([]ListFavoritesRow, error)
 	ListGroceries(ctx context.Context, scheduleID int64) ([]ListGroceriesRow, error)
+	ListGroceryAmounts(ctx context.Context, arg ListGroceryAmountsParams) ([]ListGroceryAmountsRow, error)
+	ListGroceryCategories(ctx context.Context) ([]GroceryCategory, error)
+	ListGroceryPackages(ctx context.Context, arg ListGroceryPackagesParams) ([]ListGroceryPackagesRow, error)
+	ListIngredientAliases(ctx context.Context, ingredientID int32) ([]IngredientsAlias, error)
+	ListIngredientCategories(ctx context.Context, ingredientIds []int32) ([]IngredientsCategory, error)
+	ListIngredientPackages(ctx context.Context, ingredientID int32) ([]ListIngredientPackagesRow, error)
+	ListIngredientPrices(ctx context.Context, arg ListIngredientPricesParams) ([]ListIngredientPricesRow, error)
+	ListIngredientShelfLives(ctx context.Context, ingredientIds []int32) ([]IngredientsShelfLife, error)
+	ListIngredientTags(ctx context.Context, ingredientID int32) ([]ListIngredientTagsRow, error)
+	ListIngredientUnits(ctx context.Context, ingredientID int32) ([]IngredientsUnit, error)
 	ListIngredients(ctx context.Context) ([]Ingredient, error)
+	ListKeywords(ctx context.Context, arg ListKeywordsParams) ([]ListKeywordsRow, error)
+	ListPantryItems(ctx context.Context, userID uuid.UUID) ([]ListPantryItemsRow, error)
+	ListPantryRecipes(ctx context.Context, userID uuid.UUID) ([]ListPantryRecipesRow, error)
+	ListPlanCandidates(ctx context.Context, arg ListPlanCandidatesParams) ([]ListPlanCandidatesRow, error)
+	ListRecipeConflicts(ctx context.Context, arg ListRecipeConflictsParams) ([]ListRecipeConflictsRow, error)
+	ListRecipeForks(ctx context.Context, forkedFrom sql.NullInt64) ([]ListRecipeForksRow, error)
+	ListRecipeImages(ctx context.Context, recipeID int64) ([]RecipesImage, error)
+	ListRecipeIngredientAmounts(ctx context.Context, recipeID int64) ([]ListRecipeIngredientAmountsRow, error)
+	ListRecipeKeywords(ctx context.Context, recipeID int64) ([]string, error)
+	ListRecipeNutrition(ctx context.Context, recipeID int64) ([]ListRecipeNutritionRow, error)
+	ListRecipePrices(ctx context.Context, recipeID int64) ([]ListRecipePricesRow, error)
+	ListRecipeReviews(ctx context.Context, arg ListRecipeReviewsParams) ([]ListRecipeReviewsRow, error)
+	ListRecipeRevisions(ctx context.Context, recipeID int64) ([]RecipesRevision, error)
+	ListRecipeSteps(ctx context.Context, recipeID int64) ([]RecipesStep, error)
+	ListRecipeStepsIngredients(ctx context.Context, recipeID int64) ([]ListRecipeStepsIngredientsRow, error)
+	ListRecipeTags(ctx context.Context, recipeID int64) ([]ListRecipeTagsRow, error)
 	ListRecipes(ctx context.Context, arg ListRecipesParams) ([]Recipe, error)
+	ListRecipesAllowed(ctx context.Context, arg ListRecipesAllowedParams) ([]Recipe, error)
 	ListRecipesUser(ctx context.Context, arg ListRecipesUserParams) ([]Recipe, error)
+	ListScheduleNutrition(ctx context.Context, scheduleID int64) ([]ListScheduleNutritionRow, error)
+	ListScheduleTemplateRecipes(ctx context.Context, templateIds []int64) ([]ListScheduleTemplateRecipesRow, error)
+	ListScheduleTemplates(ctx context.Context, author uuid.UUID) ([]ScheduleTemplate, error)
 	ListSchedules(ctx context.Context, arg ListSchedulesParams) ([]Schedule, error)
 	ListSchedulesUser(ctx context.Context, arg ListSchedulesUserParams) ([]Schedule, error)
 	ListUnits(ctx context.Context) ([]Unit, error)
+	ListUserCooked(ctx context.Context, arg ListUserCookedParams) ([]ListUserCookedRow, error)
+	ListUserRestrictions(ctx context.Context, userID uuid.UUID) ([]ListUserRestrictionsRow, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	MarkPantryItemsAlerted(ctx context.Context, ids []int64) error
 	SearchIngredientName(ctx context.Context, name string) (Ingredient, error)
 	SearchIngredients(ctx context.Context, name string) ([]SearchIngredientsRow, error)
 	SearchRecipe(ctx context.Context, arg SearchRecipeParams) ([]SearchRecipeRow, error)
+	SearchRecipeAllowed(ctx context.Context, arg SearchRecipeAllowedParams) ([]SearchRecipeAllowedRow, error)
+	ShiftCollectionRecipes(ctx context.Context, arg ShiftCollectionRecipesParams) error
+	ShiftRecipeSteps(ctx context.Context, arg ShiftRecipeStepsParams) error
+	SyncRecipeStepsText(ctx context.Context, id int64) (Recipe, error)
+	UpdateCollectionName(ctx context.Context, arg UpdateCollectionNameParams) (Collection, error)
+	UpdateCollectionRecipePosition(ctx context.Context, arg UpdateCollectionRecipePositionParams) error
+	UpdateCollectionShareToken(ctx context.Context, arg UpdateCollectionShareTokenParams) (Collection, error)
+	UpdateExpiryAlert(ctx context.Context, arg UpdateExpiryAlertParams) (User, error)
 	UpdateIngredient(ctx context.Context, arg UpdateIngredientParams) (Ingredient, error)
 	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) (UpdatePasswordRow, error)
 	UpdateRecipe(ctx context.Context, arg UpdateRecipeParams) (Recipe, error)
 	UpdateRecipeIngredient(ctx context.Context, arg UpdateRecipeIngredientParams) (RecipesIngredient, error)
+	UpdateRecipeStep(ctx context.Context, arg UpdateRecipeStepParams) (RecipesStep, error)
+	UpdateRecipeStepPosition(ctx context.Context, arg UpdateRecipeStepPositionParams) error
+	UpdateRecipeTaxonomy(ctx context.Context, arg UpdateRecipeTaxonomyParams) (Recipe, error)
 	UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateVerified(ctx context.Context, arg UpdateVerifiedParams) (User, error)
+	UpsertCalendarFeed(ctx context.Context, arg UpsertCalendarFeedParams) (CalendarFeed, error)
+	UpsertIngredientCategory(ctx context.Context, arg UpsertIngredientCategoryParams) (IngredientsCategory, error)
+	UpsertIngredientShelfLife(ctx context.Context, arg UpsertIngredientShelfLifeParams) (IngredientsShelfLife, error)
+	UpsertIngredientUnit(ctx context.Context, arg UpsertIngredientUnitParams) (IngredientsUnit, error)
+	UpsertNutrition(ctx context.Context, arg UpsertNutritionParams) (Nutrition, error)
+	UpsertRecipeReview(ctx context.Context, arg UpsertRecipeReviewParams) (RecipesReview, error)
 }
 
 var _ Querier = (*Queries)(nil)
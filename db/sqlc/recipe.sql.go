@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createRecipe = `-- name: CreateRecipe :one
@@ -18,18 +19,26 @@ INSERT INTO recipes (
     name,
     author,
     portion,
-    steps
+    steps,
+    cuisine,
+    course,
+    difficulty,
+    total_time
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
-RETURNING id, name, author, portion, steps, created_at, modified_at
+RETURNING id, name, author, portion, steps, created_at, modified_at, forked_from, cuisine, course, difficulty, total_time
 `
 
 type CreateRecipeParams struct {
-	Name    string         `json:"name"`
-	Author  uuid.UUID      `json:"author"`
-	Portion int32          `json:"portion"`
-	Steps   sql.NullString `json:"steps"`
+	Name       string         `json:"name"`
+	Author     uuid.UUID      `json:"author"`
+	Portion    int32          `json:"portion"`
+	Steps      sql.NullString `json:"steps"`
+	Cuisine    sql.NullString `json:"cuisine"`
+	Course     sql.NullString `json:"course"`
+	Difficulty sql.NullString `json:"difficulty"`
+	TotalTime  sql.NullInt32  `json:"totalTime"`
 }
 
 func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Recipe, error) {
@@ -38,6 +47,10 @@ func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Rec
 		arg.Author,
 		arg.Portion,
 		arg.Steps,
+		arg.Cuisine,
+		arg.Course,
+		arg.Difficulty,
+		arg.TotalTime,
 	)
 	var i Recipe
 	err := row.Scan(
@@ -48,6 +61,11 @@ func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Rec
 		&i.Steps,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.ForkedFrom,
+		&i.Cuisine,
+		&i.Course,
+		&i.Difficulty,
+		&i.TotalTime,
 	)
 	return i, err
 }
@@ -114,7 +132,7 @@ func (q *Queries) DeleteRecipeIngredient(ctx context.Context, arg DeleteRecipeIn
 }
 
 const getRecipe = `-- name: GetRecipe :one
-SELECT id, name, author, portion, steps, created_at, modified_at from recipes
+SELECT id, name, author, portion, steps, created_at, modified_at, forked_from, cuisine, course, difficulty, total_time from recipes
 WHERE id = $1 LIMIT 1
 `
 
@@ -129,25 +147,33 @@ func (q *Queries) GetRecipe(ctx context.Context, id int64) (Recipe, error) {
 		&i.Steps,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.ForkedFrom,
+		&i.Cuisine,
+		&i.Course,
+		&i.Difficulty,
+		&i.TotalTime,
 	)
 	return i, err
 }
 
 const getRecipeIngredients = `-- name: GetRecipeIngredients :many
-SELECT ri.recipe_id, ri.ingredient_id, i.name, ri. amount, ri.unit_id
+SELECT ri.recipe_id, ri.ingredient_id, i.name, ri. amount, ri.unit_id, u.name AS unit_name
 from recipes_ingredients as ri
 INNER JOIN ingredients as i
 ON ri.ingredient_id = i.id
+LEFT JOIN units as u
+ON ri.unit_id = u.id
 WHERE recipe_id = $1
-FOR SHARE
+FOR SHARE OF ri, i
 `
 
 type GetRecipeIngredientsRow struct {
-	RecipeID     int64   `json:"recipeID"`
-	IngredientID int32   `json:"ingredientID"`
-	Name         string  `json:"name"`
-	Amount       float32 `json:"amount"`
-	UnitID       int32   `json:"unitID"`
+	RecipeID     int64          `json:"recipeID"`
+	IngredientID int32          `json:"ingredientID"`
+	Name         string         `json:"name"`
+	Amount       float32        `json:"amount"`
+	UnitID       int32          `json:"unitID"`
+	UnitName     sql.NullString `json:"unitName"`
 }
 
 func (q *Queries) GetRecipeIngredients(ctx context.Context, recipeID int64) ([]GetRecipeIngredientsRow, error) {
@@ -165,6 +191,55 @@ func (q *Queries) GetRecipeIngredients(ctx context.Context, recipeID int64) ([]G
 			&i.Name,
 			&i.Amount,
 			&i.UnitID,
+			&i.UnitName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipeIngredientAmounts = `-- name: ListRecipeIngredientAmounts :many
+SELECT ri.ingredient_id, i.name, ri.amount, ri.unit_id, u.name AS unit_name
+from recipes_ingredients as ri
+INNER JOIN ingredients as i
+ON ri.ingredient_id = i.id
+LEFT JOIN units as u
+ON ri.unit_id = u.id
+WHERE ri.recipe_id = $1
+ORDER BY i.name
+`
+
+type ListRecipeIngredientAmountsRow struct {
+	IngredientID int32          `json:"ingredientID"`
+	Name         string         `json:"name"`
+	Amount       float32        `json:"amount"`
+	UnitID       int32          `json:"unitID"`
+	UnitName     sql.NullString `json:"unitName"`
+}
+
+func (q *Queries) ListRecipeIngredientAmounts(ctx context.Context, recipeID int64) ([]ListRecipeIngredientAmountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipeIngredientAmounts, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRecipeIngredientAmountsRow{}
+	for rows.Next() {
+		var i ListRecipeIngredientAmountsRow
+		if err := rows.Scan(
+			&i.IngredientID,
+			&i.Name,
+			&i.Amount,
+			&i.UnitID,
+			&i.UnitName,
 		); err != nil {
 			return nil, err
 		}
@@ -180,19 +255,137 @@ func (q *Queries) GetRecipeIngredients(ctx context.Context, recipeID int64) ([]G
 }
 
 const listRecipes = `-- name: ListRecipes :many
-SELECT id, name, author, portion, steps, created_at, modified_at from recipes
-ORDER BY modified_at
-LIMIT $1
-OFFSET $2
+SELECT r.id, r.name, r.author, r.portion, r.steps, r.created_at, r.modified_at, r.forked_from, r.cuisine, r.course, r.difficulty, r.total_time from recipes AS r
+WHERE ($1::text IS NULL OR r.cuisine = $1)
+    AND ($2::text IS NULL OR r.course = $2)
+    AND ($3::text IS NULL OR r.difficulty = $3)
+    AND ($4::int IS NULL OR r.total_time <= $4)
+    AND COALESCE(cardinality($5::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY($5::text[])
+    )
+ORDER BY
+    CASE WHEN $6::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN $6::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT $7
+OFFSET $8
 `
 
 type ListRecipesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Cuisine      sql.NullString `json:"cuisine"`
+	Course       sql.NullString `json:"course"`
+	Difficulty   sql.NullString `json:"difficulty"`
+	MaxTotalTime sql.NullInt32  `json:"maxTotalTime"`
+	Keywords     []string       `json:"keywords"`
+	Sort         string         `json:"sort"`
+	Limit        int32          `json:"limit"`
+	Offset       int32          `json:"offset"`
 }
 
 func (q *Queries) ListRecipes(ctx context.Context, arg ListRecipesParams) ([]Recipe, error) {
-	rows, err := q.db.QueryContext(ctx, listRecipes, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listRecipes,
+		arg.Cuisine,
+		arg.Course,
+		arg.Difficulty,
+		arg.MaxTotalTime,
+		pq.Array(arg.Keywords),
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Recipe{}
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Author,
+			&i.Portion,
+			&i.Steps,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+			&i.ForkedFrom,
+			&i.Cuisine,
+			&i.Course,
+			&i.Difficulty,
+			&i.TotalTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesAllowed = `-- name: ListRecipesAllowed :many
+SELECT r.id, r.name, r.author, r.portion, r.steps, r.created_at, r.modified_at, r.forked_from, r.cuisine, r.course, r.difficulty, r.total_time from recipes as r
+WHERE NOT EXISTS (
+    SELECT 1 from recipes_conflicts as rc
+    WHERE rc.recipe_id = r.id AND rc.user_id = $1
+)
+    AND ($2::text IS NULL OR r.cuisine = $2)
+    AND ($3::text IS NULL OR r.course = $3)
+    AND ($4::text IS NULL OR r.difficulty = $4)
+    AND ($5::int IS NULL OR r.total_time <= $5)
+    AND COALESCE(cardinality($6::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY($6::text[])
+    )
+ORDER BY
+    CASE WHEN $7::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN $7::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT $8
+OFFSET $9
+`
+
+type ListRecipesAllowedParams struct {
+	UserID       uuid.UUID      `json:"userID"`
+	Cuisine      sql.NullString `json:"cuisine"`
+	Course       sql.NullString `json:"course"`
+	Difficulty   sql.NullString `json:"difficulty"`
+	MaxTotalTime sql.NullInt32  `json:"maxTotalTime"`
+	Keywords     []string       `json:"keywords"`
+	Sort         string         `json:"sort"`
+	Limit        int32          `json:"limit"`
+	Offset       int32          `json:"offset"`
+}
+
+func (q *Queries) ListRecipesAllowed(ctx context.Context, arg ListRecipesAllowedParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesAllowed,
+		arg.UserID,
+		arg.Cuisine,
+		arg.Course,
+		arg.Difficulty,
+		arg.MaxTotalTime,
+		pq.Array(arg.Keywords),
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +401,11 @@ func (q *Queries) ListRecipes(ctx context.Context, arg ListRecipesParams) ([]Rec
 			&i.Steps,
 			&i.CreatedAt,
 			&i.ModifiedAt,
+			&i.ForkedFrom,
+			&i.Cuisine,
+			&i.Course,
+			&i.Difficulty,
+			&i.TotalTime,
 		); err != nil {
 			return nil, err
 		}
@@ -223,7 +421,7 @@ func (q *Queries) ListRecipes(ctx context.Context, arg ListRecipesParams) ([]Rec
 }
 
 const listRecipesUser = `-- name: ListRecipesUser :many
-SELECT id, name, author, portion, steps, created_at, modified_at from recipes
+SELECT id, name, author, portion, steps, created_at, modified_at, forked_from, cuisine, course, difficulty, total_time from recipes
 WHERE author = $1
 ORDER BY modified_at
 LIMIT $2
@@ -253,6 +451,11 @@ func (q *Queries) ListRecipesUser(ctx context.Context, arg ListRecipesUserParams
 			&i.Steps,
 			&i.CreatedAt,
 			&i.ModifiedAt,
+			&i.ForkedFrom,
+			&i.Cuisine,
+			&i.Course,
+			&i.Difficulty,
+			&i.TotalTime,
 		); err != nil {
 			return nil, err
 		}
@@ -268,16 +471,40 @@ func (q *Queries) ListRecipesUser(ctx context.Context, arg ListRecipesUserParams
 }
 
 const searchRecipe = `-- name: SearchRecipe :many
-SELECT id, name, author, modified_at from recipes
-WHERE name LIKE $1
-LIMIT $2
-OFFSET $3
+SELECT r.id, r.name, r.author, r.modified_at from recipes AS r
+WHERE r.name LIKE $1
+    AND ($2::text IS NULL OR r.cuisine = $2)
+    AND ($3::text IS NULL OR r.course = $3)
+    AND ($4::text IS NULL OR r.difficulty = $4)
+    AND ($5::int IS NULL OR r.total_time <= $5)
+    AND COALESCE(cardinality($6::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY($6::text[])
+    )
+ORDER BY
+    CASE WHEN $7::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN $7::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT $8
+OFFSET $9
 `
 
 type SearchRecipeParams struct {
-	Name   string `json:"name"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Name         string         `json:"name"`
+	Cuisine      sql.NullString `json:"cuisine"`
+	Course       sql.NullString `json:"course"`
+	Difficulty   sql.NullString `json:"difficulty"`
+	MaxTotalTime sql.NullInt32  `json:"maxTotalTime"`
+	Keywords     []string       `json:"keywords"`
+	Sort         string         `json:"sort"`
+	Limit        int32          `json:"limit"`
+	Offset       int32          `json:"offset"`
 }
 
 type SearchRecipeRow struct {
@@ -288,7 +515,17 @@ type SearchRecipeRow struct {
 }
 
 func (q *Queries) SearchRecipe(ctx context.Context, arg SearchRecipeParams) ([]SearchRecipeRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchRecipe, arg.Name, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, searchRecipe,
+		arg.Name,
+		arg.Cuisine,
+		arg.Course,
+		arg.Difficulty,
+		arg.MaxTotalTime,
+		pq.Array(arg.Keywords),
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -315,6 +552,93 @@ func (q *Queries) SearchRecipe(ctx context.Context, arg SearchRecipeParams) ([]S
 	return items, nil
 }
 
+const searchRecipeAllowed = `-- name: SearchRecipeAllowed :many
+SELECT r.id, r.name, r.author, r.modified_at from recipes as r
+WHERE r.name LIKE $1 AND NOT EXISTS (
+    SELECT 1 from recipes_conflicts as rc
+    WHERE rc.recipe_id = r.id AND rc.user_id = $2
+)
+    AND ($3::text IS NULL OR r.cuisine = $3)
+    AND ($4::text IS NULL OR r.course = $4)
+    AND ($5::text IS NULL OR r.difficulty = $5)
+    AND ($6::int IS NULL OR r.total_time <= $6)
+    AND COALESCE(cardinality($7::text[]), 0) = (
+        SELECT count(*) from recipes_keywords as rk
+        WHERE rk.recipe_id = r.id AND rk.keyword = ANY($7::text[])
+    )
+ORDER BY
+    CASE WHEN $8::text = 'rating' THEN (
+        SELECT avg(rr.rating)::double precision from recipes_reviews as rr
+        WHERE rr.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    CASE WHEN $8::text = 'popularity' THEN (
+        SELECT count(*) from recipes_cooked_log as cl
+        WHERE cl.recipe_id = r.id
+    ) END DESC NULLS LAST,
+    r.modified_at
+LIMIT $9
+OFFSET $10
+`
+
+type SearchRecipeAllowedParams struct {
+	Name         string         `json:"name"`
+	UserID       uuid.UUID      `json:"userID"`
+	Cuisine      sql.NullString `json:"cuisine"`
+	Course       sql.NullString `json:"course"`
+	Difficulty   sql.NullString `json:"difficulty"`
+	MaxTotalTime sql.NullInt32  `json:"maxTotalTime"`
+	Keywords     []string       `json:"keywords"`
+	Sort         string         `json:"sort"`
+	Limit        int32          `json:"limit"`
+	Offset       int32          `json:"offset"`
+}
+
+type SearchRecipeAllowedRow struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Author     uuid.UUID `json:"author"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+func (q *Queries) SearchRecipeAllowed(ctx context.Context, arg SearchRecipeAllowedParams) ([]SearchRecipeAllowedRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchRecipeAllowed,
+		arg.Name,
+		arg.UserID,
+		arg.Cuisine,
+		arg.Course,
+		arg.Difficulty,
+		arg.MaxTotalTime,
+		pq.Array(arg.Keywords),
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchRecipeAllowedRow{}
+	for rows.Next() {
+		var i SearchRecipeAllowedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Author,
+			&i.ModifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateRecipe = `-- name: UpdateRecipe :one
 UPDATE recipes
     set name = $2,
@@ -322,7 +646,7 @@ UPDATE recipes
     steps = $4,
     modified_at = (now() at time zone 'utc')
 WHERE id = $1
-RETURNING id, name, author, portion, steps, created_at, modified_at
+RETURNING id, name, author, portion, steps, created_at, modified_at, forked_from, cuisine, course, difficulty, total_time
 `
 
 type UpdateRecipeParams struct {
@@ -348,6 +672,11 @@ func (q *Queries) UpdateRecipe(ctx context.Context, arg UpdateRecipeParams) (Rec
 		&i.Steps,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.ForkedFrom,
+		&i.Cuisine,
+		&i.Course,
+		&i.Difficulty,
+		&i.TotalTime,
 	)
 	return i, err
 }
@@ -198,6 +198,8 @@ func TestGetRecipeIngredients(t *testing.T) {
 		require.Equal(t, recipeIngredientsNew[0].Name, row.Name)
 		require.Equal(t, recipeIngredientsNew[0].Amount, row.Amount)
 		require.Equal(t, recipeIngredientsNew[0].UnitID, row.UnitID)
+		require.True(t, row.UnitName.Valid)
+		require.Equal(t, recipeIngredientsNew[0].UnitName, row.UnitName)
 	}
 }
 
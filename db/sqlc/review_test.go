@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertRecipeReview(t *testing.T) {
+	recipe := CreateRandomRecipe(t)
+	user := CreateRandomUser(t)
+
+	arg := UpsertRecipeReviewParams{
+		RecipeID: recipe.ID,
+		UserID:   user.ID,
+		Rating:   2,
+		Comment: sql.NullString{
+			String: util.RandomString(20),
+			Valid:  true,
+		},
+	}
+	review, err := testQueries.UpsertRecipeReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Rating, review.Rating)
+	require.Equal(t, arg.Comment, review.Comment)
+
+	// Reviewing again replaces the review
+	arg.Rating = 5
+	arg.Comment = sql.NullString{}
+	updated, err := testQueries.UpsertRecipeReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(5), updated.Rating)
+	require.False(t, updated.Comment.Valid)
+	require.Equal(t, review.CreatedAt, updated.CreatedAt)
+
+	reviews, err := testQueries.ListRecipeReviews(context.Background(), ListRecipeReviewsParams{
+		RecipeID: recipe.ID,
+		Limit:    10,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, user.Username, reviews[0].Username)
+
+	arg.Rating = 6
+	_, err = testQueries.UpsertRecipeReview(context.Background(), arg)
+	require.Error(t, err)
+
+	err = testQueries.DeleteRecipeReview(context.Background(), DeleteRecipeReviewParams{
+		RecipeID: recipe.ID,
+		UserID:   user.ID,
+	})
+	require.NoError(t, err)
+}
+
+func TestRecipeStats(t *testing.T) {
+	recipe := CreateRandomRecipe(t)
+
+	stats, err := testQueries.GetRecipeStats(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.False(t, stats.RatingAvg.Valid)
+	require.Zero(t, stats.RatingCount)
+	require.Zero(t, stats.CookedCount)
+
+	for _, rating := range []int32{3, 4} {
+		_, err = testQueries.UpsertRecipeReview(context.Background(), UpsertRecipeReviewParams{
+			RecipeID: recipe.ID,
+			UserID:   CreateRandomUser(t).ID,
+			Rating:   rating,
+		})
+		require.NoError(t, err)
+	}
+
+	// One meal logged by hand, one planned yesterday and one planned tomorrow
+	user := CreateRandomUser(t)
+	cooked, err := testQueries.CreateRecipeCooked(context.Background(), CreateRecipeCookedParams{
+		RecipeID: recipe.ID,
+		UserID:   user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Now().UTC().Format("2006-01-02"), cooked.CookedOn.Format("2006-01-02"))
+
+	schedule := createRandomScheduleUser(t, user.ID)
+	for _, days := range []int{-1, 1} {
+		_, err = testQueries.CreateScheduleRecipe(context.Background(), CreateScheduleRecipeParams{
+			ScheduleID: schedule.ID,
+			RecipeID:   recipe.ID,
+			Portion:    1,
+			ScheduledDate: sql.NullTime{
+				Time:  time.Now().UTC().AddDate(0, 0, days),
+				Valid: true,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	stats, err = testQueries.GetRecipeStats(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.InDelta(t, 3.5, stats.RatingAvg.Float64, 0.001)
+	require.Equal(t, int64(2), stats.RatingCount)
+	require.Equal(t, int64(2), stats.CookedCount)
+
+	log, err := testQueries.ListUserCooked(context.Background(), ListUserCookedParams{
+		UserID: user.ID,
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	require.Equal(t, sql.NullInt64{Int64: cooked.ID, Valid: true}, log[0].ID)
+	require.False(t, log[0].ScheduleID.Valid)
+	require.False(t, log[1].ID.Valid)
+	require.Equal(t, sql.NullInt64{Int64: schedule.ID, Valid: true}, log[1].ScheduleID)
+
+	err = testQueries.DeleteRecipeCooked(context.Background(), cooked.ID)
+	require.NoError(t, err)
+	_, err = testQueries.GetRecipeCooked(context.Background(), cooked.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListRecipesSortByRating(t *testing.T) {
+	low := CreateRandomRecipe(t)
+	high := CreateRandomRecipe(t)
+	user := CreateRandomUser(t)
+
+	for recipeID, rating := range map[int64]int32{low.ID: 1, high.ID: 5} {
+		_, err := testQueries.UpsertRecipeReview(context.Background(), UpsertRecipeReviewParams{
+			RecipeID: recipeID,
+			UserID:   user.ID,
+			Rating:   rating,
+		})
+		require.NoError(t, err)
+	}
+
+	recipes, err := testQueries.ListRecipes(context.Background(), ListRecipesParams{
+		Sort:   "rating",
+		Limit:  1000,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+
+	position := make(map[int64]int, len(recipes))
+	for i, recipe := range recipes {
+		position[recipe.ID] = i
+	}
+	require.Less(t, position[high.ID], position[low.ID])
+}
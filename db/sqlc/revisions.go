@@ -0,0 +1,353 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevisionIngredient struct {
+	IngredientID int32   `json:"ingredientID"`
+	Name         string  `json:"name"`
+	Amount       float32 `json:"amount"`
+	UnitID       int32   `json:"unitID"`
+}
+
+type RevisionStep struct {
+	Text string `json:"text"`
+	// Zero when the step has no timer
+	DurationSeconds int32 `json:"durationSeconds"`
+	// Recipe ingredients used in the step
+	Ingredients []int32 `json:"ingredients"`
+}
+
+type Revision struct {
+	ID          int64                `json:"id"`
+	RecipeID    int64                `json:"recipeID"`
+	Revision    int32                `json:"revision"`
+	Name        string               `json:"name"`
+	Portion     int32                `json:"portion"`
+	Steps       sql.NullString       `json:"steps"`
+	Ingredients []RevisionIngredient `json:"ingredients"`
+	StepList    []RevisionStep       `json:"stepList"`
+	Taxonomy    RecipeTaxonomy       `json:"taxonomy"`
+	Keywords    []string             `json:"keywords"`
+	// User that made the change
+	Author    uuid.NullUUID `json:"author"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type StringChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type PortionChange struct {
+	Old int32 `json:"old"`
+	New int32 `json:"new"`
+}
+
+type TotalTimeChange struct {
+	Old sql.NullInt32 `json:"old"`
+	New sql.NullInt32 `json:"new"`
+}
+
+type KeywordsChange struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+type StepListChange struct {
+	Old []RevisionStep `json:"old"`
+	New []RevisionStep `json:"new"`
+}
+
+type IngredientChange struct {
+	IngredientID int32              `json:"ingredientID"`
+	Old          RevisionIngredient `json:"old"`
+	New          RevisionIngredient `json:"new"`
+}
+
+// Changes from one revision to another, unchanged fields are left out
+type RevisionDiff struct {
+	From    int32          `json:"from"`
+	To      int32          `json:"to"`
+	Name    *StringChange  `json:"name,omitempty"`
+	Portion *PortionChange `json:"portion,omitempty"`
+	Steps   *StringChange  `json:"steps,omitempty"`
+	// Both step lists when any step, its timer, or its ingredients differ
+	StepList   *StepListChange  `json:"stepList,omitempty"`
+	Cuisine    *StringChange    `json:"cuisine,omitempty"`
+	Course     *StringChange    `json:"course,omitempty"`
+	Difficulty *StringChange    `json:"difficulty,omitempty"`
+	TotalTime  *TotalTimeChange `json:"totalTime,omitempty"`
+	Keywords   *KeywordsChange  `json:"keywords,omitempty"`
+	// Ingredients in To but not in From
+	Added []RevisionIngredient `json:"added"`
+	// Ingredients in From but not in To
+	Removed []RevisionIngredient `json:"removed"`
+	// Ingredients with another amount or unit
+	Changed []IngredientChange `json:"changed"`
+}
+
+// Decode the ingredients and steps snapshots of a revision row
+func RevisionOf(row RecipesRevision) (Revision, error) {
+	revision := Revision{
+		ID:          row.ID,
+		RecipeID:    row.RecipeID,
+		Revision:    row.Revision,
+		Name:        row.Name,
+		Portion:     row.Portion,
+		Steps:       row.Steps,
+		Ingredients: []RevisionIngredient{},
+		StepList:    []RevisionStep{},
+		Taxonomy: RecipeTaxonomy{
+			Cuisine:    row.Cuisine,
+			Course:     row.Course,
+			Difficulty: row.Difficulty,
+			TotalTime:  row.TotalTime,
+		},
+		Keywords:  row.Keywords,
+		Author:    row.Author,
+		CreatedAt: row.CreatedAt,
+	}
+
+	if len(row.Ingredients) > 0 {
+		if err := json.Unmarshal(row.Ingredients, &revision.Ingredients); err != nil {
+			return Revision{}, err
+		}
+	}
+	if revision.Keywords == nil {
+		revision.Keywords = []string{}
+	}
+	if len(row.StepList) > 0 {
+		if err := json.Unmarshal(row.StepList, &revision.StepList); err != nil {
+			return Revision{}, err
+		}
+	}
+
+	return revision, nil
+}
+
+func DiffRevisions(from, to Revision) RevisionDiff {
+	diff := RevisionDiff{
+		From:    from.Revision,
+		To:      to.Revision,
+		Added:   []RevisionIngredient{},
+		Removed: []RevisionIngredient{},
+		Changed: []IngredientChange{},
+	}
+
+	if from.Name != to.Name {
+		diff.Name = &StringChange{Old: from.Name, New: to.Name}
+	}
+	if from.Portion != to.Portion {
+		diff.Portion = &PortionChange{Old: from.Portion, New: to.Portion}
+	}
+	if from.Steps != to.Steps {
+		diff.Steps = &StringChange{Old: from.Steps.String, New: to.Steps.String}
+	}
+	if !sameSteps(from.StepList, to.StepList) {
+		diff.StepList = &StepListChange{Old: from.StepList, New: to.StepList}
+	}
+	diff.Cuisine = nullStringChange(from.Taxonomy.Cuisine, to.Taxonomy.Cuisine)
+	diff.Course = nullStringChange(from.Taxonomy.Course, to.Taxonomy.Course)
+	diff.Difficulty = nullStringChange(from.Taxonomy.Difficulty, to.Taxonomy.Difficulty)
+	if from.Taxonomy.TotalTime != to.Taxonomy.TotalTime {
+		diff.TotalTime = &TotalTimeChange{Old: from.Taxonomy.TotalTime, New: to.Taxonomy.TotalTime}
+	}
+	diff.Keywords = keywordsChange(from.Keywords, to.Keywords)
+
+	old := make(map[int32]RevisionIngredient, len(from.Ingredients))
+	for _, ingredient := range from.Ingredients {
+		old[ingredient.IngredientID] = ingredient
+	}
+
+	for _, ingredient := range to.Ingredients {
+		prev, ok := old[ingredient.IngredientID]
+		if !ok {
+			diff.Added = append(diff.Added, ingredient)
+			continue
+		}
+		delete(old, ingredient.IngredientID)
+
+		if prev.Amount != ingredient.Amount || prev.UnitID != ingredient.UnitID {
+			diff.Changed = append(diff.Changed, IngredientChange{
+				IngredientID: ingredient.IngredientID,
+				Old:          prev,
+				New:          ingredient,
+			})
+		}
+	}
+
+	// Keep the order of the From ingredients
+	for _, ingredient := range from.Ingredients {
+		if _, ok := old[ingredient.IngredientID]; ok {
+			diff.Removed = append(diff.Removed, ingredient)
+		}
+	}
+
+	return diff
+}
+
+func nullStringChange(from, to sql.NullString) *StringChange {
+	if from == to {
+		return nil
+	}
+
+	return &StringChange{Old: from.String, New: to.String}
+}
+
+// Keywords only in to are added, keywords only in from are removed
+func keywordsChange(from, to []string) *KeywordsChange {
+	old := make(map[string]bool, len(from))
+	for _, keyword := range from {
+		old[keyword] = true
+	}
+
+	change := KeywordsChange{Added: []string{}, Removed: []string{}}
+	for _, keyword := range to {
+		if old[keyword] {
+			delete(old, keyword)
+			continue
+		}
+		change.Added = append(change.Added, keyword)
+	}
+	for _, keyword := range from {
+		if old[keyword] {
+			change.Removed = append(change.Removed, keyword)
+		}
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		return nil
+	}
+
+	return &change
+}
+
+func sameSteps(a, b []RevisionStep) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Text != b[i].Text || a[i].DurationSeconds != b[i].DurationSeconds || len(a[i].Ingredients) != len(b[i].Ingredients) {
+			return false
+		}
+		for j := range a[i].Ingredients {
+			if a[i].Ingredients[j] != b[i].Ingredients[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Snapshot the current state of a recipe as its next revision
+func createRevision(ctx context.Context, q *Queries, recipeID int64, author uuid.UUID) (RecipesRevision, error) {
+	return q.CreateRecipeRevision(
+		ctx,
+		CreateRecipeRevisionParams{
+			Author: uuid.NullUUID{
+				UUID:  author,
+				Valid: author != uuid.Nil,
+			},
+			RecipeID: recipeID,
+		},
+	)
+}
+
+// Replace all steps of a recipe with the steps of a revision, the step
+// ingredients have to be ingredients of the recipe already
+func restoreRecipeSteps(ctx context.Context, q *Queries, recipeID int64, steps []RevisionStep) error {
+	err := q.DeleteRecipeSteps(ctx, recipeID)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range steps {
+		step, err := q.CreateRecipeStep(
+			ctx,
+			CreateRecipeStepParams{
+				RecipeID: recipeID,
+				Position: int32(i + 1),
+				Text:     item.Text,
+				DurationSeconds: sql.NullInt32{
+					Int32: item.DurationSeconds,
+					Valid: item.DurationSeconds > 0,
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = createStepIngredients(ctx, q, step, item.Ingredients)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Make the recipe ingredients exactly the given ones: ingredients that are not
+// listed are deleted, listed ones are updated or created
+func syncRecipeIngredients(ctx context.Context, q *Queries, recipeID int64, ingredients []CreateRecipeIngredientParams) error {
+	current, err := q.GetRecipeIngredients(ctx, recipeID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[int32]bool, len(current))
+	for _, ingredient := range current {
+		existing[ingredient.IngredientID] = true
+	}
+
+	wanted := make(map[int32]bool, len(ingredients))
+	for _, ingredient := range ingredients {
+		wanted[ingredient.IngredientID] = true
+	}
+
+	for _, ingredient := range current {
+		if wanted[ingredient.IngredientID] {
+			continue
+		}
+		err = q.DeleteRecipeIngredient(
+			ctx,
+			DeleteRecipeIngredientParams{
+				RecipeID:     recipeID,
+				IngredientID: ingredient.IngredientID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ingredient := range ingredients {
+		ingredient.RecipeID = recipeID
+		if existing[ingredient.IngredientID] {
+			_, err = q.UpdateRecipeIngredient(
+				ctx,
+				UpdateRecipeIngredientParams{
+					RecipeID:     recipeID,
+					IngredientID: ingredient.IngredientID,
+					Amount:       ingredient.Amount,
+					UnitID:       ingredient.UnitID,
+				},
+			)
+		} else {
+			_, err = q.CreateRecipeIngredient(ctx, ingredient)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,336 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newRevisionRecipe(t *testing.T, storage *SQLStorage) (RecipeResult, []Ingredient) {
+	author := CreateRandomUser(t)
+	arg := NewRecipeParams{
+		Name:    util.RandomString(10),
+		Author:  author.ID,
+		Portion: 2,
+	}
+
+	var ingredients []Ingredient
+	for i := 0; i < 3; i++ {
+		ingredient := CreateRandomIngredient(t)
+		ingredients = append(ingredients, ingredient)
+		arg.ListIngredients = append(arg.ListIngredients, ListIngredientParam{
+			ID: sql.NullInt32{
+				Int32: ingredient.ID,
+				Valid: true,
+			},
+			Name:   ingredient.Name,
+			Amount: float32(util.RandomInt(50, 175)),
+			UnitID: ingredient.DefaultUnit.Int32,
+		})
+	}
+
+	recipe, err := storage.NewRecipeTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	return recipe, ingredients
+}
+
+func TestDiffRevisions(t *testing.T) {
+	from := Revision{
+		Revision: 1,
+		Name:     "soup",
+		Portion:  2,
+		Ingredients: []RevisionIngredient{
+			{IngredientID: 1, Name: "onion", Amount: 1, UnitID: 1},
+			{IngredientID: 2, Name: "tomato", Amount: 400, UnitID: 2},
+			{IngredientID: 3, Name: "salt", Amount: 1, UnitID: 3},
+		},
+		StepList: []RevisionStep{
+			{Text: "chop the onion", Ingredients: []int32{1}},
+			{Text: "simmer", DurationSeconds: 600, Ingredients: []int32{2}},
+		},
+	}
+	to := Revision{
+		Revision: 3,
+		Name:     "tomato soup",
+		Portion:  2,
+		Ingredients: []RevisionIngredient{
+			{IngredientID: 1, Name: "onion", Amount: 1, UnitID: 1},
+			{IngredientID: 2, Name: "tomato", Amount: 800, UnitID: 2},
+			{IngredientID: 4, Name: "basil", Amount: 5, UnitID: 2},
+		},
+		StepList: []RevisionStep{
+			{Text: "chop the onion", Ingredients: []int32{1}},
+			{Text: "simmer", DurationSeconds: 600, Ingredients: []int32{2}},
+		},
+	}
+
+	diff := DiffRevisions(from, to)
+	require.Equal(t, int32(1), diff.From)
+	require.Equal(t, int32(3), diff.To)
+	require.Equal(t, &StringChange{Old: "soup", New: "tomato soup"}, diff.Name)
+	require.Nil(t, diff.Portion)
+	require.Nil(t, diff.Steps)
+	require.Nil(t, diff.StepList)
+	require.Equal(t, []RevisionIngredient{to.Ingredients[2]}, diff.Added)
+	require.Equal(t, []RevisionIngredient{from.Ingredients[2]}, diff.Removed)
+	require.Equal(t, []IngredientChange{
+		{IngredientID: 2, Old: from.Ingredients[1], New: to.Ingredients[1]},
+	}, diff.Changed)
+
+	to.StepList = []RevisionStep{
+		{Text: "chop the onion", Ingredients: []int32{1}},
+		{Text: "simmer", DurationSeconds: 600, Ingredients: []int32{2, 4}},
+	}
+	diff = DiffRevisions(from, to)
+	require.Equal(t, &StepListChange{Old: from.StepList, New: to.StepList}, diff.StepList)
+	require.Nil(t, diff.Cuisine)
+	require.Nil(t, diff.Keywords)
+
+	from.Taxonomy = RecipeTaxonomy{
+		Cuisine:   sql.NullString{String: "italian", Valid: true},
+		TotalTime: sql.NullInt32{Int32: 30, Valid: true},
+	}
+	from.Keywords = []string{"quick", "vegetarian"}
+	to.Taxonomy = RecipeTaxonomy{
+		Cuisine:   sql.NullString{String: "italian", Valid: true},
+		Course:    sql.NullString{String: "soup", Valid: true},
+		TotalTime: sql.NullInt32{Int32: 45, Valid: true},
+	}
+	to.Keywords = []string{"vegetarian", "winter"}
+	diff = DiffRevisions(from, to)
+	require.Nil(t, diff.Cuisine)
+	require.Equal(t, &StringChange{Old: "", New: "soup"}, diff.Course)
+	require.Nil(t, diff.Difficulty)
+	require.Equal(t, &TotalTimeChange{Old: from.Taxonomy.TotalTime, New: to.Taxonomy.TotalTime}, diff.TotalTime)
+	require.Equal(t, &KeywordsChange{Added: []string{"winter"}, Removed: []string{"quick"}}, diff.Keywords)
+}
+
+func TestRecipeRevisions(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, _ := newRevisionRecipe(t, storage)
+	editor := CreateRandomUser(t)
+
+	rows, err := storage.ListRecipeRevisions(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	first, err := RevisionOf(rows[0])
+	require.NoError(t, err)
+	require.Equal(t, int32(1), first.Revision)
+	require.Equal(t, recipe.Recipe.Name, first.Name)
+	require.Equal(t, recipe.Recipe.Author, first.Author.UUID)
+	require.Len(t, first.Ingredients, len(recipe.Ingredients))
+
+	_, err = storage.UpdateRecipeTx(context.Background(), TxUpdateRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    util.RandomString(12),
+			Portion: 6,
+		},
+		Editor: editor.ID,
+	})
+	require.NoError(t, err)
+
+	err = storage.DeleteRecipeIngredientTx(context.Background(), DeleteRecipeIngredientTxParams{
+		RecipeID:     recipe.Recipe.ID,
+		IngredientID: recipe.Ingredients[0].IngredientID,
+		Editor:       editor.ID,
+	})
+	require.NoError(t, err)
+
+	latest, err := storage.GetLatestRecipeRevision(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), latest.Revision)
+	require.Equal(t, uuid.NullUUID{UUID: editor.ID, Valid: true}, latest.Author)
+
+	third, err := RevisionOf(latest)
+	require.NoError(t, err)
+	diff := DiffRevisions(first, third)
+	require.NotNil(t, diff.Name)
+	require.Equal(t, &PortionChange{Old: recipe.Recipe.Portion, New: 6}, diff.Portion)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, recipe.Ingredients[0].IngredientID, diff.Removed[0].IngredientID)
+
+	// Revert to the first revision
+	reverted, err := storage.RevertRecipeTx(context.Background(), RevertRecipeParams{
+		RecipeID: recipe.Recipe.ID,
+		Revision: 1,
+		Editor:   editor.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.Recipe.Name, reverted.Recipe.Name)
+	require.Equal(t, recipe.Recipe.Portion, reverted.Recipe.Portion)
+	require.ElementsMatch(t, recipe.Ingredients, reverted.Ingredients)
+
+	rows, err = storage.ListRecipeRevisions(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, int32(4), rows[0].Revision)
+
+	_, err = storage.RevertRecipeTx(context.Background(), RevertRecipeParams{
+		RecipeID: recipe.Recipe.ID,
+		Revision: 10,
+		Editor:   editor.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRecipeStepRevisions(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, _ := newRevisionRecipe(t, storage)
+	editor := CreateRandomUser(t)
+	ingredientID := recipe.Ingredients[0].IngredientID
+
+	steps, err := storage.InsertRecipeStepTx(context.Background(), InsertRecipeStepParams{
+		RecipeID: recipe.Recipe.ID,
+		Step: StepParam{
+			Text:            util.RandomString(20),
+			DurationSeconds: sql.NullInt32{Int32: 90, Valid: true},
+			Ingredients:     []int32{ingredientID},
+		},
+		Editor: editor.ID,
+	})
+	require.NoError(t, err)
+
+	row, err := storage.GetLatestRecipeRevision(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), row.Revision)
+	require.Equal(t, uuid.NullUUID{UUID: editor.ID, Valid: true}, row.Author)
+	second, err := RevisionOf(row)
+	require.NoError(t, err)
+	require.Equal(t, []RevisionStep{
+		{Text: steps[0].Text, DurationSeconds: 90, Ingredients: []int32{ingredientID}},
+	}, second.StepList)
+
+	// Every step change is a revision
+	steps, err = storage.InsertRecipeStepTx(context.Background(), InsertRecipeStepParams{
+		RecipeID: recipe.Recipe.ID,
+		Step:     StepParam{Text: util.RandomString(20)},
+		Editor:   editor.ID,
+	})
+	require.NoError(t, err)
+	_, err = storage.UpdateRecipeStepTx(context.Background(), UpdateRecipeStepTxParams{
+		ID:     steps[0].ID,
+		Step:   StepParam{Text: util.RandomString(20)},
+		Editor: editor.ID,
+	})
+	require.NoError(t, err)
+	_, err = storage.ReorderRecipeStepsTx(context.Background(), ReorderRecipeStepsParams{
+		RecipeID: recipe.Recipe.ID,
+		StepIDs:  []int64{steps[1].ID, steps[0].ID},
+		Editor:   editor.ID,
+	})
+	require.NoError(t, err)
+	_, err = storage.DeleteRecipeStepTx(context.Background(), DeleteRecipeStepTxParams{
+		ID:     steps[0].ID,
+		Editor: editor.ID,
+	})
+	require.NoError(t, err)
+
+	row, err = storage.GetLatestRecipeRevision(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(6), row.Revision)
+
+	// Reverting restores the steps with their timers and ingredients
+	reverted, err := storage.RevertRecipeTx(context.Background(), RevertRecipeParams{
+		RecipeID: recipe.Recipe.ID,
+		Revision: 2,
+		Editor:   editor.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, reverted.Steps, 1)
+	require.Equal(t, steps[0].Text, reverted.Steps[0].Text)
+	require.Equal(t, sql.NullInt32{Int32: 90, Valid: true}, reverted.Steps[0].DurationSeconds)
+	require.Equal(t, []int32{ingredientID}, reverted.Steps[0].Ingredients)
+
+	listed, err := storage.ListRecipeStepsTx(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, reverted.Steps, listed)
+}
+
+func TestRevertRecipeTxTaxonomy(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := newTaxonomyRecipe(t, storage, RecipeTaxonomy{
+		Cuisine:    sql.NullString{String: "thai", Valid: true},
+		Difficulty: sql.NullString{String: DifficultyMedium, Valid: true},
+		TotalTime:  sql.NullInt32{Int32: 40, Valid: true},
+	}, []string{"spicy"})
+
+	row, err := storage.GetLatestRecipeRevision(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	first, err := RevisionOf(row)
+	require.NoError(t, err)
+	require.Equal(t, "thai", first.Taxonomy.Cuisine.String)
+	require.Equal(t, []string{"spicy"}, first.Keywords)
+
+	_, err = storage.UpdateRecipeTx(context.Background(), TxUpdateRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    recipe.Recipe.Name,
+			Portion: recipe.Recipe.Portion,
+		},
+		Editor: recipe.Recipe.Author,
+		Taxonomy: &RecipeTaxonomy{
+			Cuisine: sql.NullString{String: "korean", Valid: true},
+		},
+		Keywords: []string{"mild"},
+	})
+	require.NoError(t, err)
+
+	row, err = storage.GetLatestRecipeRevision(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	second, err := RevisionOf(row)
+	require.NoError(t, err)
+	diff := DiffRevisions(first, second)
+	require.Equal(t, &StringChange{Old: "thai", New: "korean"}, diff.Cuisine)
+	require.Equal(t, &StringChange{Old: DifficultyMedium, New: ""}, diff.Difficulty)
+	require.Equal(t, &KeywordsChange{Added: []string{"mild"}, Removed: []string{"spicy"}}, diff.Keywords)
+
+	reverted, err := storage.RevertRecipeTx(context.Background(), RevertRecipeParams{
+		RecipeID: recipe.Recipe.ID,
+		Revision: first.Revision,
+		Editor:   recipe.Recipe.Author,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.Recipe.Cuisine, reverted.Recipe.Cuisine)
+	require.Equal(t, recipe.Recipe.Difficulty, reverted.Recipe.Difficulty)
+	require.Equal(t, recipe.Recipe.TotalTime, reverted.Recipe.TotalTime)
+	require.Equal(t, []string{"spicy"}, reverted.Keywords)
+}
+
+func TestGenerateGroceriesPinRevisions(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, _ := newRevisionRecipe(t, storage)
+
+	result, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: recipe.Recipe.ID, Portion: recipe.Recipe.Portion},
+		},
+		PinRevisions: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Recipes, 1)
+	require.True(t, result.Recipes[0].RevisionID.Valid)
+	require.Len(t, result.Groceries, len(recipe.Ingredients))
+
+	// Editing the recipe keeps the pinned groceries
+	err = storage.DeleteRecipeIngredientTx(context.Background(), DeleteRecipeIngredientTxParams{
+		RecipeID:     recipe.Recipe.ID,
+		IngredientID: recipe.Ingredients[0].IngredientID,
+	})
+	require.NoError(t, err)
+
+	rows, err := storage.ListGroceryAmounts(context.Background(), ListGroceryAmountsParams{
+		ScheduleIds: []int64{result.Schedule.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, len(recipe.Ingredients))
+
+	nutrition, err := storage.ListScheduleNutrition(context.Background(), result.Schedule.ID)
+	require.NoError(t, err)
+	require.Len(t, nutrition, len(recipe.Ingredients))
+}
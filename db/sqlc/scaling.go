@@ -0,0 +1,100 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+type ScaledIngredient struct {
+	IngredientID int32   `json:"ingredientID"`
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`
+	Unit         string  `json:"unit"`
+}
+
+type GroceryItem struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+	// Grocery category of the ingredient, empty when it has none
+	Category string `json:"category,omitempty"`
+	// One quantity per group of units that can not be added up, e.g. grams and pieces
+	Quantities []measure.Quantity `json:"quantities"`
+	// Estimated cost, unset when the ingredient has no usable price
+	Cost *float64 `json:"cost,omitempty"`
+	// Packages to buy, unset when the ingredient has no usable package size
+	Purchase *GroceryPurchase `json:"purchase,omitempty"`
+}
+
+// Amount of a recipe ingredient for the requested portions, not rounded yet
+func scaledQuantity(amount float32, unitName sql.NullString, recipePortion, portions int32) measure.Quantity {
+	return measure.Quantity{
+		Amount: measure.Scale(float64(amount), recipePortion, portions),
+		Unit:   unitName.String,
+	}
+}
+
+// Convert a quantity to the unit system and round it for display. An empty
+// system keeps the system of the quantity's own unit.
+func displayQuantity(q measure.Quantity, system string) measure.Quantity {
+	return measure.Round(measure.Convert(q, system))
+}
+
+func scaleIngredients(rows []ListRecipeIngredientAmountsRow, recipePortion, portions int32, system string) []ScaledIngredient {
+	result := []ScaledIngredient{}
+
+	for _, row := range rows {
+		q := displayQuantity(scaledQuantity(row.Amount, row.UnitName, recipePortion, portions), system)
+		result = append(result, ScaledIngredient{
+			IngredientID: row.IngredientID,
+			Name:         row.Name,
+			Amount:       q.Amount,
+			Unit:         q.Unit,
+		})
+	}
+
+	return result
+}
+
+// Sum the scaled amounts of every ingredient over the schedule. Amounts are
+// rounded only once they are summed up.
+func aggregateGroceries(rows []ListGroceryAmountsRow, system string) []GroceryItem {
+	result := []GroceryItem{}
+
+	// rows are ordered by ingredient
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].ID != row.ID {
+			result = append(result, GroceryItem{
+				ID:         row.ID,
+				Name:       row.Name,
+				Quantities: []measure.Quantity{},
+			})
+		}
+		item := &result[len(result)-1]
+
+		q := scaledQuantity(row.Amount, row.UnitName, row.RecipePortion, row.SchedulePortion)
+		added := false
+		for i := range item.Quantities {
+			if sum, ok := measure.Add(item.Quantities[i], q); ok {
+				item.Quantities[i] = sum
+				added = true
+				break
+			}
+		}
+		if !added {
+			item.Quantities = append(item.Quantities, q)
+		}
+	}
+
+	for i := range result {
+		for j, q := range result[i].Quantities {
+			target := system
+			if target == "" {
+				target = measure.SystemOf(q.Unit)
+			}
+			result[i].Quantities[j] = displayQuantity(q, target)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleIngredients(t *testing.T) {
+	rows := []ListRecipeIngredientAmountsRow{
+		{IngredientID: 1, Name: "egg", Amount: 1, UnitName: sql.NullString{String: "pcs", Valid: true}},
+		{IngredientID: 2, Name: "flour", Amount: 250, UnitName: sql.NullString{String: "g", Valid: true}},
+		{IngredientID: 3, Name: "salt", Amount: 1, UnitName: sql.NullString{}},
+	}
+
+	result := scaleIngredients(rows, 3, 1, "")
+	require.Len(t, result, 3)
+	// no third of an egg
+	require.Equal(t, ScaledIngredient{IngredientID: 1, Name: "egg", Amount: 1, Unit: "pcs"}, result[0])
+	require.Equal(t, ScaledIngredient{IngredientID: 2, Name: "flour", Amount: 83, Unit: "g"}, result[1])
+	require.Equal(t, 1.0, result[2].Amount)
+
+	result = scaleIngredients(rows, 1, 8, measure.Metric)
+	require.Equal(t, ScaledIngredient{IngredientID: 2, Name: "flour", Amount: 2, Unit: "kg"}, result[1])
+}
+
+func TestAggregateGroceries(t *testing.T) {
+	grams := sql.NullString{String: "g", Valid: true}
+	rows := []ListGroceryAmountsRow{
+		{ID: 1, Name: "egg", Amount: 1, UnitName: sql.NullString{String: "pcs", Valid: true}, RecipePortion: 3, SchedulePortion: 1},
+		{ID: 1, Name: "egg", Amount: 1, UnitName: sql.NullString{String: "pcs", Valid: true}, RecipePortion: 3, SchedulePortion: 1},
+		{ID: 2, Name: "flour", Amount: 600, UnitName: grams, RecipePortion: 2, SchedulePortion: 2},
+		{ID: 2, Name: "flour", Amount: 0.5, UnitName: sql.NullString{String: "kg", Valid: true}, RecipePortion: 1, SchedulePortion: 1},
+		{ID: 2, Name: "flour", Amount: 1, UnitName: sql.NullString{String: "cup", Valid: true}, RecipePortion: 1, SchedulePortion: 1},
+	}
+
+	result := aggregateGroceries(rows, "")
+	require.Len(t, result, 2)
+	// two thirds of an egg are summed before rounding
+	require.Equal(t, []measure.Quantity{{Amount: 1, Unit: "pcs"}}, result[0].Quantities)
+	require.Equal(t, []measure.Quantity{
+		{Amount: 1.1, Unit: "kg"},
+		{Amount: 1, Unit: "cup"},
+	}, result[1].Quantities)
+
+	result = aggregateGroceries(rows, measure.Imperial)
+	require.Equal(t, "lb", result[1].Quantities[0].Unit)
+	require.Equal(t, 2.5, result[1].Quantities[0].Amount)
+}
+
+func TestScaleRecipeTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, recipeIngredients := CreateRandomRecipeIngredient(t)
+
+	result, err := storage.ScaleRecipeTx(context.Background(), ScaleRecipeParams{
+		ID:       recipe.ID,
+		Portions: recipe.Portion * 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.ID, result.Recipe.ID)
+	require.Equal(t, recipe.Portion*2, result.Portions)
+	require.Len(t, result.Ingredients, 1)
+	require.Equal(t, recipeIngredients[0].IngredientID, result.Ingredients[0].IngredientID)
+
+	// portions default to the recipe portion
+	result, err = storage.ScaleRecipeTx(context.Background(), ScaleRecipeParams{
+		ID: recipe.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.Portion, result.Portions)
+
+	_, err = storage.ScaleRecipeTx(context.Background(), ScaleRecipeParams{
+		ID: -1,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -7,8 +7,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createSchedule = `-- name: CreateSchedule :one
@@ -30,22 +32,38 @@ const createScheduleRecipe = `-- name: CreateScheduleRecipe :one
 INSERT INTO schedules_recipes (
     schedule_id,
     recipe_id,
-    portion
+    portion,
+    scheduled_date,
+    revision_id
 ) VALUES (
-    $1, $2, $3
-) RETURNING schedule_id, recipe_id, portion
+    $1, $2, $3, $4, $5
+) RETURNING schedule_id, recipe_id, portion, scheduled_date, revision_id
 `
 
 type CreateScheduleRecipeParams struct {
-	ScheduleID int64 `json:"scheduleID"`
-	RecipeID   int64 `json:"recipeID"`
-	Portion    int32 `json:"portion"`
+	ScheduleID    int64         `json:"scheduleID"`
+	RecipeID      int64         `json:"recipeID"`
+	Portion       int32         `json:"portion"`
+	ScheduledDate sql.NullTime  `json:"scheduledDate"`
+	RevisionID    sql.NullInt64 `json:"revisionID"`
 }
 
 func (q *Queries) CreateScheduleRecipe(ctx context.Context, arg CreateScheduleRecipeParams) (SchedulesRecipe, error) {
-	row := q.db.QueryRowContext(ctx, createScheduleRecipe, arg.ScheduleID, arg.RecipeID, arg.Portion)
+	row := q.db.QueryRowContext(ctx, createScheduleRecipe,
+		arg.ScheduleID,
+		arg.RecipeID,
+		arg.Portion,
+		arg.ScheduledDate,
+		arg.RevisionID,
+	)
 	var i SchedulesRecipe
-	err := row.Scan(&i.ScheduleID, &i.RecipeID, &i.Portion)
+	err := row.Scan(
+		&i.ScheduleID,
+		&i.RecipeID,
+		&i.Portion,
+		&i.ScheduledDate,
+		&i.RevisionID,
+	)
 	return i, err
 }
 
@@ -87,18 +105,21 @@ func (q *Queries) GetSchedule(ctx context.Context, id int64) (Schedule, error) {
 }
 
 const getScheduleRecipe = `-- name: GetScheduleRecipe :many
-SELECT sr.schedule_id, sr.recipe_id, r.name, sr.portion
+SELECT sr.schedule_id, sr.recipe_id, r.name, sr.portion, sr.scheduled_date, sr.revision_id
 from schedules_recipes as sr 
 INNER JOIN recipes as r
 ON sr.recipe_id = r.id
 WHERE sr.schedule_id = $1
+ORDER BY sr.scheduled_date
 `
 
 type GetScheduleRecipeRow struct {
-	ScheduleID int64  `json:"scheduleID"`
-	RecipeID   int64  `json:"recipeID"`
-	Name       string `json:"name"`
-	Portion    int32  `json:"portion"`
+	ScheduleID    int64         `json:"scheduleID"`
+	RecipeID      int64         `json:"recipeID"`
+	Name          string        `json:"name"`
+	Portion       int32         `json:"portion"`
+	ScheduledDate sql.NullTime  `json:"scheduledDate"`
+	RevisionID    sql.NullInt64 `json:"revisionID"`
 }
 
 func (q *Queries) GetScheduleRecipe(ctx context.Context, scheduleID int64) ([]GetScheduleRecipeRow, error) {
@@ -115,6 +136,8 @@ func (q *Queries) GetScheduleRecipe(ctx context.Context, scheduleID int64) ([]Ge
 			&i.RecipeID,
 			&i.Name,
 			&i.Portion,
+			&i.ScheduledDate,
+			&i.RevisionID,
 		); err != nil {
 			return nil, err
 		}
@@ -169,6 +192,105 @@ func (q *Queries) ListGroceries(ctx context.Context, scheduleID int64) ([]ListGr
 	return items, nil
 }
 
+const listGroceryAmounts = `-- name: ListGroceryAmounts :many
+WITH schedule_ingredients AS (
+    SELECT sr.schedule_id, ri.ingredient_id, ri.amount, ri.unit_id,
+        r.portion AS recipe_portion, sr.portion AS schedule_portion
+    FROM schedules_recipes AS sr
+    INNER JOIN recipes AS r
+    ON sr.recipe_id = r.id
+    INNER JOIN recipes_ingredients AS ri
+    ON sr.recipe_id = ri.recipe_id
+    WHERE sr.schedule_id = ANY($1::bigint[]) AND sr.revision_id IS NULL
+    UNION ALL
+    SELECT sr.schedule_id, (e->>'ingredientID')::int, (e->>'amount')::real, (e->>'unitID')::int,
+        rv.portion, sr.portion
+    FROM schedules_recipes AS sr
+    INNER JOIN recipes_revisions AS rv
+    ON sr.revision_id = rv.id
+    CROSS JOIN LATERAL jsonb_array_elements(rv.ingredients) AS e
+    WHERE sr.schedule_id = ANY($1::bigint[])
+)
+SELECT si.schedule_id, i.id, i.name, si.amount, u.name AS unit_name,
+    si.recipe_portion, si.schedule_portion,
+    iu.grams AS ingredient_unit_grams, u.grams AS unit_grams,
+    p.price, p.unit_name AS price_unit_name,
+    p.unit_grams AS price_unit_grams, p.ingredient_unit_grams AS price_ingredient_unit_grams
+FROM schedule_ingredients AS si
+INNER JOIN ingredients AS i
+ON si.ingredient_id = i.id
+LEFT JOIN units AS u
+ON si.unit_id = u.id
+LEFT JOIN ingredients_units AS iu
+ON si.ingredient_id = iu.ingredient_id AND si.unit_id = iu.unit_id
+LEFT JOIN LATERAL (
+    SELECT sp.price, sp.unit_name, sp.unit_grams, sp.ingredient_unit_grams
+    FROM ingredients_store_prices AS sp
+    WHERE sp.ingredient_id = si.ingredient_id
+    ORDER BY COALESCE(sp.store = $2, false) DESC, sp.observed_on DESC, sp.id DESC
+    LIMIT 1
+) AS p ON true
+ORDER BY i.name, i.id, si.schedule_id
+`
+
+type ListGroceryAmountsParams struct {
+	ScheduleIds []int64 `json:"scheduleIds"`
+	Store       string  `json:"store"`
+}
+
+type ListGroceryAmountsRow struct {
+	ScheduleID               int64           `json:"scheduleID"`
+	ID                       int32           `json:"id"`
+	Name                     string          `json:"name"`
+	Amount                   float32         `json:"amount"`
+	UnitName                 sql.NullString  `json:"unitName"`
+	RecipePortion            int32           `json:"recipePortion"`
+	SchedulePortion          int32           `json:"schedulePortion"`
+	IngredientUnitGrams      sql.NullFloat64 `json:"ingredientUnitGrams"`
+	UnitGrams                sql.NullFloat64 `json:"unitGrams"`
+	Price                    sql.NullFloat64 `json:"price"`
+	PriceUnitName            sql.NullString  `json:"priceUnitName"`
+	PriceUnitGrams           sql.NullFloat64 `json:"priceUnitGrams"`
+	PriceIngredientUnitGrams sql.NullFloat64 `json:"priceIngredientUnitGrams"`
+}
+
+func (q *Queries) ListGroceryAmounts(ctx context.Context, arg ListGroceryAmountsParams) ([]ListGroceryAmountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGroceryAmounts, pq.Array(arg.ScheduleIds), arg.Store)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGroceryAmountsRow{}
+	for rows.Next() {
+		var i ListGroceryAmountsRow
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.ID,
+			&i.Name,
+			&i.Amount,
+			&i.UnitName,
+			&i.RecipePortion,
+			&i.SchedulePortion,
+			&i.IngredientUnitGrams,
+			&i.UnitGrams,
+			&i.Price,
+			&i.PriceUnitName,
+			&i.PriceUnitGrams,
+			&i.PriceIngredientUnitGrams,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSchedules = `-- name: ListSchedules :many
 SELECT id, author, created_at from schedules
 ORDER BY created_at
@@ -78,6 +78,56 @@ func TestCreateScheduleRecipe(t *testing.T) {
 	require.Equal(t, arg.Portion, scheduleRecipe.Portion)
 }
 
+func TestCreateScheduleRecipeDated(t *testing.T) {
+	recipeNew := CreateRandomRecipe(t)
+	scheduleNew := createRandomSchedule(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// Same recipe planned on two different days
+	for i := 0; i < 2; i++ {
+		arg := CreateScheduleRecipeParams {
+			ScheduleID: scheduleNew.ID,
+			RecipeID: recipeNew.ID,
+			Portion: 2,
+			ScheduledDate: sql.NullTime{
+				Time: today.AddDate(0, 0, i),
+				Valid: true,
+			},
+		}
+
+		scheduleRecipe, err := testQueries.CreateScheduleRecipe(
+			context.Background(),
+			arg,
+		)
+		require.NoError(t, err)
+		require.True(t, scheduleRecipe.ScheduledDate.Valid)
+		require.WithinDuration(t, arg.ScheduledDate.Time, scheduleRecipe.ScheduledDate.Time, time.Second)
+	}
+
+	// Same recipe on the same day is rejected
+	_, err := testQueries.CreateScheduleRecipe(
+		context.Background(),
+		CreateScheduleRecipeParams {
+			ScheduleID: scheduleNew.ID,
+			RecipeID: recipeNew.ID,
+			Portion: 1,
+			ScheduledDate: sql.NullTime{
+				Time: today,
+				Valid: true,
+			},
+		},
+	)
+	require.Error(t, err)
+
+	scheduleRecipe, err := testQueries.GetScheduleRecipe(
+		context.Background(),
+		scheduleNew.ID,
+	)
+	require.NoError(t, err)
+	require.Len(t, scheduleRecipe, 2)
+	require.True(t, scheduleRecipe[0].ScheduledDate.Time.Before(scheduleRecipe[1].ScheduledDate.Time))
+}
+
 func TestDeleteSchedule(t *testing.T) {
 	scheduleNew := createRandomSchedule(t)
 
@@ -239,4 +289,54 @@ func TestListGroceries(t *testing.T) {
 	require.Len(t, groceries, 1)
 
 	require.Equal(t, recipeIngredients[0].IngredientID, groceries[0].ID)
-}
\ No newline at end of file
+}
+func TestDuplicateScheduleTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	user := CreateRandomUser(t)
+	copier := CreateRandomUser(t)
+	recipe, _ := CreateRandomRecipeIngredient(t)
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	source, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+		Author: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: recipe.ID, Portion: 2, ScheduledDate: sql.NullTime{Time: monday, Valid: true}},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := storage.DuplicateScheduleTx(context.Background(), DuplicateScheduleParams{
+		ScheduleID: source.Schedule.ID,
+		Author:     copier.ID,
+		ShiftDays:  7,
+		Multiplier: 1.5,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, source.Schedule.ID, result.Schedule.ID)
+	require.Equal(t, copier.ID, result.Schedule.Author.UUID)
+	require.Len(t, result.Recipes, 1)
+	require.Equal(t, int32(3), result.Recipes[0].Portion)
+	require.Equal(t, monday.AddDate(0, 0, 7), result.Recipes[0].ScheduledDate.Time.UTC())
+	require.Len(t, result.Groceries, len(source.Groceries))
+}
+
+func TestDuplicateScheduleRecipes(t *testing.T) {
+	monday := sql.NullTime{Time: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Valid: true}
+	rows := []GetScheduleRecipeRow{
+		{RecipeID: 1, Portion: 4, ScheduledDate: monday, RevisionID: sql.NullInt64{Int64: 9, Valid: true}},
+		{RecipeID: 2, Portion: 1},
+	}
+
+	copied := duplicateScheduleRecipes(rows, 0, 0)
+	require.Equal(t, []ScheduleRecipePortion{
+		{RecipeID: 1, Portion: 4, ScheduledDate: monday, RevisionID: sql.NullInt64{Int64: 9, Valid: true}},
+		{RecipeID: 2, Portion: 1},
+	}, copied)
+
+	copied = duplicateScheduleRecipes(rows, -7, 0.25)
+	require.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), copied[0].ScheduledDate.Time)
+	require.Equal(t, int32(1), copied[0].Portion)
+	require.False(t, copied[1].ScheduledDate.Valid)
+	// never below one portion
+	require.Equal(t, int32(1), copied[1].Portion)
+}
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func insertRandomStep(t *testing.T, storage *SQLStorage, recipeID int64, position int32, ingredients []int32) []RecipeStep {
+	steps, err := storage.InsertRecipeStepTx(
+		context.Background(),
+		InsertRecipeStepParams{
+			RecipeID: recipeID,
+			Position: position,
+			Step: StepParam{
+				Text: util.RandomString(30),
+				DurationSeconds: sql.NullInt32{
+					Int32: int32(util.RandomInt(1, 600)),
+					Valid: true,
+				},
+				Ingredients: ingredients,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	return steps
+}
+
+func requireStepPositions(t *testing.T, steps []RecipeStep) {
+	for i, step := range steps {
+		require.Equal(t, int32(i+1), step.Position)
+	}
+}
+
+func TestInsertRecipeStepTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+
+	first := insertRandomStep(t, storage, recipe.ID, 0, []int32{ingredients[0].IngredientID})
+	require.Len(t, first, 1)
+	require.Equal(t, []int32{ingredients[0].IngredientID}, first[0].Ingredients)
+
+	// Appended past the last step
+	second := insertRandomStep(t, storage, recipe.ID, 10, nil)
+	require.Len(t, second, 2)
+	require.Equal(t, first[0].ID, second[0].ID)
+	require.Empty(t, second[1].Ingredients)
+
+	// Inserted before the first step
+	third := insertRandomStep(t, storage, recipe.ID, 1, nil)
+	require.Len(t, third, 3)
+	require.Equal(t, first[0].ID, third[1].ID)
+	require.Equal(t, second[1].ID, third[2].ID)
+	requireStepPositions(t, third)
+
+	// Ingredient that is not part of the recipe
+	other := CreateRandomIngredient(t)
+	_, err := storage.InsertRecipeStepTx(
+		context.Background(),
+		InsertRecipeStepParams{
+			RecipeID: recipe.ID,
+			Step: StepParam{
+				Text:        util.RandomString(30),
+				Ingredients: []int32{other.ID},
+			},
+		},
+	)
+	require.Error(t, err)
+
+	steps, err := storage.ListRecipeStepsTx(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, third, steps)
+}
+
+func TestUpdateRecipeStepTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, ingredients := CreateRandomRecipeIngredient(t)
+	steps := insertRandomStep(t, storage, recipe.ID, 0, []int32{ingredients[0].IngredientID})
+
+	arg := UpdateRecipeStepTxParams{
+		ID: steps[0].ID,
+		Step: StepParam{
+			Text: util.RandomString(30),
+		},
+	}
+	updated, err := storage.UpdateRecipeStepTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	require.Equal(t, arg.Step.Text, updated[0].Text)
+	require.False(t, updated[0].DurationSeconds.Valid)
+	require.Empty(t, updated[0].Ingredients)
+}
+
+func TestDeleteRecipeStepTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := CreateRandomRecipe(t)
+	insertRandomStep(t, storage, recipe.ID, 0, nil)
+	insertRandomStep(t, storage, recipe.ID, 0, nil)
+	steps := insertRandomStep(t, storage, recipe.ID, 0, nil)
+
+	remaining, err := storage.DeleteRecipeStepTx(context.Background(), DeleteRecipeStepTxParams{ID: steps[0].ID})
+	require.NoError(t, err)
+	require.Len(t, remaining, 2)
+	require.Equal(t, steps[1].ID, remaining[0].ID)
+	require.Equal(t, steps[2].ID, remaining[1].ID)
+	requireStepPositions(t, remaining)
+
+	_, err = storage.DeleteRecipeStepTx(context.Background(), DeleteRecipeStepTxParams{ID: steps[0].ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestReorderRecipeStepsTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := CreateRandomRecipe(t)
+	insertRandomStep(t, storage, recipe.ID, 0, nil)
+	insertRandomStep(t, storage, recipe.ID, 0, nil)
+	steps := insertRandomStep(t, storage, recipe.ID, 0, nil)
+
+	order := []int64{steps[2].ID, steps[0].ID, steps[1].ID}
+	reordered, err := storage.ReorderRecipeStepsTx(
+		context.Background(),
+		ReorderRecipeStepsParams{
+			RecipeID: recipe.ID,
+			StepIDs:  order,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, reordered, 3)
+	for i, step := range reordered {
+		require.Equal(t, order[i], step.ID)
+	}
+	requireStepPositions(t, reordered)
+
+	// Missing and repeated steps
+	_, err = storage.ReorderRecipeStepsTx(
+		context.Background(),
+		ReorderRecipeStepsParams{
+			RecipeID: recipe.ID,
+			StepIDs:  order[:2],
+		},
+	)
+	require.ErrorIs(t, err, ErrInvalidStepOrder)
+
+	_, err = storage.ReorderRecipeStepsTx(
+		context.Background(),
+		ReorderRecipeStepsParams{
+			RecipeID: recipe.ID,
+			StepIDs:  []int64{order[0], order[0], order[1]},
+		},
+	)
+	require.ErrorIs(t, err, ErrInvalidStepOrder)
+}
+
+func TestRecipeStepsText(t *testing.T) {
+	storage := NewStorage(testDB)
+	author := CreateRandomUser(t)
+
+	// The step list wins over the text given with it
+	recipe, err := storage.NewRecipeTx(context.Background(), NewRecipeParams{
+		Name:    util.RandomString(10),
+		Author:  author.ID,
+		Portion: 2,
+		Steps:   sql.NullString{String: util.RandomString(40), Valid: true},
+		StepList: []StepParam{
+			{Text: "boil water"},
+			{Text: "add pasta"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: "boil water\nadd pasta", Valid: true}, recipe.Recipe.Steps)
+
+	// Text steps of an update follow the step list
+	updated, err := storage.UpdateRecipeTx(context.Background(), TxUpdateRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    recipe.Recipe.Name,
+			Portion: recipe.Recipe.Portion,
+			Steps:   sql.NullString{String: util.RandomString(40), Valid: true},
+		},
+		Editor: author.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.Recipe.Steps, updated.Recipe.Steps)
+
+	steps, err := storage.InsertRecipeStepTx(context.Background(), InsertRecipeStepParams{
+		RecipeID: recipe.Recipe.ID,
+		Position: 2,
+		Step:     StepParam{Text: "salt the water"},
+		Editor:   author.ID,
+	})
+	require.NoError(t, err)
+	stored, err := testQueries.GetRecipe(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, "boil water\nsalt the water\nadd pasta", stored.Steps.String)
+
+	for _, step := range steps {
+		_, err = storage.DeleteRecipeStepTx(context.Background(), DeleteRecipeStepTxParams{ID: step.ID})
+		require.NoError(t, err)
+	}
+	stored, err = testQueries.GetRecipe(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.False(t, stored.Steps.Valid)
+
+	// Recipes without a step list keep their text
+	text := sql.NullString{String: util.RandomString(40), Valid: true}
+	updated, err = storage.UpdateRecipeTx(context.Background(), TxUpdateRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    recipe.Recipe.Name,
+			Portion: recipe.Recipe.Portion,
+			Steps:   text,
+		},
+		Editor: author.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, text, updated.Recipe.Steps)
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrInvalidStepOrder = errors.New("step order has to contain every step of the recipe exactly once")
+
+type RecipeStep struct {
+	ID              int64         `json:"id"`
+	Position        int32         `json:"position"`
+	Text            string        `json:"text"`
+	DurationSeconds sql.NullInt32 `json:"durationSeconds"`
+	// Recipe ingredients used in this step
+	Ingredients []int32 `json:"ingredients"`
+}
+
+type StepParam struct {
+	Text            string        `json:"text"`
+	DurationSeconds sql.NullInt32 `json:"durationSeconds"`
+	Ingredients     []int32       `json:"ingredients"`
+}
+
+func recipeSteps(steps []RecipesStep, refs []ListRecipeStepsIngredientsRow) []RecipeStep {
+	ingredients := make(map[int64][]int32)
+	for _, ref := range refs {
+		ingredients[ref.StepID] = append(ingredients[ref.StepID], ref.IngredientID)
+	}
+
+	result := make([]RecipeStep, len(steps))
+	for i, step := range steps {
+		result[i] = RecipeStep{
+			ID:              step.ID,
+			Position:        step.Position,
+			Text:            step.Text,
+			DurationSeconds: step.DurationSeconds,
+			Ingredients:     ingredients[step.ID],
+		}
+		if result[i].Ingredients == nil {
+			result[i].Ingredients = []int32{}
+		}
+	}
+
+	return result
+}
+
+func recipeStepList(ctx context.Context, q *Queries, recipeID int64) ([]RecipeStep, error) {
+	steps, err := q.ListRecipeSteps(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := q.ListRecipeStepsIngredients(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipeSteps(steps, refs), nil
+}
+
+func createStepIngredients(ctx context.Context, q *Queries, step RecipesStep, ingredients []int32) error {
+	for _, ingredientID := range ingredients {
+		_, err := q.CreateRecipeStepIngredient(
+			ctx,
+			CreateRecipeStepIngredientParams{
+				StepID:       step.ID,
+				RecipeID:     step.RecipeID,
+				IngredientID: ingredientID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The step list is the source of truth for the steps of a recipe that has one,
+// the text steps kept for older clients are rewritten from it
+func deriveStepsText(ctx context.Context, q *Queries, recipe Recipe) (Recipe, error) {
+	count, err := q.CountRecipeSteps(ctx, recipe.ID)
+	if err != nil || count == 0 {
+		return recipe, err
+	}
+
+	return q.SyncRecipeStepsText(ctx, recipe.ID)
+}
@@ -4,14 +4,42 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	"github.com/google/uuid"
 )
 
 type Storage interface {
 	Querier
 	NewRecipeTx(ctx context.Context, arg NewRecipeParams) (RecipeResult, error)
 	GetRecipeTx(ctx context.Context, id int64) (RecipeResult, error)
+	ScaleRecipeTx(ctx context.Context, arg ScaleRecipeParams) (ScaledRecipeResult, error)
 	UpdateRecipeTx(ctx context.Context, arg TxUpdateRecipeParams) (RecipeResult, error)
+	ReplaceRecipeTx(ctx context.Context, arg ReplaceRecipeParams) (RecipeResult, error)
+	ForkRecipeTx(ctx context.Context, arg ForkRecipeParams) (RecipeResult, error)
+	RevertRecipeTx(ctx context.Context, arg RevertRecipeParams) (RecipeResult, error)
+	DeleteRecipeIngredientTx(ctx context.Context, arg DeleteRecipeIngredientTxParams) error
+	ListRecipeStepsTx(ctx context.Context, recipeID int64) ([]RecipeStep, error)
+	InsertRecipeStepTx(ctx context.Context, arg InsertRecipeStepParams) ([]RecipeStep, error)
+	UpdateRecipeStepTx(ctx context.Context, arg UpdateRecipeStepTxParams) ([]RecipeStep, error)
+	DeleteRecipeStepTx(ctx context.Context, arg DeleteRecipeStepTxParams) ([]RecipeStep, error)
+	ReorderRecipeStepsTx(ctx context.Context, arg ReorderRecipeStepsParams) ([]RecipeStep, error)
 	GenerateGroceries(ctx context.Context, arg GenerateGroceriesParam) (GenerateGroceriesResult, error)
+	GetScheduleNutritionTx(ctx context.Context, scheduleID int64) (ScheduleNutritionResult, error)
+	ImportNutritionTx(ctx context.Context, arg []UpsertNutritionParams) ([]Nutrition, error)
+	SetUserRestrictionsTx(ctx context.Context, arg SetUserRestrictionsParams) ([]ListUserRestrictionsRow, error)
+	RemoveCollectionRecipeTx(ctx context.Context, arg RemoveCollectionRecipeParams) ([]ListCollectionRecipesRow, error)
+	ReorderCollectionTx(ctx context.Context, arg ReorderCollectionParams) ([]ListCollectionRecipesRow, error)
+	SuggestMealPlanTx(ctx context.Context, arg SuggestMealPlanParams) (MealPlanSuggestion, error)
+	ListScheduleLeftoversTx(ctx context.Context, arg ScheduleLeftoversParams) ([]ScheduleLeftover, error)
+	AddScheduleLeftoversTx(ctx context.Context, arg ScheduleLeftoversParams) ([]PantryItem, error)
+	SuggestPantryRecipesTx(ctx context.Context, arg SuggestPantryRecipesParams) ([]PantrySuggestion, error)
+	NewScheduleTemplateTx(ctx context.Context, arg NewScheduleTemplateParams) (ScheduleTemplateResult, error)
+	ListScheduleTemplatesTx(ctx context.Context, author uuid.UUID) ([]ScheduleTemplateResult, error)
+	GenerateWeekTx(ctx context.Context, arg GenerateWeekParams) (GenerateGroceriesResult, error)
+	DuplicateScheduleTx(ctx context.Context, arg DuplicateScheduleParams) (GenerateGroceriesResult, error)
+	MergeGroceriesTx(ctx context.Context, arg MergeGroceriesParams) (MergedGroceriesResult, error)
+	GetScheduleGroceriesTx(ctx context.Context, arg ScheduleGroceriesParams) (ScheduleGroceriesResult, error)
+	GetCalendarFeedTx(ctx context.Context, arg CalendarFeedParams) ([]CalendarEntry, error)
 }
 
 type SQLStorage struct {
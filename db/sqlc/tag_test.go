@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomDietaryTag(t *testing.T, kind string) DietaryTag {
+	arg := CreateDietaryTagParams{
+		Name: util.RandomString(20),
+		Kind: kind,
+	}
+
+	tag, err := testQueries.CreateDietaryTag(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, tag.Name)
+	require.Equal(t, arg.Kind, tag.Kind)
+
+	return tag
+}
+
+func CreateRandomIngredientTag(t *testing.T, ingredientID int32, tag DietaryTag) IngredientsTag {
+	arg := CreateIngredientTagParams{
+		IngredientID: ingredientID,
+		Tag:          tag.Name,
+	}
+
+	ingredientTag, err := testQueries.CreateIngredientTag(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.IngredientID, ingredientTag.IngredientID)
+	require.Equal(t, arg.Tag, ingredientTag.Tag)
+
+	return ingredientTag
+}
+
+func TestCreateDietaryTag(t *testing.T) {
+	tag := CreateRandomDietaryTag(t, TagKindAllergen)
+
+	_, err := testQueries.CreateDietaryTag(context.Background(), CreateDietaryTagParams{
+		Name: util.RandomString(20),
+		Kind: "taste",
+	})
+	require.Error(t, err)
+
+	tags, err := testQueries.ListDietaryTags(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, tags, tag)
+
+	err = testQueries.DeleteDietaryTag(context.Background(), tag.Name)
+	require.NoError(t, err)
+}
+
+func TestIngredientTags(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	allergen := CreateRandomDietaryTag(t, TagKindAllergen)
+	diet := CreateRandomDietaryTag(t, TagKindDiet)
+	CreateRandomIngredientTag(t, ingredient.ID, allergen)
+	CreateRandomIngredientTag(t, ingredient.ID, diet)
+
+	tags, err := testQueries.ListIngredientTags(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Equal(t, []ListIngredientTagsRow{
+		{Tag: allergen.Name, Kind: TagKindAllergen},
+		{Tag: diet.Name, Kind: TagKindDiet},
+	}, tags)
+
+	err = testQueries.DeleteIngredientTag(context.Background(), DeleteIngredientTagParams{
+		IngredientID: ingredient.ID,
+		Tag:          allergen.Name,
+	})
+	require.NoError(t, err)
+
+	tags, err = testQueries.ListIngredientTags(context.Background(), ingredient.ID)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+}
+
+func TestRecipeTagsAndConflicts(t *testing.T) {
+	allergen := CreateRandomDietaryTag(t, TagKindAllergen)
+	diet := CreateRandomDietaryTag(t, TagKindDiet)
+
+	// the recipe has two ingredients, only one of them fits the diet
+	recipe, recipeIngredients := CreateRandomRecipeIngredient(t)
+	other := CreateRandomIngredient(t)
+	_, err := testQueries.CreateRecipeIngredient(context.Background(), CreateRecipeIngredientParams{
+		IngredientID: other.ID,
+		RecipeID:     recipe.ID,
+		Amount:       1,
+		UnitID:       recipeIngredients[0].UnitID,
+	})
+	require.NoError(t, err)
+	CreateRandomIngredientTag(t, recipeIngredients[0].IngredientID, allergen)
+	CreateRandomIngredientTag(t, recipeIngredients[0].IngredientID, diet)
+
+	tags, err := testQueries.ListRecipeTags(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	result := recipeTags(tags)
+	require.Equal(t, []string{allergen.Name}, result.Allergens)
+	require.Empty(t, result.Diets)
+
+	user := CreateRandomUser(t)
+	storage := NewStorage(testDB)
+	restrictions, err := storage.SetUserRestrictionsTx(context.Background(), SetUserRestrictionsParams{
+		UserID: user.ID,
+		Tags:   []string{allergen.Name, diet.Name},
+	})
+	require.NoError(t, err)
+	require.Len(t, restrictions, 2)
+
+	conflicts, err := testQueries.ListRecipeConflicts(context.Background(), ListRecipeConflictsParams{
+		UserID:    user.ID,
+		RecipeIds: []int64{recipe.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+
+	searched, err := testQueries.SearchRecipeAllowed(context.Background(), SearchRecipeAllowedParams{
+		Name:   recipe.Name,
+		UserID: user.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, searched)
+
+	// an unrestricted user sees the recipe
+	searched, err = testQueries.SearchRecipeAllowed(context.Background(), SearchRecipeAllowedParams{
+		Name:   recipe.Name,
+		UserID: uuid.New(),
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, searched, 1)
+
+	recipes, err := testQueries.ListRecipesAllowed(context.Background(), ListRecipesAllowedParams{
+		UserID: user.ID,
+		Limit:  1000,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	for _, r := range recipes {
+		require.NotEqual(t, recipe.ID, r.ID)
+	}
+
+	// tagging the other ingredient makes the recipe fit the diet
+	CreateRandomIngredientTag(t, other.ID, diet)
+	conflicts, err = testQueries.ListRecipeConflicts(context.Background(), ListRecipeConflictsParams{
+		UserID:    user.ID,
+		RecipeIds: []int64{recipe.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListRecipeConflictsRow{{RecipeID: recipe.ID, Tag: allergen.Name}}, conflicts)
+}
+
+func TestSetUserRestrictionsTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	user := CreateRandomUser(t)
+	tag := CreateRandomDietaryTag(t, TagKindDiet)
+
+	_, err := storage.SetUserRestrictionsTx(context.Background(), SetUserRestrictionsParams{
+		UserID: user.ID,
+		Tags:   []string{tag.Name, "gluten"},
+	})
+	require.NoError(t, err)
+
+	// replaces the previous restrictions
+	restrictions, err := storage.SetUserRestrictionsTx(context.Background(), SetUserRestrictionsParams{
+		UserID: user.ID,
+		Tags:   []string{tag.Name},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListUserRestrictionsRow{{Tag: tag.Name, Kind: TagKindDiet}}, restrictions)
+
+	// unknown tags roll back
+	_, err = storage.SetUserRestrictionsTx(context.Background(), SetUserRestrictionsParams{
+		UserID: user.ID,
+		Tags:   []string{util.RandomString(20)},
+	})
+	require.Error(t, err)
+
+	restrictions, err = testQueries.ListUserRestrictions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, restrictions, 1)
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Difficulty levels accepted by the recipes table
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// Controlled classification of a recipe, cuisine and course must be listed in their tables
+type RecipeTaxonomy struct {
+	Cuisine    sql.NullString `json:"cuisine"`
+	Course     sql.NullString `json:"course"`
+	Difficulty sql.NullString `json:"difficulty"`
+	// Preparation and cooking time in minutes
+	TotalTime sql.NullInt32 `json:"totalTime"`
+}
+
+func (t RecipeTaxonomy) updateParams(recipeID int64) UpdateRecipeTaxonomyParams {
+	return UpdateRecipeTaxonomyParams{
+		ID:         recipeID,
+		Cuisine:    t.Cuisine,
+		Course:     t.Course,
+		Difficulty: t.Difficulty,
+		TotalTime:  t.TotalTime,
+	}
+}
+
+// Replace the free-form keywords of a recipe and return them in order
+func setRecipeKeywords(ctx context.Context, q *Queries, recipeID int64, keywords []string) ([]string, error) {
+	err := q.DeleteRecipeKeywords(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, keyword := range keywords {
+		err = q.CreateRecipeKeyword(
+			ctx,
+			CreateRecipeKeywordParams{
+				RecipeID: recipeID,
+				Keyword:  keyword,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return q.ListRecipeKeywords(ctx, recipeID)
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaxonomyRecipe(t *testing.T, storage *SQLStorage, taxonomy RecipeTaxonomy, keywords []string) RecipeResult {
+	author := CreateRandomUser(t)
+	ingredient := CreateRandomIngredient(t)
+
+	recipe, err := storage.NewRecipeTx(context.Background(), NewRecipeParams{
+		Name:    util.RandomString(10),
+		Author:  author.ID,
+		Portion: 2,
+		ListIngredients: []ListIngredientParam{
+			{
+				ID:     sql.NullInt32{Int32: ingredient.ID, Valid: true},
+				Amount: float32(util.RandomInt(50, 175)),
+				UnitID: ingredient.DefaultUnit.Int32,
+			},
+		},
+		Taxonomy: taxonomy,
+		Keywords: keywords,
+	})
+	require.NoError(t, err)
+	require.Equal(t, taxonomy.Cuisine, recipe.Recipe.Cuisine)
+	require.Equal(t, taxonomy.Course, recipe.Recipe.Course)
+	require.Equal(t, taxonomy.Difficulty, recipe.Recipe.Difficulty)
+	require.Equal(t, taxonomy.TotalTime, recipe.Recipe.TotalTime)
+	require.ElementsMatch(t, keywords, recipe.Keywords)
+
+	return recipe
+}
+
+func TestRecipeTaxonomyFilters(t *testing.T) {
+	storage := NewStorage(testDB)
+	keyword := util.RandomString(12)
+
+	quick := newTaxonomyRecipe(t, storage, RecipeTaxonomy{
+		Cuisine:    sql.NullString{String: "thai", Valid: true},
+		Course:     sql.NullString{String: "main", Valid: true},
+		Difficulty: sql.NullString{String: DifficultyEasy, Valid: true},
+		TotalTime:  sql.NullInt32{Int32: 20, Valid: true},
+	}, []string{keyword, "quick"})
+	slow := newTaxonomyRecipe(t, storage, RecipeTaxonomy{
+		Cuisine:   sql.NullString{String: "thai", Valid: true},
+		TotalTime: sql.NullInt32{Int32: 180, Valid: true},
+	}, []string{keyword})
+
+	recipes, err := testQueries.ListRecipes(context.Background(), ListRecipesParams{
+		Keywords: []string{keyword},
+		Limit:    10,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, recipes, 2)
+
+	recipes, err = testQueries.ListRecipes(context.Background(), ListRecipesParams{
+		Cuisine:      sql.NullString{String: "thai", Valid: true},
+		MaxTotalTime: sql.NullInt32{Int32: 60, Valid: true},
+		Keywords:     []string{keyword},
+		Limit:        10,
+		Offset:       0,
+	})
+	require.NoError(t, err)
+	require.Len(t, recipes, 1)
+	require.Equal(t, quick.Recipe.ID, recipes[0].ID)
+
+	// every keyword has to match
+	searched, err := testQueries.SearchRecipe(context.Background(), SearchRecipeParams{
+		Name:     "%" + slow.Recipe.Name + "%",
+		Keywords: []string{keyword, "quick"},
+		Limit:    10,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, searched)
+
+	// nil keywords do not filter
+	searched, err = testQueries.SearchRecipe(context.Background(), SearchRecipeParams{
+		Name:   "%" + slow.Recipe.Name + "%",
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, searched, 1)
+}
+
+func TestRecipeTaxonomyUnknownCuisine(t *testing.T) {
+	storage := NewStorage(testDB)
+	author := CreateRandomUser(t)
+
+	_, err := storage.NewRecipeTx(context.Background(), NewRecipeParams{
+		Name:     util.RandomString(10),
+		Author:   author.ID,
+		Portion:  2,
+		Taxonomy: RecipeTaxonomy{Cuisine: sql.NullString{String: util.RandomString(12), Valid: true}},
+	})
+	require.Error(t, err)
+}
+
+func TestUpdateRecipeTxKeywords(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := newTaxonomyRecipe(t, storage, RecipeTaxonomy{
+		Course: sql.NullString{String: "dessert", Valid: true},
+	}, []string{"sweet"})
+
+	arg := TxUpdateRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    recipe.Recipe.Name,
+			Portion: recipe.Recipe.Portion,
+		},
+		Editor: recipe.Recipe.Author,
+		Taxonomy: &RecipeTaxonomy{
+			Course:     sql.NullString{String: "snack", Valid: true},
+			Difficulty: sql.NullString{String: DifficultyHard, Valid: true},
+		},
+	}
+
+	// nil keywords are kept
+	updated, err := storage.UpdateRecipeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "snack", updated.Recipe.Course.String)
+	require.Equal(t, DifficultyHard, updated.Recipe.Difficulty.String)
+	require.Equal(t, []string{"sweet"}, updated.Keywords)
+
+	arg.Keywords = []string{}
+	updated, err = storage.UpdateRecipeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, updated.Keywords)
+
+	keywords, err := testQueries.ListRecipeKeywords(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Empty(t, keywords)
+}
+
+func TestUpdateRecipeTxKeepsTaxonomy(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := newTaxonomyRecipe(t, storage, RecipeTaxonomy{
+		Course:     sql.NullString{String: "dessert", Valid: true},
+		Difficulty: sql.NullString{String: DifficultyEasy, Valid: true},
+		TotalTime:  sql.NullInt32{Int32: 20, Valid: true},
+	}, []string{"sweet"})
+
+	// nil taxonomy and keywords are kept
+	updated, err := storage.UpdateRecipeTx(context.Background(), TxUpdateRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    recipe.Recipe.Name,
+			Portion: recipe.Recipe.Portion + 1,
+		},
+		Editor: recipe.Recipe.Author,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.Recipe.Portion+1, updated.Recipe.Portion)
+	require.Equal(t, recipe.Recipe.Course, updated.Recipe.Course)
+	require.Equal(t, recipe.Recipe.Difficulty, updated.Recipe.Difficulty)
+	require.Equal(t, recipe.Recipe.TotalTime, updated.Recipe.TotalTime)
+	require.Equal(t, []string{"sweet"}, updated.Keywords)
+
+	stored, err := testQueries.GetRecipe(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, recipe.Recipe.Course, stored.Course)
+	require.Equal(t, recipe.Recipe.TotalTime, stored.TotalTime)
+}
+
+func TestForkRecipeTxTaxonomy(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe := newTaxonomyRecipe(t, storage, RecipeTaxonomy{
+		Cuisine:   sql.NullString{String: "korean", Valid: true},
+		TotalTime: sql.NullInt32{Int32: 35, Valid: true},
+	}, []string{"fermented", "spicy"})
+	user := CreateRandomUser(t)
+
+	fork, err := storage.ForkRecipeTx(context.Background(), ForkRecipeParams{
+		Author: user.ID,
+		ID:     recipe.Recipe.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipe.Recipe.Cuisine, fork.Recipe.Cuisine)
+	require.Equal(t, recipe.Recipe.TotalTime, fork.Recipe.TotalTime)
+	require.Equal(t, recipe.Keywords, fork.Keywords)
+}
+
+func TestListKeywords(t *testing.T) {
+	storage := NewStorage(testDB)
+	keyword := util.RandomString(12)
+	for i := 0; i < 2; i++ {
+		newTaxonomyRecipe(t, storage, RecipeTaxonomy{}, []string{keyword})
+	}
+
+	rows, err := testQueries.ListKeywords(context.Background(), ListKeywordsParams{
+		Limit:  1000,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, row := range rows {
+		if row.Keyword == keyword {
+			found = true
+			require.Equal(t, int64(2), row.RecipeCount)
+		}
+	}
+	require.True(t, found)
+
+	cuisines, err := testQueries.ListCuisines(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, cuisines)
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleTemplateTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	user := CreateRandomUser(t)
+	breakfast := CreateRandomRecipe(t)
+	lunch := CreateRandomRecipe(t)
+	// the week of Monday 2026-10-19
+	weekOf := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+
+	template, err := storage.NewScheduleTemplateTx(context.Background(), NewScheduleTemplateParams{
+		Author:   user.ID,
+		Name:     "weekday breakfast",
+		Rule:     "freq=weekly;byday=fr,mo,tu,we,th",
+		StartsOn: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Recipes: []TemplateRecipePortion{
+			{RecipeID: breakfast.ID, Portion: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", template.Template.Rrule)
+	require.Len(t, template.Recipes, 1)
+
+	templates, err := storage.ListScheduleTemplatesTx(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, template, templates[0])
+
+	// the ad-hoc breakfast on Monday adds up with the template's
+	result, err := storage.GenerateWeekTx(context.Background(), GenerateWeekParams{
+		Author: user.ID,
+		WeekOf: weekOf,
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: breakfast.ID, Portion: 2, ScheduledDate: sql.NullTime{Time: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Valid: true}},
+			{RecipeID: lunch.ID, Portion: 1, ScheduledDate: sql.NullTime{Time: time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC), Valid: true}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Recipes, 6)
+	require.Equal(t, breakfast.ID, result.Recipes[0].RecipeID)
+	require.Equal(t, int32(3), result.Recipes[0].Portion)
+	require.Equal(t, lunch.ID, result.Recipes[5].RecipeID)
+
+	_, err = storage.GenerateWeekTx(context.Background(), GenerateWeekParams{
+		Author:      user.ID,
+		WeekOf:      weekOf,
+		TemplateIDs: []int64{template.Template.ID + 1000000},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = testQueries.DeleteScheduleTemplate(context.Background(), template.Template.ID)
+	require.NoError(t, err)
+	_, err = storage.GenerateWeekTx(context.Background(), GenerateWeekParams{
+		Author: user.ID,
+		WeekOf: weekOf,
+	})
+	require.ErrorIs(t, err, ErrEmptyWeek)
+}
+
+func TestMaterializeTemplates(t *testing.T) {
+	templates := []ScheduleTemplateResult{
+		{
+			Template: ScheduleTemplate{
+				ID:       1,
+				Rrule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU",
+				StartsOn: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+			},
+			Recipes: []ListScheduleTemplateRecipesRow{
+				{TemplateID: 1, RecipeID: 10, Portion: 4},
+				{TemplateID: 1, RecipeID: 11, Portion: 1},
+			},
+		},
+		{
+			Template: ScheduleTemplate{
+				ID:       2,
+				Rrule:    "FREQ=DAILY;COUNT=2",
+				StartsOn: time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+			},
+			Recipes: []ListScheduleTemplateRecipesRow{
+				{TemplateID: 2, RecipeID: 12, Portion: 2},
+			},
+		},
+	}
+
+	// the Sunday rule skips the week of 2026-10-12
+	planned, err := materializeTemplates(templates, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Empty(t, planned)
+
+	planned, err = materializeTemplates(templates, time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, planned, 4)
+	require.Equal(t, int64(10), planned[0].RecipeID)
+	require.Equal(t, time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), planned[0].ScheduledDate.Time)
+	require.Equal(t, int64(12), planned[2].RecipeID)
+	require.Equal(t, time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC), planned[2].ScheduledDate.Time)
+	require.Equal(t, time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), planned[3].ScheduledDate.Time)
+
+	templates[0].Template.Rrule = "FREQ=YEARLY"
+	_, err = materializeTemplates(templates, time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+}
+
+func TestMergeScheduleRecipes(t *testing.T) {
+	monday := sql.NullTime{Time: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Valid: true}
+	tuesday := sql.NullTime{Time: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	merged := mergeScheduleRecipes(
+		[]ScheduleRecipePortion{
+			{RecipeID: 1, Portion: 1, ScheduledDate: monday},
+			{RecipeID: 2, Portion: 1},
+		},
+		[]ScheduleRecipePortion{
+			{RecipeID: 1, Portion: 2, ScheduledDate: monday},
+			{RecipeID: 1, Portion: 1, ScheduledDate: tuesday},
+			{RecipeID: 2, Portion: 3},
+		},
+	)
+	require.Equal(t, []ScheduleRecipePortion{
+		{RecipeID: 1, Portion: 3, ScheduledDate: monday},
+		{RecipeID: 2, Portion: 4},
+		{RecipeID: 1, Portion: 1, ScheduledDate: tuesday},
+	}, merged)
+}
+
+func TestSelectTemplates(t *testing.T) {
+	templates := []ScheduleTemplate{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	selected, err := selectTemplates(templates, nil)
+	require.NoError(t, err)
+	require.Equal(t, templates, selected)
+
+	selected, err = selectTemplates(templates, []int64{3, 1, 3})
+	require.NoError(t, err)
+	require.Equal(t, []ScheduleTemplate{{ID: 3}, {ID: 1}}, selected)
+
+	_, err = selectTemplates(templates, []int64{4})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
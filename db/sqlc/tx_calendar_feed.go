@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/hasnaroihan/grocery-planner/recur"
+)
+
+type CalendarFeedParams struct {
+	Token uuid.UUID `json:"token"`
+	// First day of the feed, older entries are left out
+	Since time.Time `json:"since"`
+}
+
+type CalendarIngredient struct {
+	Name     string           `json:"name"`
+	Quantity measure.Quantity `json:"quantity"`
+}
+
+// A dated recipe of one of the user's schedules with its ingredients for the
+// scheduled portions. Pinned recipes use the ingredients of their revision
+type CalendarEntry struct {
+	ListCalendarEntriesRow
+	Ingredients []CalendarIngredient `json:"ingredients"`
+}
+
+// Dated schedule entries of the user the feed token belongs to, an unknown token
+// is not found
+func (s *SQLStorage) GetCalendarFeedTx(ctx context.Context, arg CalendarFeedParams) ([]CalendarEntry, error) {
+	var result []CalendarEntry
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		feed, err := q.GetCalendarFeedByToken(ctx, arg.Token)
+		if err != nil {
+			return err
+		}
+
+		author := uuid.NullUUID{
+			UUID:  feed.UserID,
+			Valid: true,
+		}
+		entries, err := q.ListCalendarEntries(ctx, ListCalendarEntriesParams{
+			Author: author,
+			Since:  arg.Since,
+		})
+		if err != nil {
+			return err
+		}
+
+		ingredients, err := q.ListCalendarEntryIngredients(ctx, ListCalendarEntryIngredientsParams{
+			Author: author,
+			Since:  arg.Since,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = calendarEntries(entries, ingredients)
+		return nil
+	})
+
+	return result, err
+}
+
+func calendarEntries(entries []ListCalendarEntriesRow, ingredients []ListCalendarEntryIngredientsRow) []CalendarEntry {
+	type key struct {
+		scheduleID int64
+		recipeID   int64
+		date       time.Time
+	}
+
+	byEntry := map[key][]CalendarIngredient{}
+	for _, row := range ingredients {
+		k := key{row.ScheduleID, row.RecipeID, recur.Date(row.ScheduledDate.Time)}
+		byEntry[k] = append(byEntry[k], CalendarIngredient{
+			Name:     row.Name,
+			Quantity: measure.Round(scaledQuantity(row.Amount, row.UnitName, row.RecipePortion, row.SchedulePortion)),
+		})
+	}
+
+	result := make([]CalendarEntry, len(entries))
+	for i, entry := range entries {
+		k := key{entry.ScheduleID, entry.RecipeID, recur.Date(entry.ScheduledDate.Time)}
+		result[i] = CalendarEntry{
+			ListCalendarEntriesRow: entry,
+			Ingredients:            byEntry[k],
+		}
+		if result[i].Ingredients == nil {
+			result[i].Ingredients = []CalendarIngredient{}
+		}
+	}
+
+	return result
+}
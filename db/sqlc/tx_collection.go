@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidCollectionOrder = errors.New("collection order has to contain every recipe of the collection exactly once")
+
+type RemoveCollectionRecipeParams struct {
+	CollectionID int64 `json:"collectionID"`
+	RecipeID     int64 `json:"recipeID"`
+}
+
+type ReorderCollectionParams struct {
+	CollectionID int64 `json:"collectionID"`
+	// Recipe ids in their new order
+	RecipeIDs []int64 `json:"recipeIDs"`
+}
+
+// Remove a recipe from a collection, following recipes are moved one position up
+func (s *SQLStorage) RemoveCollectionRecipeTx(ctx context.Context, arg RemoveCollectionRecipeParams) ([]ListCollectionRecipesRow, error) {
+	var result []ListCollectionRecipesRow
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		removed, err := q.DeleteCollectionRecipe(ctx, DeleteCollectionRecipeParams(arg))
+		if err != nil {
+			return err
+		}
+
+		err = q.ShiftCollectionRecipes(
+			ctx,
+			ShiftCollectionRecipesParams{
+				CollectionID: removed.CollectionID,
+				Position:     removed.Position,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.ListCollectionRecipes(ctx, arg.CollectionID)
+
+		return err
+	})
+
+	return result, err
+}
+
+// Reorder every recipe of a collection, positions are only checked for uniqueness on commit
+func (s *SQLStorage) ReorderCollectionTx(ctx context.Context, arg ReorderCollectionParams) ([]ListCollectionRecipesRow, error) {
+	var result []ListCollectionRecipesRow
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		recipes, err := q.ListCollectionRecipes(ctx, arg.CollectionID)
+		if err != nil {
+			return err
+		}
+		if len(recipes) != len(arg.RecipeIDs) {
+			return ErrInvalidCollectionOrder
+		}
+
+		remaining := make(map[int64]bool, len(recipes))
+		for _, recipe := range recipes {
+			remaining[recipe.RecipeID] = true
+		}
+
+		for i, id := range arg.RecipeIDs {
+			if !remaining[id] {
+				return ErrInvalidCollectionOrder
+			}
+			delete(remaining, id)
+
+			err = q.UpdateCollectionRecipePosition(
+				ctx,
+				UpdateCollectionRecipePositionParams{
+					CollectionID: arg.CollectionID,
+					RecipeID:     id,
+					Position:     int32(i + 1),
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = q.ListCollectionRecipes(ctx, arg.CollectionID)
+
+		return err
+	})
+
+	return result, err
+}
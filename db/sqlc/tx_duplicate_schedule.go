@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+type DuplicateScheduleParams struct {
+	ScheduleID int64     `json:"scheduleID"`
+	Author     uuid.UUID `json:"author"`
+	// Days added to every scheduled date, 7 repeats a plan one week later
+	ShiftDays int `json:"shiftDays"`
+	// Portions are multiplied and rounded, every recipe keeps at least one portion.
+	// Zero keeps the portions
+	Multiplier float64 `json:"multiplier"`
+	UnitSystem string  `json:"unitSystem"`
+	Store      string  `json:"store"`
+}
+
+// Copy the recipes of a schedule into a new schedule of the author and generate its
+// groceries. Pinned revisions stay pinned, so the copy buys what the original did
+func (s *SQLStorage) DuplicateScheduleTx(ctx context.Context, arg DuplicateScheduleParams) (GenerateGroceriesResult, error) {
+	var result GenerateGroceriesResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		rows, err := q.GetScheduleRecipe(ctx, arg.ScheduleID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.generateGroceries(ctx, GenerateGroceriesParam{
+			Author: uuid.NullUUID{
+				UUID:  arg.Author,
+				Valid: true,
+			},
+			Recipes:    duplicateScheduleRecipes(rows, arg.ShiftDays, arg.Multiplier),
+			UnitSystem: arg.UnitSystem,
+			Store:      arg.Store,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func duplicateScheduleRecipes(rows []GetScheduleRecipeRow, shiftDays int, multiplier float64) []ScheduleRecipePortion {
+	result := make([]ScheduleRecipePortion, len(rows))
+	for i, row := range rows {
+		recipe := ScheduleRecipePortion{
+			RecipeID:      row.RecipeID,
+			Portion:       row.Portion,
+			ScheduledDate: row.ScheduledDate,
+			RevisionID:    row.RevisionID,
+		}
+		if recipe.ScheduledDate.Valid {
+			recipe.ScheduledDate.Time = recipe.ScheduledDate.Time.AddDate(0, 0, shiftDays)
+		}
+		if multiplier > 0 {
+			recipe.Portion = int32(math.Max(1, math.Round(float64(row.Portion)*multiplier)))
+		}
+		result[i] = recipe
+	}
+
+	return result
+}
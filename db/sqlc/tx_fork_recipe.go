@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Parent and direct forks of a recipe
+type RecipeForks struct {
+	Parent   *ListRecipeForksRow  `json:"parent,omitempty"`
+	Children []ListRecipeForksRow `json:"children"`
+}
+
+// Copy a recipe with its ingredients and steps into a new recipe owned by arg.Author,
+// the copy keeps a reference to the recipe it was forked from
+func (s *SQLStorage) ForkRecipeTx(ctx context.Context, arg ForkRecipeParams) (RecipeResult, error) {
+	var result RecipeResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Recipe, err = q.ForkRecipe(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		err = q.CopyRecipeIngredients(
+			ctx,
+			CopyRecipeIngredientsParams{
+				RecipeID: result.Recipe.ID,
+				SourceID: arg.ID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = q.CopyRecipeKeywords(
+			ctx,
+			CopyRecipeKeywordsParams{
+				RecipeID: result.Recipe.ID,
+				SourceID: arg.ID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		steps, err := recipeStepList(ctx, q, arg.ID)
+		if err != nil {
+			return err
+		}
+		for _, item := range steps {
+			step, err := q.CreateRecipeStep(
+				ctx,
+				CreateRecipeStepParams{
+					RecipeID:        result.Recipe.ID,
+					Position:        item.Position,
+					Text:            item.Text,
+					DurationSeconds: item.DurationSeconds,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			err = createStepIngredients(ctx, q, step, item.Ingredients)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Ingredients, err = q.GetRecipeIngredients(ctx, result.Recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Steps, err = recipeStepList(ctx, q, result.Recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Keywords, err = q.ListRecipeKeywords(ctx, result.Recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Forks, err = recipeForks(ctx, q, result.Recipe)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, result.Recipe.ID, arg.Author)
+
+		return err
+	})
+
+	return result, err
+}
+
+func recipeForks(ctx context.Context, q *Queries, recipe Recipe) (*RecipeForks, error) {
+	var forks RecipeForks
+	var err error
+
+	if recipe.ForkedFrom.Valid {
+		parent, err := q.GetRecipe(ctx, recipe.ForkedFrom.Int64)
+		if err != nil {
+			return nil, err
+		}
+		forks.Parent = &ListRecipeForksRow{
+			ID:         parent.ID,
+			Name:       parent.Name,
+			Author:     parent.Author,
+			ModifiedAt: parent.ModifiedAt,
+		}
+	}
+
+	forks.Children, err = q.ListRecipeForks(
+		ctx,
+		sql.NullInt64{
+			Int64: recipe.ID,
+			Valid: true,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forks, nil
+}
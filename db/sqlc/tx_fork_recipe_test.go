@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkRecipeTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, _ := newRevisionRecipe(t, storage)
+	user := CreateRandomUser(t)
+
+	_, err := storage.InsertRecipeStepTx(context.Background(), InsertRecipeStepParams{
+		RecipeID: recipe.Recipe.ID,
+		Step: StepParam{
+			Text:        "mix everything",
+			Ingredients: []int32{recipe.Ingredients[0].IngredientID},
+		},
+	})
+	require.NoError(t, err)
+
+	fork, err := storage.ForkRecipeTx(context.Background(), ForkRecipeParams{
+		Author: user.ID,
+		ID:     recipe.Recipe.ID,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, recipe.Recipe.ID, fork.Recipe.ID)
+	require.Equal(t, user.ID, fork.Recipe.Author)
+	require.Equal(t, recipe.Recipe.Name, fork.Recipe.Name)
+	require.Equal(t, recipe.Recipe.Portion, fork.Recipe.Portion)
+	require.Equal(t, sql.NullInt64{Int64: recipe.Recipe.ID, Valid: true}, fork.Recipe.ForkedFrom)
+	require.Len(t, fork.Ingredients, len(recipe.Ingredients))
+	for i := range fork.Ingredients {
+		require.Equal(t, fork.Recipe.ID, fork.Ingredients[i].RecipeID)
+	}
+	require.Len(t, fork.Steps, 1)
+	require.Equal(t, []int32{recipe.Ingredients[0].IngredientID}, fork.Steps[0].Ingredients)
+	require.NotNil(t, fork.Forks.Parent)
+	require.Equal(t, recipe.Recipe.ID, fork.Forks.Parent.ID)
+	require.Empty(t, fork.Forks.Children)
+
+	latest, err := storage.GetLatestRecipeRevision(context.Background(), fork.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), latest.Revision)
+
+	// The parent lists its forks
+	parent, err := storage.GetRecipeTx(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Nil(t, parent.Forks.Parent)
+	require.Len(t, parent.Forks.Children, 1)
+	require.Equal(t, fork.Recipe.ID, parent.Forks.Children[0].ID)
+
+	// Forks outlive their parent
+	err = storage.DeleteRecipe(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	orphan, err := storage.GetRecipeTx(context.Background(), fork.Recipe.ID)
+	require.NoError(t, err)
+	require.False(t, orphan.Recipe.ForkedFrom.Valid)
+	require.Nil(t, orphan.Forks.Parent)
+
+	_, err = storage.ForkRecipeTx(context.Background(), ForkRecipeParams{
+		Author: user.ID,
+		ID:     recipe.Recipe.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
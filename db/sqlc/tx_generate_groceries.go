@@ -2,24 +2,44 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type ScheduleRecipePortion struct {
-	RecipeID int64 `json:"recipe_id"`
-	Portion  int32 `json:"portion"`
+	RecipeID      int64        `json:"recipe_id"`
+	Portion       int32        `json:"portion"`
+	ScheduledDate sql.NullTime `json:"scheduled_date"`
+	// Revision the recipe is already pinned to, only set when copying a schedule
+	RevisionID sql.NullInt64 `json:"-"`
 }
 
 type GenerateGroceriesParam struct {
 	Author  uuid.NullUUID           `json:"author"`
 	Recipes []ScheduleRecipePortion `json:"recipes"`
+	// RestrictionsExclude or RestrictionsWarn to check the recipes against
+	// the dietary restrictions of RestrictionsUser, empty to skip the check
+	Restrictions string `json:"restrictions"`
+	// Authenticated user whose restrictions are checked, never taken from
+	// the request body
+	RestrictionsUser uuid.UUID `json:"-"`
+	// Unit system of the grocery amounts, empty keeps the recipe units' systems
+	UnitSystem string `json:"unitSystem"`
+	// Plan with the current revision of every recipe, later edits do not
+	// change the schedule's groceries
+	PinRevisions bool `json:"pinRevisions"`
+	// Store to buy from, its package sizes and latest prices are preferred
+	// over the ones from anywhere
+	Store string `json:"store"`
 }
 
 type GenerateGroceriesResult struct {
-	Schedule  Schedule               `json:"schedule"`
-	Recipes   []GetScheduleRecipeRow `json:"recipes"`
-	Groceries []ListGroceriesRow     `json:"groceries"`
+	Schedule  Schedule                 `json:"schedule"`
+	Recipes   []GetScheduleRecipeRow   `json:"recipes"`
+	Groceries []GroceryItem            `json:"groceries"`
+	Cost      GroceryCost              `json:"cost"`
+	Conflicts []ListRecipeConflictsRow `json:"conflicts,omitempty"`
 }
 
 // Create schedule, create schedule recipes, return list of groceries
@@ -28,37 +48,102 @@ func (s *SQLStorage) GenerateGroceries(ctx context.Context, arg GenerateGrocerie
 
 	err := s.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = q.generateGroceries(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+func (q *Queries) generateGroceries(ctx context.Context, arg GenerateGroceriesParam) (GenerateGroceriesResult, error) {
+	var result GenerateGroceriesResult
+	var err error
 
-		result.Schedule, err = q.CreateSchedule(ctx, arg.Author)
+	result.Schedule, err = q.CreateSchedule(ctx, arg.Author)
+	if err != nil {
+		return result, err
+	}
+
+	excluded := map[int64]bool{}
+	if arg.Restrictions != "" {
+		recipeIDs := make([]int64, 0, len(arg.Recipes))
+		for _, recipe := range arg.Recipes {
+			recipeIDs = append(recipeIDs, recipe.RecipeID)
+		}
+
+		result.Conflicts, err = q.ListRecipeConflicts(
+			ctx,
+			ListRecipeConflictsParams{
+				UserID:    arg.RestrictionsUser,
+				RecipeIds: recipeIDs,
+			},
+		)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		for _, recipe := range arg.Recipes {
-			_, err = q.CreateScheduleRecipe(
-				ctx,
-				CreateScheduleRecipeParams{
-					ScheduleID: result.Schedule.ID,
-					RecipeID:   recipe.RecipeID,
-					Portion:    recipe.Portion,
-				},
-			)
-			if err != nil {
-				return err
+		if arg.Restrictions == RestrictionsExclude {
+			for _, conflict := range result.Conflicts {
+				excluded[conflict.RecipeID] = true
 			}
 		}
-		result.Recipes, err = q.GetScheduleRecipe(ctx, result.Schedule.ID)
-		if err != nil {
-			return err
+	}
+
+	for _, recipe := range arg.Recipes {
+		if excluded[recipe.RecipeID] {
+			continue
+		}
+
+		revisionID := recipe.RevisionID
+		if !revisionID.Valid && arg.PinRevisions {
+			revision, err := q.GetLatestRecipeRevision(ctx, recipe.RecipeID)
+			if err != nil && err != sql.ErrNoRows {
+				return result, err
+			}
+			revisionID = sql.NullInt64{
+				Int64: revision.ID,
+				Valid: err == nil,
+			}
 		}
 
-		result.Groceries, err = q.ListGroceries(ctx, result.Schedule.ID)
+		_, err = q.CreateScheduleRecipe(
+			ctx,
+			CreateScheduleRecipeParams{
+				ScheduleID:    result.Schedule.ID,
+				RecipeID:      recipe.RecipeID,
+				Portion:       recipe.Portion,
+				ScheduledDate: recipe.ScheduledDate,
+				RevisionID:    revisionID,
+			},
+		)
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
+	result.Recipes, err = q.GetScheduleRecipe(ctx, result.Schedule.ID)
+	if err != nil {
+		return result, err
+	}
 
-		return nil
+	groceryRows, err := q.ListGroceryAmounts(ctx, ListGroceryAmountsParams{
+		ScheduleIds: []int64{result.Schedule.ID},
+		Store:       arg.Store,
 	})
+	if err != nil {
+		return result, err
+	}
+	result.Groceries = aggregateGroceries(groceryRows, arg.UnitSystem)
+	result.Cost = estimateGroceryCost(groceryRows, result.Groceries)
 
-	return result, err
-}
\ No newline at end of file
+	_, err = q.purchaseGroceries(ctx, groceryRows, result.Groceries, arg.Store)
+	if err != nil {
+		return result, err
+	}
+
+	err = q.categorizeGroceries(ctx, result.Groceries)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -68,4 +68,44 @@ func TestGenerateGroceries(t *testing.T) {
 		idx := slices.IndexFunc(recipeIngredients, func(i GetRecipeIngredientsRow) bool { return int32(i.RecipeID) == row.ID })
 		require.NotNil(t, idx)
 	}
-}
\ No newline at end of file
+}
+func TestGenerateGroceriesRestrictions(t *testing.T) {
+	storage := NewStorage(testDB)
+	author := CreateRandomUser(t)
+	allergen := CreateRandomDietaryTag(t, TagKindAllergen)
+
+	conflicting, ingredients := CreateRandomRecipeIngredient(t)
+	CreateRandomIngredientTag(t, ingredients[0].IngredientID, allergen)
+	allowed, _ := CreateRandomRecipeIngredient(t)
+
+	_, err := storage.SetUserRestrictionsTx(context.Background(), SetUserRestrictionsParams{
+		UserID: author.ID,
+		Tags:   []string{allergen.Name},
+	})
+	require.NoError(t, err)
+
+	arg := GenerateGroceriesParam{
+		Author: uuid.NullUUID{
+			UUID:  author.ID,
+			Valid: true,
+		},
+		Recipes: []ScheduleRecipePortion{
+			{RecipeID: conflicting.ID, Portion: 1},
+			{RecipeID: allowed.ID, Portion: 1},
+		},
+		Restrictions:     RestrictionsWarn,
+		RestrictionsUser: author.ID,
+	}
+
+	result, err := storage.GenerateGroceries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Recipes, 2)
+	require.Equal(t, []ListRecipeConflictsRow{{RecipeID: conflicting.ID, Tag: allergen.Name}}, result.Conflicts)
+
+	arg.Restrictions = RestrictionsExclude
+	result, err = storage.GenerateGroceries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Recipes, 1)
+	require.Equal(t, allowed.ID, result.Recipes[0].RecipeID)
+	require.Len(t, result.Conflicts, 1)
+}
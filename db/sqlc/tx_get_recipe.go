@@ -18,6 +18,54 @@ func (s *SQLStorage) GetRecipeTx(ctx context.Context, id int64) (RecipeResult, e
 			return err
 		}
 
+		nutritionRows, err := q.ListRecipeNutrition(ctx, id)
+		if err != nil {
+			return err
+		}
+		nutrition := computeRecipeNutrition(nutritionRows, result.Recipe.Portion)
+		result.Nutrition = &nutrition
+
+		priceRows, err := q.ListRecipePrices(ctx, id)
+		if err != nil {
+			return err
+		}
+		cost := computeRecipeCost(priceRows, result.Recipe.Portion)
+		result.Cost = &cost
+
+		tagRows, err := q.ListRecipeTags(ctx, id)
+		if err != nil {
+			return err
+		}
+		tags := recipeTags(tagRows)
+		result.Tags = &tags
+
+		result.Steps, err = recipeStepList(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		result.Keywords, err = q.ListRecipeKeywords(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		imageRows, err := q.ListRecipeImages(ctx, id)
+		if err != nil {
+			return err
+		}
+		result.Images = recipeImages(imageRows)
+
+		result.Forks, err = recipeForks(ctx, q, result.Recipe)
+		if err != nil {
+			return err
+		}
+
+		stats, err := q.GetRecipeStats(ctx, id)
+		if err != nil {
+			return err
+		}
+		result.Stats = &stats
+
 		return nil
 	})
 
@@ -35,6 +35,10 @@ func TestGetRecipeTx(t *testing.T) {
 	require.Equal(t, recipeNew.Portion, result.Recipe.Portion)
 	require.Equal(t, recipeNew.Steps, result.Recipe.Steps)
 	require.WithinDuration(t, recipeNew.CreatedAt, result.Recipe.CreatedAt, time.Second)
+	require.NotNil(t, result.Nutrition)
+	require.Len(t, result.Nutrition.Missing, 1)
+	require.NotNil(t, result.Tags)
+	require.Empty(t, result.Tags.Allergens)
 
 	for _, row := range recipeIngredientsNew {
 		require.Equal(t, recipeIngredientsNew[0].IngredientID, row.IngredientID)
@@ -0,0 +1,26 @@
+package db
+
+import "context"
+
+// Sum the nutrition of every scheduled recipe per scheduled day
+func (s *SQLStorage) GetScheduleNutritionTx(ctx context.Context, scheduleID int64) (ScheduleNutritionResult, error) {
+	var result ScheduleNutritionResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetSchedule(ctx, scheduleID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.ListScheduleNutrition(ctx, scheduleID)
+		if err != nil {
+			return err
+		}
+
+		result = computeScheduleNutrition(scheduleID, rows)
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetScheduleNutritionTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, recipeIngredients := CreateRandomRecipeIngredient(t)
+	ingredient, err := testQueries.GetIngredient(context.Background(), recipeIngredients[0].IngredientID)
+	require.NoError(t, err)
+	CreateRandomNutrition(t, ingredient)
+
+	schedule := createRandomSchedule(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := 0; i < 2; i++ {
+		_, err := testQueries.CreateScheduleRecipe(
+			context.Background(),
+			CreateScheduleRecipeParams{
+				ScheduleID: schedule.ID,
+				RecipeID:   recipe.ID,
+				Portion:    recipe.Portion,
+				ScheduledDate: sql.NullTime{
+					Time:  today.AddDate(0, 0, i),
+					Valid: true,
+				},
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	result, err := storage.GetScheduleNutritionTx(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	require.Equal(t, schedule.ID, result.ScheduleID)
+	require.Len(t, result.Days, 2)
+	require.Empty(t, result.Missing)
+	require.Equal(t, result.Days[0].Total, result.Days[1].Total)
+	require.True(t, result.Days[0].Date.Time.Before(result.Days[1].Date.Time))
+
+	// Unknown schedule
+	_, err = storage.GetScheduleNutritionTx(context.Background(), -1)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
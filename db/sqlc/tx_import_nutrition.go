@@ -0,0 +1,23 @@
+package db
+
+import "context"
+
+// Upsert nutrition facts of many ingredients, either all of them are saved or none
+func (s *SQLStorage) ImportNutritionTx(ctx context.Context, arg []UpsertNutritionParams) ([]Nutrition, error) {
+	result := []Nutrition{}
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		for _, item := range arg {
+			nutrition, err := q.UpsertNutrition(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, nutrition)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
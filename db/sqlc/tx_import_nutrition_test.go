@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportNutritionTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	arg := []UpsertNutritionParams{}
+	for i := 0; i < 3; i++ {
+		ingredient := CreateRandomIngredient(t)
+		arg = append(arg, UpsertNutritionParams{
+			IngredientID: ingredient.ID,
+			Calories:     float32(100 + i),
+			Protein:      1,
+			Fat:          2,
+			Carbs:        3,
+			Fiber:        4,
+			Sodium:       5,
+		})
+	}
+
+	result, err := storage.ImportNutritionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result, len(arg))
+	for i, nutrition := range result {
+		require.Equal(t, arg[i].IngredientID, nutrition.IngredientID)
+		require.Equal(t, arg[i].Calories, nutrition.Calories)
+	}
+
+	// A missing ingredient rolls back the whole import
+	arg[0].Calories = 999
+	arg = append(arg, UpsertNutritionParams{IngredientID: -1})
+	_, err = storage.ImportNutritionTx(context.Background(), arg)
+	require.Error(t, err)
+
+	nutrition, err := testQueries.GetNutrition(context.Background(), arg[0].IngredientID)
+	require.NoError(t, err)
+	require.Equal(t, float32(100), nutrition.Calories)
+}
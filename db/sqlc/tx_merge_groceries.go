@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+type MergeGroceriesParams struct {
+	ScheduleIDs []int64 `json:"scheduleIDs"`
+	UnitSystem  string  `json:"unitSystem"`
+	Store       string  `json:"store"`
+}
+
+// What one schedule needs of a merged grocery line
+type ScheduleGroceryShare struct {
+	ScheduleID int64              `json:"scheduleID"`
+	Quantities []measure.Quantity `json:"quantities"`
+}
+
+type MergedGroceryItem struct {
+	GroceryItem
+	Schedules []ScheduleGroceryShare `json:"schedules"`
+}
+
+type MergedGroceriesResult struct {
+	ScheduleIDs []int64             `json:"scheduleIDs"`
+	Groceries   []MergedGroceryItem `json:"groceries"`
+	Cost        GroceryCost         `json:"cost"`
+}
+
+// One grocery list for several schedules. Lines are summed over all schedules before
+// rounding, priced and packed once, and list the share of every schedule
+func (s *SQLStorage) MergeGroceriesTx(ctx context.Context, arg MergeGroceriesParams) (MergedGroceriesResult, error) {
+	result := MergedGroceriesResult{
+		ScheduleIDs: arg.ScheduleIDs,
+	}
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		rows, err := q.ListGroceryAmounts(ctx, ListGroceryAmountsParams{
+			ScheduleIds: arg.ScheduleIDs,
+			Store:       arg.Store,
+		})
+		if err != nil {
+			return err
+		}
+
+		groceries := aggregateGroceries(rows, arg.UnitSystem)
+		result.Cost = estimateGroceryCost(rows, groceries)
+
+		_, err = q.purchaseGroceries(ctx, rows, groceries, arg.Store)
+		if err != nil {
+			return err
+		}
+
+		err = q.categorizeGroceries(ctx, groceries)
+		if err != nil {
+			return err
+		}
+
+		result.Groceries = mergeGroceryShares(groceries, arg.ScheduleIDs, groceryAmountsBySchedule(rows), arg.UnitSystem)
+		return nil
+	})
+
+	return result, err
+}
+
+// Amount rows of every schedule, in ingredient order
+func groceryAmountsBySchedule(rows []ListGroceryAmountsRow) map[int64][]ListGroceryAmountsRow {
+	bySchedule := map[int64][]ListGroceryAmountsRow{}
+	for _, row := range rows {
+		bySchedule[row.ScheduleID] = append(bySchedule[row.ScheduleID], row)
+	}
+
+	return bySchedule
+}
+
+// Attach the per schedule quantities to the merged lines, schedules follow the
+// requested order and only the ones needing the ingredient are listed
+func mergeGroceryShares(groceries []GroceryItem, scheduleIDs []int64, bySchedule map[int64][]ListGroceryAmountsRow, system string) []MergedGroceryItem {
+	shares := map[int32][]ScheduleGroceryShare{}
+	for _, id := range scheduleIDs {
+		for _, item := range aggregateGroceries(bySchedule[id], system) {
+			shares[item.ID] = append(shares[item.ID], ScheduleGroceryShare{
+				ScheduleID: id,
+				Quantities: item.Quantities,
+			})
+		}
+	}
+
+	result := make([]MergedGroceryItem, len(groceries))
+	for i, item := range groceries {
+		result[i] = MergedGroceryItem{
+			GroceryItem: item,
+			Schedules:   shares[item.ID],
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeGroceriesTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	user := CreateRandomUser(t)
+	recipe, _ := CreateRandomRecipeIngredient(t)
+
+	var schedules []GenerateGroceriesResult
+	for portion := int32(1); portion <= 2; portion++ {
+		schedule, err := storage.GenerateGroceries(context.Background(), GenerateGroceriesParam{
+			Author: uuid.NullUUID{UUID: user.ID, Valid: true},
+			Recipes: []ScheduleRecipePortion{
+				{RecipeID: recipe.ID, Portion: portion * recipe.Portion},
+			},
+		})
+		require.NoError(t, err)
+		schedules = append(schedules, schedule)
+	}
+
+	result, err := storage.MergeGroceriesTx(context.Background(), MergeGroceriesParams{
+		ScheduleIDs: []int64{schedules[0].Schedule.ID, schedules[1].Schedule.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Groceries, len(schedules[0].Groceries))
+
+	for _, item := range result.Groceries {
+		require.Len(t, item.Schedules, 2)
+		require.Equal(t, schedules[0].Schedule.ID, item.Schedules[0].ScheduleID)
+		require.Equal(t, schedules[1].Schedule.ID, item.Schedules[1].ScheduleID)
+	}
+}
+
+func TestMergeGroceryShares(t *testing.T) {
+	rows := []ListGroceryAmountsRow{
+		{ScheduleID: 1, ID: 10, Name: "flour", Amount: 100, UnitName: sql.NullString{String: "gram", Valid: true}, RecipePortion: 1, SchedulePortion: 1},
+		{ScheduleID: 2, ID: 10, Name: "flour", Amount: 100, UnitName: sql.NullString{String: "gram", Valid: true}, RecipePortion: 1, SchedulePortion: 2},
+		{ScheduleID: 2, ID: 11, Name: "salt", Amount: 1, UnitName: sql.NullString{String: "teaspoon", Valid: true}, RecipePortion: 1, SchedulePortion: 1},
+	}
+
+	bySchedule := groceryAmountsBySchedule(rows)
+	require.Len(t, bySchedule[1], 1)
+	require.Len(t, bySchedule[2], 2)
+
+	groceries := aggregateGroceries(rows, "")
+	merged := mergeGroceryShares(groceries, []int64{2, 1}, bySchedule, "")
+	require.Len(t, merged, 2)
+
+	require.Equal(t, "flour", merged[0].Name)
+	require.Equal(t, []measure.Quantity{{Amount: 300, Unit: "g"}}, merged[0].Quantities)
+	require.Equal(t, []ScheduleGroceryShare{
+		{ScheduleID: 2, Quantities: []measure.Quantity{{Amount: 200, Unit: "g"}}},
+		{ScheduleID: 1, Quantities: []measure.Quantity{{Amount: 100, Unit: "g"}}},
+	}, merged[0].Schedules)
+
+	require.Equal(t, "salt", merged[1].Name)
+	require.Len(t, merged[1].Schedules, 1)
+	require.Equal(t, int64(2), merged[1].Schedules[0].ScheduleID)
+}
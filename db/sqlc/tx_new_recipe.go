@@ -19,6 +19,11 @@ type NewRecipeParams struct {
 	Portion         int32                 `json:"portion"`
 	Steps           sql.NullString        `json:"steps"`
 	ListIngredients []ListIngredientParam `json:"ingredients"`
+	// Structured steps in order, step ingredients refer to existing ingredient ids.
+	// When given, Steps is replaced by their texts one per line
+	StepList []StepParam    `json:"stepList"`
+	Taxonomy RecipeTaxonomy `json:"taxonomy"`
+	Keywords []string       `json:"keywords"`
 }
 
 type IngredientResult struct {
@@ -30,6 +35,14 @@ type IngredientResult struct {
 type RecipeResult struct {
 	Recipe      Recipe                    `json:"recipe"`
 	Ingredients []GetRecipeIngredientsRow `json:"ingredients"`
+	Nutrition   *RecipeNutrition          `json:"nutrition,omitempty"`
+	Cost        *RecipeCost               `json:"cost,omitempty"`
+	Tags        *RecipeTags               `json:"tags,omitempty"`
+	Steps       []RecipeStep              `json:"stepList,omitempty"`
+	Forks       *RecipeForks              `json:"forks,omitempty"`
+	Stats       *RecipesStat              `json:"stats,omitempty"`
+	Keywords    []string                  `json:"keywords,omitempty"`
+	Images      []RecipeImage             `json:"images,omitempty"`
 }
 
 // Create recipe, create new ingredients, create recipe-ingredients
@@ -44,8 +57,12 @@ func (s *SQLStorage) NewRecipeTx(ctx context.Context, arg NewRecipeParams) (Reci
 			CreateRecipeParams{
 				Name:    arg.Name,
 				Author:  arg.Author,
-				Portion: arg.Portion,
-				Steps:   arg.Steps,
+				Portion:    arg.Portion,
+				Steps:      arg.Steps,
+				Cuisine:    arg.Taxonomy.Cuisine,
+				Course:     arg.Taxonomy.Course,
+				Difficulty: arg.Taxonomy.Difficulty,
+				TotalTime:  arg.Taxonomy.TotalTime,
 			},
 		)
 		if err != nil {
@@ -95,11 +112,51 @@ func (s *SQLStorage) NewRecipeTx(ctx context.Context, arg NewRecipeParams) (Reci
 			}			
 		}
 
+		for i, item := range arg.StepList {
+			step, err := q.CreateRecipeStep(
+				ctx,
+				CreateRecipeStepParams{
+					RecipeID:        result.Recipe.ID,
+					Position:        int32(i + 1),
+					Text:            item.Text,
+					DurationSeconds: item.DurationSeconds,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			err = createStepIngredients(ctx, q, step, item.Ingredients)
+			if err != nil {
+				return err
+			}
+		}
+
 		result.Ingredients, err = q.GetRecipeIngredients(ctx, result.Recipe.ID)
 		if err != nil {
 			return err
 		}
 
+		result.Keywords, err = setRecipeKeywords(ctx, q, result.Recipe.ID, arg.Keywords)
+		if err != nil {
+			return err
+		}
+
+		result.Steps, err = recipeStepList(ctx, q, result.Recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Recipe, err = deriveStepsText(ctx, q, result.Recipe)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, result.Recipe.ID, arg.Author)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleLeftoversParams struct {
+	ScheduleID int64 `json:"scheduleID"`
+	// Owner of the pantry the leftovers are added to
+	UserID uuid.UUID `json:"userID"`
+	// Store the groceries are bought from, see GenerateGroceriesParam
+	Store string `json:"store"`
+	// Day the schedule is cooked, zero for its last scheduled day or today when
+	// no recipe is scheduled on a day
+	CookedOn time.Time `json:"cookedOn"`
+}
+
+type SuggestPantryRecipesParams struct {
+	UserID uuid.UUID `json:"userID"`
+	Limit  int       `json:"limit"`
+}
+
+// Expected leftovers of a schedule's groceries
+func (s *SQLStorage) ListScheduleLeftoversTx(ctx context.Context, arg ScheduleLeftoversParams) ([]ScheduleLeftover, error) {
+	var result []ScheduleLeftover
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.listScheduleLeftovers(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// Add the expected leftovers of a schedule to the user's pantry, a schedule's
+// leftovers can only be added once
+func (s *SQLStorage) AddScheduleLeftoversTx(ctx context.Context, arg ScheduleLeftoversParams) ([]PantryItem, error) {
+	result := []PantryItem{}
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		leftovers, err := q.listScheduleLeftovers(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		for _, leftover := range leftovers {
+			item, err := q.CreatePantryItem(ctx, CreatePantryItemParams{
+				UserID:       arg.UserID,
+				IngredientID: leftover.IngredientID,
+				Amount:       leftover.Amount,
+				UnitID:       leftover.UnitID,
+				ExpiresOn:    leftover.ExpiresOn,
+				ScheduleID: sql.NullInt64{
+					Int64: arg.ScheduleID,
+					Valid: true,
+				},
+			})
+			if err != nil {
+				return err
+			}
+			result = append(result, item)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Recipes that use up the pantry, see rankPantryRecipes
+func (s *SQLStorage) SuggestPantryRecipesTx(ctx context.Context, arg SuggestPantryRecipesParams) ([]PantrySuggestion, error) {
+	var result []PantrySuggestion
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		rows, err := q.ListPantryRecipes(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		result = rankPantryRecipes(rows, arg.Limit)
+		return nil
+	})
+
+	return result, err
+}
+
+func (q *Queries) listScheduleLeftovers(ctx context.Context, arg ScheduleLeftoversParams) ([]ScheduleLeftover, error) {
+	cookedOn := arg.CookedOn
+	if cookedOn.IsZero() {
+		cookedOn = time.Now().UTC().Truncate(24 * time.Hour)
+
+		recipes, err := q.GetScheduleRecipe(ctx, arg.ScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		var last time.Time
+		for _, recipe := range recipes {
+			if recipe.ScheduledDate.Valid && recipe.ScheduledDate.Time.After(last) {
+				last = recipe.ScheduledDate.Time
+			}
+		}
+		if !last.IsZero() {
+			cookedOn = last
+		}
+	}
+
+	rows, err := q.ListGroceryAmounts(ctx, ListGroceryAmountsParams{
+		ScheduleIds: []int64{arg.ScheduleID},
+		Store:       arg.Store,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := aggregateGroceries(rows, "")
+
+	packages, err := q.purchaseGroceries(ctx, rows, items, arg.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredientIDs := make([]int32, 0, len(items))
+	for _, item := range items {
+		ingredientIDs = append(ingredientIDs, item.ID)
+	}
+	shelfLives, err := q.ListIngredientShelfLives(ctx, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduleLeftovers(items, packages, shelfLives, cookedOn), nil
+}
@@ -0,0 +1,260 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type InsertRecipeStepParams struct {
+	RecipeID int64 `json:"recipeID"`
+	// Position of the new step starting from 1, zero or past the last step appends it
+	Position int32     `json:"position"`
+	Step     StepParam `json:"step"`
+	// User making the change, recorded in the new revision
+	Editor uuid.UUID `json:"editor"`
+}
+
+type UpdateRecipeStepTxParams struct {
+	ID     int64     `json:"id"`
+	Step   StepParam `json:"step"`
+	Editor uuid.UUID `json:"editor"`
+}
+
+type DeleteRecipeStepTxParams struct {
+	ID     int64     `json:"id"`
+	Editor uuid.UUID `json:"editor"`
+}
+
+type ReorderRecipeStepsParams struct {
+	RecipeID int64 `json:"recipeID"`
+	// Step ids in their new order
+	StepIDs []int64   `json:"stepIDs"`
+	Editor  uuid.UUID `json:"editor"`
+}
+
+// Insert a step at a position, following steps are moved one position down.
+// Every step change rewrites the text steps of the recipe and stores a new revision
+func (s *SQLStorage) InsertRecipeStepTx(ctx context.Context, arg InsertRecipeStepParams) ([]RecipeStep, error) {
+	var result []RecipeStep
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		count, err := q.CountRecipeSteps(ctx, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		position := arg.Position
+		if position <= 0 || int64(position) > count {
+			position = int32(count) + 1
+		} else {
+			err = q.ShiftRecipeSteps(
+				ctx,
+				ShiftRecipeStepsParams{
+					Shift:        1,
+					RecipeID:     arg.RecipeID,
+					FromPosition: position,
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		step, err := q.CreateRecipeStep(
+			ctx,
+			CreateRecipeStepParams{
+				RecipeID:        arg.RecipeID,
+				Position:        position,
+				Text:            arg.Step.Text,
+				DurationSeconds: arg.Step.DurationSeconds,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = createStepIngredients(ctx, q, step, arg.Step.Ingredients)
+		if err != nil {
+			return err
+		}
+
+		result, err = recipeStepList(ctx, q, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.SyncRecipeStepsText(ctx, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, arg.RecipeID, arg.Editor)
+
+		return err
+	})
+
+	return result, err
+}
+
+// Update the text, timer, and ingredients of a step, the ingredients are replaced
+func (s *SQLStorage) UpdateRecipeStepTx(ctx context.Context, arg UpdateRecipeStepTxParams) ([]RecipeStep, error) {
+	var result []RecipeStep
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		step, err := q.UpdateRecipeStep(
+			ctx,
+			UpdateRecipeStepParams{
+				ID:              arg.ID,
+				Text:            arg.Step.Text,
+				DurationSeconds: arg.Step.DurationSeconds,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRecipeStepIngredients(ctx, step.ID)
+		if err != nil {
+			return err
+		}
+
+		err = createStepIngredients(ctx, q, step, arg.Step.Ingredients)
+		if err != nil {
+			return err
+		}
+
+		result, err = recipeStepList(ctx, q, step.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.SyncRecipeStepsText(ctx, step.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, step.RecipeID, arg.Editor)
+
+		return err
+	})
+
+	return result, err
+}
+
+// Delete a step, following steps are moved one position up
+func (s *SQLStorage) DeleteRecipeStepTx(ctx context.Context, arg DeleteRecipeStepTxParams) ([]RecipeStep, error) {
+	var result []RecipeStep
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		step, err := q.GetRecipeStep(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRecipeStep(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.ShiftRecipeSteps(
+			ctx,
+			ShiftRecipeStepsParams{
+				Shift:        -1,
+				RecipeID:     step.RecipeID,
+				FromPosition: step.Position + 1,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		result, err = recipeStepList(ctx, q, step.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.SyncRecipeStepsText(ctx, step.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, step.RecipeID, arg.Editor)
+
+		return err
+	})
+
+	return result, err
+}
+
+// Reorder all steps of a recipe, the order has to contain every step exactly once
+func (s *SQLStorage) ReorderRecipeStepsTx(ctx context.Context, arg ReorderRecipeStepsParams) ([]RecipeStep, error) {
+	var result []RecipeStep
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		steps, err := q.ListRecipeSteps(ctx, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+		if len(steps) != len(arg.StepIDs) {
+			return ErrInvalidStepOrder
+		}
+
+		remaining := make(map[int64]bool, len(steps))
+		for _, step := range steps {
+			remaining[step.ID] = true
+		}
+
+		for i, id := range arg.StepIDs {
+			if !remaining[id] {
+				return ErrInvalidStepOrder
+			}
+			delete(remaining, id)
+
+			err = q.UpdateRecipeStepPosition(
+				ctx,
+				UpdateRecipeStepPositionParams{
+					ID:       id,
+					Position: int32(i + 1),
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = recipeStepList(ctx, q, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.SyncRecipeStepsText(ctx, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, arg.RecipeID, arg.Editor)
+
+		return err
+	})
+
+	return result, err
+}
+
+// List the steps of a recipe with their ingredients, sql.ErrNoRows when the recipe does not exist
+func (s *SQLStorage) ListRecipeStepsTx(ctx context.Context, recipeID int64) ([]RecipeStep, error) {
+	var result []RecipeStep
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetRecipe(ctx, recipeID)
+		if err != nil {
+			return err
+		}
+
+		result, err = recipeStepList(ctx, q, recipeID)
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicateRecipeIngredient = errors.New("ingredient is listed more than once")
+
+type ReplaceRecipeParams struct {
+	Recipe UpdateRecipeParams `json:"recipe"`
+	// Full desired ingredient list, recipe ingredients missing from it are deleted
+	ListIngredients []ListIngredientParam `json:"ingredients"`
+	// User making the change, recorded in the new revision
+	Editor   uuid.UUID      `json:"editor"`
+	Taxonomy RecipeTaxonomy `json:"taxonomy"`
+	// Full desired keyword list
+	Keywords []string `json:"keywords"`
+}
+
+// Replace the recipe details, classification, keywords and its whole ingredient list. Ingredients are inserted, updated
+// or deleted so that the recipe ends up with exactly the given list, stored as a new revision.
+func (s *SQLStorage) ReplaceRecipeTx(ctx context.Context, arg ReplaceRecipeParams) (RecipeResult, error) {
+	var result RecipeResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		_, err = q.UpdateRecipe(ctx, arg.Recipe)
+		if err != nil {
+			return err
+		}
+
+		result.Recipe, err = q.UpdateRecipeTaxonomy(ctx, arg.Taxonomy.updateParams(arg.Recipe.ID))
+		if err != nil {
+			return err
+		}
+
+		result.Keywords, err = setRecipeKeywords(ctx, q, result.Recipe.ID, arg.Keywords)
+		if err != nil {
+			return err
+		}
+
+		ingredients := make([]CreateRecipeIngredientParams, len(arg.ListIngredients))
+		listed := make(map[int32]bool, len(arg.ListIngredients))
+		for i, item := range arg.ListIngredients {
+			ingredientID, err := recipeIngredientID(ctx, q, item)
+			if err != nil {
+				return err
+			}
+			// new names may resolve to an ingredient that is already listed
+			if listed[ingredientID] {
+				return ErrDuplicateRecipeIngredient
+			}
+			listed[ingredientID] = true
+
+			ingredients[i] = CreateRecipeIngredientParams{
+				IngredientID: ingredientID,
+				RecipeID:     result.Recipe.ID,
+				Amount:       item.Amount,
+				UnitID:       item.UnitID,
+			}
+		}
+
+		err = syncRecipeIngredients(ctx, q, result.Recipe.ID, ingredients)
+		if err != nil {
+			return err
+		}
+
+		result.Ingredients, err = q.GetRecipeIngredients(ctx, result.Recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Recipe, err = deriveStepsText(ctx, q, result.Recipe)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, result.Recipe.ID, arg.Editor)
+
+		return err
+	})
+
+	return result, err
+}
+
+// Id of a listed ingredient, ingredients without id are created or looked up by name
+func recipeIngredientID(ctx context.Context, q *Queries, item ListIngredientParam) (int32, error) {
+	if item.ID.Int32 > 0 {
+		return item.ID.Int32, nil
+	}
+
+	ingredient, err := q.CreateIngredient(
+		ctx,
+		CreateIngredientParams{
+			Name: item.Name,
+			DefaultUnit: sql.NullInt32{
+				Int32: item.UnitID,
+				Valid: true,
+			},
+		},
+	)
+	if err == sql.ErrNoRows {
+		ingredient, err = q.SearchIngredientName(ctx, item.Name)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return ingredient.ID, nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hasnaroihan/grocery-planner/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceRecipeTx(t *testing.T) {
+	storage := NewStorage(testDB)
+	recipe, ingredients := newRevisionRecipe(t, storage)
+	unit := CreateRandomUnit(t)
+	added := CreateRandomIngredient(t)
+	newName := util.RandomString(12)
+
+	arg := ReplaceRecipeParams{
+		Recipe: UpdateRecipeParams{
+			ID:      recipe.Recipe.ID,
+			Name:    util.RandomString(10),
+			Portion: 4,
+		},
+		ListIngredients: []ListIngredientParam{
+			// kept with a new amount, ingredients[0] is dropped
+			{
+				ID:     sql.NullInt32{Int32: ingredients[1].ID, Valid: true},
+				Amount: 11,
+				UnitID: unit.ID,
+			},
+			{
+				ID:     sql.NullInt32{Int32: ingredients[2].ID, Valid: true},
+				Amount: 5,
+				UnitID: ingredients[2].DefaultUnit.Int32,
+			},
+			{
+				ID:     sql.NullInt32{Int32: added.ID, Valid: true},
+				Amount: 3,
+				UnitID: unit.ID,
+			},
+			{
+				Name:   newName,
+				Amount: 7,
+				UnitID: unit.ID,
+			},
+		},
+		Editor: recipe.Recipe.Author,
+	}
+
+	result, err := storage.ReplaceRecipeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Recipe.Name, result.Recipe.Name)
+	require.Equal(t, arg.Recipe.Portion, result.Recipe.Portion)
+	require.False(t, result.Recipe.Steps.Valid)
+	require.Len(t, result.Ingredients, 4)
+
+	amounts := make(map[string]float32, len(result.Ingredients))
+	for _, ingredient := range result.Ingredients {
+		require.NotEqual(t, ingredients[0].ID, ingredient.IngredientID)
+		amounts[ingredient.Name] = ingredient.Amount
+	}
+	require.Equal(t, float32(11), amounts[ingredients[1].Name])
+	require.Equal(t, float32(3), amounts[added.Name])
+	require.Equal(t, float32(7), amounts[newName])
+
+	latest, err := storage.GetLatestRecipeRevision(context.Background(), recipe.Recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), latest.Revision)
+
+	// A name resolving to an already listed ingredient is rejected
+	arg.ListIngredients = append(arg.ListIngredients, ListIngredientParam{
+		Name:   added.Name,
+		Amount: 1,
+		UnitID: unit.ID,
+	})
+	_, err = storage.ReplaceRecipeTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrDuplicateRecipeIngredient)
+
+	missing := arg
+	missing.Recipe.ID = recipe.Recipe.ID + 100000
+	missing.ListIngredients = arg.ListIngredients[:1]
+	_, err = storage.ReplaceRecipeTx(context.Background(), missing)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RevertRecipeParams struct {
+	RecipeID int64 `json:"recipeID"`
+	Revision int32 `json:"revision"`
+	// User reverting the recipe, recorded in the new revision
+	Editor uuid.UUID `json:"editor"`
+}
+
+type DeleteRecipeIngredientTxParams struct {
+	RecipeID     int64     `json:"recipeID"`
+	IngredientID int32     `json:"ingredientID"`
+	Editor       uuid.UUID `json:"editor"`
+}
+
+// Restore the recipe fields, classification, keywords, ingredients, and steps of a revision, the restored state is stored as a new revision
+func (s *SQLStorage) RevertRecipeTx(ctx context.Context, arg RevertRecipeParams) (RecipeResult, error) {
+	var result RecipeResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		row, err := q.GetRecipeRevision(
+			ctx,
+			GetRecipeRevisionParams{
+				RecipeID: arg.RecipeID,
+				Revision: arg.Revision,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		revision, err := RevisionOf(row)
+		if err != nil {
+			return err
+		}
+
+		result.Recipe, err = q.UpdateRecipe(
+			ctx,
+			UpdateRecipeParams{
+				ID:      arg.RecipeID,
+				Name:    revision.Name,
+				Portion: revision.Portion,
+				Steps:   revision.Steps,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		result.Recipe, err = q.UpdateRecipeTaxonomy(ctx, revision.Taxonomy.updateParams(arg.RecipeID))
+		if err != nil {
+			return err
+		}
+
+		result.Keywords, err = setRecipeKeywords(ctx, q, arg.RecipeID, revision.Keywords)
+		if err != nil {
+			return err
+		}
+
+		ingredients := make([]CreateRecipeIngredientParams, len(revision.Ingredients))
+		for i, ingredient := range revision.Ingredients {
+			ingredients[i] = CreateRecipeIngredientParams{
+				IngredientID: ingredient.IngredientID,
+				RecipeID:     arg.RecipeID,
+				Amount:       ingredient.Amount,
+				UnitID:       ingredient.UnitID,
+			}
+		}
+		err = syncRecipeIngredients(ctx, q, arg.RecipeID, ingredients)
+		if err != nil {
+			return err
+		}
+
+		result.Ingredients, err = q.GetRecipeIngredients(ctx, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+
+		// after the ingredients, which the steps refer to
+		err = restoreRecipeSteps(ctx, q, arg.RecipeID, revision.StepList)
+		if err != nil {
+			return err
+		}
+		result.Steps, err = recipeStepList(ctx, q, arg.RecipeID)
+		if err != nil {
+			return err
+		}
+		result.Recipe, err = deriveStepsText(ctx, q, result.Recipe)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, arg.RecipeID, arg.Editor)
+
+		return err
+	})
+
+	return result, err
+}
+
+// Delete an ingredient from a recipe and store the change as a new revision
+func (s *SQLStorage) DeleteRecipeIngredientTx(ctx context.Context, arg DeleteRecipeIngredientTxParams) error {
+	return s.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteRecipeIngredient(
+			ctx,
+			DeleteRecipeIngredientParams{
+				RecipeID:     arg.RecipeID,
+				IngredientID: arg.IngredientID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = createRevision(ctx, q, arg.RecipeID, arg.Editor)
+
+		return err
+	})
+}
@@ -0,0 +1,48 @@
+package db
+
+import "context"
+
+type ScaleRecipeParams struct {
+	ID int64 `json:"id"`
+	// Zero keeps the recipe's own portion
+	Portions int32 `json:"portions"`
+	// measure.Metric, measure.Imperial or empty to keep the recipe units
+	UnitSystem string `json:"unitSystem"`
+}
+
+type ScaledRecipeResult struct {
+	Recipe      Recipe             `json:"recipe"`
+	Portions    int32              `json:"portions"`
+	UnitSystem  string             `json:"unitSystem"`
+	Ingredients []ScaledIngredient `json:"ingredients"`
+}
+
+// Get a recipe with the ingredient amounts scaled to the requested portions
+func (s *SQLStorage) ScaleRecipeTx(ctx context.Context, arg ScaleRecipeParams) (ScaledRecipeResult, error) {
+	var result ScaledRecipeResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Recipe, err = q.GetRecipe(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Portions = arg.Portions
+		if result.Portions <= 0 {
+			result.Portions = result.Recipe.Portion
+		}
+		result.UnitSystem = arg.UnitSystem
+
+		rows, err := q.ListRecipeIngredientAmounts(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		result.Ingredients = scaleIngredients(rows, result.Recipe.Portion, result.Portions, arg.UnitSystem)
+
+		return nil
+	})
+
+	return result, err
+}
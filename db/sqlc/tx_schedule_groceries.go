@@ -0,0 +1,89 @@
+package db
+
+import "context"
+
+type ScheduleGroceriesParams struct {
+	ScheduleID int64  `json:"scheduleID"`
+	UnitSystem string `json:"unitSystem"`
+	Store      string `json:"store"`
+}
+
+type ScheduleGroceriesResult struct {
+	Schedule  Schedule               `json:"schedule"`
+	Recipes   []GetScheduleRecipeRow `json:"recipes"`
+	Groceries []GroceryItem          `json:"groceries"`
+	Cost      GroceryCost            `json:"cost"`
+	// Every category in store order, to group the groceries by
+	Categories []GroceryCategory `json:"categories"`
+}
+
+// Grocery list of an existing schedule, computed like GenerateGroceries does
+func (s *SQLStorage) GetScheduleGroceriesTx(ctx context.Context, arg ScheduleGroceriesParams) (ScheduleGroceriesResult, error) {
+	var result ScheduleGroceriesResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Schedule, err = q.GetSchedule(ctx, arg.ScheduleID)
+		if err != nil {
+			return err
+		}
+
+		result.Recipes, err = q.GetScheduleRecipe(ctx, arg.ScheduleID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.ListGroceryAmounts(ctx, ListGroceryAmountsParams{
+			ScheduleIds: []int64{arg.ScheduleID},
+			Store:       arg.Store,
+		})
+		if err != nil {
+			return err
+		}
+		result.Groceries = aggregateGroceries(rows, arg.UnitSystem)
+		result.Cost = estimateGroceryCost(rows, result.Groceries)
+
+		_, err = q.purchaseGroceries(ctx, rows, result.Groceries, arg.Store)
+		if err != nil {
+			return err
+		}
+
+		err = q.categorizeGroceries(ctx, result.Groceries)
+		if err != nil {
+			return err
+		}
+
+		result.Categories, err = q.ListGroceryCategories(ctx)
+		return err
+	})
+
+	return result, err
+}
+
+// Set the category of every grocery line that has one
+func (q *Queries) categorizeGroceries(ctx context.Context, items []GroceryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ingredientIDs := make([]int32, len(items))
+	for i, item := range items {
+		ingredientIDs[i] = item.ID
+	}
+
+	rows, err := q.ListIngredientCategories(ctx, ingredientIDs)
+	if err != nil {
+		return err
+	}
+
+	categories := map[int32]string{}
+	for _, row := range rows {
+		categories[row.IngredientID] = row.Category
+	}
+	for i := range items {
+		items[i].Category = categories[items[i].ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/recur"
+)
+
+var ErrEmptyWeek = errors.New("no recipe is scheduled in the week")
+
+type TemplateRecipePortion struct {
+	RecipeID int64 `json:"recipe_id"`
+	Portion  int32 `json:"portion"`
+}
+
+type NewScheduleTemplateParams struct {
+	Author uuid.UUID `json:"author"`
+	Name   string    `json:"name"`
+	// RRULE like "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", stored in its canonical form
+	Rule     string                  `json:"rule"`
+	StartsOn time.Time               `json:"startsOn"`
+	Recipes  []TemplateRecipePortion `json:"recipes"`
+}
+
+type ScheduleTemplateResult struct {
+	Template ScheduleTemplate                 `json:"template"`
+	Recipes  []ListScheduleTemplateRecipesRow `json:"recipes"`
+}
+
+type GenerateWeekParams struct {
+	Author uuid.UUID `json:"author"`
+	// Any day of the week, weeks run from Monday to Sunday
+	WeekOf time.Time `json:"weekOf"`
+	// Templates to materialize, empty for all of the author's templates
+	TemplateIDs []int64 `json:"templateIDs"`
+	// Ad-hoc recipes planned next to the templates
+	Recipes      []ScheduleRecipePortion `json:"recipes"`
+	Restrictions string                  `json:"restrictions"`
+	UnitSystem   string                  `json:"unitSystem"`
+	PinRevisions bool                    `json:"pinRevisions"`
+	Store        string                  `json:"store"`
+}
+
+// Create a template with its recipes, the rule has to parse
+func (s *SQLStorage) NewScheduleTemplateTx(ctx context.Context, arg NewScheduleTemplateParams) (ScheduleTemplateResult, error) {
+	var result ScheduleTemplateResult
+
+	rule, err := recur.Parse(arg.Rule)
+	if err != nil {
+		return result, err
+	}
+
+	err = s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Template, err = q.CreateScheduleTemplate(ctx, CreateScheduleTemplateParams{
+			Author:   arg.Author,
+			Name:     arg.Name,
+			Rrule:    rule.String(),
+			StartsOn: recur.Date(arg.StartsOn),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, recipe := range arg.Recipes {
+			_, err = q.CreateScheduleTemplateRecipe(ctx, CreateScheduleTemplateRecipeParams{
+				TemplateID: result.Template.ID,
+				RecipeID:   recipe.RecipeID,
+				Portion:    recipe.Portion,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Recipes, err = q.ListScheduleTemplateRecipes(ctx, []int64{result.Template.ID})
+		return err
+	})
+
+	return result, err
+}
+
+// List the author's templates with their recipes
+func (s *SQLStorage) ListScheduleTemplatesTx(ctx context.Context, author uuid.UUID) ([]ScheduleTemplateResult, error) {
+	var result []ScheduleTemplateResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		templates, err := q.ListScheduleTemplates(ctx, author)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.templateResults(ctx, templates)
+		return err
+	})
+
+	return result, err
+}
+
+// Materialize the author's templates into dated recipes of one week, merge them with the
+// ad-hoc recipes and generate the week's schedule and groceries
+func (s *SQLStorage) GenerateWeekTx(ctx context.Context, arg GenerateWeekParams) (GenerateGroceriesResult, error) {
+	var result GenerateGroceriesResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		templates, err := q.ListScheduleTemplates(ctx, arg.Author)
+		if err != nil {
+			return err
+		}
+		templates, err = selectTemplates(templates, arg.TemplateIDs)
+		if err != nil {
+			return err
+		}
+
+		templateResults, err := q.templateResults(ctx, templates)
+		if err != nil {
+			return err
+		}
+		planned, err := materializeTemplates(templateResults, arg.WeekOf)
+		if err != nil {
+			return err
+		}
+
+		recipes := mergeScheduleRecipes(arg.Recipes, planned)
+		if len(recipes) == 0 {
+			return ErrEmptyWeek
+		}
+
+		result, err = q.generateGroceries(ctx, GenerateGroceriesParam{
+			Author: uuid.NullUUID{
+				UUID:  arg.Author,
+				Valid: true,
+			},
+			Recipes:          recipes,
+			Restrictions:     arg.Restrictions,
+			RestrictionsUser: arg.Author,
+			UnitSystem:       arg.UnitSystem,
+			PinRevisions:     arg.PinRevisions,
+			Store:            arg.Store,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func (q *Queries) templateResults(ctx context.Context, templates []ScheduleTemplate) ([]ScheduleTemplateResult, error) {
+	result := make([]ScheduleTemplateResult, len(templates))
+	if len(templates) == 0 {
+		return result, nil
+	}
+
+	ids := make([]int64, len(templates))
+	index := map[int64]int{}
+	for i, template := range templates {
+		ids[i] = template.ID
+		index[template.ID] = i
+		result[i] = ScheduleTemplateResult{
+			Template: template,
+			Recipes:  []ListScheduleTemplateRecipesRow{},
+		}
+	}
+
+	rows, err := q.ListScheduleTemplateRecipes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		i := index[row.TemplateID]
+		result[i].Recipes = append(result[i].Recipes, row)
+	}
+
+	return result, nil
+}
+
+// Keep the requested templates, a template of another author is not found
+func selectTemplates(templates []ScheduleTemplate, ids []int64) ([]ScheduleTemplate, error) {
+	if len(ids) == 0 {
+		return templates, nil
+	}
+
+	byID := map[int64]ScheduleTemplate{}
+	for _, template := range templates {
+		byID[template.ID] = template
+	}
+
+	result := make([]ScheduleTemplate, 0, len(ids))
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		template, ok := byID[id]
+		if !ok {
+			return nil, sql.ErrNoRows
+		}
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, template)
+		}
+	}
+
+	return result, nil
+}
+
+// Dated recipes of the templates in the week of weekOf
+func materializeTemplates(templates []ScheduleTemplateResult, weekOf time.Time) ([]ScheduleRecipePortion, error) {
+	from := recur.WeekStart(weekOf)
+	to := from.AddDate(0, 0, 6)
+
+	result := []ScheduleRecipePortion{}
+	for _, template := range templates {
+		rule, err := recur.Parse(template.Template.Rrule)
+		if err != nil {
+			return nil, fmt.Errorf("template %d: %w", template.Template.ID, err)
+		}
+
+		for _, day := range rule.Between(template.Template.StartsOn, from, to) {
+			for _, recipe := range template.Recipes {
+				result = append(result, ScheduleRecipePortion{
+					RecipeID:      recipe.RecipeID,
+					Portion:       recipe.Portion,
+					ScheduledDate: sql.NullTime{Time: day, Valid: true},
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// A schedule holds a recipe once per day, so portions of the same recipe on the same
+// day are added up. The order of first appearance is kept
+func mergeScheduleRecipes(lists ...[]ScheduleRecipePortion) []ScheduleRecipePortion {
+	type key struct {
+		recipeID int64
+		date     time.Time
+		dated    bool
+	}
+
+	result := []ScheduleRecipePortion{}
+	index := map[key]int{}
+	for _, list := range lists {
+		for _, recipe := range list {
+			k := key{recipe.RecipeID, recur.Date(recipe.ScheduledDate.Time), recipe.ScheduledDate.Valid}
+			if !k.dated {
+				k.date = time.Time{}
+			}
+
+			if i, ok := index[k]; ok {
+				result[i].Portion += recipe.Portion
+				continue
+			}
+			index[k] = len(result)
+			result = append(result, recipe)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SetUserRestrictionsParams struct {
+	UserID uuid.UUID `json:"userID"`
+	Tags   []string  `json:"tags"`
+}
+
+// Replace all dietary restrictions of a user
+func (s *SQLStorage) SetUserRestrictionsTx(ctx context.Context, arg SetUserRestrictionsParams) ([]ListUserRestrictionsRow, error) {
+	var result []ListUserRestrictionsRow
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteUserRestrictions(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range arg.Tags {
+			_, err = q.CreateUserRestriction(
+				ctx,
+				CreateUserRestrictionParams{
+					UserID: arg.UserID,
+					Tag:    tag,
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = q.ListUserRestrictions(ctx, arg.UserID)
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hasnaroihan/grocery-planner/planner"
+)
+
+// Highest rated recipes considered for a meal plan
+const mealPlanPoolSize = 200
+
+type SuggestMealPlanParams struct {
+	Author uuid.NullUUID `json:"author"`
+	// RestrictionsExclude to leave out recipes conflicting with the author's
+	// dietary restrictions, empty to plan from every recipe
+	Restrictions string         `json:"restrictions"`
+	Budget       float64        `json:"budget"`
+	Days         int            `json:"days"`
+	MealsPerDay  int            `json:"mealsPerDay"`
+	Servings     int32          `json:"servings"`
+	StartDate    time.Time      `json:"startDate"`
+	Cuisine      sql.NullString `json:"cuisine"`
+	Course       sql.NullString `json:"course"`
+}
+
+type SuggestedMeal struct {
+	Date       time.Time `json:"date"`
+	Slot       int       `json:"slot"`
+	RecipeID   int64     `json:"recipeID"`
+	RecipeName string    `json:"recipeName"`
+	Cost       float64   `json:"cost"`
+}
+
+type MealPlanSuggestion struct {
+	Meals []SuggestedMeal `json:"meals"`
+	// Draft schedule to accept as the recipes of GenerateGroceries
+	Recipes           []ScheduleRecipePortion `json:"recipes"`
+	Cost              float64                 `json:"cost"`
+	Budget            float64                 `json:"budget"`
+	SharedIngredients int                     `json:"sharedIngredients"`
+	// Pantry ingredients of the author the plan uses before they expire
+	PantryIngredients []int32 `json:"pantryIngredients"`
+	// Recipes left out because some of their ingredients have no usable price
+	Unpriced []int64 `json:"unpriced"`
+}
+
+// Suggest a meal plan from the priced recipes of the catalog that stays under
+// the budget, using up the author's pantry where it can, see planner.Suggest
+func (s *SQLStorage) SuggestMealPlanTx(ctx context.Context, arg SuggestMealPlanParams) (MealPlanSuggestion, error) {
+	result := MealPlanSuggestion{
+		Budget:   arg.Budget,
+		Unpriced: []int64{},
+	}
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		candidateParams := ListPlanCandidatesParams{
+			Cuisine:  arg.Cuisine,
+			Course:   arg.Course,
+			PoolSize: mealPlanPoolSize,
+		}
+		if arg.Restrictions == RestrictionsExclude {
+			candidateParams.UserID = arg.Author
+		}
+
+		rows, err := q.ListPlanCandidates(ctx, candidateParams)
+		if err != nil {
+			return err
+		}
+
+		candidates, names, unpriced := planCandidates(rows)
+		result.Unpriced = unpriced
+
+		params := planner.Params{
+			Budget:      arg.Budget,
+			Days:        arg.Days,
+			MealsPerDay: arg.MealsPerDay,
+			Servings:    arg.Servings,
+		}
+		if arg.Author.Valid {
+			pantry, err := q.ListPantryItems(ctx, arg.Author.UUID)
+			if err != nil {
+				return err
+			}
+			params.Pantry = plannerPantry(pantry, arg.StartDate, arg.Days)
+		}
+
+		plan, err := planner.Suggest(params, candidates)
+		if err != nil {
+			return err
+		}
+
+		result.Meals = make([]SuggestedMeal, 0, len(plan.Meals))
+		result.Recipes = make([]ScheduleRecipePortion, 0, len(plan.Meals))
+		for _, meal := range plan.Meals {
+			date := arg.StartDate.AddDate(0, 0, meal.Day)
+			result.Meals = append(result.Meals, SuggestedMeal{
+				Date:       date,
+				Slot:       meal.Slot,
+				RecipeID:   meal.RecipeID,
+				RecipeName: names[meal.RecipeID],
+				Cost:       roundCost(meal.Cost),
+			})
+			result.Recipes = append(result.Recipes, ScheduleRecipePortion{
+				RecipeID: meal.RecipeID,
+				Portion:  arg.Servings,
+				ScheduledDate: sql.NullTime{
+					Time:  date,
+					Valid: true,
+				},
+			})
+		}
+		result.Cost = roundCost(plan.Cost)
+		result.SharedIngredients = plan.SharedIngredients
+		result.PantryIngredients = plan.PantryIngredients
+		if result.PantryIngredients == nil {
+			result.PantryIngredients = []int32{}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Group the candidate rows per recipe with the cost of one portion. Recipes with
+// an ingredient that can not be priced are returned apart.
+func planCandidates(rows []ListPlanCandidatesRow) ([]planner.Candidate, map[int64]string, []int64) {
+	candidates := []planner.Candidate{}
+	names := map[int64]string{}
+	unpriced := []int64{}
+
+	var current *planner.Candidate
+	var total float64
+	var portion int32
+	priced := true
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if !priced || portion <= 0 {
+			unpriced = append(unpriced, current.RecipeID)
+			return
+		}
+		current.PortionCost = total / float64(portion)
+		candidates = append(candidates, *current)
+	}
+
+	for _, row := range rows {
+		if current == nil || current.RecipeID != row.RecipeID {
+			flush()
+			current = &planner.Candidate{RecipeID: row.RecipeID}
+			names[row.RecipeID] = row.RecipeName
+			total = 0
+			portion = row.RecipePortion
+			priced = true
+		}
+
+		current.Ingredients = append(current.Ingredients, row.IngredientID)
+		cost, ok := pricedAmount{
+			Amount:                   float64(row.Amount),
+			UnitName:                 row.UnitName,
+			IngredientUnitGrams:      row.IngredientUnitGrams,
+			UnitGrams:                row.UnitGrams,
+			Price:                    row.Price,
+			PriceUnitName:            row.PriceUnitName,
+			PriceIngredientUnitGrams: row.PriceIngredientUnitGrams,
+			PriceUnitGrams:           row.PriceUnitGrams,
+		}.cost()
+		if !ok {
+			priced = false
+			continue
+		}
+		total += cost
+	}
+	flush()
+
+	return candidates, names, unpriced
+}
+
+// Pantry items with the last day of the plan they can be used on. Items without
+// an expiry keep for the whole plan.
+func plannerPantry(items []ListPantryItemsRow, startDate time.Time, days int) []planner.PantryItem {
+	result := make([]planner.PantryItem, 0, len(items))
+	for _, item := range items {
+		last := days - 1
+		if item.ExpiresOn.Valid {
+			last = int(math.Floor(item.ExpiresOn.Time.Sub(startDate).Hours() / 24))
+		}
+		if last < 0 {
+			continue
+		}
+		result = append(result, planner.PantryItem{
+			IngredientID: item.IngredientID,
+			LastDay:      last,
+		})
+	}
+
+	return result
+}
@@ -4,14 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"log"
+
+	"github.com/google/uuid"
 )
 
 type TxUpdateRecipeParams struct {
 	Recipe UpdateRecipeParams	`json:"recipe"`
 	ListIngredients []ListIngredientParam	`json:"ingredients"`
+	// User making the change, recorded in the new revision
+	Editor uuid.UUID	`json:"editor"`
+	// Replaces the recipe's classification, nil keeps it
+	Taxonomy *RecipeTaxonomy	`json:"taxonomy"`
+	// Replaces the recipe's keywords, nil keeps them
+	Keywords []string	`json:"keywords"`
 }
 
-// Update recipe does not delete recipe_ingredients, it only update recipe details, recipe ingredient details, or create new recipe ingredients
+// Update recipe does not delete recipe_ingredients, it only update recipe details, recipe ingredient details, or create new recipe ingredients.
+// Every update stores a new revision of the recipe. Use ReplaceRecipeTx to also remove ingredients.
 func (s *SQLStorage) UpdateRecipeTx(ctx context.Context, arg TxUpdateRecipeParams) (RecipeResult, error) {
 	var result RecipeResult
 
@@ -24,6 +33,27 @@ func (s *SQLStorage) UpdateRecipeTx(ctx context.Context, arg TxUpdateRecipeParam
 			return err
 		}
 
+		if arg.Taxonomy != nil {
+			result.Recipe, err = q.UpdateRecipeTaxonomy(ctx, arg.Taxonomy.updateParams(arg.Recipe.ID))
+			if err != nil {
+				log.Print("update recipe taxonomy")
+				return err
+			}
+		}
+
+		if arg.Keywords != nil {
+			_, err = setRecipeKeywords(ctx, q, result.Recipe.ID, arg.Keywords)
+			if err != nil {
+				log.Print("set recipe keywords")
+				return err
+			}
+		}
+		result.Keywords, err = q.ListRecipeKeywords(ctx, result.Recipe.ID)
+		if err != nil {
+			log.Print("list recipe keywords")
+			return err
+		}
+
 		for _, item := range arg.ListIngredients {
 			if item.ID.Int32 > 0 {
 				_, err = q.UpdateRecipeIngredient(
@@ -83,6 +113,18 @@ func (s *SQLStorage) UpdateRecipeTx(ctx context.Context, arg TxUpdateRecipeParam
 			return err
 		}
 
+		result.Recipe, err = deriveStepsText(ctx, q, result.Recipe)
+		if err != nil {
+			log.Print("derive recipe steps text")
+			return err
+		}
+
+		_, err = createRevision(ctx, q, result.Recipe.ID, arg.Editor)
+		if err != nil {
+			log.Print("create recipe revision")
+			return err
+		}
+
 		return nil
 	})
 
@@ -7,23 +7,45 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createUnit = `-- name: CreateUnit :one
 INSERT INTO units (
-    name
+    name,
+    grams
 ) VALUES (
-    $1
-) RETURNING id, name
+    $1, $2
+) RETURNING id, name, grams
 `
 
-func (q *Queries) CreateUnit(ctx context.Context, name string) (Unit, error) {
-	row := q.db.QueryRowContext(ctx, createUnit, name)
+type CreateUnitParams struct {
+	Name  string          `json:"name"`
+	Grams sql.NullFloat64 `json:"grams"`
+}
+
+func (q *Queries) CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, error) {
+	row := q.db.QueryRowContext(ctx, createUnit, arg.Name, arg.Grams)
 	var i Unit
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Grams)
 	return i, err
 }
 
+const deleteIngredientUnit = `-- name: DeleteIngredientUnit :exec
+DELETE FROM ingredients_units
+WHERE ingredient_id = $1 AND unit_id = $2
+`
+
+type DeleteIngredientUnitParams struct {
+	IngredientID int32 `json:"ingredientID"`
+	UnitID       int32 `json:"unitID"`
+}
+
+func (q *Queries) DeleteIngredientUnit(ctx context.Context, arg DeleteIngredientUnitParams) error {
+	_, err := q.db.ExecContext(ctx, deleteIngredientUnit, arg.IngredientID, arg.UnitID)
+	return err
+}
+
 const deleteUnit = `-- name: DeleteUnit :exec
 DELETE FROM units
 WHERE id = $1
@@ -35,19 +57,47 @@ func (q *Queries) DeleteUnit(ctx context.Context, id int32) error {
 }
 
 const getUnit = `-- name: GetUnit :one
-SELECT id, name from units
+SELECT id, name, grams from units
 WHERE id = $1
 `
 
 func (q *Queries) GetUnit(ctx context.Context, id int32) (Unit, error) {
 	row := q.db.QueryRowContext(ctx, getUnit, id)
 	var i Unit
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Grams)
 	return i, err
 }
 
+const listIngredientUnits = `-- name: ListIngredientUnits :many
+SELECT ingredient_id, unit_id, grams from ingredients_units
+WHERE ingredient_id = $1
+`
+
+func (q *Queries) ListIngredientUnits(ctx context.Context, ingredientID int32) ([]IngredientsUnit, error) {
+	rows, err := q.db.QueryContext(ctx, listIngredientUnits, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []IngredientsUnit{}
+	for rows.Next() {
+		var i IngredientsUnit
+		if err := rows.Scan(&i.IngredientID, &i.UnitID, &i.Grams); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUnits = `-- name: ListUnits :many
-SELECT id, name from units
+SELECT id, name, grams from units
 `
 
 func (q *Queries) ListUnits(ctx context.Context) ([]Unit, error) {
@@ -59,7 +109,7 @@ func (q *Queries) ListUnits(ctx context.Context) ([]Unit, error) {
 	items := []Unit{}
 	for rows.Next() {
 		var i Unit
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.Grams); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -75,19 +125,46 @@ func (q *Queries) ListUnits(ctx context.Context) ([]Unit, error) {
 
 const updateUnit = `-- name: UpdateUnit :one
 UPDATE units
-    set name = $2
+    set name = $2,
+    grams = $3
 WHERE id = $1
-RETURNING id, name
+RETURNING id, name, grams
 `
 
 type UpdateUnitParams struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID    int32           `json:"id"`
+	Name  string          `json:"name"`
+	Grams sql.NullFloat64 `json:"grams"`
 }
 
 func (q *Queries) UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, error) {
-	row := q.db.QueryRowContext(ctx, updateUnit, arg.ID, arg.Name)
+	row := q.db.QueryRowContext(ctx, updateUnit, arg.ID, arg.Name, arg.Grams)
 	var i Unit
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Grams)
+	return i, err
+}
+
+const upsertIngredientUnit = `-- name: UpsertIngredientUnit :one
+INSERT INTO ingredients_units (
+    ingredient_id,
+    unit_id,
+    grams
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (ingredient_id, unit_id) DO UPDATE
+    set grams = EXCLUDED.grams
+RETURNING ingredient_id, unit_id, grams
+`
+
+type UpsertIngredientUnitParams struct {
+	IngredientID int32   `json:"ingredientID"`
+	UnitID       int32   `json:"unitID"`
+	Grams        float32 `json:"grams"`
+}
+
+func (q *Queries) UpsertIngredientUnit(ctx context.Context, arg UpsertIngredientUnitParams) (IngredientsUnit, error) {
+	row := q.db.QueryRowContext(ctx, upsertIngredientUnit, arg.IngredientID, arg.UnitID, arg.Grams)
+	var i IngredientsUnit
+	err := row.Scan(&i.IngredientID, &i.UnitID, &i.Grams)
 	return i, err
 }
@@ -10,31 +10,41 @@ import (
 )
 
 func CreateRandomUnit(t *testing.T) Unit{
-	name := util.RandomUnit()
+	arg := CreateUnitParams{
+		Name: util.RandomUnit(),
+		Grams: sql.NullFloat64{
+			Float64: float64(util.RandomInt(1, 1000)),
+			Valid: true,
+		},
+	}
 	unit, err := testQueries.CreateUnit(
 		context.Background(),
-		name,
+		arg,
 	)
 	require.NoError(t, err)
 	require.NotEmpty(t, unit)
 
 	require.NotZero(t, unit.ID)
-	require.Equal(t, name, unit.Name)
+	require.Equal(t, arg.Name, unit.Name)
+	require.Equal(t, arg.Grams, unit.Grams)
 
 	return unit
 }
 
 func TestCreateUnit(t *testing.T) {
-	name := util.RandomUnit()
+	arg := CreateUnitParams{
+		Name: util.RandomUnit(),
+	}
 	unit, err := testQueries.CreateUnit(
 		context.Background(),
-		name,
+		arg,
 	)
 	require.NoError(t, err)
 	require.NotEmpty(t, unit)
 
 	require.NotZero(t, unit.ID)
-	require.Equal(t, name, unit.Name)
+	require.Equal(t, arg.Name, unit.Name)
+	require.False(t, unit.Grams.Valid)
 }
 
 func TestDeleteUnit(t *testing.T) {
@@ -90,6 +100,10 @@ func TestUpdateUnit(t *testing.T) {
 	arg := UpdateUnitParams {
 		ID: unitNew.ID,
 		Name: util.RandomUnit(),
+		Grams: sql.NullFloat64{
+			Float64: 1000,
+			Valid: true,
+		},
 	}
 
 	unit, err := testQueries.UpdateUnit(
@@ -101,4 +115,86 @@ func TestUpdateUnit(t *testing.T) {
 
 	require.Equal(t, arg.ID, unit.ID)
 	require.Equal(t, arg.Name, unit.Name)
+	require.Equal(t, arg.Grams, unit.Grams)
+}
+
+func CreateRandomIngredientUnit(t *testing.T, ingredient Ingredient) IngredientsUnit {
+	unit := CreateRandomUnit(t)
+
+	arg := UpsertIngredientUnitParams{
+		IngredientID: ingredient.ID,
+		UnitID: unit.ID,
+		Grams: float32(util.RandomInt(1, 500)),
+	}
+
+	ingredientUnit, err := testQueries.UpsertIngredientUnit(
+		context.Background(),
+		arg,
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, ingredientUnit)
+
+	require.Equal(t, arg.IngredientID, ingredientUnit.IngredientID)
+	require.Equal(t, arg.UnitID, ingredientUnit.UnitID)
+	require.Equal(t, arg.Grams, ingredientUnit.Grams)
+
+	return ingredientUnit
+}
+
+func TestUpsertIngredientUnit(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	ingredientUnitNew := CreateRandomIngredientUnit(t, ingredient)
+
+	// Upsert existing conversion
+	arg := UpsertIngredientUnitParams{
+		IngredientID: ingredientUnitNew.IngredientID,
+		UnitID: ingredientUnitNew.UnitID,
+		Grams: ingredientUnitNew.Grams + 1,
+	}
+
+	ingredientUnit, err := testQueries.UpsertIngredientUnit(
+		context.Background(),
+		arg,
+	)
+	require.NoError(t, err)
+	require.Equal(t, arg.Grams, ingredientUnit.Grams)
+}
+
+func TestListIngredientUnits(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	for i := 0; i < 3; i++ {
+		CreateRandomIngredientUnit(t, ingredient)
+	}
+
+	ingredientUnits, err := testQueries.ListIngredientUnits(
+		context.Background(),
+		ingredient.ID,
+	)
+	require.NoError(t, err)
+	require.Len(t, ingredientUnits, 3)
+
+	for _,row := range ingredientUnits {
+		require.Equal(t, ingredient.ID, row.IngredientID)
+	}
+}
+
+func TestDeleteIngredientUnit(t *testing.T) {
+	ingredient := CreateRandomIngredient(t)
+	ingredientUnit := CreateRandomIngredientUnit(t, ingredient)
+
+	err := testQueries.DeleteIngredientUnit(
+		context.Background(),
+		DeleteIngredientUnitParams{
+			IngredientID: ingredientUnit.IngredientID,
+			UnitID: ingredientUnit.UnitID,
+		},
+	)
+	require.NoError(t, err)
+
+	ingredientUnits, err := testQueries.ListIngredientUnits(
+		context.Background(),
+		ingredient.ID,
+	)
+	require.NoError(t, err)
+	require.Empty(t, ingredientUnits)
 }
\ No newline at end of file
@@ -21,7 +21,7 @@ INSERT INTO users (
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING id, username, email, password, created_at, role, verified_at
+RETURNING id, username, email, password, created_at, role, verified_at, expiry_alert_days
 `
 
 type CreateUserParams struct {
@@ -47,6 +47,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.Role,
 		&i.VerifiedAt,
+		&i.ExpiryAlertDays,
 	)
 	return i, err
 }
@@ -62,7 +63,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getLogin = `-- name: GetLogin :one
-SELECT id, username, email, password, created_at, role, verified_at from users
+SELECT id, username, email, password, created_at, role, verified_at, expiry_alert_days from users
 WHERE username = $1 LIMIT 1
 FOR SHARE
 `
@@ -78,6 +79,7 @@ func (q *Queries) GetLogin(ctx context.Context, username string) (User, error) {
 		&i.CreatedAt,
 		&i.Role,
 		&i.VerifiedAt,
+		&i.ExpiryAlertDays,
 	)
 	return i, err
 }
@@ -101,7 +103,7 @@ func (q *Queries) GetPermission(ctx context.Context, id uuid.UUID) (GetPermissio
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, email, password, created_at, role, verified_at from users
+SELECT id, username, email, password, created_at, role, verified_at, expiry_alert_days from users
 WHERE id = $1 LIMIT 1
 `
 
@@ -116,12 +118,13 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.Role,
 		&i.VerifiedAt,
+		&i.ExpiryAlertDays,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, username, email, password, created_at, role, verified_at from users
+SELECT id, username, email, password, created_at, role, verified_at, expiry_alert_days from users
 ORDER BY username
 LIMIT $1
 OFFSET $2
@@ -149,6 +152,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.CreatedAt,
 			&i.Role,
 			&i.VerifiedAt,
+			&i.ExpiryAlertDays,
 		); err != nil {
 			return nil, err
 		}
@@ -163,6 +167,34 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const updateExpiryAlert = `-- name: UpdateExpiryAlert :one
+UPDATE users
+  set expiry_alert_days = $2
+WHERE id = $1
+RETURNING id, username, email, password, created_at, role, verified_at, expiry_alert_days
+`
+
+type UpdateExpiryAlertParams struct {
+	ID              uuid.UUID     `json:"id"`
+	ExpiryAlertDays sql.NullInt32 `json:"expiryAlertDays"`
+}
+
+func (q *Queries) UpdateExpiryAlert(ctx context.Context, arg UpdateExpiryAlertParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateExpiryAlert, arg.ID, arg.ExpiryAlertDays)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.CreatedAt,
+		&i.Role,
+		&i.VerifiedAt,
+		&i.ExpiryAlertDays,
+	)
+	return i, err
+}
+
 const updatePassword = `-- name: UpdatePassword :one
 UPDATE users
   set password = $2
@@ -192,7 +224,7 @@ UPDATE users
   set username = $2,
   email = $3
 WHERE id = $1
-RETURNING id, username, email, password, created_at, role, verified_at
+RETURNING id, username, email, password, created_at, role, verified_at, expiry_alert_days
 `
 
 type UpdateUserParams struct {
@@ -212,6 +244,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.Role,
 		&i.VerifiedAt,
+		&i.ExpiryAlertDays,
 	)
 	return i, err
 }
@@ -220,7 +253,7 @@ const updateVerified = `-- name: UpdateVerified :one
 UPDATE users
   set verified_at = $2
 WHERE id = $1
-RETURNING id, username, email, password, created_at, role, verified_at
+RETURNING id, username, email, password, created_at, role, verified_at, expiry_alert_days
 `
 
 type UpdateVerifiedParams struct {
@@ -239,6 +272,7 @@ func (q *Queries) UpdateVerified(ctx context.Context, arg UpdateVerifiedParams)
 		&i.CreatedAt,
 		&i.Role,
 		&i.VerifiedAt,
+		&i.ExpiryAlertDays,
 	)
 	return i, err
 }
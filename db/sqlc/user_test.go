@@ -185,6 +185,23 @@ func TestUpdateVerified(t *testing.T) {
 
 }
 
+func TestUpdateExpiryAlert(t *testing.T) {
+	user := CreateRandomUser(t)
+	require.False(t, user.ExpiryAlertDays.Valid)
+
+	arg := UpdateExpiryAlertParams{
+		ID:              user.ID,
+		ExpiryAlertDays: sql.NullInt32{Int32: 3, Valid: true},
+	}
+	updated, err := testQueries.UpdateExpiryAlert(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ExpiryAlertDays, updated.ExpiryAlertDays)
+
+	updated, err = testQueries.UpdateExpiryAlert(context.Background(), UpdateExpiryAlertParams{ID: user.ID})
+	require.NoError(t, err)
+	require.False(t, updated.ExpiryAlertDays.Valid)
+}
+
 func TestUpdatePassword(t *testing.T) {
 	userNew := CreateRandomUser(t)
 
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+)
+
+// Length of a meal whose recipe has neither a total time nor timed steps
+const defaultMealDuration = time.Hour
+
+// Longest content line of RFC 5545 in octets, longer lines are folded
+const icalLineLength = 75
+
+type CalendarOptions struct {
+	// Name calendar apps show for the feed
+	Name string
+	// Time of day the meals start. Events use floating times, so it is the same
+	// local time in every time zone the calendar is viewed in
+	MealTime time.Duration
+	// Absolute URL of the API the recipe links point to, e.g. https://example.com
+	BaseURL string
+}
+
+// iCalendar (RFC 5545) document with an event for every dated schedule entry
+func Calendar(entries []db.CalendarEntry, options CalendarOptions) []byte {
+	var b strings.Builder
+
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//grocery-planner//meal plan//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	if options.Name != "" {
+		writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(options.Name))
+	}
+
+	for _, entry := range entries {
+		if !entry.ScheduledDate.Valid {
+			continue
+		}
+
+		date := entry.ScheduledDate.Time
+		start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Add(options.MealTime)
+		link := fmt.Sprintf("%s/recipe/%d", strings.TrimSuffix(options.BaseURL, "/"), entry.RecipeID)
+
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, fmt.Sprintf("UID:schedule-%d-recipe-%d-%s@grocery-planner", entry.ScheduleID, entry.RecipeID, date.Format("20060102")))
+		writeICalLine(&b, "DTSTAMP:"+entry.CreatedAt.UTC().Format("20060102T150405Z"))
+		writeICalLine(&b, "DTSTART:"+start.Format("20060102T150405"))
+		writeICalLine(&b, "DURATION:"+icalDuration(entryDuration(entry)))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(entry.Name))
+		writeICalLine(&b, "URL:"+link)
+		writeICalLine(&b, "DESCRIPTION:"+escapeICalText(entryDescription(entry, link)))
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// Recipe link and the ingredients for the scheduled portions
+func entryDescription(entry db.CalendarEntry, link string) string {
+	lines := []string{link}
+	if len(entry.Ingredients) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Ingredients for %d:", entry.Portion))
+		for _, ingredient := range entry.Ingredients {
+			lines = append(lines, "- "+ingredientLine(ingredient.Quantity.Amount, ingredient.Quantity.Unit, ingredient.Name))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Total time of the recipe, or the time of its steps rounded up to minutes
+func entryDuration(entry db.CalendarEntry) time.Duration {
+	if entry.TotalTime.Valid && entry.TotalTime.Int32 > 0 {
+		return time.Duration(entry.TotalTime.Int32) * time.Minute
+	}
+	if entry.StepsSeconds > 0 {
+		return (time.Duration(entry.StepsSeconds)*time.Second + time.Minute - 1).Truncate(time.Minute)
+	}
+
+	return defaultMealDuration
+}
+
+// "PT1H30M"
+func icalDuration(d time.Duration) string {
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	s := "PT"
+	if hours > 0 {
+		s += fmt.Sprintf("%dH", hours)
+	}
+	if minutes > 0 || hours == 0 {
+		s += fmt.Sprintf("%dM", minutes)
+	}
+
+	return s
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Escape a TEXT value, line breaks become \n and other control characters
+// except tabs are dropped since they are not allowed in content lines
+func escapeICalText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, icalEscaper.Replace(s))
+}
+
+// Write a content line ending in CRLF, folded after 75 octets without splitting
+// a character. Continuation lines start with a space
+func writeICalLine(b *strings.Builder, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts towards the length
+		limit = icalLineLength - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
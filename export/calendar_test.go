@@ -0,0 +1,108 @@
+package export
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/stretchr/testify/require"
+)
+
+func testCalendarEntry() db.CalendarEntry {
+	return db.CalendarEntry{
+		ListCalendarEntriesRow: db.ListCalendarEntriesRow{
+			ScheduleID:    12,
+			RecipeID:      3,
+			Name:          "Soup, tomato; spicy",
+			Portion:       4,
+			ScheduledDate: sql.NullTime{Time: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Valid: true},
+			TotalTime:     sql.NullInt32{Int32: 90, Valid: true},
+			CreatedAt:     time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		},
+		Ingredients: []db.CalendarIngredient{
+			{Name: "onion", Quantity: measure.Quantity{Amount: 2, Unit: "cup"}},
+			{Name: "eggs", Quantity: measure.Quantity{Amount: 3, Unit: "piece"}},
+		},
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	undated := testCalendarEntry()
+	undated.ScheduledDate = sql.NullTime{}
+
+	data := Calendar([]db.CalendarEntry{testCalendarEntry(), undated}, CalendarOptions{
+		Name:     "Meal plan",
+		MealTime: 18*time.Hour + 30*time.Minute,
+		BaseURL:  "https://example.com/",
+	})
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//grocery-planner//meal plan//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Meal plan",
+		"BEGIN:VEVENT",
+		"UID:schedule-12-recipe-3-20261019@grocery-planner",
+		"DTSTAMP:20261018T093000Z",
+		"DTSTART:20261019T183000",
+		"DURATION:PT1H30M",
+		`SUMMARY:Soup\, tomato\; spicy`,
+		"URL:https://example.com/recipe/3",
+		`DESCRIPTION:https://example.com/recipe/3\n\nIngredients for 4:\n- 2 cup oni`,
+		` on\n- 3 eggs`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	require.Equal(t, want, string(data))
+}
+
+func TestEntryDuration(t *testing.T) {
+	entry := testCalendarEntry()
+	require.Equal(t, 90*time.Minute, entryDuration(entry))
+
+	entry.TotalTime = sql.NullInt32{}
+	entry.StepsSeconds = 1210
+	require.Equal(t, 21*time.Minute, entryDuration(entry))
+
+	entry.StepsSeconds = 0
+	require.Equal(t, defaultMealDuration, entryDuration(entry))
+
+	require.Equal(t, "PT45M", icalDuration(45*time.Minute))
+	require.Equal(t, "PT2H", icalDuration(2*time.Hour))
+	require.Equal(t, "PT0M", icalDuration(0))
+}
+
+func TestEscapeICalText(t *testing.T) {
+	require.Equal(t, `a\, b\; c\\d`, escapeICalText(`a, b; c\d`))
+	require.Equal(t, `one\ntwo\nthree\nfour`, escapeICalText("one\r\ntwo\nthree\rfour"))
+	require.Equal(t, "tab\tbell", escapeICalText("tab\tbe\x07ll\x00"))
+
+	var b strings.Builder
+	writeICalLine(&b, "SUMMARY:"+escapeICalText("soup\rBEGIN:VALARM"))
+	require.Equal(t, "SUMMARY:soup\\nBEGIN:VALARM\r\n", b.String())
+}
+
+func TestWriteICalLine(t *testing.T) {
+	var b strings.Builder
+	line := "SUMMARY:" + strings.Repeat("é", 50)
+	writeICalLine(&b, line)
+
+	folded := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(folded), 1)
+	for i, part := range folded {
+		require.LessOrEqual(t, len(part), icalLineLength)
+		require.True(t, strings.ToValidUTF8(part, "") == part)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(part, " "))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(b.String(), "\r\n"), "\r\n ", "")
+	require.Equal(t, line, unfolded)
+}
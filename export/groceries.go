@@ -0,0 +1,317 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"strings"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+// Heading of the groceries without a category
+const otherCategory = "Other"
+
+var groceriesHTML = template.Must(template.New("groceries").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; padding: 0 1em; }
+h2 { font-size: 1.1em; border-bottom: 1px solid #ccc; margin-bottom: 0.3em; }
+ul { list-style: none; padding: 0; margin: 0; }
+li { padding: 0.2em 0; }
+.dates, .cost { color: #555; }
+@media print {
+  @page { margin: 1.5cm; }
+  body { max-width: none; margin: 0; padding: 0; font-size: 11pt; }
+  main { columns: 2; column-gap: 2em; }
+  section { break-inside: avoid; }
+  h2 { border-bottom-color: #000; }
+  input[type=checkbox] { appearance: none; width: 0.9em; height: 0.9em; border: 1px solid #000; margin: 0 0.4em 0 0; vertical-align: middle; }
+  .dates, .cost { color: #000; }
+}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Dates}}
+<p class="dates">{{.Dates}}</p>
+{{- end}}
+<main>
+{{- range .Groups}}
+<section>
+<h2>{{.Name}}</h2>
+<ul>
+{{- range .Lines}}
+<li><label><input type="checkbox"> {{.}}</label></li>
+{{- end}}
+</ul>
+</section>
+{{- end}}
+</main>
+{{- if .Cost}}
+<p class="cost">{{.Cost}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+type groceryGroup struct {
+	Name  string
+	Items []db.GroceryItem
+}
+
+// Export the grocery list of a schedule in one of the formats
+func Groceries(groceries db.ScheduleGroceriesResult, format string) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		return GroceriesCSV(groceries)
+	case FormatMarkdown:
+		return []byte(GroceriesMarkdown(groceries)), nil
+	case FormatText:
+		return []byte(GroceriesText(groceries)), nil
+	case FormatHTML:
+		return GroceriesHTML(groceries)
+	}
+
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// One row per quantity of every grocery line, packages and cost are on the
+// first row of a line
+func GroceriesCSV(groceries db.ScheduleGroceriesResult) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+
+	err := w.Write([]string{"category", "ingredient", "amount", "unit", "packages", "package_amount", "package_unit", "cost"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groceryGroups(groceries) {
+		for _, item := range group.Items {
+			for i, quantity := range item.Quantities {
+				record := []string{group.Name, item.Name, formatAmount(quantity.Amount), quantity.Unit, "", "", "", ""}
+				if i == 0 && item.Purchase != nil {
+					record[4] = fmt.Sprint(item.Purchase.Count)
+					record[5] = formatAmount(item.Purchase.Size.Amount)
+					record[6] = item.Purchase.Size.Unit
+				}
+				if i == 0 && item.Cost != nil {
+					record[7] = fmt.Sprintf("%.2f", *item.Cost)
+				}
+
+				for j := range record {
+					record[j] = csvCell(record[j])
+				}
+
+				err = w.Write(record)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// Spreadsheets run cells starting with these as formulas
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// Prefix a cell that would start a formula with a quote so it is shown as text
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// Markdown checklist with a section per category
+func GroceriesMarkdown(groceries db.ScheduleGroceriesResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", groceriesTitle(groceries))
+	if dates := scheduleDates(groceries.Recipes); dates != "" {
+		fmt.Fprintf(&b, "\n%s\n", dates)
+	}
+
+	for _, group := range groceryGroups(groceries) {
+		fmt.Fprintf(&b, "\n## %s\n\n", group.Name)
+		for _, item := range group.Items {
+			fmt.Fprintf(&b, "- [ ] %s\n", groceryLine(item))
+		}
+	}
+
+	if cost := costLine(groceries.Cost); cost != "" {
+		fmt.Fprintf(&b, "\n%s\n", cost)
+	}
+
+	return b.String()
+}
+
+// Plain text with boxes to tick, for pasting into note apps
+func GroceriesText(groceries db.ScheduleGroceriesResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n", strings.ToUpper(groceriesTitle(groceries)))
+	if dates := scheduleDates(groceries.Recipes); dates != "" {
+		fmt.Fprintf(&b, "%s\n", dates)
+	}
+
+	for _, group := range groceryGroups(groceries) {
+		fmt.Fprintf(&b, "\n%s:\n", group.Name)
+		for _, item := range group.Items {
+			fmt.Fprintf(&b, "[ ] %s\n", groceryLine(item))
+		}
+	}
+
+	if cost := costLine(groceries.Cost); cost != "" {
+		fmt.Fprintf(&b, "\n%s\n", cost)
+	}
+
+	return b.String()
+}
+
+// Standalone page with checkboxes and a print stylesheet putting the categories
+// in two columns
+func GroceriesHTML(groceries db.ScheduleGroceriesResult) ([]byte, error) {
+	type htmlGroup struct {
+		Name  string
+		Lines []string
+	}
+
+	groups := []htmlGroup{}
+	for _, group := range groceryGroups(groceries) {
+		lines := make([]string, len(group.Items))
+		for i, item := range group.Items {
+			lines[i] = groceryLine(item)
+		}
+		groups = append(groups, htmlGroup{Name: group.Name, Lines: lines})
+	}
+
+	var b bytes.Buffer
+	err := groceriesHTML.Execute(&b, struct {
+		Title  string
+		Dates  string
+		Groups []htmlGroup
+		Cost   string
+	}{
+		Title:  groceriesTitle(groceries),
+		Dates:  scheduleDates(groceries.Recipes),
+		Groups: groups,
+		Cost:   costLine(groceries.Cost),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// Groceries grouped by category in store order, lines without a known category last
+func groceryGroups(groceries db.ScheduleGroceriesResult) []groceryGroup {
+	byCategory := map[string][]db.GroceryItem{}
+	known := map[string]bool{}
+	for _, category := range groceries.Categories {
+		known[category.Name] = true
+	}
+
+	var other []db.GroceryItem
+	for _, item := range groceries.Groceries {
+		if !known[item.Category] {
+			other = append(other, item)
+			continue
+		}
+		byCategory[item.Category] = append(byCategory[item.Category], item)
+	}
+
+	groups := []groceryGroup{}
+	for _, category := range groceries.Categories {
+		if items := byCategory[category.Name]; len(items) > 0 {
+			groups = append(groups, groceryGroup{Name: categoryHeading(category.Name), Items: items})
+		}
+	}
+	if len(other) > 0 {
+		groups = append(groups, groceryGroup{Name: otherCategory, Items: other})
+	}
+
+	return groups
+}
+
+func categoryHeading(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func groceriesTitle(groceries db.ScheduleGroceriesResult) string {
+	return fmt.Sprintf("Groceries for schedule %d", groceries.Schedule.ID)
+}
+
+// "2026-10-19 to 2026-10-25", empty when no recipe is scheduled on a day
+func scheduleDates(recipes []db.GetScheduleRecipeRow) string {
+	var first, last string
+	for _, recipe := range recipes {
+		if !recipe.ScheduledDate.Valid {
+			continue
+		}
+
+		day := recipe.ScheduledDate.Time.Format("2006-01-02")
+		if first == "" || day < first {
+			first = day
+		}
+		if day > last {
+			last = day
+		}
+	}
+
+	if first == "" {
+		return ""
+	}
+	if first == last {
+		return first
+	}
+
+	return first + " to " + last
+}
+
+// "2 cup + 300 g onion (2 × 400 g)", the packages to buy in brackets
+func groceryLine(item db.GroceryItem) string {
+	amounts := make([]string, len(item.Quantities))
+	for i, quantity := range item.Quantities {
+		amounts[i] = quantityText(quantity)
+	}
+
+	line := item.Name
+	if len(amounts) > 0 {
+		line = strings.Join(amounts, " + ") + " " + item.Name
+	}
+	if item.Purchase != nil && item.Purchase.Count > 0 {
+		line += fmt.Sprintf(" (%d × %s)", item.Purchase.Count, quantityText(item.Purchase.Size))
+	}
+
+	return line
+}
+
+// Amount and unit, counted quantities leave the unit out
+func quantityText(quantity measure.Quantity) string {
+	text := formatAmount(quantity.Amount)
+	if canonical, ok := measure.UnitName(quantity.Unit); quantity.Unit != "" && (!ok || canonical != "piece") {
+		text += " " + quantity.Unit
+	}
+
+	return text
+}
+
+func costLine(cost db.GroceryCost) string {
+	if cost.Total <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Estimated cost: %.2f", cost.Total)
+}
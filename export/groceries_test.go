@@ -0,0 +1,148 @@
+package export
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
+	"github.com/stretchr/testify/require"
+)
+
+func testGroceries() db.ScheduleGroceriesResult {
+	cost := 3.5
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	return db.ScheduleGroceriesResult{
+		Schedule: db.Schedule{ID: 12},
+		Recipes: []db.GetScheduleRecipeRow{
+			{RecipeID: 1, ScheduledDate: sql.NullTime{Time: monday.AddDate(0, 0, 2), Valid: true}},
+			{RecipeID: 2, ScheduledDate: sql.NullTime{Time: monday, Valid: true}},
+			{RecipeID: 3},
+		},
+		Groceries: []db.GroceryItem{
+			{ID: 1, Name: "eggs", Category: "dairy & eggs", Quantities: []measure.Quantity{{Amount: 6, Unit: "piece"}}},
+			{ID: 2, Name: "onion", Category: "produce", Quantities: []measure.Quantity{{Amount: 2, Unit: "cup"}, {Amount: 300, Unit: "g"}}},
+			{ID: 3, Name: "salt", Quantities: []measure.Quantity{{Amount: 1, Unit: "tsp"}}},
+			{
+				ID:         4,
+				Name:       "tomatoes",
+				Category:   "produce",
+				Quantities: []measure.Quantity{{Amount: 800, Unit: "g"}},
+				Cost:       &cost,
+				Purchase: &db.GroceryPurchase{
+					PackageID: 1,
+					Size:      measure.Quantity{Amount: 400, Unit: "g"},
+					Count:     2,
+				},
+			},
+		},
+		Cost: db.GroceryCost{Total: cost},
+		Categories: []db.GroceryCategory{
+			{Name: "produce", Position: 1},
+			{Name: "dairy & eggs", Position: 4},
+			{Name: "pantry", Position: 6},
+		},
+	}
+}
+
+func TestGroceriesMarkdown(t *testing.T) {
+	want := `# Groceries for schedule 12
+
+2026-10-19 to 2026-10-21
+
+## Produce
+
+- [ ] 2 cup + 300 g onion
+- [ ] 800 g tomatoes (2 × 400 g)
+
+## Dairy & eggs
+
+- [ ] 6 eggs
+
+## Other
+
+- [ ] 1 tsp salt
+
+Estimated cost: 3.50
+`
+	require.Equal(t, want, GroceriesMarkdown(testGroceries()))
+}
+
+func TestGroceriesText(t *testing.T) {
+	groceries := testGroceries()
+	groceries.Recipes = nil
+	groceries.Cost = db.GroceryCost{}
+
+	want := `GROCERIES FOR SCHEDULE 12
+
+Produce:
+[ ] 2 cup + 300 g onion
+[ ] 800 g tomatoes (2 × 400 g)
+
+Dairy & eggs:
+[ ] 6 eggs
+
+Other:
+[ ] 1 tsp salt
+`
+	require.Equal(t, want, GroceriesText(groceries))
+}
+
+func TestGroceriesCSV(t *testing.T) {
+	data, err := Groceries(testGroceries(), FormatCSV)
+	require.NoError(t, err)
+
+	want := `category,ingredient,amount,unit,packages,package_amount,package_unit,cost
+Produce,onion,2,cup,,,,
+Produce,onion,300,g,,,,
+Produce,tomatoes,800,g,2,400,g,3.50
+Dairy & eggs,eggs,6,piece,,,,
+Other,salt,1,tsp,,,,
+`
+	require.Equal(t, want, string(data))
+}
+
+func TestGroceriesCSVFormulas(t *testing.T) {
+	groceries := testGroceries()
+	groceries.Groceries[0].Name = "=HYPERLINK(\"http://example.com\")"
+	groceries.Groceries[1].Name = "@SUM(A1:A2)"
+	groceries.Groceries[1].Quantities[0].Unit = "+cmd"
+	groceries.Groceries[2].Name = "-salt"
+
+	data, err := Groceries(groceries, FormatCSV)
+	require.NoError(t, err)
+
+	want := `category,ingredient,amount,unit,packages,package_amount,package_unit,cost
+Produce,'@SUM(A1:A2),2,'+cmd,,,,
+Produce,'@SUM(A1:A2),300,g,,,,
+Produce,tomatoes,800,g,2,400,g,3.50
+Dairy & eggs,"'=HYPERLINK(""http://example.com"")",6,piece,,,,
+Other,'-salt,1,tsp,,,,
+`
+	require.Equal(t, want, string(data))
+}
+
+func TestGroceriesHTML(t *testing.T) {
+	groceries := testGroceries()
+	groceries.Groceries[2].Name = "<b>salt</b>"
+
+	data, err := Groceries(groceries, FormatHTML)
+	require.NoError(t, err)
+
+	page := string(data)
+	require.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	require.Contains(t, page, "<title>Groceries for schedule 12</title>")
+	require.Contains(t, page, "@media print")
+	require.Contains(t, page, `<li><label><input type="checkbox"> 800 g tomatoes (2 × 400 g)</label></li>`)
+	require.Contains(t, page, "&lt;b&gt;salt&lt;/b&gt;")
+	require.NotContains(t, page, "<b>salt</b>")
+	require.Less(t, strings.Index(page, "<h2>Produce</h2>"), strings.Index(page, "<h2>Dairy &amp; eggs</h2>"))
+	require.Less(t, strings.Index(page, "<h2>Dairy &amp; eggs</h2>"), strings.Index(page, "<h2>Other</h2>"))
+	require.NotContains(t, page, "<h2>Pantry</h2>")
+
+	_, err = Groceries(groceries, FormatJSONLD)
+	require.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+const (
+	FormatJSONLD   = "jsonld"
+	FormatMarkdown = "markdown"
+	FormatText     = "txt"
+	FormatCSV      = "csv"
+	FormatHTML     = "html"
+	FormatICal     = "ics"
+)
+
+// Content types of the export formats
+var ContentTypes = map[string]string{
+	FormatJSONLD:   "application/ld+json; charset=utf-8",
+	FormatMarkdown: "text/markdown; charset=utf-8",
+	FormatText:     "text/plain; charset=utf-8",
+	FormatCSV:      "text/csv; charset=utf-8",
+	FormatHTML:     "text/html; charset=utf-8",
+	FormatICal:     "text/calendar; charset=utf-8",
+}
+
+// schema.org diets for the diet tags that have one
+var schemaDiets = map[string]string{
+	"vegan":      "https://schema.org/VeganDiet",
+	"vegetarian": "https://schema.org/VegetarianDiet",
+	"halal":      "https://schema.org/HalalDiet",
+}
+
+type howToStep struct {
+	Type     string `json:"@type"`
+	Position int32  `json:"position"`
+	Text     string `json:"text"`
+}
+
+type nutritionInformation struct {
+	Type                string `json:"@type"`
+	ServingSize         string `json:"servingSize"`
+	Calories            string `json:"calories"`
+	ProteinContent      string `json:"proteinContent"`
+	FatContent          string `json:"fatContent"`
+	CarbohydrateContent string `json:"carbohydrateContent"`
+	FiberContent        string `json:"fiberContent"`
+	SodiumContent       string `json:"sodiumContent"`
+}
+
+type jsonLDRecipe struct {
+	Context            string                `json:"@context"`
+	Type               string                `json:"@type"`
+	Name               string                `json:"name"`
+	RecipeYield        string                `json:"recipeYield"`
+	DatePublished      string                `json:"datePublished"`
+	DateModified       string                `json:"dateModified"`
+	RecipeIngredient   []string              `json:"recipeIngredient"`
+	RecipeInstructions []howToStep           `json:"recipeInstructions"`
+	Nutrition          *nutritionInformation `json:"nutrition,omitempty"`
+	SuitableForDiet    []string              `json:"suitableForDiet,omitempty"`
+}
+
+type step struct {
+	text     string
+	duration int32
+}
+
+// Export a recipe in one of the formats
+func Recipe(recipe db.RecipeResult, format string) ([]byte, error) {
+	switch format {
+	case FormatJSONLD:
+		return RecipeJSONLD(recipe)
+	case FormatMarkdown:
+		return []byte(RecipeMarkdown(recipe)), nil
+	case FormatText:
+		return []byte(RecipeText(recipe)), nil
+	}
+
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// schema.org Recipe JSON-LD document, ready to be embedded as rich snippet
+func RecipeJSONLD(recipe db.RecipeResult) ([]byte, error) {
+	doc := jsonLDRecipe{
+		Context:            "https://schema.org",
+		Type:               "Recipe",
+		Name:               recipe.Recipe.Name,
+		RecipeYield:        strconv.Itoa(int(recipe.Recipe.Portion)),
+		DatePublished:      recipe.Recipe.CreatedAt.Format(time.RFC3339),
+		DateModified:       recipe.Recipe.ModifiedAt.Format(time.RFC3339),
+		RecipeIngredient:   ingredientLines(recipe.Ingredients),
+		RecipeInstructions: []howToStep{},
+	}
+
+	for i, s := range recipeSteps(recipe) {
+		doc.RecipeInstructions = append(doc.RecipeInstructions, howToStep{
+			Type:     "HowToStep",
+			Position: int32(i + 1),
+			Text:     s.text,
+		})
+	}
+
+	// partial or empty totals would understate the recipe, so they are left out
+	if recipe.Nutrition != nil && len(recipe.Nutrition.Missing) == 0 && recipe.Nutrition.Total != (db.NutritionFacts{}) {
+		facts := recipe.Nutrition.PerPortion
+		doc.Nutrition = &nutritionInformation{
+			Type:                "NutritionInformation",
+			ServingSize:         "1 portion",
+			Calories:            fmt.Sprintf("%.0f calories", facts.Calories),
+			ProteinContent:      fmt.Sprintf("%.1f g", facts.Protein),
+			FatContent:          fmt.Sprintf("%.1f g", facts.Fat),
+			CarbohydrateContent: fmt.Sprintf("%.1f g", facts.Carbs),
+			FiberContent:        fmt.Sprintf("%.1f g", facts.Fiber),
+			SodiumContent:       fmt.Sprintf("%.0f mg", facts.Sodium),
+		}
+	}
+
+	if recipe.Tags != nil {
+		for _, diet := range recipe.Tags.Diets {
+			if url, ok := schemaDiets[diet]; ok {
+				doc.SuitableForDiet = append(doc.SuitableForDiet, url)
+			}
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Markdown document with the ingredients as list and the steps numbered
+func RecipeMarkdown(recipe db.RecipeResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", recipe.Recipe.Name)
+	fmt.Fprintf(&b, "Serves %d\n\n", recipe.Recipe.Portion)
+
+	b.WriteString("## Ingredients\n\n")
+	for _, line := range ingredientLines(recipe.Ingredients) {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+
+	steps := recipeSteps(recipe)
+	if len(steps) > 0 {
+		b.WriteString("\n## Steps\n\n")
+		for i, s := range steps {
+			fmt.Fprintf(&b, "%d. %s%s\n", i+1, s.text, durationSuffix(s.duration))
+		}
+	}
+
+	return b.String()
+}
+
+// Plain text for pasting into chats
+func RecipeText(recipe db.RecipeResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n", strings.ToUpper(recipe.Recipe.Name))
+	fmt.Fprintf(&b, "Serves %d\n\n", recipe.Recipe.Portion)
+
+	b.WriteString("Ingredients:\n")
+	for _, line := range ingredientLines(recipe.Ingredients) {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+
+	steps := recipeSteps(recipe)
+	if len(steps) > 0 {
+		b.WriteString("\nSteps:\n")
+		for i, s := range steps {
+			fmt.Fprintf(&b, "%d. %s%s\n", i+1, s.text, durationSuffix(s.duration))
+		}
+	}
+
+	return b.String()
+}
+
+func ingredientLines(ingredients []db.GetRecipeIngredientsRow) []string {
+	lines := make([]string, len(ingredients))
+	for i, ingredient := range ingredients {
+		lines[i] = ingredientLine(float64(ingredient.Amount), ingredient.UnitName.String, ingredient.Name)
+	}
+
+	return lines
+}
+
+// "2 cup onion", counted ingredients leave the unit out ("3 eggs")
+func ingredientLine(amount float64, unit, name string) string {
+	return quantityText(measure.Quantity{Amount: amount, Unit: unit}) + " " + name
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 32)
+}
+
+// Structured steps, or the lines of the free text steps for recipes without them
+func recipeSteps(recipe db.RecipeResult) []step {
+	steps := []step{}
+	if len(recipe.Steps) > 0 {
+		for _, s := range recipe.Steps {
+			steps = append(steps, step{text: s.Text, duration: s.DurationSeconds.Int32})
+		}
+
+		return steps
+	}
+
+	for _, line := range strings.Split(recipe.Recipe.Steps.String, "\n") {
+		if text := strings.TrimSpace(line); text != "" {
+			steps = append(steps, step{text: text})
+		}
+	}
+
+	return steps
+}
+
+func durationSuffix(seconds int32) string {
+	if seconds <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s)", time.Duration(seconds)*time.Second)
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecipe() db.RecipeResult {
+	created := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	return db.RecipeResult{
+		Recipe: db.Recipe{
+			ID:         1,
+			Name:       "Tomato Soup",
+			Portion:    4,
+			CreatedAt:  created,
+			ModifiedAt: created,
+		},
+		Ingredients: []db.GetRecipeIngredientsRow{
+			{IngredientID: 1, Name: "onion", Amount: 2, UnitName: sql.NullString{String: "cup", Valid: true}},
+			{IngredientID: 2, Name: "tomatoes", Amount: 800, UnitName: sql.NullString{String: "g", Valid: true}},
+			{IngredientID: 3, Name: "eggs", Amount: 1.5, UnitName: sql.NullString{String: "pcs", Valid: true}},
+		},
+		Steps: []db.RecipeStep{
+			{ID: 1, Position: 1, Text: "Fry the onion.", DurationSeconds: sql.NullInt32{Int32: 300, Valid: true}},
+			{ID: 2, Position: 2, Text: "Add the tomatoes."},
+		},
+		Tags: &db.RecipeTags{
+			Allergens: []string{"egg"},
+			Diets:     []string{"vegetarian", "halal"},
+		},
+	}
+}
+
+func TestRecipeMarkdown(t *testing.T) {
+	want := `# Tomato Soup
+
+Serves 4
+
+## Ingredients
+
+- 2 cup onion
+- 800 g tomatoes
+- 1.5 eggs
+
+## Steps
+
+1. Fry the onion. (5m0s)
+2. Add the tomatoes.
+`
+	require.Equal(t, want, RecipeMarkdown(testRecipe()))
+}
+
+func TestRecipeText(t *testing.T) {
+	recipe := testRecipe()
+	recipe.Steps = nil
+	recipe.Recipe.Steps = sql.NullString{String: "Fry the onion.\n\nAdd the tomatoes.", Valid: true}
+
+	want := `TOMATO SOUP
+Serves 4
+
+Ingredients:
+- 2 cup onion
+- 800 g tomatoes
+- 1.5 eggs
+
+Steps:
+1. Fry the onion.
+2. Add the tomatoes.
+`
+	require.Equal(t, want, RecipeText(recipe))
+}
+
+func TestRecipeJSONLD(t *testing.T) {
+	data, err := Recipe(testRecipe(), FormatJSONLD)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Equal(t, "https://schema.org", doc["@context"])
+	require.Equal(t, "Recipe", doc["@type"])
+	require.Equal(t, "Tomato Soup", doc["name"])
+	require.Equal(t, "4", doc["recipeYield"])
+	require.Equal(t, []interface{}{"2 cup onion", "800 g tomatoes", "1.5 eggs"}, doc["recipeIngredient"])
+	require.Len(t, doc["recipeInstructions"], 2)
+	require.Equal(t, []interface{}{"https://schema.org/VegetarianDiet", "https://schema.org/HalalDiet"}, doc["suitableForDiet"])
+	require.NotContains(t, doc, "nutrition")
+
+	_, err = Recipe(testRecipe(), "pdf")
+	require.Error(t, err)
+}
+
+func TestRecipeJSONLDNutrition(t *testing.T) {
+	recipe := testRecipe()
+	recipe.Nutrition = &db.RecipeNutrition{
+		Total:      db.NutritionFacts{Calories: 800, Protein: 20, Sodium: 1200},
+		PerPortion: db.NutritionFacts{Calories: 200, Protein: 5, Sodium: 300},
+		Missing:    []int32{},
+	}
+
+	data, err := Recipe(recipe, FormatJSONLD)
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Contains(t, doc, "nutrition")
+	nutrition := doc["nutrition"].(map[string]interface{})
+	require.Equal(t, "200 calories", nutrition["calories"])
+	require.Equal(t, "300 mg", nutrition["sodiumContent"])
+
+	// Ingredients without nutrition data
+	recipe.Nutrition.Missing = []int32{3}
+	data, err = Recipe(recipe, FormatJSONLD)
+	require.NoError(t, err)
+	doc = nil
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.NotContains(t, doc, "nutrition")
+
+	// No nutrition data at all
+	recipe.Nutrition = &db.RecipeNutrition{Missing: []int32{}}
+	data, err = Recipe(recipe, FormatJSONLD)
+	require.NoError(t, err)
+	doc = nil
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.NotContains(t, doc, "nutrition")
+}
@@ -0,0 +1,117 @@
+package fdc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	foodFile         = "food.csv"
+	foodNutrientFile = "food_nutrient.csv"
+)
+
+// Read foods from an extracted FoodData Central CSV dump directory,
+// only food.csv and food_nutrient.csv are used
+func ReadCSV(dir string) ([]Food, error) {
+	foods := []Food{}
+	index := map[int64]int{}
+
+	err := readCSVFile(filepath.Join(dir, foodFile), []string{"fdc_id", "description"}, func(record []string) error {
+		id, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		index[id] = len(foods)
+		foods = append(foods, Food{
+			FdcID:       id,
+			Description: record[1],
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readCSVFile(filepath.Join(dir, foodNutrientFile), []string{"fdc_id", "nutrient_id", "amount"}, func(record []string) error {
+		id, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		i, ok := index[id]
+		if !ok {
+			return nil
+		}
+		nutrientID, err := strconv.ParseInt(record[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		// some rows are reported without amount
+		if record[2] == "" {
+			return nil
+		}
+		amount, err := strconv.ParseFloat(record[2], 32)
+		if err != nil {
+			return err
+		}
+		foods[i].setNutrient(nutrientID, float32(amount))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return foods, nil
+}
+
+// Call fn for every record of a CSV file with the values of the requested columns
+func readCSVFile(path string, columns []string, fn func(record []string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	positions := make([]int, len(columns))
+	for i, column := range columns {
+		positions[i] = -1
+		for j, name := range header {
+			if name == column {
+				positions[i] = j
+			}
+		}
+		if positions[i] < 0 {
+			return fmt.Errorf("%s: missing column %q", path, column)
+		}
+	}
+
+	record := make([]string, len(columns))
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for i, p := range positions {
+			record[i] = row[p]
+		}
+		if err := fn(record); err != nil {
+			return fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+	}
+}
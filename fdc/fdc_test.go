@@ -0,0 +1,106 @@
+package fdc
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	foods, err := ReadCSV("testdata")
+	require.NoError(t, err)
+	require.Len(t, foods, 4)
+
+	hummus := foods[0]
+	require.Equal(t, int64(321358), hummus.FdcID)
+	require.Equal(t, "Hummus, commercial", hummus.Description)
+	require.Equal(t, float32(229), hummus.Calories)
+	require.Equal(t, float32(7.35), hummus.Protein)
+	require.Equal(t, float32(17.1), hummus.Fat)
+	require.Equal(t, float32(14.9), hummus.Carbs)
+	require.Equal(t, float32(5.4), hummus.Fiber)
+	require.Equal(t, float32(438), hummus.Sodium)
+
+	// Atwater energy is used when nutrient 1008 is missing
+	require.Equal(t, float32(27), foods[1].Calories)
+	require.Equal(t, float32(148), foods[2].Calories)
+}
+
+func TestReadCSVMissingFile(t *testing.T) {
+	_, err := ReadCSV(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestReadJSON(t *testing.T) {
+	foods, err := ReadJSONFile("testdata/foundation.json")
+	require.NoError(t, err)
+	require.Len(t, foods, 2)
+	require.Equal(t, float32(229), foods[0].Calories)
+	require.Equal(t, float32(438), foods[0].Sodium)
+	require.Equal(t, float32(27), foods[1].Calories)
+
+	// API responses are plain arrays
+	foods, err = ReadJSON(strings.NewReader(`[{"fdcId": 1, "description": "Butter, salted", "foodNutrients": []}]`))
+	require.NoError(t, err)
+	require.Len(t, foods, 1)
+	require.Equal(t, "Butter, salted", foods[0].Description)
+
+	_, err = ReadJSON(strings.NewReader(`"butter"`))
+	require.Error(t, err)
+}
+
+func TestMatchFoods(t *testing.T) {
+	ingredients := []db.Ingredient{
+		{ID: 1, Name: "tomato"},
+		{ID: 2, Name: "egg"},
+		{ID: 3, Name: "chickpea dip"},
+		{ID: 4, Name: "butter"},
+	}
+	aliases := []db.IngredientsAlias{
+		{IngredientID: 3, Alias: "hummus, commercial"},
+	}
+	foods := []Food{
+		{FdcID: 1, Description: "Tomatoes, grape, raw"},
+		{FdcID: 2, Description: "Eggs, Grade A, Large, egg whole"},
+		{FdcID: 3, Description: "Hummus, commercial"},
+		{FdcID: 4, Description: "Dragon fruit, raw"},
+		{FdcID: 5, Description: "Butter, salted"},
+		{FdcID: 6, Description: "Butter"},
+		{FdcID: 7, Description: "Eggs, Grade A, Large, egg white"},
+	}
+
+	report := MatchFoods(foods, ingredients, aliases)
+	require.Len(t, report.Matched, 4)
+
+	matched := map[int32]int64{}
+	for _, m := range report.Matched {
+		matched[m.IngredientID] = m.Food.FdcID
+	}
+	require.Equal(t, int64(1), matched[1])
+	require.Equal(t, int64(2), matched[2])
+	require.Equal(t, int64(3), matched[3])
+	// whole description match wins over the earlier segment match
+	require.Equal(t, int64(6), matched[4])
+
+	require.Len(t, report.Unmatched, 1)
+	require.Equal(t, int64(4), report.Unmatched[0].FdcID)
+
+	require.Len(t, report.Duplicates, 2)
+}
+
+func TestSingularize(t *testing.T) {
+	testCases := map[string]string{
+		"tomatoes": "tomato",
+		"berries":  "berry",
+		"eggs":     "egg",
+		"peaches":  "peach",
+		"grass":    "grass",
+		"rice":     "rice",
+	}
+
+	for word, want := range testCases {
+		require.Equal(t, want, singularize(word))
+	}
+}
@@ -0,0 +1,50 @@
+package fdc
+
+// FoodData Central nutrient ids, the same ids are used by the CSV and JSON dumps
+const (
+	nutrientProtein        = 1003
+	nutrientFat            = 1004
+	nutrientCarbs          = 1005
+	nutrientEnergy         = 1008
+	nutrientFiber          = 1079
+	nutrientSodium         = 1093
+	nutrientEnergyGeneral  = 2047
+	nutrientEnergySpecific = 2048
+)
+
+// Nutrient values of a food per 100 g, sodium in mg
+type Food struct {
+	FdcID       int64   `json:"fdcID"`
+	Description string  `json:"description"`
+	Calories    float32 `json:"calories"`
+	Protein     float32 `json:"protein"`
+	Fat         float32 `json:"fat"`
+	Carbs       float32 `json:"carbs"`
+	Fiber       float32 `json:"fiber"`
+	Sodium      float32 `json:"sodium"`
+
+	// Foundation foods often only report Atwater energy instead of nutrient 1008
+	hasEnergy bool
+}
+
+func (f *Food) setNutrient(id int64, amount float32) {
+	switch id {
+	case nutrientProtein:
+		f.Protein = amount
+	case nutrientFat:
+		f.Fat = amount
+	case nutrientCarbs:
+		f.Carbs = amount
+	case nutrientFiber:
+		f.Fiber = amount
+	case nutrientSodium:
+		f.Sodium = amount
+	case nutrientEnergy:
+		f.Calories = amount
+		f.hasEnergy = true
+	case nutrientEnergyGeneral, nutrientEnergySpecific:
+		if !f.hasEnergy {
+			f.Calories = amount
+		}
+	}
+}
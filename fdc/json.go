@@ -0,0 +1,70 @@
+package fdc
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+)
+
+type jsonFood struct {
+	FdcID         int64  `json:"fdcId"`
+	Description   string `json:"description"`
+	FoodNutrients []struct {
+		Nutrient struct {
+			ID int64 `json:"id"`
+		} `json:"nutrient"`
+		Amount float32 `json:"amount"`
+	} `json:"foodNutrients"`
+}
+
+// Read foods from a FoodData Central JSON dump file
+func ReadJSONFile(path string) ([]Food, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadJSON(file)
+}
+
+// Read foods from a FoodData Central JSON dump. The downloads wrap the foods in an
+// object keyed by data type (FoundationFoods, SRLegacyFoods, ...), a plain array
+// of foods as returned by the API is accepted too.
+func ReadJSON(r io.Reader) ([]Food, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	var items []jsonFood
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var groups map[string][]jsonFood
+		if err := json.Unmarshal(raw, &groups); err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(groups))
+		for key := range groups {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			items = append(items, groups[key]...)
+		}
+	}
+
+	foods := []Food{}
+	for _, item := range items {
+		food := Food{
+			FdcID:       item.FdcID,
+			Description: item.Description,
+		}
+		for _, n := range item.FoodNutrients {
+			food.setNutrient(n.Nutrient.ID, n.Amount)
+		}
+		foods = append(foods, food)
+	}
+
+	return foods, nil
+}
@@ -0,0 +1,137 @@
+package fdc
+
+import (
+	"sort"
+	"strings"
+
+	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+)
+
+// How close a food description is to the ingredient name, lower is better
+const (
+	rankDescription = iota
+	rankSegment
+)
+
+type Match struct {
+	IngredientID int32 `json:"ingredientID"`
+	Food         Food  `json:"food"`
+	rank         int
+}
+
+type Report struct {
+	Matched []Match `json:"matched"`
+	// Foods that do not resemble any ingredient name or alias
+	Unmatched []Food `json:"unmatched"`
+	// Foods that resemble an ingredient that already got a better match
+	Duplicates []Food `json:"duplicates"`
+}
+
+// Map foods to ingredients by name or alias. A food matches when its whole
+// description ("butter, salted") or its first segment ("butter") equals an
+// ingredient name or alias, ignoring case and plurals. Each ingredient gets
+// at most one food, preferring whole description matches and then file order.
+func MatchFoods(foods []Food, ingredients []db.Ingredient, aliases []db.IngredientsAlias) Report {
+	names := map[string]int32{}
+	for _, ingredient := range ingredients {
+		addName(names, ingredient.Name, ingredient.ID)
+	}
+	for _, alias := range aliases {
+		addName(names, alias.Alias, alias.IngredientID)
+	}
+
+	report := Report{
+		Matched:    []Match{},
+		Unmatched:  []Food{},
+		Duplicates: []Food{},
+	}
+	best := map[int32]int{}
+
+	for _, food := range foods {
+		ingredientID, rank, ok := lookup(names, food.Description)
+		if !ok {
+			report.Unmatched = append(report.Unmatched, food)
+			continue
+		}
+
+		i, exists := best[ingredientID]
+		if !exists {
+			best[ingredientID] = len(report.Matched)
+			report.Matched = append(report.Matched, Match{
+				IngredientID: ingredientID,
+				Food:         food,
+				rank:         rank,
+			})
+			continue
+		}
+
+		if rank < report.Matched[i].rank {
+			report.Duplicates = append(report.Duplicates, report.Matched[i].Food)
+			report.Matched[i].Food = food
+			report.Matched[i].rank = rank
+			continue
+		}
+		report.Duplicates = append(report.Duplicates, food)
+	}
+
+	sort.Slice(report.Matched, func(i, j int) bool {
+		return report.Matched[i].IngredientID < report.Matched[j].IngredientID
+	})
+
+	return report
+}
+
+// Names are registered in their singular form, explicit names win over
+// singular forms of other names
+func addName(names map[string]int32, name string, id int32) {
+	key := normalize(name)
+	names[key] = id
+	if singular := singularize(key); singular != key {
+		if _, ok := names[singular]; !ok {
+			names[singular] = id
+		}
+	}
+}
+
+func lookup(names map[string]int32, description string) (int32, int, bool) {
+	key := normalize(description)
+	if id, ok := findName(names, key); ok {
+		return id, rankDescription, true
+	}
+
+	segment, _, found := strings.Cut(key, ",")
+	if found {
+		if id, ok := findName(names, strings.TrimSpace(segment)); ok {
+			return id, rankSegment, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+func findName(names map[string]int32, key string) (int32, bool) {
+	if id, ok := names[key]; ok {
+		return id, true
+	}
+	id, ok := names[singularize(key)]
+
+	return id, ok
+}
+
+func normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// Naive english singular, good enough for food names ("tomatoes", "berries", "eggs")
+func singularize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	}
+
+	return word
+}
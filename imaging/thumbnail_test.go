@@ -0,0 +1,125 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// left half red, right half blue
+			if x < w/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	return img
+}
+
+func TestThumbnail(t *testing.T) {
+	testCases := []struct {
+		name          string
+		width, height int
+		size          int
+		wantW, wantH  int
+	}{
+		{"Landscape", 800, 400, 100, 100, 50},
+		{"Portrait", 300, 900, 150, 50, 150},
+		{"Square", 64, 64, 32, 32, 32},
+		{"Small", 40, 20, 100, 40, 20},
+		{"Thin", 1000, 2, 100, 100, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			thumb := Thumbnail(testImage(tc.width, tc.height), tc.size)
+			require.Equal(t, tc.wantW, thumb.Bounds().Dx())
+			require.Equal(t, tc.wantH, thumb.Bounds().Dy())
+		})
+	}
+}
+
+func TestThumbnailAverages(t *testing.T) {
+	// every thumbnail pixel covers 2x2 source pixels, one column of each color
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x%2 == 0 {
+				img.Set(x, y, color.RGBA{R: 200, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{G: 100, A: 255})
+			}
+		}
+	}
+
+	thumb := Thumbnail(img, 2)
+	require.Equal(t, 2, thumb.Bounds().Dx())
+	require.Equal(t, 1, thumb.Bounds().Dy())
+	require.Equal(t, color.RGBA{R: 100, G: 50, A: 255}, thumb.RGBAAt(0, 0))
+	require.Equal(t, color.RGBA{R: 100, G: 50, A: 255}, thumb.RGBAAt(1, 0))
+}
+
+func TestThumbnailOffsetBounds(t *testing.T) {
+	img := testImage(200, 100).SubImage(image.Rect(100, 0, 200, 100))
+
+	thumb := Thumbnail(img, 10)
+	require.Equal(t, 10, thumb.Bounds().Dx())
+	require.Equal(t, color.RGBA{B: 255, A: 255}, thumb.RGBAAt(0, 0))
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(20, 10)))
+
+	img, format, err := Decode(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "png", format)
+	require.Equal(t, 20, img.Bounds().Dx())
+
+	buf.Reset()
+	require.NoError(t, jpeg.Encode(&buf, testImage(20, 10), nil))
+	_, format, err = Decode(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "jpeg", format)
+
+	_, _, err = Decode([]byte("not an image"))
+	require.ErrorIs(t, err, image.ErrFormat)
+}
+
+func TestDecodeTooManyPixels(t *testing.T) {
+	// only the header is read, the pixels are never decoded
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 10000, 10000))))
+
+	_, _, err := Decode(buf.Bytes())
+	require.ErrorIs(t, err, ErrTooManyPixels)
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	contentType, err := Encode(&buf, testImage(10, 10), "jpeg")
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", contentType)
+	_, format, err := image.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "jpeg", format)
+
+	buf.Reset()
+	contentType, err = Encode(&buf, testImage(10, 10), "gif")
+	require.NoError(t, err)
+	require.Equal(t, "image/png", contentType)
+	_, format, err = image.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "png", format)
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
+	"github.com/hasnaroihan/grocery-planner/alert"
 	"github.com/hasnaroihan/grocery-planner/api"
+	"github.com/hasnaroihan/grocery-planner/blob"
 	db "github.com/hasnaroihan/grocery-planner/db/sqlc"
+	"github.com/hasnaroihan/grocery-planner/notify"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
@@ -17,11 +22,16 @@ var POSTGRES_PASSWORD string
 var POSTGRES_HOST string
 var SERVER_ADDRESS string
 var SYM_KEY string
+var IMAGE_DIR string
+var ALERT_NOTIFIER string
+var ALERT_INTERVAL string
 var dbDriver string
 var dbSource string
 
 const (
-	envPath = ".env"
+	envPath              = ".env"
+	defaultAlertInterval = time.Hour
+	defaultImageDir      = "./images"
 )
 
 func main() {
@@ -33,7 +43,29 @@ func main() {
 	}
 
 	storage := db.NewStorage(conn)
-	server, err := api.NewServer(storage)
+	blobs, err := blob.NewLocalStore(IMAGE_DIR, api.ImagesPath)
+	if err != nil {
+		log.Fatal("Cannot create image storage", err)
+	}
+
+	notifier, err := newNotifier()
+	if err != nil {
+		log.Fatal("Cannot create expiry alert notifier", err)
+	}
+	interval := defaultAlertInterval
+	if ALERT_INTERVAL != "" {
+		interval, err = time.ParseDuration(ALERT_INTERVAL)
+		if err != nil {
+			log.Fatal("Invalid expiry alert interval", err)
+		}
+	}
+	scheduler, err := alert.NewScheduler(storage, notifier, interval)
+	if err != nil {
+		log.Fatal("Cannot create expiry alert scheduler", err)
+	}
+	go scheduler.Run(context.Background())
+
+	server, err := api.NewServer(storage, blobs)
 	if err != nil {
 		log.Fatal("Cannot create server", err)
 	}
@@ -54,6 +86,12 @@ func initConfig() {
 	POSTGRES_PASSWORD = os.Getenv("POSTGRES_PASSWORD")
 	POSTGRES_HOST = os.Getenv("HOST")
 	SYM_KEY = os.Getenv("SYM_KEY")
+	IMAGE_DIR = os.Getenv("IMAGE_DIR")
+	if IMAGE_DIR == "" {
+		IMAGE_DIR = defaultImageDir
+	}
+	ALERT_NOTIFIER = os.Getenv("ALERT_NOTIFIER")
+	ALERT_INTERVAL = os.Getenv("ALERT_INTERVAL")
 
 	dbDriver = "postgres"
 	dbSource = fmt.Sprintf("postgresql://%s:%s@%v:5432/grocery-planner?sslmode=disable",
@@ -61,3 +99,21 @@ func initConfig() {
 		POSTGRES_PASSWORD,
 		POSTGRES_HOST)
 }
+
+// Expiry alerts are logged unless ALERT_NOTIFIER picks email or a webhook
+func newNotifier() (notify.Notifier, error) {
+	switch ALERT_NOTIFIER {
+	case "", "log":
+		return notify.NewLogNotifier(nil), nil
+	case "smtp":
+		return notify.NewSMTPNotifier(
+			os.Getenv("SMTP_ADDR"),
+			os.Getenv("SMTP_FROM"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"))
+	case "webhook":
+		return notify.NewWebhookNotifier(os.Getenv("ALERT_WEBHOOK_URL"))
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", ALERT_NOTIFIER)
+	}
+}
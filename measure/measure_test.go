@@ -0,0 +1,125 @@
+package measure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScale(t *testing.T) {
+	require.Equal(t, 500.0, Scale(250, 2, 4))
+	require.InDelta(t, 0.3333, Scale(1, 3, 1), 0.001)
+	require.Equal(t, 250.0, Scale(250, 0, 4))
+}
+
+func TestAdd(t *testing.T) {
+	sum, ok := Add(Quantity{Amount: 1, Unit: "kg"}, Quantity{Amount: 500, Unit: "grams"})
+	require.True(t, ok)
+	require.Equal(t, Quantity{Amount: 1.5, Unit: "kg"}, sum)
+
+	sum, ok = Add(Quantity{Amount: 2, Unit: "eggs"}, Quantity{Amount: 1, Unit: "eggs"})
+	require.True(t, ok)
+	require.Equal(t, 3.0, sum.Amount)
+
+	sum, ok = Add(Quantity{Amount: 2, Unit: "pcs"}, Quantity{Amount: 1, Unit: ""})
+	require.True(t, ok)
+	require.Equal(t, 3.0, sum.Amount)
+
+	_, ok = Add(Quantity{Amount: 1, Unit: "kg"}, Quantity{Amount: 1, Unit: "l"})
+	require.False(t, ok)
+
+	_, ok = Add(Quantity{Amount: 2, Unit: "cloves"}, Quantity{Amount: 1, Unit: "whole"})
+	require.False(t, ok)
+
+	_, ok = Add(Quantity{Amount: 1, Unit: "pinch"}, Quantity{Amount: 1, Unit: "g"})
+	require.False(t, ok)
+}
+
+func TestRatio(t *testing.T) {
+	ratio, ok := Ratio("g", "kg")
+	require.True(t, ok)
+	require.Equal(t, 0.001, ratio)
+
+	ratio, ok = Ratio("cup", "ml")
+	require.True(t, ok)
+	require.InDelta(t, 236.588, ratio, 0.001)
+
+	ratio, ok = Ratio("", "pcs")
+	require.True(t, ok)
+	require.Equal(t, 1.0, ratio)
+
+	_, ok = Ratio("eggs", "piece")
+	require.False(t, ok)
+
+	ratio, ok = Ratio("pinch", "pinch")
+	require.True(t, ok)
+	require.Equal(t, 1.0, ratio)
+
+	_, ok = Ratio("kg", "l")
+	require.False(t, ok)
+
+	_, ok = Ratio("pinch", "g")
+	require.False(t, ok)
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		name   string
+		q      Quantity
+		system string
+		want   Quantity
+	}{
+		{"Metric Upgrade", Quantity{1500, "g"}, Metric, Quantity{1.5, "kg"}},
+		{"Metric Downgrade", Quantity{0.5, "kg"}, Metric, Quantity{500, "g"}},
+		{"Imperial Mass", Quantity{453.59237, "g"}, Imperial, Quantity{1, "lb"}},
+		{"Imperial Volume", Quantity{3, "tsp"}, Imperial, Quantity{1, "tbsp"}},
+		{"Metric Volume", Quantity{2, "cups"}, Metric, Quantity{473.176473, "ml"}},
+		{"Metric Keeps Spoons", Quantity{2, "tbsp"}, Metric, Quantity{2, "tbsp"}},
+		{"Count", Quantity{3, "eggs"}, Imperial, Quantity{3, "eggs"}},
+		{"Unknown", Quantity{1, "pinch"}, Metric, Quantity{1, "pinch"}},
+		{"No System", Quantity{1500, "g"}, "", Quantity{1500, "g"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Convert(tc.q, tc.system)
+			require.Equal(t, tc.want.Unit, got.Unit)
+			require.InDelta(t, tc.want.Amount, got.Amount, 0.0001)
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	testCases := []struct {
+		q    Quantity
+		want float64
+	}{
+		{Quantity{0.3333, "eggs"}, 1},
+		{Quantity{2.4, "eggs"}, 2},
+		{Quantity{0.3333, "cup"}, 0.25},
+		{Quantity{0.4, "tsp"}, 0.5},
+		{Quantity{0.05, "tsp"}, 0.25},
+		{Quantity{333.33, "g"}, 335},
+		{Quantity{33.33, "g"}, 33},
+		{Quantity{3.333, "g"}, 3.3},
+		{Quantity{0.3333, "kg"}, 0.33},
+		{Quantity{0.001, "g"}, 0.01},
+		{Quantity{1.23456, "pinch"}, 1.23},
+		{Quantity{0, "g"}, 0},
+	}
+
+	for _, tc := range testCases {
+		got := Round(tc.q)
+		require.Equal(t, tc.q.Unit, got.Unit)
+		require.Equal(t, tc.want, got.Amount, "%v", tc.q)
+	}
+}
+
+func TestSystemOf(t *testing.T) {
+	require.Equal(t, Metric, SystemOf("Grams"))
+	require.Equal(t, Imperial, SystemOf("lbs"))
+	require.Equal(t, "", SystemOf("tbsp"))
+	require.Equal(t, "", SystemOf("pinch"))
+	require.Equal(t, "kg", Canonical("Kilograms"))
+	require.Equal(t, "pinch", Canonical("pinch"))
+}
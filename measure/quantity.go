@@ -0,0 +1,123 @@
+package measure
+
+import "math"
+
+type Quantity struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+}
+
+// Scale an amount written for base portions to the requested portions
+func Scale(amount float64, base, portions int32) float64 {
+	if base <= 0 {
+		return amount
+	}
+
+	return amount * float64(portions) / float64(base)
+}
+
+// Add two quantities of the same ingredient. Quantities of the same dimension are
+// summed in the unit of a, other combinations, including different counted units
+// like cloves and slices, can not be added.
+func Add(a, b Quantity) (Quantity, bool) {
+	if a.Unit == b.Unit {
+		return Quantity{Amount: a.Amount + b.Amount, Unit: a.Unit}, true
+	}
+
+	ua, okA := lookup(a.Unit)
+	ub, okB := lookup(b.Unit)
+	if !okA || !okB || !ua.compatible(ub) {
+		return a, false
+	}
+
+	return Quantity{
+		Amount: a.Amount + b.Amount*ub.base/ua.base,
+		Unit:   a.Unit,
+	}, true
+}
+
+// How many of the unit to make one of the unit from, for units of the same
+// dimension. Unknown units and counted units only match themselves.
+func Ratio(from, to string) (float64, bool) {
+	uf, okF := lookup(from)
+	ut, okT := lookup(to)
+	if !okF || !okT {
+		return 1, from == to
+	}
+	if !uf.compatible(ut) {
+		return 0, false
+	}
+
+	return uf.base / ut.base, true
+}
+
+// Convert a quantity to the most readable unit of the system, the largest one
+// that keeps the amount at or above 1. Counted, unknown and system neutral units
+// are kept when converting to metric, as are all units for an empty system.
+func Convert(q Quantity, system string) Quantity {
+	u, ok := lookup(q.Unit)
+	if !ok || system == "" {
+		return q
+	}
+	candidates := systemUnits[system][u.dimension]
+	if len(candidates) == 0 {
+		return q
+	}
+	if u.system == "" && system == Metric {
+		return q
+	}
+
+	base := q.Amount * u.base
+	best, _ := lookup(candidates[0])
+	for _, name := range candidates[1:] {
+		c, _ := lookup(name)
+		if base/c.base < 1 {
+			break
+		}
+		best = c
+	}
+
+	return Quantity{
+		Amount: base / best.base,
+		Unit:   best.name,
+	}
+}
+
+// Round an amount to what can be measured in a kitchen: counted items to whole
+// pieces, spoons, cups and imperial units to quarters, metric and unknown units
+// to a sensible precision. Positive amounts never round down to zero.
+func Round(q Quantity) Quantity {
+	if q.Amount <= 0 {
+		return q
+	}
+
+	u, ok := lookup(q.Unit)
+	if !ok {
+		return Quantity{Amount: roundTo(q.Amount, 0.01), Unit: q.Unit}
+	}
+
+	if u.step > 0 {
+		return Quantity{Amount: math.Max(roundTo(q.Amount, u.step), u.step), Unit: q.Unit}
+	}
+
+	var step float64
+	switch {
+	case q.Amount >= 100:
+		step = 5
+	case q.Amount >= 10:
+		step = 1
+	case q.Amount >= 1:
+		step = 0.1
+	default:
+		step = 0.01
+	}
+
+	return Quantity{Amount: math.Max(roundTo(q.Amount, step), step), Unit: q.Unit}
+}
+
+func roundTo(amount, step float64) float64 {
+	rounded := math.Round(amount/step) * step
+
+	// drop floating point noise like 0.30000000000000004
+	return math.Round(rounded*1e6) / 1e6
+}
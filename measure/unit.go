@@ -0,0 +1,172 @@
+package measure
+
+import "strings"
+
+const (
+	Metric   = "metric"
+	Imperial = "imperial"
+)
+
+type dimension int
+
+const (
+	mass dimension = iota + 1
+	volume
+	count
+)
+
+type unit struct {
+	name      string
+	dimension dimension
+	// size in grams for mass and in ml for volume
+	base float64
+	// empty for units used by both systems, like spoons
+	system string
+	// kitchen units are rounded to a fraction of the unit, other units to
+	// a number of significant digits
+	step float64
+}
+
+var units = []unit{
+	{name: "mg", dimension: mass, base: 0.001, system: Metric},
+	{name: "g", dimension: mass, base: 1, system: Metric},
+	{name: "kg", dimension: mass, base: 1000, system: Metric},
+	{name: "oz", dimension: mass, base: 28.349523125, system: Imperial, step: 0.25},
+	{name: "lb", dimension: mass, base: 453.59237, system: Imperial, step: 0.25},
+	{name: "ml", dimension: volume, base: 1, system: Metric},
+	{name: "l", dimension: volume, base: 1000, system: Metric},
+	{name: "tsp", dimension: volume, base: 4.92892159375, step: 0.25},
+	{name: "tbsp", dimension: volume, base: 14.78676478125, step: 0.25},
+	{name: "fl oz", dimension: volume, base: 29.5735295625, system: Imperial, step: 0.25},
+	{name: "cup", dimension: volume, base: 236.5882365, system: Imperial, step: 0.25},
+	{name: "pint", dimension: volume, base: 473.176473, system: Imperial, step: 0.25},
+	{name: "quart", dimension: volume, base: 946.352946, system: Imperial, step: 0.25},
+	{name: "gallon", dimension: volume, base: 3785.411784, system: Imperial, step: 0.25},
+	// counted units only combine with themselves, a clove is not a slice
+	{name: "piece", dimension: count, base: 1, step: 1},
+	{name: "egg", dimension: count, base: 1, step: 1},
+	{name: "clove", dimension: count, base: 1, step: 1},
+	{name: "slice", dimension: count, base: 1, step: 1},
+	{name: "whole", dimension: count, base: 1, step: 1},
+}
+
+// Units picked when converting to a system, from small to large
+var systemUnits = map[string]map[dimension][]string{
+	Metric: {
+		mass:   {"mg", "g", "kg"},
+		volume: {"ml", "l"},
+	},
+	Imperial: {
+		mass:   {"oz", "lb"},
+		volume: {"tsp", "tbsp", "cup", "quart", "gallon"},
+	},
+}
+
+var aliases = map[string]string{
+	"milligram":   "mg",
+	"milligrams":  "mg",
+	"gr":          "g",
+	"gram":        "g",
+	"grams":       "g",
+	"kilogram":    "kg",
+	"kilograms":   "kg",
+	"kgs":         "kg",
+	"ounce":       "oz",
+	"ounces":      "oz",
+	"pound":       "lb",
+	"pounds":      "lb",
+	"lbs":         "lb",
+	"milliliter":  "ml",
+	"milliliters": "ml",
+	"millilitre":  "ml",
+	"millilitres": "ml",
+	"liter":       "l",
+	"liters":      "l",
+	"litre":       "l",
+	"litres":      "l",
+	"teaspoon":    "tsp",
+	"teaspoons":   "tsp",
+	"tsps":        "tsp",
+	"tablespoon":  "tbsp",
+	"tablespoons": "tbsp",
+	"tbsps":       "tbsp",
+	"tbs":         "tbsp",
+	"fluid ounce": "fl oz",
+	"fl. oz":      "fl oz",
+	"floz":        "fl oz",
+	"cups":        "cup",
+	"c":           "cup",
+	"pints":       "pint",
+	"pt":          "pint",
+	"quarts":      "quart",
+	"qt":          "quart",
+	"gallons":     "gallon",
+	"gal":         "gallon",
+	"":            "piece",
+	"pieces":      "piece",
+	"pc":          "piece",
+	"pcs":         "piece",
+	"eggs":        "egg",
+	"cloves":      "clove",
+	"slices":      "slice",
+}
+
+func lookup(name string) (unit, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := aliases[key]; ok {
+		key = alias
+	}
+
+	for _, u := range units {
+		if u.name == key {
+			return u, true
+		}
+	}
+
+	return unit{}, false
+}
+
+// Units of the same dimension convert into each other, except counted units
+// which only match the same unit
+func (u unit) compatible(other unit) bool {
+	if u.dimension != other.dimension {
+		return false
+	}
+
+	return u.dimension != count || u.name == other.name
+}
+
+// Canonical name of a known unit ("grams" -> "g"), unknown units are returned as is
+func Canonical(name string) string {
+	u, ok := lookup(name)
+	if !ok || u.dimension == count {
+		return name
+	}
+
+	return u.name
+}
+
+// The unit system a unit belongs to, empty for unknown and system neutral units
+func SystemOf(name string) string {
+	u, _ := lookup(name)
+
+	return u.system
+}
+
+// Canonical name of a known unit including counted ones ("cloves" -> "clove",
+// "pcs" -> "piece"), false for unknown units
+func UnitName(name string) (string, bool) {
+	u, ok := lookup(name)
+	if !ok || strings.TrimSpace(name) == "" {
+		return "", false
+	}
+
+	return u.name, true
+}
+
+// Whether a unit counts items, like pieces or cloves
+func IsCount(name string) bool {
+	u, ok := lookup(name)
+
+	return ok && u.dimension == count
+}
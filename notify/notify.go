@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A pantry item that is about to expire
+type Item struct {
+	PantryItemID int64     `json:"pantryItemID"`
+	IngredientID int32     `json:"ingredientID"`
+	Name         string    `json:"name"`
+	Amount       float64   `json:"amount"`
+	Unit         string    `json:"unit"`
+	ExpiresOn    time.Time `json:"expiresOn"`
+}
+
+// Expiry alert for one user, items are ordered by expiry date
+type Notification struct {
+	UserID   uuid.UUID `json:"userID"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+	Items    []Item    `json:"items"`
+}
+
+// Delivers expiry alerts, an error means the user was not notified and the alert is retried
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+func (n Notification) Subject() string {
+	if len(n.Items) == 1 {
+		return fmt.Sprintf("%s in your pantry expires soon", n.Items[0].Name)
+	}
+
+	return fmt.Sprintf("%d items in your pantry expire soon", len(n.Items))
+}
+
+// Plain text message listing every item with its amount and expiry date
+func (n Notification) Body() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\n", n.Username)
+	b.WriteString("These items in your pantry are about to expire:\n\n")
+	for _, item := range n.Items {
+		amount := strconv.FormatFloat(item.Amount, 'f', -1, 64)
+		if item.Unit != "" {
+			amount += " " + item.Unit
+		}
+		fmt.Fprintf(&b, "- %s, %s (expires %s)\n", item.Name, amount, item.ExpiresOn.Format(time.DateOnly))
+	}
+
+	return b.String()
+}
+
+// Writes alerts to a logger, used when no delivery channel is configured
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogNotifier{logger: logger}
+}
+
+func (l *LogNotifier) Notify(ctx context.Context, n Notification) error {
+	l.logger.Printf("expiry alert for %s <%s>: %s\n%s", n.Username, n.Email, n.Subject(), n.Body())
+
+	return nil
+}
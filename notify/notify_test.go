@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomNotification(t *testing.T) Notification {
+	id, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	return Notification{
+		UserID:   id,
+		Username: "dana",
+		Email:    "dana@example.com",
+		Items: []Item{
+			{
+				PantryItemID: 1,
+				IngredientID: 10,
+				Name:         "milk",
+				Amount:       0.5,
+				Unit:         "liter",
+				ExpiresOn:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				PantryItemID: 2,
+				IngredientID: 11,
+				Name:         "egg",
+				Amount:       3,
+				ExpiresOn:    time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
+
+func TestNotificationMessage(t *testing.T) {
+	n := randomNotification(t)
+
+	require.Equal(t, "2 items in your pantry expire soon", n.Subject())
+	require.Equal(t, "Hi dana,\n\n"+
+		"These items in your pantry are about to expire:\n\n"+
+		"- milk, 0.5 liter (expires 2026-10-19)\n"+
+		"- egg, 3 (expires 2026-10-21)\n", n.Body())
+
+	n.Items = n.Items[:1]
+	require.Equal(t, "milk in your pantry expires soon", n.Subject())
+}
+
+func TestLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	notifier := NewLogNotifier(log.New(&buf, "", 0))
+
+	err := notifier.Notify(context.Background(), randomNotification(t))
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "expiry alert for dana <dana@example.com>")
+	require.Contains(t, buf.String(), "- egg, 3 (expires 2026-10-21)")
+}
@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// Minimal SMTP server that accepts one message without authentication
+func fakeSMTPServer(t *testing.T) (string, <-chan smtpMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var msg smtpMessage
+		tp.PrintfLine("220 localhost ready")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				msg.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				lines, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				msg.data = strings.Join(lines, "\n")
+				tp.PrintfLine("250 OK")
+			case cmd == "QUIT":
+				tp.PrintfLine("221 Bye")
+				messages <- msg
+				return
+			default:
+				tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, messages := fakeSMTPServer(t)
+
+	notifier, err := NewSMTPNotifier(addr, "pantry@grocery-planner.local", "", "")
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), randomNotification(t))
+	require.NoError(t, err)
+
+	msg := <-messages
+	require.Equal(t, "pantry@grocery-planner.local", msg.from)
+	require.Equal(t, []string{"dana@example.com"}, msg.to)
+
+	header, err := textproto.NewReader(bufio.NewReader(strings.NewReader(msg.data + "\n"))).ReadMIMEHeader()
+	require.NoError(t, err)
+	require.Equal(t, "dana@example.com", header.Get("To"))
+	require.Equal(t, "2 items in your pantry expire soon", header.Get("Subject"))
+	require.Contains(t, msg.data, "- milk, 0.5 liter (expires 2026-10-19)")
+}
+
+func TestNewSMTPNotifier(t *testing.T) {
+	_, err := NewSMTPNotifier("localhost", "pantry@grocery-planner.local", "", "")
+	require.Error(t, err)
+
+	_, err = NewSMTPNotifier("localhost:1025", "", "", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	n := randomNotification(t)
+
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(server.URL)
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), n)
+	require.NoError(t, err)
+	require.Equal(t, n, received)
+}
+
+func TestWebhookNotifierStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(server.URL)
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), randomNotification(t))
+	require.EqualError(t, err, "webhook responded with status 502")
+
+	_, err = NewWebhookNotifier("")
+	require.Error(t, err)
+}
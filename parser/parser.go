@@ -0,0 +1,287 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hasnaroihan/grocery-planner/measure"
+)
+
+var (
+	ErrNoAmount = errors.New("ingredient line does not start with an amount")
+	ErrNoName   = errors.New("ingredient line does not name an ingredient")
+)
+
+// An ingredient line like "1 1/2 tbsp olive oil, divided"
+type Line struct {
+	Amount float64 `json:"amount"`
+	// Upper bound of a range like "2-3", zero otherwise
+	AmountMax float64 `json:"amountMax,omitempty"`
+	// Canonical unit name, see measure.UnitName, or the name of a unit only
+	// known to the parser
+	Unit string `json:"unit"`
+	Name string `json:"name"`
+	// Preparation like "chopped" or "divided"
+	Note string `json:"note,omitempty"`
+}
+
+var vulgarFractions = map[rune]float64{
+	'½': 1.0 / 2,
+	'⅓': 1.0 / 3,
+	'⅔': 2.0 / 3,
+	'¼': 1.0 / 4,
+	'¾': 3.0 / 4,
+	'⅕': 1.0 / 5,
+	'⅖': 2.0 / 5,
+	'⅗': 3.0 / 5,
+	'⅘': 4.0 / 5,
+	'⅙': 1.0 / 6,
+	'⅚': 5.0 / 6,
+	'⅛': 1.0 / 8,
+	'⅜': 3.0 / 8,
+	'⅝': 5.0 / 8,
+	'⅞': 7.0 / 8,
+}
+
+// Words in front of the ingredient name that describe its preparation
+var preparations = map[string]bool{
+	"chopped":   true,
+	"diced":     true,
+	"minced":    true,
+	"sliced":    true,
+	"grated":    true,
+	"shredded":  true,
+	"crushed":   true,
+	"peeled":    true,
+	"melted":    true,
+	"softened":  true,
+	"beaten":    true,
+	"cubed":     true,
+	"mashed":    true,
+	"halved":    true,
+	"julienned": true,
+	"toasted":   true,
+	"cooked":    true,
+}
+
+type Parser struct {
+	// Units besides the measure units, like "pinch", by lower case name
+	units map[string]string
+}
+
+// Parser that also recognizes the given unit names, usually the units table
+func New(units []string) *Parser {
+	p := &Parser{units: make(map[string]string, len(units))}
+	for _, unit := range units {
+		if _, ok := measure.UnitName(unit); !ok {
+			p.units[strings.ToLower(unit)] = unit
+		}
+	}
+
+	return p
+}
+
+// Parse a line with the measure units only
+func ParseLine(line string) (Line, error) {
+	return New(nil).Parse(line)
+}
+
+// Parse an ingredient line into amount, unit, ingredient name and preparation
+// note. Lines without a unit are counted in pieces.
+func (p *Parser) Parse(line string) (Line, error) {
+	var result Line
+
+	line, result.Note = splitNote(line)
+	fields := strings.Fields(normalize(line))
+
+	amount, amountMax, n := parseAmount(fields)
+	if n == 0 {
+		return Line{}, ErrNoAmount
+	}
+	result.Amount, result.AmountMax = amount, amountMax
+	fields = fields[n:]
+
+	unit, n := p.parseUnit(fields)
+	// "3 eggs" counts eggs instead of naming nothing
+	if n == len(fields) && measure.IsCount(unit) {
+		unit, n = "piece", 0
+	}
+	result.Unit = unit
+	fields = fields[n:]
+
+	// "of" in "2 cups of flour"
+	if len(fields) > 1 && strings.EqualFold(fields[0], "of") {
+		fields = fields[1:]
+	}
+
+	var notes []string
+	for len(fields) > 1 && preparations[strings.ToLower(fields[0])] {
+		notes = append(notes, strings.ToLower(fields[0]))
+		fields = fields[1:]
+	}
+	if result.Note != "" {
+		notes = append(notes, result.Note)
+	}
+	result.Note = strings.Join(notes, ", ")
+
+	result.Name = strings.ToLower(strings.Join(fields, " "))
+	if result.Name == "" {
+		return Line{}, ErrNoName
+	}
+
+	return result, nil
+}
+
+// Split "olive oil, divided" and "onion (chopped)" into the line and its note
+func splitNote(line string) (string, string) {
+	var notes []string
+
+	for {
+		open := strings.Index(line, "(")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(line[open:], ")")
+		if end < 0 {
+			break
+		}
+		notes = append(notes, strings.TrimSpace(line[open+1:open+end]))
+		line = line[:open] + " " + line[open+end+1:]
+	}
+
+	if before, after, ok := strings.Cut(line, ","); ok {
+		line = before
+		notes = append(notes, strings.TrimSpace(after))
+	}
+
+	var result []string
+	for _, note := range notes {
+		if note != "" {
+			result = append(result, note)
+		}
+	}
+
+	return line, strings.Join(result, ", ")
+}
+
+// Separate vulgar fractions, ranges and units attached to numbers into their own
+// fields: "1½" -> "1 ½", "2-3" -> "2 - 3", "200g" -> "200 g"
+func normalize(line string) string {
+	var b strings.Builder
+	runes := []rune(line)
+
+	for i, r := range runes {
+		if i > 0 {
+			prev := runes[i-1]
+			_, isFraction := vulgarFractions[r]
+			_, prevFraction := vulgarFractions[prev]
+			switch {
+			case isFraction && unicode.IsDigit(prev):
+				b.WriteRune(' ')
+			case (r == '-' || r == '–') && (unicode.IsDigit(prev) || prevFraction):
+				b.WriteRune(' ')
+			case (prev == '-' || prev == '–') && (unicode.IsDigit(r) || isFraction):
+				b.WriteRune(' ')
+			case unicode.IsLetter(r) && !isFraction && (unicode.IsDigit(prev) || prevFraction):
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Amount at the start of the fields, the upper bound of a range, and the number
+// of fields used. Supports integers, decimals, fractions, unicode fractions, mixed
+// numbers ("1 1/2") and ranges ("2-3", "2 to 3").
+func parseAmount(fields []string) (float64, float64, int) {
+	amount, n := parseMixed(fields)
+	if n == 0 {
+		return 0, 0, 0
+	}
+
+	if len(fields) > n+1 && isRangeSeparator(fields[n]) {
+		if amountMax, m := parseMixed(fields[n+1:]); m > 0 && amountMax > amount {
+			return amount, amountMax, n + 1 + m
+		}
+	}
+
+	return amount, 0, n
+}
+
+func isRangeSeparator(s string) bool {
+	return s == "-" || s == "–" || strings.EqualFold(s, "to") || strings.EqualFold(s, "or")
+}
+
+func parseMixed(fields []string) (float64, int) {
+	if len(fields) == 0 {
+		return 0, 0
+	}
+
+	amount, ok := parseNumber(fields[0])
+	if !ok {
+		return 0, 0
+	}
+	if len(fields) > 1 && isWhole(fields[0]) && !isWhole(fields[1]) {
+		if fraction, ok := parseNumber(fields[1]); ok && fraction < 1 {
+			return amount + fraction, 2
+		}
+	}
+
+	return amount, 1
+}
+
+func isWhole(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func parseNumber(s string) (float64, bool) {
+	if r := []rune(s); len(r) == 1 {
+		if fraction, ok := vulgarFractions[r[0]]; ok {
+			return fraction, true
+		}
+	}
+
+	if numerator, denominator, ok := strings.Cut(s, "/"); ok {
+		n, err := strconv.ParseFloat(numerator, 64)
+		if err != nil {
+			return 0, false
+		}
+		d, err := strconv.ParseFloat(denominator, 64)
+		if err != nil || d == 0 {
+			return 0, false
+		}
+
+		return n / d, true
+	}
+
+	n, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// Unit at the start of the fields and the number of fields it used, units of two
+// words like "fl oz" are tried first
+func (p *Parser) parseUnit(fields []string) (string, int) {
+	for n := 2; n > 0; n-- {
+		if len(fields) < n {
+			continue
+		}
+		word := strings.TrimSuffix(strings.Join(fields[:n], " "), ".")
+		if unit, ok := measure.UnitName(word); ok {
+			return unit, n
+		}
+		if unit, ok := p.units[strings.ToLower(word)]; ok {
+			return unit, n
+		}
+	}
+
+	return "piece", 0
+}
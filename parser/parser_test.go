@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	testCases := []struct {
+		line string
+		want Line
+	}{
+		{"2 cups chopped onion", Line{Amount: 2, Unit: "cup", Name: "onion", Note: "chopped"}},
+		{"1 1/2 tbsp Olive Oil, divided", Line{Amount: 1.5, Unit: "tbsp", Name: "olive oil", Note: "divided"}},
+		{"1/2 tsp salt", Line{Amount: 0.5, Unit: "tsp", Name: "salt"}},
+		{"½ tsp salt", Line{Amount: 0.5, Unit: "tsp", Name: "salt"}},
+		{"1½ cups of milk", Line{Amount: 1.5, Unit: "cup", Name: "milk"}},
+		{"1 ¼ cups sugar", Line{Amount: 1.25, Unit: "cup", Name: "sugar"}},
+		{"0.5 kg flour", Line{Amount: 0.5, Unit: "kg", Name: "flour"}},
+		{"200g butter", Line{Amount: 200, Unit: "g", Name: "butter"}},
+		{"1.5kg potatoes (peeled)", Line{Amount: 1.5, Unit: "kg", Name: "potatoes", Note: "peeled"}},
+		{"2-3 tomatoes", Line{Amount: 2, AmountMax: 3, Unit: "piece", Name: "tomatoes"}},
+		{"2 to 3 cloves garlic, minced", Line{Amount: 2, AmountMax: 3, Unit: "clove", Name: "garlic", Note: "minced"}},
+		{"2 fl oz cream", Line{Amount: 2, Unit: "fl oz", Name: "cream"}},
+		{"3 eggs", Line{Amount: 3, Unit: "piece", Name: "eggs"}},
+		{"500 g ground beef", Line{Amount: 500, Unit: "g", Name: "ground beef"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			got, err := ParseLine(tc.line)
+			require.NoError(t, err)
+			require.InDelta(t, tc.want.Amount, got.Amount, 0.0001)
+			got.Amount = tc.want.Amount
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseTableUnits(t *testing.T) {
+	p := New([]string{"gram", "Pinch", "bunch"})
+
+	got, err := p.Parse("1 pinch nutmeg")
+	require.NoError(t, err)
+	require.Equal(t, Line{Amount: 1, Unit: "Pinch", Name: "nutmeg"}, got)
+
+	got, err = p.Parse("2 grams saffron")
+	require.NoError(t, err)
+	require.Equal(t, Line{Amount: 2, Unit: "g", Name: "saffron"}, got)
+
+	got, err = ParseLine("1 pinch nutmeg")
+	require.NoError(t, err)
+	require.Equal(t, Line{Amount: 1, Unit: "piece", Name: "pinch nutmeg"}, got)
+}
+
+func TestParseLineError(t *testing.T) {
+	_, err := ParseLine("salt to taste")
+	require.ErrorIs(t, err, ErrNoAmount)
+
+	_, err = ParseLine("")
+	require.ErrorIs(t, err, ErrNoAmount)
+
+	_, err = ParseLine("200 g")
+	require.ErrorIs(t, err, ErrNoName)
+
+	_, err = ParseLine("200g, sifted")
+	require.ErrorIs(t, err, ErrNoName)
+}
@@ -0,0 +1,175 @@
+package planner
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Weights of the slot score. Repeats weigh most so the plan only repeats a recipe
+// when cheaper ones are needed to stay under budget, cost is relative to the
+// average budget of a meal. Using up the pantry weighs more than sharing
+// ingredients between meals.
+const (
+	repeatWeight = 10
+	pantryWeight = 3
+	reuseWeight  = 2
+	costWeight   = 1
+)
+
+var (
+	ErrNoCandidates = errors.New("no recipe with a known cost matches the request")
+	ErrOverBudget   = errors.New("budget is too small for the requested meals")
+	ErrNotEnough    = errors.New("not enough affordable recipes to fill every day without repeating a recipe on the same day")
+)
+
+type Candidate struct {
+	RecipeID int64
+	// Estimated cost of one portion
+	PortionCost float64
+	Ingredients []int32
+}
+
+// An ingredient already at home
+type PantryItem struct {
+	IngredientID int32
+	// Last zero based day of the plan the item can be used on
+	LastDay int
+}
+
+type Params struct {
+	Budget      float64
+	Days        int
+	MealsPerDay int
+	Servings    int32
+	Pantry      []PantryItem
+}
+
+type Meal struct {
+	// Zero based day and meal of the day
+	Day      int
+	Slot     int
+	RecipeID int64
+	Cost     float64
+}
+
+type Plan struct {
+	Meals []Meal
+	Cost  float64
+	// Ingredients used by more than one meal
+	SharedIngredients int
+	// Pantry ingredients used before they expire
+	PantryIngredients []int32
+}
+
+// Fill every meal of every day greedily. Each pick keeps enough budget to fill the
+// remaining meals with the cheapest recipe, prefers recipes that were not planned
+// yet, that use pantry items before they expire and that share ingredients with
+// the planned ones, and then cheaper recipes. A recipe is never planned twice on
+// the same day.
+func Suggest(params Params, candidates []Candidate) (Plan, error) {
+	if len(candidates) == 0 {
+		return Plan{}, ErrNoCandidates
+	}
+
+	candidates = append([]Candidate(nil), candidates...)
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].RecipeID < candidates[j].RecipeID
+	})
+
+	servings := float64(params.Servings)
+	slots := params.Days * params.MealsPerDay
+	cheapest := math.Inf(1)
+	for _, c := range candidates {
+		cheapest = math.Min(cheapest, c.PortionCost*servings)
+	}
+	if cheapest*float64(slots) > params.Budget+1e-9 {
+		return Plan{}, ErrOverBudget
+	}
+	average := params.Budget / float64(slots)
+
+	plan := Plan{Meals: make([]Meal, 0, slots)}
+	remaining := params.Budget
+	uses := map[int64]int{}
+	ingredientUses := map[int32]int{}
+	today := map[int64]bool{}
+	pantry := map[int32]int{}
+	for _, item := range params.Pantry {
+		if last, ok := pantry[item.IngredientID]; !ok || item.LastDay > last {
+			pantry[item.IngredientID] = item.LastDay
+		}
+	}
+
+	for slot := 0; slot < slots; slot++ {
+		day := slot / params.MealsPerDay
+		if slot%params.MealsPerDay == 0 {
+			today = map[int64]bool{}
+		}
+		left := float64(slots - slot - 1)
+
+		best := -1
+		bestScore := math.Inf(-1)
+		for i, c := range candidates {
+			cost := c.PortionCost * servings
+			if today[c.RecipeID] || remaining-cost < cheapest*left-1e-9 {
+				continue
+			}
+
+			score := -repeatWeight*float64(uses[c.RecipeID]) - costWeight*cost/average
+			if len(c.Ingredients) > 0 && len(ingredientUses) > 0 {
+				shared := 0
+				for _, id := range c.Ingredients {
+					if ingredientUses[id] > 0 {
+						shared++
+					}
+				}
+				score += reuseWeight * float64(shared) / float64(len(c.Ingredients))
+			}
+			if len(c.Ingredients) > 0 && len(pantry) > 0 {
+				fresh := 0
+				for _, id := range c.Ingredients {
+					if last, ok := pantry[id]; ok && day <= last {
+						fresh++
+					}
+				}
+				score += pantryWeight * float64(fresh) / float64(len(c.Ingredients))
+			}
+
+			if score > bestScore {
+				best = i
+				bestScore = score
+			}
+		}
+		if best < 0 {
+			return Plan{}, ErrNotEnough
+		}
+
+		c := candidates[best]
+		cost := c.PortionCost * servings
+		plan.Meals = append(plan.Meals, Meal{
+			Day:      day,
+			Slot:     slot % params.MealsPerDay,
+			RecipeID: c.RecipeID,
+			Cost:     cost,
+		})
+		plan.Cost += cost
+		remaining -= cost
+		today[c.RecipeID] = true
+		uses[c.RecipeID]++
+		for _, id := range c.Ingredients {
+			ingredientUses[id]++
+			if last, ok := pantry[id]; ok && day <= last {
+				plan.PantryIngredients = append(plan.PantryIngredients, id)
+				delete(pantry, id)
+			}
+		}
+	}
+
+	for _, n := range ingredientUses {
+		if n > 1 {
+			plan.SharedIngredients++
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,108 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func recipeIDs(plan Plan) []int64 {
+	ids := make([]int64, len(plan.Meals))
+	for i, meal := range plan.Meals {
+		ids[i] = meal.RecipeID
+	}
+
+	return ids
+}
+
+func TestSuggestVariety(t *testing.T) {
+	candidates := []Candidate{
+		{RecipeID: 3, PortionCost: 2, Ingredients: []int32{1, 2}},
+		{RecipeID: 1, PortionCost: 1, Ingredients: []int32{3, 4}},
+		{RecipeID: 2, PortionCost: 3, Ingredients: []int32{5, 6}},
+	}
+
+	plan, err := Suggest(Params{Budget: 100, Days: 3, MealsPerDay: 1, Servings: 2}, candidates)
+	require.NoError(t, err)
+	// every recipe once, cheapest first
+	require.Equal(t, []int64{1, 3, 2}, recipeIDs(plan))
+	require.Equal(t, 12.0, plan.Cost)
+	require.Equal(t, 0, plan.SharedIngredients)
+	require.Equal(t, Meal{Day: 2, Slot: 0, RecipeID: 2, Cost: 6}, plan.Meals[2])
+}
+
+func TestSuggestReuse(t *testing.T) {
+	candidates := []Candidate{
+		{RecipeID: 1, PortionCost: 1, Ingredients: []int32{1, 2}},
+		{RecipeID: 2, PortionCost: 1, Ingredients: []int32{3, 4}},
+		{RecipeID: 3, PortionCost: 1.2, Ingredients: []int32{1, 2}},
+	}
+
+	plan, err := Suggest(Params{Budget: 100, Days: 2, MealsPerDay: 1, Servings: 1}, candidates)
+	require.NoError(t, err)
+	// the slightly dearer recipe uses the same ingredients as the first one
+	require.Equal(t, []int64{1, 3}, recipeIDs(plan))
+	require.Equal(t, 2, plan.SharedIngredients)
+}
+
+func TestSuggestPantry(t *testing.T) {
+	candidates := []Candidate{
+		{RecipeID: 1, PortionCost: 1, Ingredients: []int32{1, 2}},
+		{RecipeID: 2, PortionCost: 1.5, Ingredients: []int32{3, 4}},
+		{RecipeID: 3, PortionCost: 1, Ingredients: []int32{5, 6}},
+	}
+	params := Params{Budget: 100, Days: 2, MealsPerDay: 1, Servings: 1}
+
+	// the pantry item is used up on the first day
+	params.Pantry = []PantryItem{{IngredientID: 3, LastDay: 0}}
+	plan, err := Suggest(params, candidates)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 1}, recipeIDs(plan))
+	require.Equal(t, []int32{3}, plan.PantryIngredients)
+
+	// expired before the plan starts
+	params.Pantry = []PantryItem{{IngredientID: 3, LastDay: -1}}
+	plan, err = Suggest(params, candidates)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3}, recipeIDs(plan))
+	require.Empty(t, plan.PantryIngredients)
+}
+
+func TestSuggestBudget(t *testing.T) {
+	candidates := []Candidate{
+		{RecipeID: 1, PortionCost: 1},
+		{RecipeID: 2, PortionCost: 2},
+		{RecipeID: 3, PortionCost: 10},
+	}
+
+	// the expensive recipe never fits, so cheap ones repeat
+	plan, err := Suggest(Params{Budget: 8, Days: 4, MealsPerDay: 1, Servings: 1}, candidates)
+	require.NoError(t, err)
+	require.Len(t, plan.Meals, 4)
+	require.LessOrEqual(t, plan.Cost, 8.0)
+	require.NotContains(t, recipeIDs(plan), int64(3))
+
+	_, err = Suggest(Params{Budget: 3, Days: 4, MealsPerDay: 1, Servings: 1}, candidates)
+	require.ErrorIs(t, err, ErrOverBudget)
+}
+
+func TestSuggestSameDay(t *testing.T) {
+	candidates := []Candidate{
+		{RecipeID: 1, PortionCost: 1},
+		{RecipeID: 2, PortionCost: 1},
+	}
+
+	plan, err := Suggest(Params{Budget: 10, Days: 2, MealsPerDay: 2, Servings: 1}, candidates)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 1, 2}, recipeIDs(plan))
+	require.Equal(t, 1, plan.Meals[3].Day)
+	require.Equal(t, 1, plan.Meals[3].Slot)
+
+	_, err = Suggest(Params{Budget: 10, Days: 1, MealsPerDay: 3, Servings: 1}, candidates)
+	require.ErrorIs(t, err, ErrNotEnough)
+}
+
+func TestSuggestNoCandidates(t *testing.T) {
+	_, err := Suggest(Params{Budget: 10, Days: 1, MealsPerDay: 1, Servings: 1}, nil)
+	require.ErrorIs(t, err, ErrNoCandidates)
+}
@@ -0,0 +1,234 @@
+package recur
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily  = "DAILY"
+	Weekly = "WEEKLY"
+
+	untilDate     = "20060102"
+	untilDateTime = "20060102T150405Z"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// A subset of RFC 5545 RRULE for meals: daily or weekly, every INTERVAL days or
+// weeks, optionally limited to some weekdays, ending after COUNT occurrences or on
+// UNTIL. "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR" is every weekday and
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU" every other Sunday. Weeks start on Monday.
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	// Zero when the rule does not end on a date
+	Until time.Time
+	// Zero when the rule does not end after a number of occurrences
+	Count int
+}
+
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return rule, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return rule, fmt.Errorf("%w: %q is not a KEY=VALUE pair", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return rule, fmt.Errorf("%w: %s is given twice", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			if value != Daily && value != Weekly {
+				return rule, fmt.Errorf("%w: only DAILY and WEEKLY are supported", ErrInvalidRule)
+			}
+			rule.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return rule, fmt.Errorf("%w: INTERVAL has to be a positive number", ErrInvalidRule)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return rule, fmt.Errorf("%w: COUNT has to be a positive number", ErrInvalidRule)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := time.Parse(untilDate, value)
+			if err != nil {
+				until, err = time.Parse(untilDateTime, value)
+			}
+			if err != nil {
+				return rule, fmt.Errorf("%w: UNTIL has to be a date like 20261231", ErrInvalidRule)
+			}
+			rule.Until = Date(until)
+		case "BYDAY":
+			days := map[time.Weekday]bool{}
+			for _, name := range strings.Split(value, ",") {
+				day, ok := weekdays[strings.TrimSpace(name)]
+				if !ok {
+					return rule, fmt.Errorf("%w: unknown BYDAY day %q", ErrInvalidRule, name)
+				}
+				if !days[day] {
+					days[day] = true
+					rule.ByDay = append(rule.ByDay, day)
+				}
+			}
+		default:
+			return rule, fmt.Errorf("%w: %s is not supported", ErrInvalidRule, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return rule, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return rule, fmt.Errorf("%w: COUNT and UNTIL can not be combined", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+// Canonical form of the rule, parsing it again gives the same rule
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, 0, len(r.ByDay))
+		// Monday first, in week order
+		for i := 1; i <= 7; i++ {
+			day := time.Weekday(i % 7)
+			if r.hasDay(day) {
+				names = append(names, weekdayNames[day])
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format(untilDate))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Dates from `from` to `to`, both included, the rule occurs on when it starts on start.
+// Intervals count from the start date, or from the week of the start date for weekly rules
+func (r Rule) Between(start time.Time, from time.Time, to time.Time) []time.Time {
+	start, from, to = Date(start), Date(from), Date(to)
+	result := []time.Time{}
+
+	// counting occurrences has to begin at the start, otherwise the first
+	// date that can be returned is enough
+	day := start
+	if r.Count == 0 && from.After(start) {
+		day = from
+	}
+
+	occurrences := 0
+	for ; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !r.Until.IsZero() && day.After(r.Until) {
+			break
+		}
+		if !r.matches(start, day) {
+			continue
+		}
+
+		occurrences++
+		if r.Count > 0 && occurrences > r.Count {
+			break
+		}
+		if !day.Before(from) {
+			result = append(result, day)
+		}
+	}
+
+	return result
+}
+
+func (r Rule) matches(start time.Time, day time.Time) bool {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Freq {
+	case Daily:
+		if days(start, day)%interval != 0 {
+			return false
+		}
+		return len(r.ByDay) == 0 || r.hasDay(day.Weekday())
+	case Weekly:
+		if days(WeekStart(start), WeekStart(day))/7%interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.hasDay(day.Weekday())
+	}
+
+	return false
+}
+
+func (r Rule) hasDay(day time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Midnight UTC of the calendar date of t
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Monday of the week t is in
+func WeekStart(t time.Time) time.Time {
+	t = Date(t)
+	offset := (int(t.Weekday()) + 6) % 7
+
+	return t.AddDate(0, 0, -offset)
+}
+
+// Whole days from a to b, both at midnight UTC
+func days(a time.Time, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
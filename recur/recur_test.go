@@ -0,0 +1,144 @@
+package recur
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func dates(ts []time.Time) []string {
+	result := make([]string, len(ts))
+	for i, t := range ts {
+		result[i] = t.Format("2006-01-02")
+	}
+	return result
+}
+
+func TestParse(t *testing.T) {
+	rule, err := Parse("RRULE:FREQ=WEEKLY;BYDAY=FR,MO,TU,WE,TH")
+	require.NoError(t, err)
+	require.Equal(t, Weekly, rule.Freq)
+	require.Equal(t, 1, rule.Interval)
+	require.Len(t, rule.ByDay, 5)
+	require.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", rule.String())
+
+	rule, err = Parse("freq=weekly;interval=2;byday=su;until=20261231")
+	require.NoError(t, err)
+	require.Equal(t, date("2026-12-31"), rule.Until)
+	require.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU;UNTIL=20261231", rule.String())
+
+	again, err := Parse(rule.String())
+	require.NoError(t, err)
+	require.Equal(t, rule, again)
+
+	invalid := []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=MONTHLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=x",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231",
+		"FREQ",
+	}
+	for _, s := range invalid {
+		_, err := Parse(s)
+		require.ErrorIs(t, err, ErrInvalidRule, s)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rule  string
+		start string
+		from  string
+		to    string
+		want  []string
+	}{
+		{
+			name:  "Weekdays",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			start: "2026-10-01",
+			from:  "2026-10-19",
+			to:    "2026-10-25",
+			want:  []string{"2026-10-19", "2026-10-20", "2026-10-21", "2026-10-22", "2026-10-23"},
+		},
+		{
+			name:  "Every Other Sunday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU",
+			start: "2026-10-05",
+			from:  "2026-10-05",
+			to:    "2026-11-08",
+			want:  []string{"2026-10-11", "2026-10-25", "2026-11-08"},
+		},
+		{
+			name:  "Weekly On Start Day",
+			rule:  "FREQ=WEEKLY",
+			start: "2026-10-07",
+			from:  "2026-10-01",
+			to:    "2026-10-21",
+			want:  []string{"2026-10-07", "2026-10-14", "2026-10-21"},
+		},
+		{
+			name:  "Every Third Day",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: "2026-10-18",
+			from:  "2026-10-20",
+			to:    "2026-10-27",
+			want:  []string{"2026-10-21", "2026-10-24", "2026-10-27"},
+		},
+		{
+			name:  "Count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: "2026-10-18",
+			from:  "2026-10-19",
+			to:    "2026-10-30",
+			want:  []string{"2026-10-19", "2026-10-20"},
+		},
+		{
+			name:  "Until",
+			rule:  "FREQ=DAILY;UNTIL=20261021",
+			start: "2026-10-18",
+			from:  "2026-10-20",
+			to:    "2026-10-30",
+			want:  []string{"2026-10-20", "2026-10-21"},
+		},
+		{
+			name:  "Before Start",
+			rule:  "FREQ=DAILY",
+			start: "2026-11-01",
+			from:  "2026-10-19",
+			to:    "2026-10-25",
+			want:  []string{},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := Parse(tc.rule)
+			require.NoError(t, err)
+
+			got := rule.Between(date(tc.start), date(tc.from), date(tc.to))
+			require.Equal(t, tc.want, dates(got))
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	require.Equal(t, date("2026-10-12"), WeekStart(date("2026-10-18")))
+	require.Equal(t, date("2026-10-19"), WeekStart(time.Date(2026, 10, 19, 13, 30, 0, 0, time.UTC)))
+}
@@ -0,0 +1,209 @@
+package schemaorg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNoRecipe = errors.New("document does not contain a schema.org Recipe")
+
+// The parts of a schema.org Recipe the planner keeps
+type Recipe struct {
+	Name string `json:"name"`
+	// Zero when the document does not state a yield
+	Yield        int32    `json:"yield"`
+	Ingredients  []string `json:"ingredients"`
+	Instructions []string `json:"instructions"`
+}
+
+type jsonRecipe struct {
+	Name               json.RawMessage `json:"name"`
+	RecipeYield        json.RawMessage `json:"recipeYield"`
+	RecipeIngredient   json.RawMessage `json:"recipeIngredient"`
+	Ingredients        json.RawMessage `json:"ingredients"`
+	RecipeInstructions json.RawMessage `json:"recipeInstructions"`
+}
+
+var scriptPattern = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+var numberPattern = regexp.MustCompile(`\d+`)
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Parse a JSON-LD document or an HTML page with JSON-LD script blocks and return
+// the first Recipe found
+func Parse(data []byte) (Recipe, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return ParseJSONLD(trimmed)
+	}
+
+	return ParseHTML(trimmed)
+}
+
+// Parse the JSON-LD script blocks of an HTML page
+func ParseHTML(data []byte) (Recipe, error) {
+	for _, match := range scriptPattern.FindAllSubmatch(data, -1) {
+		recipe, err := ParseJSONLD(match[1])
+		if err == nil {
+			return recipe, nil
+		}
+	}
+
+	return Recipe{}, ErrNoRecipe
+}
+
+// Parse a JSON-LD document, the Recipe can be the document itself, an element of
+// an array or of an @graph
+func ParseJSONLD(data []byte) (Recipe, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Recipe{}, err
+	}
+
+	node, ok := findRecipe(doc)
+	if !ok {
+		return Recipe{}, ErrNoRecipe
+	}
+
+	raw, err := json.Marshal(node)
+	if err != nil {
+		return Recipe{}, err
+	}
+	var jr jsonRecipe
+	if err := json.Unmarshal(raw, &jr); err != nil {
+		return Recipe{}, err
+	}
+
+	ingredients := jr.RecipeIngredient
+	if len(ingredients) == 0 {
+		ingredients = jr.Ingredients
+	}
+
+	return Recipe{
+		Name:         firstText(jr.Name),
+		Yield:        parseYield(jr.RecipeYield),
+		Ingredients:  texts(ingredients),
+		Instructions: texts(jr.RecipeInstructions),
+	}, nil
+}
+
+func findRecipe(node interface{}) (map[string]interface{}, bool) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, item := range n {
+			if recipe, ok := findRecipe(item); ok {
+				return recipe, true
+			}
+		}
+	case map[string]interface{}:
+		if isType(n["@type"], "Recipe") {
+			return n, true
+		}
+		if graph, ok := n["@graph"]; ok {
+			return findRecipe(graph)
+		}
+	}
+
+	return nil, false
+}
+
+// @type is either a single type or a list of types
+func isType(value interface{}, name string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == name || strings.HasSuffix(v, "/"+name)
+	case []interface{}:
+		for _, item := range v {
+			if isType(item, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Texts of a string, a list of strings, or HowToStep and HowToSection objects
+func texts(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return []string{}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return []string{}
+	}
+
+	result := []string{}
+	collectTexts(value, &result)
+
+	return result
+}
+
+func collectTexts(value interface{}, result *[]string) {
+	switch v := value.(type) {
+	case string:
+		for _, line := range strings.Split(v, "\n") {
+			if text := cleanText(line); text != "" {
+				*result = append(*result, text)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectTexts(item, result)
+		}
+	case map[string]interface{}:
+		if items, ok := v["itemListElement"]; ok { // HowToSection
+			collectTexts(items, result)
+			return
+		}
+		if text, ok := v["text"]; ok { // HowToStep
+			collectTexts(text, result)
+			return
+		}
+		if name, ok := v["name"]; ok {
+			collectTexts(name, result)
+		}
+	}
+}
+
+func firstText(raw json.RawMessage) string {
+	list := texts(raw)
+	if len(list) == 0 {
+		return ""
+	}
+
+	return list[0]
+}
+
+// Yield is free text ("4 servings"), a number, or a list of both
+func parseYield(raw json.RawMessage) int32 {
+	var number float64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return int32(number)
+	}
+
+	for _, text := range texts(raw) {
+		if match := numberPattern.FindString(text); match != "" {
+			yield, err := strconv.Atoi(match)
+			if err == nil {
+				return int32(yield)
+			}
+		}
+	}
+
+	return 0
+}
+
+func cleanText(s string) string {
+	s = tagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
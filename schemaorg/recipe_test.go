@@ -0,0 +1,49 @@
+package schemaorg
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONLD(t *testing.T) {
+	data, err := os.ReadFile("testdata/recipe.json")
+	require.NoError(t, err)
+
+	recipe, err := Parse(data)
+	require.NoError(t, err)
+	require.Equal(t, "Tomato Soup", recipe.Name)
+	require.Equal(t, int32(4), recipe.Yield)
+	require.Equal(t, []string{
+		"2 cups chopped onion",
+		"1 1/2 tbsp olive oil",
+		"800 g tomatoes",
+		"salt to taste",
+	}, recipe.Ingredients)
+	require.Equal(t, []string{
+		"Fry the onion in the olive oil.",
+		"Add the tomatoes & simmer for 20 minutes.",
+		"Season with salt.",
+	}, recipe.Instructions)
+}
+
+func TestParseHTML(t *testing.T) {
+	data, err := os.ReadFile("testdata/recipe.html")
+	require.NoError(t, err)
+
+	recipe, err := Parse(data)
+	require.NoError(t, err)
+	require.Equal(t, "Pancakes", recipe.Name)
+	require.Equal(t, int32(2), recipe.Yield)
+	require.Equal(t, []string{"200 g flour", "2 eggs", "300 ml milk"}, recipe.Ingredients)
+	require.Equal(t, []string{"Whisk everything together.", "Fry in a hot pan."}, recipe.Instructions)
+}
+
+func TestParseNoRecipe(t *testing.T) {
+	_, err := Parse([]byte(`{"@type": "Organization", "name": "Example Kitchen"}`))
+	require.ErrorIs(t, err, ErrNoRecipe)
+
+	_, err = Parse([]byte(`<html><body>No recipe here</body></html>`))
+	require.ErrorIs(t, err, ErrNoRecipe)
+}